@@ -15,6 +15,7 @@ func main() {
 
 	root := &cli.Command{Name: "focusd", Commands: []*cli.Command{
 		serve.Command,
+		serve.ConfigCommand,
 	}}
 
 	if err := root.Run(context.Background(), os.Args); err != nil {
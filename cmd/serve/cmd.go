@@ -3,8 +3,8 @@ package serve
 import (
 	"context"
 	"database/sql"
+	"expvar"
 	"fmt"
-	"log"
 	"log/slog"
 	"net/http"
 	"os"
@@ -14,10 +14,8 @@ import (
 	"connectrpc.com/connect"
 	"connectrpc.com/validate"
 	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
-	commonv1 "github.com/focusd-so/brain/gen/common/v1"
 	"github.com/focusd-so/brain/internal/auth"
 	"github.com/focusd-so/brain/internal/brain"
-	"github.com/joho/godotenv"
 	"github.com/urfave/cli/v3"
 
 	"golang.org/x/net/http2"
@@ -29,75 +27,183 @@ import (
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
 )
 
+// defaultMaxMessageBytes caps a single request message, large enough for agent
+// instructions and tool schemas
+const defaultMaxMessageBytes = 4 << 20
+
+// defaultCompressMinBytes skips compressing responses too small to benefit
+const defaultCompressMinBytes = 1024
+
+// flags are shared by serve and config so both resolve the same settings
+var flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "port",
+		Value:   "8089",
+		Usage:   "port to listen on",
+		Aliases: []string{"p"},
+		Sources: cli.EnvVars("PORT"),
+	},
+	&cli.StringFlag{
+		Name:    "turso-db-url",
+		Value:   "",
+		Sources: cli.EnvVars("TURSO_CONNECTION_PATH"),
+	},
+	&cli.StringFlag{
+		Name:    "turso-db-token",
+		Sources: cli.EnvVars("TURSO_CONNECTION_TOKEN"),
+	},
+	&cli.DurationFlag{
+		Name:    "turso-connect-timeout",
+		Value:   defaultTursoConnectTimeout,
+		Usage:   "how long to wait for each database to answer at startup before refusing to start",
+		Sources: cli.EnvVars("TURSO_CONNECT_TIMEOUT"),
+	},
+	&cli.StringSliceFlag{
+		Name:    "turso-read-replica-urls",
+		Usage:   "read replicas for classification cache lookups, writes stay on the primary",
+		Sources: cli.EnvVars("TURSO_READ_REPLICA_URLS"),
+	},
+	&cli.StringFlag{
+		Name:    "debug-addr",
+		Usage:   "internal address serving /debug/vars, e.g. 127.0.0.1:9090; unset keeps it off",
+		Sources: cli.EnvVars("DEBUG_ADDR"),
+	},
+	&cli.DurationFlag{
+		Name:    "gemini-probe-interval",
+		Value:   time.Minute,
+		Usage:   "how often to check that Gemini is reachable",
+		Sources: cli.EnvVars("GEMINI_PROBE_INTERVAL"),
+	},
+	&cli.BoolFlag{
+		Name:    "enforce-device-binding",
+		Usage:   "reject device-bound tokens sent without their X-Device-Fingerprint",
+		Sources: cli.EnvVars("AUTH_ENFORCE_DEVICE_BINDING"),
+	},
+	&cli.BoolFlag{
+		Name:    "allow-migrations",
+		Usage:   "run migrations that rewrite or index existing rows, which may lock large tables",
+		Sources: cli.EnvVars("ALLOW_MIGRATIONS"),
+	},
+	&cli.IntFlag{
+		Name:    "max-message-bytes",
+		Value:   defaultMaxMessageBytes,
+		Usage:   "largest request message accepted, larger ones fail with resource_exhausted",
+		Sources: cli.EnvVars("MAX_MESSAGE_BYTES"),
+	},
+	&cli.BoolFlag{
+		Name:    "compression",
+		Value:   true,
+		Usage:   "gzip responses for clients that accept it",
+		Sources: cli.EnvVars("RESPONSE_COMPRESSION"),
+	},
+	&cli.IntFlag{
+		Name:    "compress-min-bytes",
+		Value:   defaultCompressMinBytes,
+		Usage:   "smallest response message worth compressing",
+		Sources: cli.EnvVars("COMPRESS_MIN_BYTES"),
+	},
+}
+
 var Command = &cli.Command{
 	Name: "serve",
-	Flags: []cli.Flag{
-		&cli.StringFlag{
-			Name:    "port",
-			Value:   "8089",
-			Usage:   "port to listen on",
-			Aliases: []string{"p"},
-			Sources: cli.EnvVars("PORT"),
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "print-config",
+			Usage: "print the effective configuration as JSON and exit",
 		},
-		&cli.StringFlag{
-			Name:    "turso-db-url",
-			Value:   "",
-			Sources: cli.EnvVars("TURSO_CONNECTION_PATH"),
-		},
-		&cli.StringFlag{
-			Name:    "turso-db-token",
-			Sources: cli.EnvVars("TURSO_CONNECTION_TOKEN"),
-		},
-	},
+	}, flags...),
 	Action: func(ctx context.Context, cmd *cli.Command) error {
-		err := godotenv.Load()
+		if err := loadDotEnv(cmd); err != nil {
+			return err
+		}
+		if cmd.Bool("print-config") {
+			return printConfig(os.Stdout, cmd)
+		}
+
+		// Without keys every authenticated request would fail, so refuse to start
+		if err := auth.CheckKeys(); err != nil {
+			return fmt.Errorf("invalid session keys: %w", err)
+		}
+
+		shutdownTracing, err := brain.SetupTracing(ctx)
 		if err != nil {
-			log.Println("Warning: Error loading .env file")
+			return fmt.Errorf("failed to set up tracing: %w", err)
 		}
 
 		url := cmd.String("turso-db-url")
 		token := cmd.String("turso-db-token")
 
-		connStr := url
-		if token != "" {
-			connStr = fmt.Sprintf("%s?authToken=%s", url, token)
-		}
-
 		slog.Info("connecting to turso", "url", url)
 
-		sqlDB, err := sql.Open("libsql", connStr)
-		if err != nil {
-			return fmt.Errorf("failed to open sql connection: %w", err)
-		}
-
-		gormDB, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+		gormDB, err := openTurso(ctx, url, token, cmd.Duration("turso-connect-timeout"))
 		if err != nil {
-			return fmt.Errorf("failed to open gorm connection: %w", err)
+			return err
 		}
 
 		slog.Info("connected to turso", "url", url)
 
-		if err := gormDB.AutoMigrate(&commonv1.UserORM{}, &commonv1.NonceORM{}, &commonv1.PromptHistoryORM{}); err != nil {
+		var replicas []*gorm.DB
+		for _, replicaURL := range cmd.StringSlice("turso-read-replica-urls") {
+			replica, err := openTurso(ctx, replicaURL, token, cmd.Duration("turso-connect-timeout"))
+			if err != nil {
+				return fmt.Errorf("read replica %s: %w", replicaURL, err)
+			}
+			replicas = append(replicas, replica)
+			slog.Info("connected to turso read replica", "url", replicaURL)
+		}
+
+		if err := checkMigrations(gormDB, cmd.Bool("allow-migrations"), migratedModels...); err != nil {
+			return err
+		}
+		if err := gormDB.AutoMigrate(migratedModels...); err != nil {
 			return fmt.Errorf("failed to auto migrate: %w", err)
 		}
 
 		// run EngineService as connect rpc handler
 		engineService := brain.NewServiceImpl(gormDB)
+		engineService.UseReadReplicas(replicas...)
+
+		var authOpts []auth.InterceptorOption
+		if cmd.Bool("enforce-device-binding") {
+			authOpts = append(authOpts, auth.WithDeviceBinding())
+		}
 
 		mux := http.NewServeMux()
-		path, handler := brainv1connect.NewBrainServiceHandler(
-			engineService,
-			connect.WithInterceptors(
-				auth.NewAuthInterceptor(),
-				validate.NewInterceptor(),
-			),
-		)
+		path, handler := newBrainHandler(engineService, brainHandlerConfig{
+			MaxMessageBytes:  cmd.Int("max-message-bytes"),
+			Compression:      cmd.Bool("compression"),
+			CompressMinBytes: cmd.Int("compress-min-bytes"),
+		}, authOpts...)
 
 		protocols := new(http.Protocols)
 		protocols.SetHTTP1(true)
 		protocols.SetUnencryptedHTTP2(true)
 		mux.Handle(path, handler)
 
+		// Browser clients without HTTP/2 bidi streaming reach the agent over
+		// WebSocket, behind the same interceptors as the RPC
+		mux.Handle("/agent/ws", brain.AgentWebSocketHandler(engineService, brainInterceptors(engineService, authOpts...)...))
+
+		// Readiness stays green while Gemini is down or the breaker is open (degraded), cached results still serve
+		probe := brain.NewGeminiProbe(cmd.Duration("gemini-probe-interval"))
+		probeCtx, stopProbe := context.WithCancel(ctx)
+		defer stopProbe()
+		go probe.Run(probeCtx)
+
+		mux.Handle("/readyz", brain.ReadinessHandler(probe, engineService))
+
+		// expvar includes the command line and memstats, so it never shares the public listener
+		var debugServer *http.Server
+		if addr := cmd.String("debug-addr"); addr != "" {
+			debugServer = newDebugServer(addr)
+			go func() {
+				slog.Info("serving debug vars", "addr", addr)
+				if err := debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					slog.Error("failed to serve debug vars", "error", err)
+				}
+			}()
+		}
+
 		slog.Info("serving engine service at", "path", path)
 
 		// 2. CRITICAL FIX: Wrap the mux in h2c.NewHandler
@@ -133,8 +239,134 @@ var Command = &cli.Command{
 		if err := server.Shutdown(shutdownCtx); err != nil {
 			slog.Error("server forced to shutdown", "error", err)
 		}
+		if debugServer != nil {
+			debugServer.Close()
+		}
+
+		// Flush cache writes once no more requests are in flight, within the same deadline
+		if err := engineService.Close(shutdownCtx); err != nil {
+			slog.Error("cache writes lost at shutdown", "error", err)
+		}
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
 
 		slog.Info("engine service shut down")
 		return nil
 	},
 }
+
+// newDebugServer serves expvar's /debug/vars on addr, meant for an internal
+// interface only
+func newDebugServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+}
+
+// defaultTursoConnectTimeout bounds the startup ping of each database
+const defaultTursoConnectTimeout = 10 * time.Second
+
+// openTurso opens a libsql database through gorm. sql.Open connects lazily
+// and the libsql driver's Ping never reaches the server, so a trivial query is
+// run within timeout to surface a bad URL or token at startup rather than on
+// the first request.
+func openTurso(ctx context.Context, url, token string, timeout time.Duration) (*gorm.DB, error) {
+	connStr := url
+	if token != "" {
+		connStr = fmt.Sprintf("%s?authToken=%s", url, token)
+	}
+
+	sqlDB, err := sql.Open("libsql", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sql connection: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var one int
+	if err := sqlDB.QueryRowContext(pingCtx, "SELECT 1").Scan(&one); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to reach database %s, check its url and token: %w", url, err)
+	}
+
+	gormDB, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gorm connection: %w", err)
+	}
+	return gormDB, nil
+}
+
+// brainHandlerConfig holds the transport settings of the brain handler
+type brainHandlerConfig struct {
+	MaxMessageBytes  int
+	Compression      bool // gzip responses when the client accepts it
+	CompressMinBytes int
+}
+
+// newBrainHandler mounts the brain service behind its interceptor chain.
+// Messages over the size cap are rejected with resource_exhausted before they
+// reach a handler.
+func newBrainHandler(svc brainv1connect.BrainServiceHandler, cfg brainHandlerConfig, authOpts ...auth.InterceptorOption) (string, http.Handler) {
+	return brainv1connect.NewBrainServiceHandler(
+		svc,
+		connect.WithReadMaxBytes(cfg.MaxMessageBytes),
+		compressionOption(cfg),
+		connect.WithInterceptors(brainInterceptors(svc, authOpts...)...),
+	)
+}
+
+// brainInterceptors is the interceptor chain of the brain service, shared by
+// every route serving its procedures. Interceptors that need the engine are
+// skipped for other implementations.
+func brainInterceptors(svc brainv1connect.BrainServiceHandler, authOpts ...auth.InterceptorOption) []connect.Interceptor {
+	if sessions, ok := svc.(auth.SessionChecker); ok {
+		// Reject revoked tokens
+		authOpts = append(authOpts, auth.WithSessionChecker(sessions))
+	}
+
+	engine, isEngine := svc.(*brain.ServiceImpl)
+
+	// Every RPC is traced, rejected or not
+	interceptors := []connect.Interceptor{brain.NewTracingInterceptor()}
+	if isEngine {
+		// Shed classifications while Gemini is saturated, before spending
+		// anything on the request
+		interceptors = append(interceptors, brain.NewLoadSheddingInterceptor(engine))
+	}
+	interceptors = append(interceptors,
+		// Deny sources outside CLASSIFICATION_ALLOWED_SOURCES
+		brain.NewSourceInterceptor(),
+		auth.NewAuthInterceptor(authOpts...),
+		validate.NewInterceptor(),
+		// Log a request's Gemini calls for admins sending X-Debug-Gemini,
+		// after auth which it needs to tell admins apart
+		brain.NewGeminiDebugInterceptor(),
+	)
+	if isEngine {
+		// Post classifications of opted-in users, outside the outcome hash
+		// so posted results carry it
+		interceptors = append(interceptors, brain.NewWebhookInterceptor(engine))
+	}
+	interceptors = append(interceptors, brain.NewOutcomeHashInterceptor())
+	if isEngine {
+		// Record classifications against open focus sessions and remember
+		// detected projects
+		interceptors = append(interceptors, brain.NewFocusSessionInterceptor(engine), brain.NewDetectedProjectInterceptor(engine))
+	}
+	return interceptors
+}
+
+// compressionOption configures gzip, which connect registers by default.
+// Registering it without constructors turns it off.
+func compressionOption(cfg brainHandlerConfig) connect.HandlerOption {
+	if !cfg.Compression {
+		return connect.WithCompression("gzip", nil, nil)
+	}
+	return connect.WithCompressMinBytes(cfg.CompressMinBytes)
+}
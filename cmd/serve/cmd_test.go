@@ -0,0 +1,243 @@
+package serve
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+type handshakeStub struct {
+	brainv1connect.UnimplementedBrainServiceHandler
+}
+
+func (handshakeStub) DeviceHandshake(ctx context.Context, req *connect.Request[brainv1.DeviceHandshakeRequest]) (*connect.Response[brainv1.DeviceHandshakeResponse], error) {
+	return connect.NewResponse(&brainv1.DeviceHandshakeResponse{SessionToken: "ok"}), nil
+}
+
+func (handshakeStub) ListSessions(ctx context.Context, req *connect.Request[brainv1.ListSessionsRequest]) (*connect.Response[brainv1.ListSessionsResponse], error) {
+	return connect.NewResponse(&brainv1.ListSessionsResponse{}), nil
+}
+
+func TestBrainHandler_RejectsOversizedMessage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(newBrainHandler(handshakeStub{}, brainHandlerConfig{MaxMessageBytes: 1024}))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+
+	_, err := client.DeviceHandshake(context.Background(), connect.NewRequest(&brainv1.DeviceHandshakeRequest{
+		DeviceFingerprint: strings.Repeat("a", 2048),
+	}))
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("expected resource_exhausted for oversized message, got %v", err)
+	}
+
+	resp, err := client.DeviceHandshake(context.Background(), connect.NewRequest(&brainv1.DeviceHandshakeRequest{
+		DeviceFingerprint: "fingerprint",
+	}))
+	if err != nil {
+		t.Fatalf("expected small message to pass, got %v", err)
+	}
+	if resp.Msg.GetSessionToken() != "ok" {
+		t.Fatalf("unexpected response %v", resp.Msg)
+	}
+}
+
+func TestBrainHandler_DeviceBinding(t *testing.T) {
+	t.Setenv("PASETO_KEYS", strings.Repeat("ab", 32))
+
+	mux := http.NewServeMux()
+	mux.Handle(newBrainHandler(handshakeStub{}, brainHandlerConfig{MaxMessageBytes: 1024}, auth.WithDeviceBinding()))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+
+	token, claims, err := auth.MintBoundSession(7, "anonymous", "laptop-fingerprint")
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+	if claims.DeviceHash != auth.HashFingerprint("laptop-fingerprint") {
+		t.Fatalf("expected the token to carry the fingerprint hash, got %q", claims.DeviceHash)
+	}
+
+	listSessions := func(fingerprint string) error {
+		req := connect.NewRequest(&brainv1.ListSessionsRequest{})
+		req.Header().Set("Authorization", "Bearer "+token)
+		if fingerprint != "" {
+			req.Header().Set(auth.DeviceFingerprintHeader, fingerprint)
+		}
+		_, err := client.ListSessions(context.Background(), req)
+		return err
+	}
+
+	if err := listSessions("laptop-fingerprint"); err != nil {
+		t.Fatalf("expected the bound device to pass, got %v", err)
+	}
+	for _, fingerprint := range []string{"stolen-elsewhere", ""} {
+		if err := listSessions(fingerprint); connect.CodeOf(err) != connect.CodeUnauthenticated {
+			t.Errorf("fingerprint %q: expected unauthenticated, got %v", fingerprint, err)
+		}
+	}
+}
+
+func TestBrainHandler_MissingKeysAreNotUnauthenticated(t *testing.T) {
+	t.Setenv("PASETO_KEYS", strings.Repeat("ab", 32))
+
+	mux := http.NewServeMux()
+	mux.Handle(newBrainHandler(handshakeStub{}, brainHandlerConfig{MaxMessageBytes: 1024}))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+	listSessions := func(token string) error {
+		req := connect.NewRequest(&brainv1.ListSessionsRequest{})
+		req.Header().Set("Authorization", "Bearer "+token)
+		_, err := client.ListSessions(context.Background(), req)
+		return err
+	}
+
+	token, err := auth.MintToken(7, "anonymous")
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+	if err := listSessions("v2.local.not-a-token"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated for a bad token, got %v", err)
+	}
+
+	// The same valid token fails on the server's side once the keys are gone
+	t.Setenv("PASETO_KEYS", "")
+	if err := listSessions(token); connect.CodeOf(err) != connect.CodeInternal {
+		t.Fatalf("expected internal without keys, got %v", err)
+	}
+}
+
+func TestCheckKeys(t *testing.T) {
+	for name, tc := range map[string]struct {
+		keys    string
+		wantErr bool
+	}{
+		"unset":        {keys: "", wantErr: true},
+		"invalid hex":  {keys: "not-hex", wantErr: true},
+		"short key":    {keys: strings.Repeat("ab", 16), wantErr: true},
+		"valid":        {keys: strings.Repeat("ab", 32)},
+		"rotated keys": {keys: strings.Repeat("ab", 32) + "," + strings.Repeat("cd", 32)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("PASETO_KEYS", tc.keys)
+			err := auth.CheckKeys()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, auth.ErrKeysNotConfigured) {
+				t.Fatalf("expected ErrKeysNotConfigured, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBrainHandler_GzipNegotiation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		compression bool
+		wantGzip    bool
+	}{
+		"enabled":  {compression: true, wantGzip: true},
+		"disabled": {compression: false, wantGzip: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle(newBrainHandler(handshakeStub{}, brainHandlerConfig{MaxMessageBytes: 1024, Compression: tc.compression}))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			// Setting Accept-Encoding ourselves keeps the transport from transparently decompressing
+			req, _ := http.NewRequest(http.MethodPost, srv.URL+brainv1connect.BrainServiceDeviceHandshakeProcedure, strings.NewReader(`{"deviceFingerprint":"fingerprint"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Encoding", "gzip")
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body := io.Reader(resp.Body)
+			if gzipped := resp.Header.Get("Content-Encoding") == "gzip"; gzipped != tc.wantGzip {
+				t.Fatalf("expected gzip %v, got Content-Encoding %q", tc.wantGzip, resp.Header.Get("Content-Encoding"))
+			} else if gzipped {
+				if body, err = gzip.NewReader(resp.Body); err != nil {
+					t.Fatalf("invalid gzip body: %v", err)
+				}
+			}
+			decoded, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if !strings.Contains(string(decoded), `"sessionToken":"ok"`) {
+				t.Fatalf("unexpected response %s", decoded)
+			}
+
+			// Connect clients negotiate gzip on their own and decode the response
+			client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+			res, err := client.DeviceHandshake(context.Background(), connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "fingerprint"}))
+			if err != nil || res.Msg.GetSessionToken() != "ok" {
+				t.Fatalf("expected the connect client to decode the response, got %v, %v", res, err)
+			}
+		})
+	}
+}
+
+func TestOpenTurso_FailsFastWhenUnreachable(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	start := time.Now()
+	_, err := openTurso(context.Background(), srv.URL, "secret-token", 100*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected the startup ping to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected startup to fail within the timeout, took %s", elapsed)
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected the error to leave out the token, got %v", err)
+	}
+
+	// A refused connection fails without waiting for the timeout
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	if _, err := openTurso(context.Background(), closed.URL, "", time.Minute); err == nil {
+		t.Fatal("expected an unreachable database to fail startup")
+	}
+}
+
+func TestDebugServer_ServesVars(t *testing.T) {
+	srv := httptest.NewServer(newDebugServer("").Handler)
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/debug/vars")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"gemini_reachable"`) {
+		t.Fatalf("expected the debug vars, got %d %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,88 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/focusd-so/brain/internal/brain"
+	"github.com/joho/godotenv"
+	"github.com/urfave/cli/v3"
+)
+
+// redacted replaces secret values that are set in printed configuration
+const redacted = "[redacted]"
+
+// secretFlags lists serve flags whose values must never be printed
+var secretFlags = map[string]bool{
+	"turso-db-token": true,
+}
+
+// ConfigCommand prints the effective configuration and exits
+var ConfigCommand = &cli.Command{
+	Name:  "config",
+	Usage: "print the effective configuration as JSON",
+	Flags: flags,
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		if err := loadDotEnv(cmd); err != nil {
+			return err
+		}
+		return printConfig(os.Stdout, cmd)
+	},
+}
+
+// loadDotEnv loads .env without overriding variables already set. Flags read
+// their environment variables while parsing, before any action runs, so flags
+// still unset are then resolved again from the updated environment.
+func loadDotEnv(cmd *cli.Command) error {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: Error loading .env file")
+		return nil
+	}
+
+	for _, f := range cmd.Flags {
+		name := f.Names()[0]
+		envFlag, ok := f.(cli.DocGenerationFlag)
+		if !ok || cmd.IsSet(name) {
+			continue
+		}
+		for _, key := range envFlag.GetEnvVars() {
+			if value, ok := os.LookupEnv(key); ok {
+				if err := cmd.Set(name, value); err != nil {
+					return fmt.Errorf("invalid %s in .env: %w", key, err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// printConfig writes every resolved flag and environment setting as JSON,
+// redacting secrets that are set.
+func printConfig(w io.Writer, cmd *cli.Command) error {
+	config := map[string]string{}
+
+	for _, f := range flags {
+		name := f.Names()[0]
+		config[name] = redact(fmt.Sprint(cmd.Value(name)), secretFlags[name])
+	}
+
+	for _, setting := range brain.Settings() {
+		config[setting.Key] = redact(setting.Value, setting.Secret)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(config)
+}
+
+func redact(value string, secret bool) string {
+	if secret && value != "" {
+		return redacted
+	}
+	return value
+}
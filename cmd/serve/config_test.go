@@ -0,0 +1,109 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestPrintConfig_RedactsSecrets(t *testing.T) {
+	t.Setenv("PORT", "9000")
+	t.Setenv("TURSO_CONNECTION_TOKEN", "turso-secret")
+	t.Setenv("GEMINI_API_KEY", "gemini-secret")
+	t.Setenv("HMAC_SECRET_KEY", "hmac-secret")
+	t.Setenv("AGENT_APP_NAME", "focusd-test")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	var out bytes.Buffer
+	cmd := &cli.Command{
+		Name:  "config",
+		Flags: flags,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return printConfig(&out, cmd)
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"config"}); err != nil {
+		t.Fatalf("config command failed: %v", err)
+	}
+
+	var config map[string]string
+	if err := json.Unmarshal(out.Bytes(), &config); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+
+	for key, want := range map[string]string{
+		"port":                  "9000",
+		"gemini-probe-interval": "1m0s",
+		"AGENT_APP_NAME":        "focusd-test",
+		"turso-db-token":        redacted,
+		"GEMINI_API_KEY":        redacted,
+		"HMAC_SECRET_KEY":       redacted,
+		"OPENAI_API_KEY":        "",
+	} {
+		got, ok := config[key]
+		if !ok {
+			t.Errorf("expected key %q in config output", key)
+			continue
+		}
+		if got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	for _, secret := range []string{"turso-secret", "gemini-secret", "hmac-secret"} {
+		if bytes.Contains(out.Bytes(), []byte(secret)) {
+			t.Errorf("secret %q leaked into config output", secret)
+		}
+	}
+}
+
+func TestPrintConfig_LoadsDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	dotEnv := "COMPRESS_MIN_BYTES=2048\nGEMINI_PROBE_INTERVAL=5m\nAGENT_APP_NAME=from-dotenv\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(dotEnv), 0o600); err != nil {
+		t.Fatalf("failed to write .env: %v", err)
+	}
+	t.Chdir(dir)
+
+	// Restored once the test ends, since loading .env sets them process-wide
+	for _, key := range []string{"COMPRESS_MIN_BYTES", "AGENT_APP_NAME"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	// Variables already set win over .env
+	t.Setenv("GEMINI_PROBE_INTERVAL", "2m")
+
+	var out bytes.Buffer
+	cmd := &cli.Command{
+		Name:  "config",
+		Flags: flags,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := loadDotEnv(cmd); err != nil {
+				return err
+			}
+			return printConfig(&out, cmd)
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"config"}); err != nil {
+		t.Fatalf("config command failed: %v", err)
+	}
+
+	var config map[string]string
+	if err := json.Unmarshal(out.Bytes(), &config); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	for key, want := range map[string]string{
+		"compress-min-bytes":    "2048",
+		"gemini-probe-interval": "2m0s",
+		"AGENT_APP_NAME":        "from-dotenv",
+	} {
+		if got := config[key]; got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package serve
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"gorm.io/gorm"
+)
+
+// migratedModels are the tables serve creates and migrates at startup
+var migratedModels = []any{
+	&commonv1.UserORM{},
+	&commonv1.NonceORM{},
+	&commonv1.SessionORM{},
+	&commonv1.PromptHistoryORM{},
+	&commonv1.LinkedProviderORM{},
+	&commonv1.ClassificationVoteORM{},
+	&commonv1.ClassificationOverrideORM{},
+	&commonv1.GlobalClassificationOverrideORM{},
+	&commonv1.FocusSessionORM{},
+	&commonv1.FocusSessionEventORM{},
+	&commonv1.DetectedProjectORM{},
+	&commonv1.PromptAddendumORM{},
+}
+
+var errMigrationsNotAllowed = errors.New("expensive migrations pending")
+
+// pendingMigration is what AutoMigrate would change in one table
+type pendingMigration struct {
+	Table          string
+	NewTable       bool
+	HasRows        bool
+	MissingColumns []string
+	ChangedColumns []string
+	MissingIndexes []string
+}
+
+// pending reports whether the table needs any change
+func (m pendingMigration) pending() bool {
+	return m.NewTable || len(m.MissingColumns) > 0 || len(m.ChangedColumns) > 0 || len(m.MissingIndexes) > 0
+}
+
+// expensive reports whether the migration has to rewrite or index existing
+// rows. Creating tables and adding columns is cheap in SQLite, but changing a
+// column rebuilds the table and an index scans all of it.
+func (m pendingMigration) expensive() bool {
+	return m.HasRows && (len(m.ChangedColumns) > 0 || len(m.MissingIndexes) > 0)
+}
+
+// planMigrations compares the current schema with the one models expect
+func planMigrations(db *gorm.DB, models ...any) ([]pendingMigration, error) {
+	migrator := db.Migrator()
+	var plan []pendingMigration
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse %T: %w", model, err)
+		}
+		m := pendingMigration{Table: stmt.Schema.Table}
+		if !migrator.HasTable(model) {
+			m.NewTable = true
+			plan = append(plan, m)
+			continue
+		}
+
+		var rows []int
+		if err := db.Table(m.Table).Select("1").Limit(1).Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to inspect %s: %w", m.Table, err)
+		}
+		m.HasRows = len(rows) > 0
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", m.Table, err)
+		}
+		current := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, columnType := range columnTypes {
+			current[strings.ToLower(columnType.Name())] = columnType
+		}
+
+		for _, name := range stmt.Schema.DBNames {
+			field := stmt.Schema.FieldsByDBName[name]
+			columnType, ok := current[strings.ToLower(name)]
+			if !ok {
+				m.MissingColumns = append(m.MissingColumns, name)
+				continue
+			}
+			if !field.PrimaryKey && !sameColumnType(migrator, migrator.FullDataTypeOf(field).SQL, columnType.DatabaseTypeName()) {
+				m.ChangedColumns = append(m.ChangedColumns, name)
+			}
+		}
+
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, index.Name) {
+				m.MissingIndexes = append(m.MissingIndexes, index.Name)
+			}
+		}
+
+		if m.pending() {
+			plan = append(plan, m)
+		}
+	}
+	return plan, nil
+}
+
+// sameColumnType follows AutoMigrate in treating a column as unchanged when
+// the target type starts with the current one or one of its aliases
+func sameColumnType(migrator gorm.Migrator, target, current string) bool {
+	target = strings.ToLower(strings.TrimSpace(target))
+	current = strings.ToLower(current)
+	if strings.HasPrefix(target, current) {
+		return true
+	}
+	for _, alias := range migrator.GetTypeAliases(current) {
+		if strings.HasPrefix(target, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMigrations logs the pending schema changes and refuses expensive ones
+// unless allowed, so a large table is never rebuilt by surprise on boot
+func checkMigrations(db *gorm.DB, allow bool, models ...any) error {
+	plan, err := planMigrations(db, models...)
+	if err != nil {
+		return err
+	}
+
+	var blocked []string
+	for _, m := range plan {
+		slog.Info("pending migration",
+			"table", m.Table,
+			"new_table", m.NewTable,
+			"has_rows", m.HasRows,
+			"missing_columns", m.MissingColumns,
+			"changed_columns", m.ChangedColumns,
+			"missing_indexes", m.MissingIndexes,
+			"expensive", m.expensive(),
+		)
+		if m.expensive() {
+			blocked = append(blocked, m.Table)
+		}
+	}
+
+	if len(blocked) > 0 && !allow {
+		return fmt.Errorf("%w on %s: they may lock these tables for a long time, run them during a maintenance window with --allow-migrations (ALLOW_MIGRATIONS=true)",
+			errMigrationsNotAllowed, strings.Join(blocked, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package serve
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openMigrationDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// Every connection to :memory: is a separate database, keep to one
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+// createLegacyPromptHistories creates prompt_histories as it was before the
+// similarity key and its index were added
+func createLegacyPromptHistories(t *testing.T, db *gorm.DB, rows int) {
+	t.Helper()
+	if err := db.Exec(`CREATE TABLE prompt_histories (prompt_hash text PRIMARY KEY, response_json TEXT NOT NULL, created_at integer NOT NULL, expires_at integer NOT NULL, last_accessed integer)`).Error; err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for i := range rows {
+		if err := db.Exec(`INSERT INTO prompt_histories VALUES (?, '{}', 1, 2, 0)`, i).Error; err != nil {
+			t.Fatalf("failed to insert: %v", err)
+		}
+	}
+}
+
+func TestCheckMigrations_NothingPendingAfterAutoMigrate(t *testing.T) {
+	db := openMigrationDB(t)
+
+	// A fresh database only creates tables, which never needs the flag
+	if err := checkMigrations(db, false, migratedModels...); err != nil {
+		t.Fatalf("expected creating tables to pass, got %v", err)
+	}
+	if err := db.AutoMigrate(migratedModels...); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	plan, err := planMigrations(db, migratedModels...)
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+	if len(plan) != 0 {
+		t.Fatalf("expected no pending migration, got %+v", plan)
+	}
+}
+
+func TestCheckMigrations_BlocksIndexingPopulatedTable(t *testing.T) {
+	db := openMigrationDB(t)
+	createLegacyPromptHistories(t, db, 3)
+
+	plan, err := planMigrations(db, &commonv1.PromptHistoryORM{})
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+	if len(plan) != 1 || !plan[0].HasRows || !slices.Contains(plan[0].MissingColumns, "similarity_key") || !slices.Contains(plan[0].MissingIndexes, "idx_prompt_history_last_accessed") || !plan[0].expensive() {
+		t.Fatalf("expected an expensive migration of prompt_histories, got %+v", plan)
+	}
+
+	err = checkMigrations(db, false, &commonv1.PromptHistoryORM{})
+	if !errors.Is(err, errMigrationsNotAllowed) {
+		t.Fatalf("expected the gate to block, got %v", err)
+	}
+
+	if err := checkMigrations(db, true, &commonv1.PromptHistoryORM{}); err != nil {
+		t.Fatalf("expected --allow-migrations to pass, got %v", err)
+	}
+}
+
+func TestCheckMigrations_AllowsChangesToEmptyTable(t *testing.T) {
+	db := openMigrationDB(t)
+	createLegacyPromptHistories(t, db, 0)
+
+	if err := checkMigrations(db, false, &commonv1.PromptHistoryORM{}); err != nil {
+		t.Fatalf("expected an empty table to migrate freely, got %v", err)
+	}
+}
@@ -36,12 +36,63 @@ const (
 	// BrainServiceDeviceHandshakeProcedure is the fully-qualified name of the BrainService's
 	// DeviceHandshake RPC.
 	BrainServiceDeviceHandshakeProcedure = "/brain.v1.BrainService/DeviceHandshake"
+	// BrainServiceVerifyHandshakeSignatureProcedure is the fully-qualified name of the BrainService's
+	// VerifyHandshakeSignature RPC.
+	BrainServiceVerifyHandshakeSignatureProcedure = "/brain.v1.BrainService/VerifyHandshakeSignature"
+	// BrainServiceListSessionsProcedure is the fully-qualified name of the BrainService's ListSessions
+	// RPC.
+	BrainServiceListSessionsProcedure = "/brain.v1.BrainService/ListSessions"
+	// BrainServiceRevokeSessionProcedure is the fully-qualified name of the BrainService's
+	// RevokeSession RPC.
+	BrainServiceRevokeSessionProcedure = "/brain.v1.BrainService/RevokeSession"
+	// BrainServiceRevokeAllSessionsProcedure is the fully-qualified name of the BrainService's
+	// RevokeAllSessions RPC.
+	BrainServiceRevokeAllSessionsProcedure = "/brain.v1.BrainService/RevokeAllSessions"
+	// BrainServiceRebindDeviceProcedure is the fully-qualified name of the BrainService's RebindDevice
+	// RPC.
+	BrainServiceRebindDeviceProcedure = "/brain.v1.BrainService/RebindDevice"
 	// BrainServiceClassifyApplicationProcedure is the fully-qualified name of the BrainService's
 	// ClassifyApplication RPC.
 	BrainServiceClassifyApplicationProcedure = "/brain.v1.BrainService/ClassifyApplication"
 	// BrainServiceClassifyWebsiteProcedure is the fully-qualified name of the BrainService's
 	// ClassifyWebsite RPC.
 	BrainServiceClassifyWebsiteProcedure = "/brain.v1.BrainService/ClassifyWebsite"
+	// BrainServiceClassifyDocumentProcedure is the fully-qualified name of the BrainService's
+	// ClassifyDocument RPC.
+	BrainServiceClassifyDocumentProcedure = "/brain.v1.BrainService/ClassifyDocument"
+	// BrainServiceClassifyEmailProcedure is the fully-qualified name of the BrainService's
+	// ClassifyEmail RPC.
+	BrainServiceClassifyEmailProcedure = "/brain.v1.BrainService/ClassifyEmail"
+	// BrainServiceClassifyCommandProcedure is the fully-qualified name of the BrainService's
+	// ClassifyCommand RPC.
+	BrainServiceClassifyCommandProcedure = "/brain.v1.BrainService/ClassifyCommand"
+	// BrainServiceRateClassificationProcedure is the fully-qualified name of the BrainService's
+	// RateClassification RPC.
+	BrainServiceRateClassificationProcedure = "/brain.v1.BrainService/RateClassification"
+	// BrainServiceExportRulesProcedure is the fully-qualified name of the BrainService's ExportRules
+	// RPC.
+	BrainServiceExportRulesProcedure = "/brain.v1.BrainService/ExportRules"
+	// BrainServiceImportRulesProcedure is the fully-qualified name of the BrainService's ImportRules
+	// RPC.
+	BrainServiceImportRulesProcedure = "/brain.v1.BrainService/ImportRules"
+	// BrainServiceSetPromptAddendumProcedure is the fully-qualified name of the BrainService's
+	// SetPromptAddendum RPC.
+	BrainServiceSetPromptAddendumProcedure = "/brain.v1.BrainService/SetPromptAddendum"
+	// BrainServiceGetPromptAddendumProcedure is the fully-qualified name of the BrainService's
+	// GetPromptAddendum RPC.
+	BrainServiceGetPromptAddendumProcedure = "/brain.v1.BrainService/GetPromptAddendum"
+	// BrainServiceGetTaxonomyProcedure is the fully-qualified name of the BrainService's GetTaxonomy
+	// RPC.
+	BrainServiceGetTaxonomyProcedure = "/brain.v1.BrainService/GetTaxonomy"
+	// BrainServiceStartFocusSessionProcedure is the fully-qualified name of the BrainService's
+	// StartFocusSession RPC.
+	BrainServiceStartFocusSessionProcedure = "/brain.v1.BrainService/StartFocusSession"
+	// BrainServiceStopFocusSessionProcedure is the fully-qualified name of the BrainService's
+	// StopFocusSession RPC.
+	BrainServiceStopFocusSessionProcedure = "/brain.v1.BrainService/StopFocusSession"
+	// BrainServiceListDetectedProjectsProcedure is the fully-qualified name of the BrainService's
+	// ListDetectedProjects RPC.
+	BrainServiceListDetectedProjectsProcedure = "/brain.v1.BrainService/ListDetectedProjects"
 	// BrainServiceAgentSessionProcedure is the fully-qualified name of the BrainService's AgentSession
 	// RPC.
 	BrainServiceAgentSessionProcedure = "/brain.v1.BrainService/AgentSession"
@@ -57,6 +108,37 @@ const (
 	// BrainServiceOAuth2RevokeAccessTokenProcedure is the fully-qualified name of the BrainService's
 	// OAuth2RevokeAccessToken RPC.
 	BrainServiceOAuth2RevokeAccessTokenProcedure = "/brain.v1.BrainService/OAuth2RevokeAccessToken"
+	// BrainServiceGetOAuth2StatusProcedure is the fully-qualified name of the BrainService's
+	// GetOAuth2Status RPC.
+	BrainServiceGetOAuth2StatusProcedure = "/brain.v1.BrainService/GetOAuth2Status"
+	// BrainServiceGetGitHubActivityProcedure is the fully-qualified name of the BrainService's
+	// GetGitHubActivity RPC.
+	BrainServiceGetGitHubActivityProcedure = "/brain.v1.BrainService/GetGitHubActivity"
+	// BrainServiceSetClassificationWebhookProcedure is the fully-qualified name of the BrainService's
+	// SetClassificationWebhook RPC.
+	BrainServiceSetClassificationWebhookProcedure = "/brain.v1.BrainService/SetClassificationWebhook"
+	// BrainServiceRunMaintenanceProcedure is the fully-qualified name of the BrainService's
+	// RunMaintenance RPC.
+	BrainServiceRunMaintenanceProcedure = "/brain.v1.BrainService/RunMaintenance"
+	// BrainServiceSetGlobalOverrideProcedure is the fully-qualified name of the BrainService's
+	// SetGlobalOverride RPC.
+	BrainServiceSetGlobalOverrideProcedure = "/brain.v1.BrainService/SetGlobalOverride"
+	// BrainServiceDeleteGlobalOverrideProcedure is the fully-qualified name of the BrainService's
+	// DeleteGlobalOverride RPC.
+	BrainServiceDeleteGlobalOverrideProcedure = "/brain.v1.BrainService/DeleteGlobalOverride"
+	// BrainServiceListGlobalOverridesProcedure is the fully-qualified name of the BrainService's
+	// ListGlobalOverrides RPC.
+	BrainServiceListGlobalOverridesProcedure = "/brain.v1.BrainService/ListGlobalOverrides"
+	// BrainServiceInvalidateCacheProcedure is the fully-qualified name of the BrainService's
+	// InvalidateCache RPC.
+	BrainServiceInvalidateCacheProcedure = "/brain.v1.BrainService/InvalidateCache"
+	// BrainServiceReplayClassificationsProcedure is the fully-qualified name of the BrainService's
+	// ReplayClassifications RPC.
+	BrainServiceReplayClassificationsProcedure = "/brain.v1.BrainService/ReplayClassifications"
+	// BrainServiceMergeUsersProcedure is the fully-qualified name of the BrainService's MergeUsers RPC.
+	BrainServiceMergeUsersProcedure = "/brain.v1.BrainService/MergeUsers"
+	// BrainServiceGetFlagsProcedure is the fully-qualified name of the BrainService's GetFlags RPC.
+	BrainServiceGetFlagsProcedure = "/brain.v1.BrainService/GetFlags"
 )
 
 // BrainServiceClient is a client for the brain.v1.BrainService service.
@@ -66,14 +148,65 @@ type BrainServiceClient interface {
 	// ---------------------------------------------------------
 	// Exchanges a Hardware Fingerprint for a PASETO Session Token.
 	// Note: Request requires HMAC Headers (X-Signature, X-Timestamp, X-Nonce).
+	// Responses and throttled errors carry the caller's remaining handshakes in
+	// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix time).
 	DeviceHandshake(context.Context, *connect.Request[v1.DeviceHandshakeRequest]) (*connect.Response[v1.DeviceHandshakeResponse], error)
+	// Checks a handshake's HMAC headers without creating a session or using up
+	// the nonce, reporting the server's string-to-sign on mismatch. Only
+	// served when HANDSHAKE_DIAGNOSTICS is enabled.
+	VerifyHandshakeSignature(context.Context, *connect.Request[v1.VerifyHandshakeSignatureRequest]) (*connect.Response[v1.VerifyHandshakeSignatureResponse], error)
+	// Lists the authenticated user's active sessions and revokes them. A revoked
+	// session's token is rejected on its next request.
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	RevokeAllSessions(context.Context, *connect.Request[v1.RevokeAllSessionsRequest]) (*connect.Response[v1.RevokeAllSessionsResponse], error)
+	// Moves the authenticated user to a new device fingerprint, e.g. after an
+	// OS reinstall, keeping their account and data. Requires the handshake's
+	// HMAC headers, signed over the new fingerprint.
+	RebindDevice(context.Context, *connect.Request[v1.RebindDeviceRequest]) (*connect.Response[v1.RebindDeviceResponse], error)
 	// ---------------------------------------------------------
 	// CLASSIFICATION
 	// ---------------------------------------------------------
 	// Analyze a specific app window to determine focus level.
 	ClassifyApplication(context.Context, *connect.Request[v1.ClassifyApplicationRequest]) (*connect.Response[v1.ClassifyApplicationResponse], error)
-	// Analyze a URL (browser tab) to determine focus level.
+	// Analyze a URL (browser tab) to determine focus level. With
+	// CLASSIFICATION_HTTPS_ONLY, non-https URLs are rejected as invalid, except
+	// local development servers while developer mode is on.
 	ClassifyWebsite(context.Context, *connect.Request[v1.ClassifyWebsiteRequest]) (*connect.Response[v1.ClassifyWebsiteResponse], error)
+	// Analyze a document path (e.g. "~/work/Q3-report.xlsx") to determine focus level.
+	ClassifyDocument(context.Context, *connect.Request[v1.ClassifyDocumentRequest]) (*connect.Response[v1.ClassifyDocumentResponse], error)
+	// Analyze an email sender and subject to tell work correspondence from newsletters/promotions.
+	ClassifyEmail(context.Context, *connect.Request[v1.ClassifyEmailRequest]) (*connect.Response[v1.ClassifyEmailResponse], error)
+	// Analyze a terminal command (e.g. "go test ./...") to tell running tests from browsing history.
+	// Secret-looking arguments are redacted before classification.
+	ClassifyCommand(context.Context, *connect.Request[v1.ClassifyCommandRequest]) (*connect.Response[v1.ClassifyCommandResponse], error)
+	// Records a thumbs up/down on a classification for prompt-quality monitoring.
+	// Votes never change what is returned for the input.
+	RateClassification(context.Context, *connect.Request[v1.RateClassificationRequest]) (*connect.Response[v1.RateClassificationResponse], error)
+	// Backs up and restores the user's classification overrides as JSON, e.g.
+	// to carry them to another device.
+	ExportRules(context.Context, *connect.Request[v1.ExportRulesRequest]) (*connect.Response[v1.ExportRulesResponse], error)
+	ImportRules(context.Context, *connect.Request[v1.ImportRulesRequest]) (*connect.Response[v1.ImportRulesResponse], error)
+	// Manages rules in plain words appended to every classification prompt,
+	// e.g. "treat our internal tool Atlas as productive". The "user" scope
+	// holds the caller's own rules, the "global" scope the organization's and
+	// requires the "admin" role. An empty text clears the scope.
+	SetPromptAddendum(context.Context, *connect.Request[v1.SetPromptAddendumRequest]) (*connect.Response[v1.SetPromptAddendumResponse], error)
+	GetPromptAddendum(context.Context, *connect.Request[v1.GetPromptAddendumRequest]) (*connect.Response[v1.GetPromptAddendumResponse], error)
+	// Lists the classifications and tags the classifiers may return, for
+	// settings UIs. Sourced from the prompts, so it always matches the server.
+	GetTaxonomy(context.Context, *connect.Request[v1.GetTaxonomyRequest]) (*connect.Response[v1.GetTaxonomyResponse], error)
+	// ---------------------------------------------------------
+	// FOCUS SESSIONS
+	// ---------------------------------------------------------
+	// Starts a focus session, closing any the user left open. Classifications
+	// made while it runs are recorded against it.
+	StartFocusSession(context.Context, *connect.Request[v1.StartFocusSessionRequest]) (*connect.Response[v1.StartFocusSessionResponse], error)
+	// Stops the user's open focus session and summarizes its classifications.
+	StopFocusSession(context.Context, *connect.Request[v1.StopFocusSessionRequest]) (*connect.Response[v1.StopFocusSessionResponse], error)
+	// Lists the projects the user's classifications detected, most recently
+	// seen first. Spelling variants of one project are listed once.
+	ListDetectedProjects(context.Context, *connect.Request[v1.ListDetectedProjectsRequest]) (*connect.Response[v1.ListDetectedProjectsResponse], error)
 	// ---------------------------------------------------------
 	// INTELLIGENCE (AI AGENTS)
 	// ---------------------------------------------------------
@@ -85,6 +218,49 @@ type BrainServiceClient interface {
 	OAuth2ExchangeAuthorizationCode(context.Context, *connect.Request[v1.OAuth2ExchangeAuthorizationCodeRequest]) (*connect.Response[v1.OAuth2ExchangeAuthorizationCodeResponse], error)
 	OAuth2RefreshAccessToken(context.Context, *connect.Request[v1.OAuth2RefreshAccessTokenRequest]) (*connect.Response[v1.OAuth2RefreshAccessTokenResponse], error)
 	OAuth2RevokeAccessToken(context.Context, *connect.Request[v1.OAuth2RevokeAccessTokenRequest]) (*connect.Response[v1.OAuth2RevokeAccessTokenResponse], error)
+	// Lists every supported provider with whether the authenticated user linked it
+	// and the health of the linked token.
+	GetOAuth2Status(context.Context, *connect.Request[v1.GetOAuth2StatusRequest]) (*connect.Response[v1.GetOAuth2StatusResponse], error)
+	// ---------------------------------------------------------
+	// INTEGRATIONS
+	// ---------------------------------------------------------
+	// Counts the user's recent commits to a repository, corroborating code
+	// editor classifications. Uses the client's linked GitHub token.
+	GetGitHubActivity(context.Context, *connect.Request[v1.GetGitHubActivityRequest]) (*connect.Response[v1.GetGitHubActivityResponse], error)
+	// Opts the authenticated user in or out of the classification webhook,
+	// which posts each of their classifications to the configured URL.
+	SetClassificationWebhook(context.Context, *connect.Request[v1.SetClassificationWebhookRequest]) (*connect.Response[v1.SetClassificationWebhookResponse], error)
+	// ---------------------------------------------------------
+	// ADMIN
+	// ---------------------------------------------------------
+	// Purges expired cache, nonce and session rows, optionally vacuuming the database.
+	// Requires a session with the "admin" role.
+	RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error)
+	// Manages the overrides served to every user ahead of the cache and the
+	// model. A user's own overrides still take precedence. Requires the "admin" role.
+	SetGlobalOverride(context.Context, *connect.Request[v1.SetGlobalOverrideRequest]) (*connect.Response[v1.SetGlobalOverrideResponse], error)
+	DeleteGlobalOverride(context.Context, *connect.Request[v1.DeleteGlobalOverrideRequest]) (*connect.Response[v1.DeleteGlobalOverrideResponse], error)
+	ListGlobalOverrides(context.Context, *connect.Request[v1.ListGlobalOverridesRequest]) (*connect.Response[v1.ListGlobalOverridesResponse], error)
+	// Deletes cached classifications matching every given criterion, e.g. all
+	// website entries for one domain after a prompt bug. Requires the "admin" role.
+	InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error)
+	// Replays recent cached classifications through the current prompts and
+	// model and reports how many changed and how, e.g. to check a prompt edit
+	// against real traffic. Entries replay from their cached input alone, so
+	// context that is not part of the cache key, such as a page's metadata, is
+	// not sent again. Read-only unless write_cache is set. Requires the
+	// "admin" role.
+	ReplayClassifications(context.Context, *connect.Request[v1.ReplayClassificationsRequest]) (*connect.Response[v1.ReplayClassificationsResponse], error)
+	// Merges a duplicate user into another, e.g. when two fingerprints turn out
+	// to be one person's devices. The source's overrides, votes, linked
+	// providers, addendum, focus sessions and projects move to the target,
+	// where the target's own rows win on conflict, and the source is deleted
+	// with its sessions revoked. Merging an already deleted source is a no-op.
+	// Requires the "admin" role.
+	MergeUsers(context.Context, *connect.Request[v1.MergeUsersRequest]) (*connect.Response[v1.MergeUsersResponse], error)
+	// Lists the feature flags loaded at startup with their rollout, optionally
+	// evaluated for one user. Requires the "admin" role.
+	GetFlags(context.Context, *connect.Request[v1.GetFlagsRequest]) (*connect.Response[v1.GetFlagsResponse], error)
 }
 
 // NewBrainServiceClient constructs a client for the brain.v1.BrainService service. By default, it
@@ -104,6 +280,36 @@ func NewBrainServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(brainServiceMethods.ByName("DeviceHandshake")),
 			connect.WithClientOptions(opts...),
 		),
+		verifyHandshakeSignature: connect.NewClient[v1.VerifyHandshakeSignatureRequest, v1.VerifyHandshakeSignatureResponse](
+			httpClient,
+			baseURL+BrainServiceVerifyHandshakeSignatureProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("VerifyHandshakeSignature")),
+			connect.WithClientOptions(opts...),
+		),
+		listSessions: connect.NewClient[v1.ListSessionsRequest, v1.ListSessionsResponse](
+			httpClient,
+			baseURL+BrainServiceListSessionsProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ListSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSession: connect.NewClient[v1.RevokeSessionRequest, v1.RevokeSessionResponse](
+			httpClient,
+			baseURL+BrainServiceRevokeSessionProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("RevokeSession")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAllSessions: connect.NewClient[v1.RevokeAllSessionsRequest, v1.RevokeAllSessionsResponse](
+			httpClient,
+			baseURL+BrainServiceRevokeAllSessionsProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("RevokeAllSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		rebindDevice: connect.NewClient[v1.RebindDeviceRequest, v1.RebindDeviceResponse](
+			httpClient,
+			baseURL+BrainServiceRebindDeviceProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("RebindDevice")),
+			connect.WithClientOptions(opts...),
+		),
 		classifyApplication: connect.NewClient[v1.ClassifyApplicationRequest, v1.ClassifyApplicationResponse](
 			httpClient,
 			baseURL+BrainServiceClassifyApplicationProcedure,
@@ -116,6 +322,78 @@ func NewBrainServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(brainServiceMethods.ByName("ClassifyWebsite")),
 			connect.WithClientOptions(opts...),
 		),
+		classifyDocument: connect.NewClient[v1.ClassifyDocumentRequest, v1.ClassifyDocumentResponse](
+			httpClient,
+			baseURL+BrainServiceClassifyDocumentProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ClassifyDocument")),
+			connect.WithClientOptions(opts...),
+		),
+		classifyEmail: connect.NewClient[v1.ClassifyEmailRequest, v1.ClassifyEmailResponse](
+			httpClient,
+			baseURL+BrainServiceClassifyEmailProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ClassifyEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		classifyCommand: connect.NewClient[v1.ClassifyCommandRequest, v1.ClassifyCommandResponse](
+			httpClient,
+			baseURL+BrainServiceClassifyCommandProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ClassifyCommand")),
+			connect.WithClientOptions(opts...),
+		),
+		rateClassification: connect.NewClient[v1.RateClassificationRequest, v1.RateClassificationResponse](
+			httpClient,
+			baseURL+BrainServiceRateClassificationProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("RateClassification")),
+			connect.WithClientOptions(opts...),
+		),
+		exportRules: connect.NewClient[v1.ExportRulesRequest, v1.ExportRulesResponse](
+			httpClient,
+			baseURL+BrainServiceExportRulesProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ExportRules")),
+			connect.WithClientOptions(opts...),
+		),
+		importRules: connect.NewClient[v1.ImportRulesRequest, v1.ImportRulesResponse](
+			httpClient,
+			baseURL+BrainServiceImportRulesProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ImportRules")),
+			connect.WithClientOptions(opts...),
+		),
+		setPromptAddendum: connect.NewClient[v1.SetPromptAddendumRequest, v1.SetPromptAddendumResponse](
+			httpClient,
+			baseURL+BrainServiceSetPromptAddendumProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("SetPromptAddendum")),
+			connect.WithClientOptions(opts...),
+		),
+		getPromptAddendum: connect.NewClient[v1.GetPromptAddendumRequest, v1.GetPromptAddendumResponse](
+			httpClient,
+			baseURL+BrainServiceGetPromptAddendumProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("GetPromptAddendum")),
+			connect.WithClientOptions(opts...),
+		),
+		getTaxonomy: connect.NewClient[v1.GetTaxonomyRequest, v1.GetTaxonomyResponse](
+			httpClient,
+			baseURL+BrainServiceGetTaxonomyProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("GetTaxonomy")),
+			connect.WithClientOptions(opts...),
+		),
+		startFocusSession: connect.NewClient[v1.StartFocusSessionRequest, v1.StartFocusSessionResponse](
+			httpClient,
+			baseURL+BrainServiceStartFocusSessionProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("StartFocusSession")),
+			connect.WithClientOptions(opts...),
+		),
+		stopFocusSession: connect.NewClient[v1.StopFocusSessionRequest, v1.StopFocusSessionResponse](
+			httpClient,
+			baseURL+BrainServiceStopFocusSessionProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("StopFocusSession")),
+			connect.WithClientOptions(opts...),
+		),
+		listDetectedProjects: connect.NewClient[v1.ListDetectedProjectsRequest, v1.ListDetectedProjectsResponse](
+			httpClient,
+			baseURL+BrainServiceListDetectedProjectsProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ListDetectedProjects")),
+			connect.WithClientOptions(opts...),
+		),
 		agentSession: connect.NewClient[v1.AgentSessionRequest, v1.AgentSessionResponse](
 			httpClient,
 			baseURL+BrainServiceAgentSessionProcedure,
@@ -146,19 +424,113 @@ func NewBrainServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(brainServiceMethods.ByName("OAuth2RevokeAccessToken")),
 			connect.WithClientOptions(opts...),
 		),
+		getOAuth2Status: connect.NewClient[v1.GetOAuth2StatusRequest, v1.GetOAuth2StatusResponse](
+			httpClient,
+			baseURL+BrainServiceGetOAuth2StatusProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("GetOAuth2Status")),
+			connect.WithClientOptions(opts...),
+		),
+		getGitHubActivity: connect.NewClient[v1.GetGitHubActivityRequest, v1.GetGitHubActivityResponse](
+			httpClient,
+			baseURL+BrainServiceGetGitHubActivityProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("GetGitHubActivity")),
+			connect.WithClientOptions(opts...),
+		),
+		setClassificationWebhook: connect.NewClient[v1.SetClassificationWebhookRequest, v1.SetClassificationWebhookResponse](
+			httpClient,
+			baseURL+BrainServiceSetClassificationWebhookProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("SetClassificationWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		runMaintenance: connect.NewClient[v1.RunMaintenanceRequest, v1.RunMaintenanceResponse](
+			httpClient,
+			baseURL+BrainServiceRunMaintenanceProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("RunMaintenance")),
+			connect.WithClientOptions(opts...),
+		),
+		setGlobalOverride: connect.NewClient[v1.SetGlobalOverrideRequest, v1.SetGlobalOverrideResponse](
+			httpClient,
+			baseURL+BrainServiceSetGlobalOverrideProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("SetGlobalOverride")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteGlobalOverride: connect.NewClient[v1.DeleteGlobalOverrideRequest, v1.DeleteGlobalOverrideResponse](
+			httpClient,
+			baseURL+BrainServiceDeleteGlobalOverrideProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("DeleteGlobalOverride")),
+			connect.WithClientOptions(opts...),
+		),
+		listGlobalOverrides: connect.NewClient[v1.ListGlobalOverridesRequest, v1.ListGlobalOverridesResponse](
+			httpClient,
+			baseURL+BrainServiceListGlobalOverridesProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ListGlobalOverrides")),
+			connect.WithClientOptions(opts...),
+		),
+		invalidateCache: connect.NewClient[v1.InvalidateCacheRequest, v1.InvalidateCacheResponse](
+			httpClient,
+			baseURL+BrainServiceInvalidateCacheProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("InvalidateCache")),
+			connect.WithClientOptions(opts...),
+		),
+		replayClassifications: connect.NewClient[v1.ReplayClassificationsRequest, v1.ReplayClassificationsResponse](
+			httpClient,
+			baseURL+BrainServiceReplayClassificationsProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("ReplayClassifications")),
+			connect.WithClientOptions(opts...),
+		),
+		mergeUsers: connect.NewClient[v1.MergeUsersRequest, v1.MergeUsersResponse](
+			httpClient,
+			baseURL+BrainServiceMergeUsersProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("MergeUsers")),
+			connect.WithClientOptions(opts...),
+		),
+		getFlags: connect.NewClient[v1.GetFlagsRequest, v1.GetFlagsResponse](
+			httpClient,
+			baseURL+BrainServiceGetFlagsProcedure,
+			connect.WithSchema(brainServiceMethods.ByName("GetFlags")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // brainServiceClient implements BrainServiceClient.
 type brainServiceClient struct {
 	deviceHandshake                 *connect.Client[v1.DeviceHandshakeRequest, v1.DeviceHandshakeResponse]
+	verifyHandshakeSignature        *connect.Client[v1.VerifyHandshakeSignatureRequest, v1.VerifyHandshakeSignatureResponse]
+	listSessions                    *connect.Client[v1.ListSessionsRequest, v1.ListSessionsResponse]
+	revokeSession                   *connect.Client[v1.RevokeSessionRequest, v1.RevokeSessionResponse]
+	revokeAllSessions               *connect.Client[v1.RevokeAllSessionsRequest, v1.RevokeAllSessionsResponse]
+	rebindDevice                    *connect.Client[v1.RebindDeviceRequest, v1.RebindDeviceResponse]
 	classifyApplication             *connect.Client[v1.ClassifyApplicationRequest, v1.ClassifyApplicationResponse]
 	classifyWebsite                 *connect.Client[v1.ClassifyWebsiteRequest, v1.ClassifyWebsiteResponse]
+	classifyDocument                *connect.Client[v1.ClassifyDocumentRequest, v1.ClassifyDocumentResponse]
+	classifyEmail                   *connect.Client[v1.ClassifyEmailRequest, v1.ClassifyEmailResponse]
+	classifyCommand                 *connect.Client[v1.ClassifyCommandRequest, v1.ClassifyCommandResponse]
+	rateClassification              *connect.Client[v1.RateClassificationRequest, v1.RateClassificationResponse]
+	exportRules                     *connect.Client[v1.ExportRulesRequest, v1.ExportRulesResponse]
+	importRules                     *connect.Client[v1.ImportRulesRequest, v1.ImportRulesResponse]
+	setPromptAddendum               *connect.Client[v1.SetPromptAddendumRequest, v1.SetPromptAddendumResponse]
+	getPromptAddendum               *connect.Client[v1.GetPromptAddendumRequest, v1.GetPromptAddendumResponse]
+	getTaxonomy                     *connect.Client[v1.GetTaxonomyRequest, v1.GetTaxonomyResponse]
+	startFocusSession               *connect.Client[v1.StartFocusSessionRequest, v1.StartFocusSessionResponse]
+	stopFocusSession                *connect.Client[v1.StopFocusSessionRequest, v1.StopFocusSessionResponse]
+	listDetectedProjects            *connect.Client[v1.ListDetectedProjectsRequest, v1.ListDetectedProjectsResponse]
 	agentSession                    *connect.Client[v1.AgentSessionRequest, v1.AgentSessionResponse]
 	oAuth2GetAuthorizationURL       *connect.Client[v1.OAuth2GetAuthorizationURLRequest, v1.OAuth2GetAuthorizationURLResponse]
 	oAuth2ExchangeAuthorizationCode *connect.Client[v1.OAuth2ExchangeAuthorizationCodeRequest, v1.OAuth2ExchangeAuthorizationCodeResponse]
 	oAuth2RefreshAccessToken        *connect.Client[v1.OAuth2RefreshAccessTokenRequest, v1.OAuth2RefreshAccessTokenResponse]
 	oAuth2RevokeAccessToken         *connect.Client[v1.OAuth2RevokeAccessTokenRequest, v1.OAuth2RevokeAccessTokenResponse]
+	getOAuth2Status                 *connect.Client[v1.GetOAuth2StatusRequest, v1.GetOAuth2StatusResponse]
+	getGitHubActivity               *connect.Client[v1.GetGitHubActivityRequest, v1.GetGitHubActivityResponse]
+	setClassificationWebhook        *connect.Client[v1.SetClassificationWebhookRequest, v1.SetClassificationWebhookResponse]
+	runMaintenance                  *connect.Client[v1.RunMaintenanceRequest, v1.RunMaintenanceResponse]
+	setGlobalOverride               *connect.Client[v1.SetGlobalOverrideRequest, v1.SetGlobalOverrideResponse]
+	deleteGlobalOverride            *connect.Client[v1.DeleteGlobalOverrideRequest, v1.DeleteGlobalOverrideResponse]
+	listGlobalOverrides             *connect.Client[v1.ListGlobalOverridesRequest, v1.ListGlobalOverridesResponse]
+	invalidateCache                 *connect.Client[v1.InvalidateCacheRequest, v1.InvalidateCacheResponse]
+	replayClassifications           *connect.Client[v1.ReplayClassificationsRequest, v1.ReplayClassificationsResponse]
+	mergeUsers                      *connect.Client[v1.MergeUsersRequest, v1.MergeUsersResponse]
+	getFlags                        *connect.Client[v1.GetFlagsRequest, v1.GetFlagsResponse]
 }
 
 // DeviceHandshake calls brain.v1.BrainService.DeviceHandshake.
@@ -166,6 +538,31 @@ func (c *brainServiceClient) DeviceHandshake(ctx context.Context, req *connect.R
 	return c.deviceHandshake.CallUnary(ctx, req)
 }
 
+// VerifyHandshakeSignature calls brain.v1.BrainService.VerifyHandshakeSignature.
+func (c *brainServiceClient) VerifyHandshakeSignature(ctx context.Context, req *connect.Request[v1.VerifyHandshakeSignatureRequest]) (*connect.Response[v1.VerifyHandshakeSignatureResponse], error) {
+	return c.verifyHandshakeSignature.CallUnary(ctx, req)
+}
+
+// ListSessions calls brain.v1.BrainService.ListSessions.
+func (c *brainServiceClient) ListSessions(ctx context.Context, req *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return c.listSessions.CallUnary(ctx, req)
+}
+
+// RevokeSession calls brain.v1.BrainService.RevokeSession.
+func (c *brainServiceClient) RevokeSession(ctx context.Context, req *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return c.revokeSession.CallUnary(ctx, req)
+}
+
+// RevokeAllSessions calls brain.v1.BrainService.RevokeAllSessions.
+func (c *brainServiceClient) RevokeAllSessions(ctx context.Context, req *connect.Request[v1.RevokeAllSessionsRequest]) (*connect.Response[v1.RevokeAllSessionsResponse], error) {
+	return c.revokeAllSessions.CallUnary(ctx, req)
+}
+
+// RebindDevice calls brain.v1.BrainService.RebindDevice.
+func (c *brainServiceClient) RebindDevice(ctx context.Context, req *connect.Request[v1.RebindDeviceRequest]) (*connect.Response[v1.RebindDeviceResponse], error) {
+	return c.rebindDevice.CallUnary(ctx, req)
+}
+
 // ClassifyApplication calls brain.v1.BrainService.ClassifyApplication.
 func (c *brainServiceClient) ClassifyApplication(ctx context.Context, req *connect.Request[v1.ClassifyApplicationRequest]) (*connect.Response[v1.ClassifyApplicationResponse], error) {
 	return c.classifyApplication.CallUnary(ctx, req)
@@ -176,6 +573,66 @@ func (c *brainServiceClient) ClassifyWebsite(ctx context.Context, req *connect.R
 	return c.classifyWebsite.CallUnary(ctx, req)
 }
 
+// ClassifyDocument calls brain.v1.BrainService.ClassifyDocument.
+func (c *brainServiceClient) ClassifyDocument(ctx context.Context, req *connect.Request[v1.ClassifyDocumentRequest]) (*connect.Response[v1.ClassifyDocumentResponse], error) {
+	return c.classifyDocument.CallUnary(ctx, req)
+}
+
+// ClassifyEmail calls brain.v1.BrainService.ClassifyEmail.
+func (c *brainServiceClient) ClassifyEmail(ctx context.Context, req *connect.Request[v1.ClassifyEmailRequest]) (*connect.Response[v1.ClassifyEmailResponse], error) {
+	return c.classifyEmail.CallUnary(ctx, req)
+}
+
+// ClassifyCommand calls brain.v1.BrainService.ClassifyCommand.
+func (c *brainServiceClient) ClassifyCommand(ctx context.Context, req *connect.Request[v1.ClassifyCommandRequest]) (*connect.Response[v1.ClassifyCommandResponse], error) {
+	return c.classifyCommand.CallUnary(ctx, req)
+}
+
+// RateClassification calls brain.v1.BrainService.RateClassification.
+func (c *brainServiceClient) RateClassification(ctx context.Context, req *connect.Request[v1.RateClassificationRequest]) (*connect.Response[v1.RateClassificationResponse], error) {
+	return c.rateClassification.CallUnary(ctx, req)
+}
+
+// ExportRules calls brain.v1.BrainService.ExportRules.
+func (c *brainServiceClient) ExportRules(ctx context.Context, req *connect.Request[v1.ExportRulesRequest]) (*connect.Response[v1.ExportRulesResponse], error) {
+	return c.exportRules.CallUnary(ctx, req)
+}
+
+// ImportRules calls brain.v1.BrainService.ImportRules.
+func (c *brainServiceClient) ImportRules(ctx context.Context, req *connect.Request[v1.ImportRulesRequest]) (*connect.Response[v1.ImportRulesResponse], error) {
+	return c.importRules.CallUnary(ctx, req)
+}
+
+// SetPromptAddendum calls brain.v1.BrainService.SetPromptAddendum.
+func (c *brainServiceClient) SetPromptAddendum(ctx context.Context, req *connect.Request[v1.SetPromptAddendumRequest]) (*connect.Response[v1.SetPromptAddendumResponse], error) {
+	return c.setPromptAddendum.CallUnary(ctx, req)
+}
+
+// GetPromptAddendum calls brain.v1.BrainService.GetPromptAddendum.
+func (c *brainServiceClient) GetPromptAddendum(ctx context.Context, req *connect.Request[v1.GetPromptAddendumRequest]) (*connect.Response[v1.GetPromptAddendumResponse], error) {
+	return c.getPromptAddendum.CallUnary(ctx, req)
+}
+
+// GetTaxonomy calls brain.v1.BrainService.GetTaxonomy.
+func (c *brainServiceClient) GetTaxonomy(ctx context.Context, req *connect.Request[v1.GetTaxonomyRequest]) (*connect.Response[v1.GetTaxonomyResponse], error) {
+	return c.getTaxonomy.CallUnary(ctx, req)
+}
+
+// StartFocusSession calls brain.v1.BrainService.StartFocusSession.
+func (c *brainServiceClient) StartFocusSession(ctx context.Context, req *connect.Request[v1.StartFocusSessionRequest]) (*connect.Response[v1.StartFocusSessionResponse], error) {
+	return c.startFocusSession.CallUnary(ctx, req)
+}
+
+// StopFocusSession calls brain.v1.BrainService.StopFocusSession.
+func (c *brainServiceClient) StopFocusSession(ctx context.Context, req *connect.Request[v1.StopFocusSessionRequest]) (*connect.Response[v1.StopFocusSessionResponse], error) {
+	return c.stopFocusSession.CallUnary(ctx, req)
+}
+
+// ListDetectedProjects calls brain.v1.BrainService.ListDetectedProjects.
+func (c *brainServiceClient) ListDetectedProjects(ctx context.Context, req *connect.Request[v1.ListDetectedProjectsRequest]) (*connect.Response[v1.ListDetectedProjectsResponse], error) {
+	return c.listDetectedProjects.CallUnary(ctx, req)
+}
+
 // AgentSession calls brain.v1.BrainService.AgentSession.
 func (c *brainServiceClient) AgentSession(ctx context.Context) *connect.BidiStreamForClient[v1.AgentSessionRequest, v1.AgentSessionResponse] {
 	return c.agentSession.CallBidiStream(ctx)
@@ -201,6 +658,61 @@ func (c *brainServiceClient) OAuth2RevokeAccessToken(ctx context.Context, req *c
 	return c.oAuth2RevokeAccessToken.CallUnary(ctx, req)
 }
 
+// GetOAuth2Status calls brain.v1.BrainService.GetOAuth2Status.
+func (c *brainServiceClient) GetOAuth2Status(ctx context.Context, req *connect.Request[v1.GetOAuth2StatusRequest]) (*connect.Response[v1.GetOAuth2StatusResponse], error) {
+	return c.getOAuth2Status.CallUnary(ctx, req)
+}
+
+// GetGitHubActivity calls brain.v1.BrainService.GetGitHubActivity.
+func (c *brainServiceClient) GetGitHubActivity(ctx context.Context, req *connect.Request[v1.GetGitHubActivityRequest]) (*connect.Response[v1.GetGitHubActivityResponse], error) {
+	return c.getGitHubActivity.CallUnary(ctx, req)
+}
+
+// SetClassificationWebhook calls brain.v1.BrainService.SetClassificationWebhook.
+func (c *brainServiceClient) SetClassificationWebhook(ctx context.Context, req *connect.Request[v1.SetClassificationWebhookRequest]) (*connect.Response[v1.SetClassificationWebhookResponse], error) {
+	return c.setClassificationWebhook.CallUnary(ctx, req)
+}
+
+// RunMaintenance calls brain.v1.BrainService.RunMaintenance.
+func (c *brainServiceClient) RunMaintenance(ctx context.Context, req *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error) {
+	return c.runMaintenance.CallUnary(ctx, req)
+}
+
+// SetGlobalOverride calls brain.v1.BrainService.SetGlobalOverride.
+func (c *brainServiceClient) SetGlobalOverride(ctx context.Context, req *connect.Request[v1.SetGlobalOverrideRequest]) (*connect.Response[v1.SetGlobalOverrideResponse], error) {
+	return c.setGlobalOverride.CallUnary(ctx, req)
+}
+
+// DeleteGlobalOverride calls brain.v1.BrainService.DeleteGlobalOverride.
+func (c *brainServiceClient) DeleteGlobalOverride(ctx context.Context, req *connect.Request[v1.DeleteGlobalOverrideRequest]) (*connect.Response[v1.DeleteGlobalOverrideResponse], error) {
+	return c.deleteGlobalOverride.CallUnary(ctx, req)
+}
+
+// ListGlobalOverrides calls brain.v1.BrainService.ListGlobalOverrides.
+func (c *brainServiceClient) ListGlobalOverrides(ctx context.Context, req *connect.Request[v1.ListGlobalOverridesRequest]) (*connect.Response[v1.ListGlobalOverridesResponse], error) {
+	return c.listGlobalOverrides.CallUnary(ctx, req)
+}
+
+// InvalidateCache calls brain.v1.BrainService.InvalidateCache.
+func (c *brainServiceClient) InvalidateCache(ctx context.Context, req *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error) {
+	return c.invalidateCache.CallUnary(ctx, req)
+}
+
+// ReplayClassifications calls brain.v1.BrainService.ReplayClassifications.
+func (c *brainServiceClient) ReplayClassifications(ctx context.Context, req *connect.Request[v1.ReplayClassificationsRequest]) (*connect.Response[v1.ReplayClassificationsResponse], error) {
+	return c.replayClassifications.CallUnary(ctx, req)
+}
+
+// MergeUsers calls brain.v1.BrainService.MergeUsers.
+func (c *brainServiceClient) MergeUsers(ctx context.Context, req *connect.Request[v1.MergeUsersRequest]) (*connect.Response[v1.MergeUsersResponse], error) {
+	return c.mergeUsers.CallUnary(ctx, req)
+}
+
+// GetFlags calls brain.v1.BrainService.GetFlags.
+func (c *brainServiceClient) GetFlags(ctx context.Context, req *connect.Request[v1.GetFlagsRequest]) (*connect.Response[v1.GetFlagsResponse], error) {
+	return c.getFlags.CallUnary(ctx, req)
+}
+
 // BrainServiceHandler is an implementation of the brain.v1.BrainService service.
 type BrainServiceHandler interface {
 	// ---------------------------------------------------------
@@ -208,14 +720,65 @@ type BrainServiceHandler interface {
 	// ---------------------------------------------------------
 	// Exchanges a Hardware Fingerprint for a PASETO Session Token.
 	// Note: Request requires HMAC Headers (X-Signature, X-Timestamp, X-Nonce).
+	// Responses and throttled errors carry the caller's remaining handshakes in
+	// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix time).
 	DeviceHandshake(context.Context, *connect.Request[v1.DeviceHandshakeRequest]) (*connect.Response[v1.DeviceHandshakeResponse], error)
+	// Checks a handshake's HMAC headers without creating a session or using up
+	// the nonce, reporting the server's string-to-sign on mismatch. Only
+	// served when HANDSHAKE_DIAGNOSTICS is enabled.
+	VerifyHandshakeSignature(context.Context, *connect.Request[v1.VerifyHandshakeSignatureRequest]) (*connect.Response[v1.VerifyHandshakeSignatureResponse], error)
+	// Lists the authenticated user's active sessions and revokes them. A revoked
+	// session's token is rejected on its next request.
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	RevokeAllSessions(context.Context, *connect.Request[v1.RevokeAllSessionsRequest]) (*connect.Response[v1.RevokeAllSessionsResponse], error)
+	// Moves the authenticated user to a new device fingerprint, e.g. after an
+	// OS reinstall, keeping their account and data. Requires the handshake's
+	// HMAC headers, signed over the new fingerprint.
+	RebindDevice(context.Context, *connect.Request[v1.RebindDeviceRequest]) (*connect.Response[v1.RebindDeviceResponse], error)
 	// ---------------------------------------------------------
 	// CLASSIFICATION
 	// ---------------------------------------------------------
 	// Analyze a specific app window to determine focus level.
 	ClassifyApplication(context.Context, *connect.Request[v1.ClassifyApplicationRequest]) (*connect.Response[v1.ClassifyApplicationResponse], error)
-	// Analyze a URL (browser tab) to determine focus level.
+	// Analyze a URL (browser tab) to determine focus level. With
+	// CLASSIFICATION_HTTPS_ONLY, non-https URLs are rejected as invalid, except
+	// local development servers while developer mode is on.
 	ClassifyWebsite(context.Context, *connect.Request[v1.ClassifyWebsiteRequest]) (*connect.Response[v1.ClassifyWebsiteResponse], error)
+	// Analyze a document path (e.g. "~/work/Q3-report.xlsx") to determine focus level.
+	ClassifyDocument(context.Context, *connect.Request[v1.ClassifyDocumentRequest]) (*connect.Response[v1.ClassifyDocumentResponse], error)
+	// Analyze an email sender and subject to tell work correspondence from newsletters/promotions.
+	ClassifyEmail(context.Context, *connect.Request[v1.ClassifyEmailRequest]) (*connect.Response[v1.ClassifyEmailResponse], error)
+	// Analyze a terminal command (e.g. "go test ./...") to tell running tests from browsing history.
+	// Secret-looking arguments are redacted before classification.
+	ClassifyCommand(context.Context, *connect.Request[v1.ClassifyCommandRequest]) (*connect.Response[v1.ClassifyCommandResponse], error)
+	// Records a thumbs up/down on a classification for prompt-quality monitoring.
+	// Votes never change what is returned for the input.
+	RateClassification(context.Context, *connect.Request[v1.RateClassificationRequest]) (*connect.Response[v1.RateClassificationResponse], error)
+	// Backs up and restores the user's classification overrides as JSON, e.g.
+	// to carry them to another device.
+	ExportRules(context.Context, *connect.Request[v1.ExportRulesRequest]) (*connect.Response[v1.ExportRulesResponse], error)
+	ImportRules(context.Context, *connect.Request[v1.ImportRulesRequest]) (*connect.Response[v1.ImportRulesResponse], error)
+	// Manages rules in plain words appended to every classification prompt,
+	// e.g. "treat our internal tool Atlas as productive". The "user" scope
+	// holds the caller's own rules, the "global" scope the organization's and
+	// requires the "admin" role. An empty text clears the scope.
+	SetPromptAddendum(context.Context, *connect.Request[v1.SetPromptAddendumRequest]) (*connect.Response[v1.SetPromptAddendumResponse], error)
+	GetPromptAddendum(context.Context, *connect.Request[v1.GetPromptAddendumRequest]) (*connect.Response[v1.GetPromptAddendumResponse], error)
+	// Lists the classifications and tags the classifiers may return, for
+	// settings UIs. Sourced from the prompts, so it always matches the server.
+	GetTaxonomy(context.Context, *connect.Request[v1.GetTaxonomyRequest]) (*connect.Response[v1.GetTaxonomyResponse], error)
+	// ---------------------------------------------------------
+	// FOCUS SESSIONS
+	// ---------------------------------------------------------
+	// Starts a focus session, closing any the user left open. Classifications
+	// made while it runs are recorded against it.
+	StartFocusSession(context.Context, *connect.Request[v1.StartFocusSessionRequest]) (*connect.Response[v1.StartFocusSessionResponse], error)
+	// Stops the user's open focus session and summarizes its classifications.
+	StopFocusSession(context.Context, *connect.Request[v1.StopFocusSessionRequest]) (*connect.Response[v1.StopFocusSessionResponse], error)
+	// Lists the projects the user's classifications detected, most recently
+	// seen first. Spelling variants of one project are listed once.
+	ListDetectedProjects(context.Context, *connect.Request[v1.ListDetectedProjectsRequest]) (*connect.Response[v1.ListDetectedProjectsResponse], error)
 	// ---------------------------------------------------------
 	// INTELLIGENCE (AI AGENTS)
 	// ---------------------------------------------------------
@@ -227,6 +790,49 @@ type BrainServiceHandler interface {
 	OAuth2ExchangeAuthorizationCode(context.Context, *connect.Request[v1.OAuth2ExchangeAuthorizationCodeRequest]) (*connect.Response[v1.OAuth2ExchangeAuthorizationCodeResponse], error)
 	OAuth2RefreshAccessToken(context.Context, *connect.Request[v1.OAuth2RefreshAccessTokenRequest]) (*connect.Response[v1.OAuth2RefreshAccessTokenResponse], error)
 	OAuth2RevokeAccessToken(context.Context, *connect.Request[v1.OAuth2RevokeAccessTokenRequest]) (*connect.Response[v1.OAuth2RevokeAccessTokenResponse], error)
+	// Lists every supported provider with whether the authenticated user linked it
+	// and the health of the linked token.
+	GetOAuth2Status(context.Context, *connect.Request[v1.GetOAuth2StatusRequest]) (*connect.Response[v1.GetOAuth2StatusResponse], error)
+	// ---------------------------------------------------------
+	// INTEGRATIONS
+	// ---------------------------------------------------------
+	// Counts the user's recent commits to a repository, corroborating code
+	// editor classifications. Uses the client's linked GitHub token.
+	GetGitHubActivity(context.Context, *connect.Request[v1.GetGitHubActivityRequest]) (*connect.Response[v1.GetGitHubActivityResponse], error)
+	// Opts the authenticated user in or out of the classification webhook,
+	// which posts each of their classifications to the configured URL.
+	SetClassificationWebhook(context.Context, *connect.Request[v1.SetClassificationWebhookRequest]) (*connect.Response[v1.SetClassificationWebhookResponse], error)
+	// ---------------------------------------------------------
+	// ADMIN
+	// ---------------------------------------------------------
+	// Purges expired cache, nonce and session rows, optionally vacuuming the database.
+	// Requires a session with the "admin" role.
+	RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error)
+	// Manages the overrides served to every user ahead of the cache and the
+	// model. A user's own overrides still take precedence. Requires the "admin" role.
+	SetGlobalOverride(context.Context, *connect.Request[v1.SetGlobalOverrideRequest]) (*connect.Response[v1.SetGlobalOverrideResponse], error)
+	DeleteGlobalOverride(context.Context, *connect.Request[v1.DeleteGlobalOverrideRequest]) (*connect.Response[v1.DeleteGlobalOverrideResponse], error)
+	ListGlobalOverrides(context.Context, *connect.Request[v1.ListGlobalOverridesRequest]) (*connect.Response[v1.ListGlobalOverridesResponse], error)
+	// Deletes cached classifications matching every given criterion, e.g. all
+	// website entries for one domain after a prompt bug. Requires the "admin" role.
+	InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error)
+	// Replays recent cached classifications through the current prompts and
+	// model and reports how many changed and how, e.g. to check a prompt edit
+	// against real traffic. Entries replay from their cached input alone, so
+	// context that is not part of the cache key, such as a page's metadata, is
+	// not sent again. Read-only unless write_cache is set. Requires the
+	// "admin" role.
+	ReplayClassifications(context.Context, *connect.Request[v1.ReplayClassificationsRequest]) (*connect.Response[v1.ReplayClassificationsResponse], error)
+	// Merges a duplicate user into another, e.g. when two fingerprints turn out
+	// to be one person's devices. The source's overrides, votes, linked
+	// providers, addendum, focus sessions and projects move to the target,
+	// where the target's own rows win on conflict, and the source is deleted
+	// with its sessions revoked. Merging an already deleted source is a no-op.
+	// Requires the "admin" role.
+	MergeUsers(context.Context, *connect.Request[v1.MergeUsersRequest]) (*connect.Response[v1.MergeUsersResponse], error)
+	// Lists the feature flags loaded at startup with their rollout, optionally
+	// evaluated for one user. Requires the "admin" role.
+	GetFlags(context.Context, *connect.Request[v1.GetFlagsRequest]) (*connect.Response[v1.GetFlagsResponse], error)
 }
 
 // NewBrainServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -242,6 +848,36 @@ func NewBrainServiceHandler(svc BrainServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(brainServiceMethods.ByName("DeviceHandshake")),
 		connect.WithHandlerOptions(opts...),
 	)
+	brainServiceVerifyHandshakeSignatureHandler := connect.NewUnaryHandler(
+		BrainServiceVerifyHandshakeSignatureProcedure,
+		svc.VerifyHandshakeSignature,
+		connect.WithSchema(brainServiceMethods.ByName("VerifyHandshakeSignature")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceListSessionsHandler := connect.NewUnaryHandler(
+		BrainServiceListSessionsProcedure,
+		svc.ListSessions,
+		connect.WithSchema(brainServiceMethods.ByName("ListSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceRevokeSessionHandler := connect.NewUnaryHandler(
+		BrainServiceRevokeSessionProcedure,
+		svc.RevokeSession,
+		connect.WithSchema(brainServiceMethods.ByName("RevokeSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceRevokeAllSessionsHandler := connect.NewUnaryHandler(
+		BrainServiceRevokeAllSessionsProcedure,
+		svc.RevokeAllSessions,
+		connect.WithSchema(brainServiceMethods.ByName("RevokeAllSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceRebindDeviceHandler := connect.NewUnaryHandler(
+		BrainServiceRebindDeviceProcedure,
+		svc.RebindDevice,
+		connect.WithSchema(brainServiceMethods.ByName("RebindDevice")),
+		connect.WithHandlerOptions(opts...),
+	)
 	brainServiceClassifyApplicationHandler := connect.NewUnaryHandler(
 		BrainServiceClassifyApplicationProcedure,
 		svc.ClassifyApplication,
@@ -254,6 +890,78 @@ func NewBrainServiceHandler(svc BrainServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(brainServiceMethods.ByName("ClassifyWebsite")),
 		connect.WithHandlerOptions(opts...),
 	)
+	brainServiceClassifyDocumentHandler := connect.NewUnaryHandler(
+		BrainServiceClassifyDocumentProcedure,
+		svc.ClassifyDocument,
+		connect.WithSchema(brainServiceMethods.ByName("ClassifyDocument")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceClassifyEmailHandler := connect.NewUnaryHandler(
+		BrainServiceClassifyEmailProcedure,
+		svc.ClassifyEmail,
+		connect.WithSchema(brainServiceMethods.ByName("ClassifyEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceClassifyCommandHandler := connect.NewUnaryHandler(
+		BrainServiceClassifyCommandProcedure,
+		svc.ClassifyCommand,
+		connect.WithSchema(brainServiceMethods.ByName("ClassifyCommand")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceRateClassificationHandler := connect.NewUnaryHandler(
+		BrainServiceRateClassificationProcedure,
+		svc.RateClassification,
+		connect.WithSchema(brainServiceMethods.ByName("RateClassification")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceExportRulesHandler := connect.NewUnaryHandler(
+		BrainServiceExportRulesProcedure,
+		svc.ExportRules,
+		connect.WithSchema(brainServiceMethods.ByName("ExportRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceImportRulesHandler := connect.NewUnaryHandler(
+		BrainServiceImportRulesProcedure,
+		svc.ImportRules,
+		connect.WithSchema(brainServiceMethods.ByName("ImportRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceSetPromptAddendumHandler := connect.NewUnaryHandler(
+		BrainServiceSetPromptAddendumProcedure,
+		svc.SetPromptAddendum,
+		connect.WithSchema(brainServiceMethods.ByName("SetPromptAddendum")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceGetPromptAddendumHandler := connect.NewUnaryHandler(
+		BrainServiceGetPromptAddendumProcedure,
+		svc.GetPromptAddendum,
+		connect.WithSchema(brainServiceMethods.ByName("GetPromptAddendum")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceGetTaxonomyHandler := connect.NewUnaryHandler(
+		BrainServiceGetTaxonomyProcedure,
+		svc.GetTaxonomy,
+		connect.WithSchema(brainServiceMethods.ByName("GetTaxonomy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceStartFocusSessionHandler := connect.NewUnaryHandler(
+		BrainServiceStartFocusSessionProcedure,
+		svc.StartFocusSession,
+		connect.WithSchema(brainServiceMethods.ByName("StartFocusSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceStopFocusSessionHandler := connect.NewUnaryHandler(
+		BrainServiceStopFocusSessionProcedure,
+		svc.StopFocusSession,
+		connect.WithSchema(brainServiceMethods.ByName("StopFocusSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceListDetectedProjectsHandler := connect.NewUnaryHandler(
+		BrainServiceListDetectedProjectsProcedure,
+		svc.ListDetectedProjects,
+		connect.WithSchema(brainServiceMethods.ByName("ListDetectedProjects")),
+		connect.WithHandlerOptions(opts...),
+	)
 	brainServiceAgentSessionHandler := connect.NewBidiStreamHandler(
 		BrainServiceAgentSessionProcedure,
 		svc.AgentSession,
@@ -284,14 +992,114 @@ func NewBrainServiceHandler(svc BrainServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(brainServiceMethods.ByName("OAuth2RevokeAccessToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	brainServiceGetOAuth2StatusHandler := connect.NewUnaryHandler(
+		BrainServiceGetOAuth2StatusProcedure,
+		svc.GetOAuth2Status,
+		connect.WithSchema(brainServiceMethods.ByName("GetOAuth2Status")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceGetGitHubActivityHandler := connect.NewUnaryHandler(
+		BrainServiceGetGitHubActivityProcedure,
+		svc.GetGitHubActivity,
+		connect.WithSchema(brainServiceMethods.ByName("GetGitHubActivity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceSetClassificationWebhookHandler := connect.NewUnaryHandler(
+		BrainServiceSetClassificationWebhookProcedure,
+		svc.SetClassificationWebhook,
+		connect.WithSchema(brainServiceMethods.ByName("SetClassificationWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceRunMaintenanceHandler := connect.NewUnaryHandler(
+		BrainServiceRunMaintenanceProcedure,
+		svc.RunMaintenance,
+		connect.WithSchema(brainServiceMethods.ByName("RunMaintenance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceSetGlobalOverrideHandler := connect.NewUnaryHandler(
+		BrainServiceSetGlobalOverrideProcedure,
+		svc.SetGlobalOverride,
+		connect.WithSchema(brainServiceMethods.ByName("SetGlobalOverride")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceDeleteGlobalOverrideHandler := connect.NewUnaryHandler(
+		BrainServiceDeleteGlobalOverrideProcedure,
+		svc.DeleteGlobalOverride,
+		connect.WithSchema(brainServiceMethods.ByName("DeleteGlobalOverride")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceListGlobalOverridesHandler := connect.NewUnaryHandler(
+		BrainServiceListGlobalOverridesProcedure,
+		svc.ListGlobalOverrides,
+		connect.WithSchema(brainServiceMethods.ByName("ListGlobalOverrides")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceInvalidateCacheHandler := connect.NewUnaryHandler(
+		BrainServiceInvalidateCacheProcedure,
+		svc.InvalidateCache,
+		connect.WithSchema(brainServiceMethods.ByName("InvalidateCache")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceReplayClassificationsHandler := connect.NewUnaryHandler(
+		BrainServiceReplayClassificationsProcedure,
+		svc.ReplayClassifications,
+		connect.WithSchema(brainServiceMethods.ByName("ReplayClassifications")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceMergeUsersHandler := connect.NewUnaryHandler(
+		BrainServiceMergeUsersProcedure,
+		svc.MergeUsers,
+		connect.WithSchema(brainServiceMethods.ByName("MergeUsers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	brainServiceGetFlagsHandler := connect.NewUnaryHandler(
+		BrainServiceGetFlagsProcedure,
+		svc.GetFlags,
+		connect.WithSchema(brainServiceMethods.ByName("GetFlags")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/brain.v1.BrainService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BrainServiceDeviceHandshakeProcedure:
 			brainServiceDeviceHandshakeHandler.ServeHTTP(w, r)
+		case BrainServiceVerifyHandshakeSignatureProcedure:
+			brainServiceVerifyHandshakeSignatureHandler.ServeHTTP(w, r)
+		case BrainServiceListSessionsProcedure:
+			brainServiceListSessionsHandler.ServeHTTP(w, r)
+		case BrainServiceRevokeSessionProcedure:
+			brainServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case BrainServiceRevokeAllSessionsProcedure:
+			brainServiceRevokeAllSessionsHandler.ServeHTTP(w, r)
+		case BrainServiceRebindDeviceProcedure:
+			brainServiceRebindDeviceHandler.ServeHTTP(w, r)
 		case BrainServiceClassifyApplicationProcedure:
 			brainServiceClassifyApplicationHandler.ServeHTTP(w, r)
 		case BrainServiceClassifyWebsiteProcedure:
 			brainServiceClassifyWebsiteHandler.ServeHTTP(w, r)
+		case BrainServiceClassifyDocumentProcedure:
+			brainServiceClassifyDocumentHandler.ServeHTTP(w, r)
+		case BrainServiceClassifyEmailProcedure:
+			brainServiceClassifyEmailHandler.ServeHTTP(w, r)
+		case BrainServiceClassifyCommandProcedure:
+			brainServiceClassifyCommandHandler.ServeHTTP(w, r)
+		case BrainServiceRateClassificationProcedure:
+			brainServiceRateClassificationHandler.ServeHTTP(w, r)
+		case BrainServiceExportRulesProcedure:
+			brainServiceExportRulesHandler.ServeHTTP(w, r)
+		case BrainServiceImportRulesProcedure:
+			brainServiceImportRulesHandler.ServeHTTP(w, r)
+		case BrainServiceSetPromptAddendumProcedure:
+			brainServiceSetPromptAddendumHandler.ServeHTTP(w, r)
+		case BrainServiceGetPromptAddendumProcedure:
+			brainServiceGetPromptAddendumHandler.ServeHTTP(w, r)
+		case BrainServiceGetTaxonomyProcedure:
+			brainServiceGetTaxonomyHandler.ServeHTTP(w, r)
+		case BrainServiceStartFocusSessionProcedure:
+			brainServiceStartFocusSessionHandler.ServeHTTP(w, r)
+		case BrainServiceStopFocusSessionProcedure:
+			brainServiceStopFocusSessionHandler.ServeHTTP(w, r)
+		case BrainServiceListDetectedProjectsProcedure:
+			brainServiceListDetectedProjectsHandler.ServeHTTP(w, r)
 		case BrainServiceAgentSessionProcedure:
 			brainServiceAgentSessionHandler.ServeHTTP(w, r)
 		case BrainServiceOAuth2GetAuthorizationURLProcedure:
@@ -302,6 +1110,28 @@ func NewBrainServiceHandler(svc BrainServiceHandler, opts ...connect.HandlerOpti
 			brainServiceOAuth2RefreshAccessTokenHandler.ServeHTTP(w, r)
 		case BrainServiceOAuth2RevokeAccessTokenProcedure:
 			brainServiceOAuth2RevokeAccessTokenHandler.ServeHTTP(w, r)
+		case BrainServiceGetOAuth2StatusProcedure:
+			brainServiceGetOAuth2StatusHandler.ServeHTTP(w, r)
+		case BrainServiceGetGitHubActivityProcedure:
+			brainServiceGetGitHubActivityHandler.ServeHTTP(w, r)
+		case BrainServiceSetClassificationWebhookProcedure:
+			brainServiceSetClassificationWebhookHandler.ServeHTTP(w, r)
+		case BrainServiceRunMaintenanceProcedure:
+			brainServiceRunMaintenanceHandler.ServeHTTP(w, r)
+		case BrainServiceSetGlobalOverrideProcedure:
+			brainServiceSetGlobalOverrideHandler.ServeHTTP(w, r)
+		case BrainServiceDeleteGlobalOverrideProcedure:
+			brainServiceDeleteGlobalOverrideHandler.ServeHTTP(w, r)
+		case BrainServiceListGlobalOverridesProcedure:
+			brainServiceListGlobalOverridesHandler.ServeHTTP(w, r)
+		case BrainServiceInvalidateCacheProcedure:
+			brainServiceInvalidateCacheHandler.ServeHTTP(w, r)
+		case BrainServiceReplayClassificationsProcedure:
+			brainServiceReplayClassificationsHandler.ServeHTTP(w, r)
+		case BrainServiceMergeUsersProcedure:
+			brainServiceMergeUsersHandler.ServeHTTP(w, r)
+		case BrainServiceGetFlagsProcedure:
+			brainServiceGetFlagsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -315,6 +1145,26 @@ func (UnimplementedBrainServiceHandler) DeviceHandshake(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.DeviceHandshake is not implemented"))
 }
 
+func (UnimplementedBrainServiceHandler) VerifyHandshakeSignature(context.Context, *connect.Request[v1.VerifyHandshakeSignatureRequest]) (*connect.Response[v1.VerifyHandshakeSignatureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.VerifyHandshakeSignature is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ListSessions is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.RevokeSession is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) RevokeAllSessions(context.Context, *connect.Request[v1.RevokeAllSessionsRequest]) (*connect.Response[v1.RevokeAllSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.RevokeAllSessions is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) RebindDevice(context.Context, *connect.Request[v1.RebindDeviceRequest]) (*connect.Response[v1.RebindDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.RebindDevice is not implemented"))
+}
+
 func (UnimplementedBrainServiceHandler) ClassifyApplication(context.Context, *connect.Request[v1.ClassifyApplicationRequest]) (*connect.Response[v1.ClassifyApplicationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ClassifyApplication is not implemented"))
 }
@@ -323,6 +1173,54 @@ func (UnimplementedBrainServiceHandler) ClassifyWebsite(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ClassifyWebsite is not implemented"))
 }
 
+func (UnimplementedBrainServiceHandler) ClassifyDocument(context.Context, *connect.Request[v1.ClassifyDocumentRequest]) (*connect.Response[v1.ClassifyDocumentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ClassifyDocument is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ClassifyEmail(context.Context, *connect.Request[v1.ClassifyEmailRequest]) (*connect.Response[v1.ClassifyEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ClassifyEmail is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ClassifyCommand(context.Context, *connect.Request[v1.ClassifyCommandRequest]) (*connect.Response[v1.ClassifyCommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ClassifyCommand is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) RateClassification(context.Context, *connect.Request[v1.RateClassificationRequest]) (*connect.Response[v1.RateClassificationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.RateClassification is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ExportRules(context.Context, *connect.Request[v1.ExportRulesRequest]) (*connect.Response[v1.ExportRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ExportRules is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ImportRules(context.Context, *connect.Request[v1.ImportRulesRequest]) (*connect.Response[v1.ImportRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ImportRules is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) SetPromptAddendum(context.Context, *connect.Request[v1.SetPromptAddendumRequest]) (*connect.Response[v1.SetPromptAddendumResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.SetPromptAddendum is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) GetPromptAddendum(context.Context, *connect.Request[v1.GetPromptAddendumRequest]) (*connect.Response[v1.GetPromptAddendumResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.GetPromptAddendum is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) GetTaxonomy(context.Context, *connect.Request[v1.GetTaxonomyRequest]) (*connect.Response[v1.GetTaxonomyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.GetTaxonomy is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) StartFocusSession(context.Context, *connect.Request[v1.StartFocusSessionRequest]) (*connect.Response[v1.StartFocusSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.StartFocusSession is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) StopFocusSession(context.Context, *connect.Request[v1.StopFocusSessionRequest]) (*connect.Response[v1.StopFocusSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.StopFocusSession is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ListDetectedProjects(context.Context, *connect.Request[v1.ListDetectedProjectsRequest]) (*connect.Response[v1.ListDetectedProjectsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ListDetectedProjects is not implemented"))
+}
+
 func (UnimplementedBrainServiceHandler) AgentSession(context.Context, *connect.BidiStream[v1.AgentSessionRequest, v1.AgentSessionResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.AgentSession is not implemented"))
 }
@@ -342,3 +1240,47 @@ func (UnimplementedBrainServiceHandler) OAuth2RefreshAccessToken(context.Context
 func (UnimplementedBrainServiceHandler) OAuth2RevokeAccessToken(context.Context, *connect.Request[v1.OAuth2RevokeAccessTokenRequest]) (*connect.Response[v1.OAuth2RevokeAccessTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.OAuth2RevokeAccessToken is not implemented"))
 }
+
+func (UnimplementedBrainServiceHandler) GetOAuth2Status(context.Context, *connect.Request[v1.GetOAuth2StatusRequest]) (*connect.Response[v1.GetOAuth2StatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.GetOAuth2Status is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) GetGitHubActivity(context.Context, *connect.Request[v1.GetGitHubActivityRequest]) (*connect.Response[v1.GetGitHubActivityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.GetGitHubActivity is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) SetClassificationWebhook(context.Context, *connect.Request[v1.SetClassificationWebhookRequest]) (*connect.Response[v1.SetClassificationWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.SetClassificationWebhook is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.RunMaintenance is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) SetGlobalOverride(context.Context, *connect.Request[v1.SetGlobalOverrideRequest]) (*connect.Response[v1.SetGlobalOverrideResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.SetGlobalOverride is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) DeleteGlobalOverride(context.Context, *connect.Request[v1.DeleteGlobalOverrideRequest]) (*connect.Response[v1.DeleteGlobalOverrideResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.DeleteGlobalOverride is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ListGlobalOverrides(context.Context, *connect.Request[v1.ListGlobalOverridesRequest]) (*connect.Response[v1.ListGlobalOverridesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ListGlobalOverrides is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.InvalidateCache is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) ReplayClassifications(context.Context, *connect.Request[v1.ReplayClassificationsRequest]) (*connect.Response[v1.ReplayClassificationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.ReplayClassifications is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) MergeUsers(context.Context, *connect.Request[v1.MergeUsersRequest]) (*connect.Response[v1.MergeUsersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.MergeUsers is not implemented"))
+}
+
+func (UnimplementedBrainServiceHandler) GetFlags(context.Context, *connect.Request[v1.GetFlagsRequest]) (*connect.Response[v1.GetFlagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("brain.v1.BrainService.GetFlags is not implemented"))
+}
@@ -72,7 +72,7 @@ func (x AgentSessionRequest_ToolCallResponse_Status) Number() protoreflect.EnumN
 
 // Deprecated: Use AgentSessionRequest_ToolCallResponse_Status.Descriptor instead.
 func (AgentSessionRequest_ToolCallResponse_Status) EnumDescriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 3, 0}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 3, 0}
 }
 
 type DeviceHandshakeRequest struct {
@@ -212,32 +212,26 @@ func (x *DeviceHandshakeResponse) GetRemainingDailyScans() int32 {
 	return 0
 }
 
-type ClassificationResult struct {
-	state                        protoimpl.MessageState `protogen:"open.v1"`
-	Classification               string                 `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"` // "productive", "supporting", "neutral", "distracting"
-	Reasoning                    string                 `protobuf:"bytes,2,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
-	ConfidenceScore              float32                `protobuf:"fixed32,3,opt,name=confidence_score,json=confidenceScore,proto3" json:"confidence_score,omitempty"` // 0.0 to 1.0 (How sure is the AI?)
-	Tags                         []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	DetectedProject              *string                `protobuf:"bytes,5,opt,name=detected_project,json=detectedProject,proto3,oneof" json:"detected_project,omitempty"`                                          // e.g. "focusd" extracted from title
-	DetectedCommunicationChannel *string                `protobuf:"bytes,6,opt,name=detected_communication_channel,json=detectedCommunicationChannel,proto3,oneof" json:"detected_communication_channel,omitempty"` // e.g. "#incident-1234" from Slack/Discord/Teams
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClassificationResult) Reset() {
-	*x = ClassificationResult{}
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
 	mi := &file_brain_v1_server_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClassificationResult) String() string {
+func (x *ListSessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClassificationResult) ProtoMessage() {}
+func (*ListSessionsRequest) ProtoMessage() {}
 
-func (x *ClassificationResult) ProtoReflect() protoreflect.Message {
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -249,76 +243,32 @@ func (x *ClassificationResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClassificationResult.ProtoReflect.Descriptor instead.
-func (*ClassificationResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ClassificationResult) GetClassification() string {
-	if x != nil {
-		return x.Classification
-	}
-	return ""
-}
-
-func (x *ClassificationResult) GetReasoning() string {
-	if x != nil {
-		return x.Reasoning
-	}
-	return ""
-}
-
-func (x *ClassificationResult) GetConfidenceScore() float32 {
-	if x != nil {
-		return x.ConfidenceScore
-	}
-	return 0
-}
-
-func (x *ClassificationResult) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-func (x *ClassificationResult) GetDetectedProject() string {
-	if x != nil && x.DetectedProject != nil {
-		return *x.DetectedProject
-	}
-	return ""
-}
-
-func (x *ClassificationResult) GetDetectedCommunicationChannel() string {
-	if x != nil && x.DetectedCommunicationChannel != nil {
-		return *x.DetectedCommunicationChannel
-	}
-	return ""
-}
-
-type ClassifyApplicationRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	ApplicationName     string                 `protobuf:"bytes,1,opt,name=application_name,json=applicationName,proto3" json:"application_name,omitempty"`               // "Visual Studio Code"
-	ApplicationBundleId string                 `protobuf:"bytes,2,opt,name=application_bundle_id,json=applicationBundleId,proto3" json:"application_bundle_id,omitempty"` // "com.microsoft.VSCode"
-	WindowTitle         string                 `protobuf:"bytes,3,opt,name=window_title,json=windowTitle,proto3" json:"window_title,omitempty"`                           // "main.go - focusd"
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"` // newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClassifyApplicationRequest) Reset() {
-	*x = ClassifyApplicationRequest{}
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
 	mi := &file_brain_v1_server_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClassifyApplicationRequest) String() string {
+func (x *ListSessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClassifyApplicationRequest) ProtoMessage() {}
+func (*ListSessionsResponse) ProtoMessage() {}
 
-func (x *ClassifyApplicationRequest) ProtoReflect() protoreflect.Message {
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -330,57 +280,45 @@ func (x *ClassifyApplicationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClassifyApplicationRequest.ProtoReflect.Descriptor instead.
-func (*ClassifyApplicationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ClassifyApplicationRequest) GetApplicationName() string {
-	if x != nil {
-		return x.ApplicationName
-	}
-	return ""
-}
-
-func (x *ClassifyApplicationRequest) GetApplicationBundleId() string {
-	if x != nil {
-		return x.ApplicationBundleId
-	}
-	return ""
-}
-
-func (x *ClassifyApplicationRequest) GetWindowTitle() string {
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
 	if x != nil {
-		return x.WindowTitle
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-type ClassifyApplicationResponse struct {
-	state                        protoimpl.MessageState `protogen:"open.v1"`
-	Classification               *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
-	DetectedCommunicationChannel *string                `protobuf:"bytes,2,opt,name=detected_communication_channel,json=detectedCommunicationChannel,proto3,oneof" json:"detected_communication_channel,omitempty"`
-	// Metadata extraction (for Context correlation)
-	DetectedProject *string `protobuf:"bytes,3,opt,name=detected_project,json=detectedProject,proto3,oneof" json:"detected_project,omitempty"` // e.g. "focusd" extracted from title
-	DetectedFile    *string `protobuf:"bytes,4,opt,name=detected_file,json=detectedFile,proto3,oneof" json:"detected_file,omitempty"`          // e.g. "main.go"
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type SessionInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // the token's jti
+	DeviceFingerprint string                 `protobuf:"bytes,2,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"`
+	OsPlatform        string                 `protobuf:"bytes,3,opt,name=os_platform,json=osPlatform,proto3" json:"os_platform,omitempty"`
+	AppVersion        string                 `protobuf:"bytes,4,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	ExpiresAt         int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp
+	Current           bool                   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`                      // the session making this request
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ClassifyApplicationResponse) Reset() {
-	*x = ClassifyApplicationResponse{}
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
 	mi := &file_brain_v1_server_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClassifyApplicationResponse) String() string {
+func (x *SessionInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClassifyApplicationResponse) ProtoMessage() {}
+func (*SessionInfo) ProtoMessage() {}
 
-func (x *ClassifyApplicationResponse) ProtoReflect() protoreflect.Message {
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -392,61 +330,81 @@ func (x *ClassifyApplicationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClassifyApplicationResponse.ProtoReflect.Descriptor instead.
-func (*ClassifyApplicationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ClassifyApplicationResponse) GetClassification() *ClassificationResult {
+func (x *SessionInfo) GetSessionId() string {
 	if x != nil {
-		return x.Classification
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-func (x *ClassifyApplicationResponse) GetDetectedCommunicationChannel() string {
-	if x != nil && x.DetectedCommunicationChannel != nil {
-		return *x.DetectedCommunicationChannel
+func (x *SessionInfo) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
 	}
 	return ""
 }
 
-func (x *ClassifyApplicationResponse) GetDetectedProject() string {
-	if x != nil && x.DetectedProject != nil {
-		return *x.DetectedProject
+func (x *SessionInfo) GetOsPlatform() string {
+	if x != nil {
+		return x.OsPlatform
 	}
 	return ""
 }
 
-func (x *ClassifyApplicationResponse) GetDetectedFile() string {
-	if x != nil && x.DetectedFile != nil {
-		return *x.DetectedFile
+func (x *SessionInfo) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
 	}
 	return ""
 }
 
-type ClassifyWebsiteRequest struct {
+func (x *SessionInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type RevokeSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClassifyWebsiteRequest) Reset() {
-	*x = ClassifyWebsiteRequest{}
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
 	mi := &file_brain_v1_server_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClassifyWebsiteRequest) String() string {
+func (x *RevokeSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClassifyWebsiteRequest) ProtoMessage() {}
+func (*RevokeSessionRequest) ProtoMessage() {}
 
-func (x *ClassifyWebsiteRequest) ProtoReflect() protoreflect.Message {
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -458,46 +416,39 @@ func (x *ClassifyWebsiteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClassifyWebsiteRequest.ProtoReflect.Descriptor instead.
-func (*ClassifyWebsiteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ClassifyWebsiteRequest) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
-	return ""
-}
-
-func (x *ClassifyWebsiteRequest) GetTitle() string {
+func (x *RevokeSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.Title
+		return x.SessionId
 	}
 	return ""
 }
 
-type ClassifyWebsiteResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Classification *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClassifyWebsiteResponse) Reset() {
-	*x = ClassifyWebsiteResponse{}
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
 	mi := &file_brain_v1_server_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClassifyWebsiteResponse) String() string {
+func (x *RevokeSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClassifyWebsiteResponse) ProtoMessage() {}
+func (*RevokeSessionResponse) ProtoMessage() {}
 
-func (x *ClassifyWebsiteResponse) ProtoReflect() protoreflect.Message {
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -509,45 +460,39 @@ func (x *ClassifyWebsiteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClassifyWebsiteResponse.ProtoReflect.Descriptor instead.
-func (*ClassifyWebsiteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ClassifyWebsiteResponse) GetClassification() *ClassificationResult {
+func (x *RevokeSessionResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Classification
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type AgentSessionRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Message:
-	//
-	//	*AgentSessionRequest_RunRequest_
-	//	*AgentSessionRequest_ToolCallResponse_
-	//	*AgentSessionRequest_Heartbeat_
-	//	*AgentSessionRequest_SessionEnd_
-	Message       isAgentSessionRequest_Message `protobuf_oneof:"message"`
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeepCurrent   bool                   `protobuf:"varint,1,opt,name=keep_current,json=keepCurrent,proto3" json:"keep_current,omitempty"` // sign out everywhere else
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentSessionRequest) Reset() {
-	*x = AgentSessionRequest{}
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
 	mi := &file_brain_v1_server_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentSessionRequest) String() string {
+func (x *RevokeAllSessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentSessionRequest) ProtoMessage() {}
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
 
-func (x *AgentSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brain_v1_server_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -559,111 +504,3888 @@ func (x *AgentSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentSessionRequest.ProtoReflect.Descriptor instead.
-func (*AgentSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
 	return file_brain_v1_server_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *AgentSessionRequest) GetMessage() isAgentSessionRequest_Message {
+func (x *RevokeAllSessionsRequest) GetKeepCurrent() bool {
 	if x != nil {
-		return x.Message
+		return x.KeepCurrent
 	}
-	return nil
+	return false
 }
 
-func (x *AgentSessionRequest) GetRunRequest() *AgentSessionRequest_RunRequest {
-	if x != nil {
-		if x, ok := x.Message.(*AgentSessionRequest_RunRequest_); ok {
-			return x.RunRequest
-		}
-	}
-	return nil
+type RevokeAllSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revoked       int64                  `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentSessionRequest) GetToolCallResponse() *AgentSessionRequest_ToolCallResponse {
-	if x != nil {
-		if x, ok := x.Message.(*AgentSessionRequest_ToolCallResponse_); ok {
-			return x.ToolCallResponse
-		}
-	}
-	return nil
+func (x *RevokeAllSessionsResponse) Reset() {
+	*x = RevokeAllSessionsResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentSessionRequest) GetHeartbeat() *AgentSessionRequest_Heartbeat {
-	if x != nil {
-		if x, ok := x.Message.(*AgentSessionRequest_Heartbeat_); ok {
-			return x.Heartbeat
-		}
-	}
-	return nil
+func (x *RevokeAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *AgentSessionRequest) GetSessionEnd() *AgentSessionRequest_SessionEnd {
+func (*RevokeAllSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[8]
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionRequest_SessionEnd_); ok {
-			return x.SessionEnd
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isAgentSessionRequest_Message interface {
-	isAgentSessionRequest_Message()
+// Deprecated: Use RevokeAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{8}
 }
 
-type AgentSessionRequest_RunRequest_ struct {
-	RunRequest *AgentSessionRequest_RunRequest `protobuf:"bytes,1,opt,name=run_request,json=runRequest,proto3,oneof"`
+func (x *RevokeAllSessionsResponse) GetRevoked() int64 {
+	if x != nil {
+		return x.Revoked
+	}
+	return 0
 }
 
-type AgentSessionRequest_ToolCallResponse_ struct {
+type VerifyHandshakeSignatureRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeviceFingerprint string                 `protobuf:"bytes,1,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VerifyHandshakeSignatureRequest) Reset() {
+	*x = VerifyHandshakeSignatureRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyHandshakeSignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyHandshakeSignatureRequest) ProtoMessage() {}
+
+func (x *VerifyHandshakeSignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyHandshakeSignatureRequest.ProtoReflect.Descriptor instead.
+func (*VerifyHandshakeSignatureRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyHandshakeSignatureRequest) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+type VerifyHandshakeSignatureResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Valid  bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // why validation failed, e.g. "invalid signature"
+	// The fingerprint + X-Timestamp + X-Nonce string the server signed, set on failure
+	ExpectedPayload string `protobuf:"bytes,3,opt,name=expected_payload,json=expectedPayload,proto3" json:"expected_payload,omitempty"`
+	ServerTime      int64  `protobuf:"varint,4,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // unix seconds, to compare against X-Timestamp
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyHandshakeSignatureResponse) Reset() {
+	*x = VerifyHandshakeSignatureResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyHandshakeSignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyHandshakeSignatureResponse) ProtoMessage() {}
+
+func (x *VerifyHandshakeSignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyHandshakeSignatureResponse.ProtoReflect.Descriptor instead.
+func (*VerifyHandshakeSignatureResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyHandshakeSignatureResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyHandshakeSignatureResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VerifyHandshakeSignatureResponse) GetExpectedPayload() string {
+	if x != nil {
+		return x.ExpectedPayload
+	}
+	return ""
+}
+
+func (x *VerifyHandshakeSignatureResponse) GetServerTime() int64 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+type RebindDeviceRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeviceFingerprint string                 `protobuf:"bytes,1,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // the new fingerprint
+	OsPlatform        string                 `protobuf:"bytes,2,opt,name=os_platform,json=osPlatform,proto3" json:"os_platform,omitempty"`
+	OsVersion         string                 `protobuf:"bytes,3,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	AppVersion        string                 `protobuf:"bytes,4,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RebindDeviceRequest) Reset() {
+	*x = RebindDeviceRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebindDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebindDeviceRequest) ProtoMessage() {}
+
+func (x *RebindDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebindDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RebindDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RebindDeviceRequest) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+func (x *RebindDeviceRequest) GetOsPlatform() string {
+	if x != nil {
+		return x.OsPlatform
+	}
+	return ""
+}
+
+func (x *RebindDeviceRequest) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *RebindDeviceRequest) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
+	}
+	return ""
+}
+
+type RebindDeviceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A session for the new device. The calling session is revoked.
+	SessionToken  string `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebindDeviceResponse) Reset() {
+	*x = RebindDeviceResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebindDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebindDeviceResponse) ProtoMessage() {}
+
+func (x *RebindDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebindDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RebindDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RebindDeviceResponse) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+type ClassificationResult struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	Classification               string                 `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"` // "productive", "supporting", "neutral", "distracting"
+	Reasoning                    string                 `protobuf:"bytes,2,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	ConfidenceScore              float32                `protobuf:"fixed32,3,opt,name=confidence_score,json=confidenceScore,proto3" json:"confidence_score,omitempty"` // 0.0 to 1.0 (How sure is the AI?)
+	Tags                         []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	DetectedProject              *string                `protobuf:"bytes,5,opt,name=detected_project,json=detectedProject,proto3,oneof" json:"detected_project,omitempty"`                                          // e.g. "focusd" extracted from title
+	DetectedCommunicationChannel *string                `protobuf:"bytes,6,opt,name=detected_communication_channel,json=detectedCommunicationChannel,proto3,oneof" json:"detected_communication_channel,omitempty"` // e.g. "#incident-1234" from Slack/Discord/Teams
+	Signals                      []string               `protobuf:"bytes,7,rep,name=signals,proto3" json:"signals,omitempty"`                                                                                       // e.g. "matched Slack #incident pattern", explains what drove the decision
+	Model                        string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`                                                                                           // model that produced the result, e.g. "gemini-2.5-flash"
+	PromptVersion                string                 `protobuf:"bytes,9,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`                                                      // prompt version label, for auditing and A/B analysis
+	Approximate                  bool                   `protobuf:"varint,10,opt,name=approximate,proto3" json:"approximate,omitempty"`                                                                             // served from a similar cached entry because the model was unavailable
+	Policy                       *ClassificationPolicy  `protobuf:"bytes,11,opt,name=policy,proto3" json:"policy,omitempty"`                                                                                        // set when fetching or classification was restricted
+	// Hash of the classification and sorted tags. It only changes when the
+	// outcome does, e.g. after a prompt update, never for reworded reasoning.
+	OutcomeHash string `protobuf:"bytes,12,opt,name=outcome_hash,json=outcomeHash,proto3" json:"outcome_hash,omitempty"`
+	// Ranked alternatives, most confident first, when the request set
+	// return_candidates. The fields above follow the first one.
+	Candidates []*ClassificationCandidate `protobuf:"bytes,13,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Confidence the model reported. confidence_score holds it mapped through
+	// CLASSIFICATION_CONFIDENCE_CALIBRATION when that is configured.
+	RawConfidenceScore float32 `protobuf:"fixed32,14,opt,name=raw_confidence_score,json=rawConfidenceScore,proto3" json:"raw_confidence_score,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ClassificationResult) Reset() {
+	*x = ClassificationResult{}
+	mi := &file_brain_v1_server_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassificationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassificationResult) ProtoMessage() {}
+
+func (x *ClassificationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassificationResult.ProtoReflect.Descriptor instead.
+func (*ClassificationResult) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ClassificationResult) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetReasoning() string {
+	if x != nil {
+		return x.Reasoning
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetConfidenceScore() float32 {
+	if x != nil {
+		return x.ConfidenceScore
+	}
+	return 0
+}
+
+func (x *ClassificationResult) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ClassificationResult) GetDetectedProject() string {
+	if x != nil && x.DetectedProject != nil {
+		return *x.DetectedProject
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetDetectedCommunicationChannel() string {
+	if x != nil && x.DetectedCommunicationChannel != nil {
+		return *x.DetectedCommunicationChannel
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetSignals() []string {
+	if x != nil {
+		return x.Signals
+	}
+	return nil
+}
+
+func (x *ClassificationResult) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *ClassificationResult) GetPolicy() *ClassificationPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *ClassificationResult) GetOutcomeHash() string {
+	if x != nil {
+		return x.OutcomeHash
+	}
+	return ""
+}
+
+func (x *ClassificationResult) GetCandidates() []*ClassificationCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ClassificationResult) GetRawConfidenceScore() float32 {
+	if x != nil {
+		return x.RawConfidenceScore
+	}
+	return 0
+}
+
+type ClassificationCandidate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Classification  string                 `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	ConfidenceScore float32                `protobuf:"fixed32,2,opt,name=confidence_score,json=confidenceScore,proto3" json:"confidence_score,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ClassificationCandidate) Reset() {
+	*x = ClassificationCandidate{}
+	mi := &file_brain_v1_server_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassificationCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassificationCandidate) ProtoMessage() {}
+
+func (x *ClassificationCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassificationCandidate.ProtoReflect.Descriptor instead.
+func (*ClassificationCandidate) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ClassificationCandidate) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *ClassificationCandidate) GetConfidenceScore() float32 {
+	if x != nil {
+		return x.ConfidenceScore
+	}
+	return 0
+}
+
+// ClassificationPolicy explains why fetching or classification was restricted,
+// so clients can tell the user instead of showing a bare neutral result
+type ClassificationPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"` // "private_ip", "denylisted_domain" or "safety_blocked"
+	Detail        string                 `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"` // human-readable explanation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClassificationPolicy) Reset() {
+	*x = ClassificationPolicy{}
+	mi := &file_brain_v1_server_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassificationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassificationPolicy) ProtoMessage() {}
+
+func (x *ClassificationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassificationPolicy.ProtoReflect.Descriptor instead.
+func (*ClassificationPolicy) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ClassificationPolicy) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ClassificationPolicy) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ClassifyApplicationRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationName     string                 `protobuf:"bytes,1,opt,name=application_name,json=applicationName,proto3" json:"application_name,omitempty"`               // "Visual Studio Code"
+	ApplicationBundleId string                 `protobuf:"bytes,2,opt,name=application_bundle_id,json=applicationBundleId,proto3" json:"application_bundle_id,omitempty"` // "com.microsoft.VSCode"
+	WindowTitle         string                 `protobuf:"bytes,3,opt,name=window_title,json=windowTitle,proto3" json:"window_title,omitempty"`                           // "main.go - focusd"
+	// Titles of the app's other tabs or windows, e.g. browser tabs. The model
+	// weighs them for the overall session; results are cached per set of tabs.
+	SecondaryTitles []string `protobuf:"bytes,4,rep,name=secondary_titles,json=secondaryTitles,proto3" json:"secondary_titles,omitempty"`
+	// Set while the user is in a meeting per their calendar, so call and chat
+	// apps read as part of the meeting rather than as interruptions.
+	InMeeting bool `protobuf:"varint,5,opt,name=in_meeting,json=inMeeting,proto3" json:"in_meeting,omitempty"`
+	// What the user declared they are doing, weighed by the model: "focus",
+	// "break" or "neutral". Empty and "neutral" classify as before.
+	UserMode string `protobuf:"bytes,6,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
+	// Skip the reasoning for a cheaper, faster answer with only the
+	// classification and tags. Cached separately from full classifications.
+	TagsOnly bool `protobuf:"varint,7,opt,name=tags_only,json=tagsOnly,proto3" json:"tags_only,omitempty"`
+	// BCP 47 language for the reasoning, e.g. "de" or "pt-BR", defaulting to
+	// the Accept-Language header. Classification and tags stay English.
+	Locale string `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Opt-in OCR'd text of the window, for context beyond the title. Emails and
+	// long numbers are masked and it is trimmed before reaching the model.
+	// Results classified with it are never cached.
+	ScreenText string `protobuf:"bytes,9,opt,name=screen_text,json=screenText,proto3" json:"screen_text,omitempty"`
+	// Seconds to cache this result for, e.g. shorter for live dashboards.
+	// Never longer than the configured TTL or CLASSIFICATION_MAX_REQUEST_CACHE_TTL;
+	// 0 uses the configured TTL.
+	CacheTtlSeconds int64 `protobuf:"varint,10,opt,name=cache_ttl_seconds,json=cacheTtlSeconds,proto3" json:"cache_ttl_seconds,omitempty"`
+	// Also return the top ranked classifications with their confidence, for
+	// ambiguous inputs. Cached separately from single answers.
+	ReturnCandidates bool `protobuf:"varint,11,opt,name=return_candidates,json=returnCandidates,proto3" json:"return_candidates,omitempty"`
+	// Whether the app is the one the user is looking at, when the client knows.
+	// Media playing in the background leans supporting, media watched in the
+	// foreground distracting. Unset classifies from the title alone.
+	Foreground *bool `protobuf:"varint,12,opt,name=foreground,proto3,oneof" json:"foreground,omitempty"`
+	// The category the OS declares for the app, e.g. macOS's
+	// "public.app-category.developer-tools". A strong hint for the model and
+	// part of the cache key; unknown categories are passed on as they are.
+	AppCategory string `protobuf:"bytes,13,opt,name=app_category,json=appCategory,proto3" json:"app_category,omitempty"`
+	// A short summary of what the user was just doing, e.g. "coding in VS Code
+	// for the last hour", so a quick check of a social site after long focus
+	// can read as a break. Trimmed to CLASSIFICATION_MAX_RECENT_ACTIVITY;
+	// results classified with it are never cached. When empty and
+	// CLASSIFICATION_RECENT_ACTIVITY is on, the server summarizes the user's
+	// open focus session instead.
+	RecentActivity string `protobuf:"bytes,14,opt,name=recent_activity,json=recentActivity,proto3" json:"recent_activity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyApplicationRequest) Reset() {
+	*x = ClassifyApplicationRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyApplicationRequest) ProtoMessage() {}
+
+func (x *ClassifyApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyApplicationRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClassifyApplicationRequest) GetApplicationName() string {
+	if x != nil {
+		return x.ApplicationName
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetApplicationBundleId() string {
+	if x != nil {
+		return x.ApplicationBundleId
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetWindowTitle() string {
+	if x != nil {
+		return x.WindowTitle
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetSecondaryTitles() []string {
+	if x != nil {
+		return x.SecondaryTitles
+	}
+	return nil
+}
+
+func (x *ClassifyApplicationRequest) GetInMeeting() bool {
+	if x != nil {
+		return x.InMeeting
+	}
+	return false
+}
+
+func (x *ClassifyApplicationRequest) GetUserMode() string {
+	if x != nil {
+		return x.UserMode
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetTagsOnly() bool {
+	if x != nil {
+		return x.TagsOnly
+	}
+	return false
+}
+
+func (x *ClassifyApplicationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetScreenText() string {
+	if x != nil {
+		return x.ScreenText
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetCacheTtlSeconds() int64 {
+	if x != nil {
+		return x.CacheTtlSeconds
+	}
+	return 0
+}
+
+func (x *ClassifyApplicationRequest) GetReturnCandidates() bool {
+	if x != nil {
+		return x.ReturnCandidates
+	}
+	return false
+}
+
+func (x *ClassifyApplicationRequest) GetForeground() bool {
+	if x != nil && x.Foreground != nil {
+		return *x.Foreground
+	}
+	return false
+}
+
+func (x *ClassifyApplicationRequest) GetAppCategory() string {
+	if x != nil {
+		return x.AppCategory
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationRequest) GetRecentActivity() string {
+	if x != nil {
+		return x.RecentActivity
+	}
+	return ""
+}
+
+type ClassifyApplicationResponse struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	Classification               *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	DetectedCommunicationChannel *string                `protobuf:"bytes,2,opt,name=detected_communication_channel,json=detectedCommunicationChannel,proto3,oneof" json:"detected_communication_channel,omitempty"`
+	// Metadata extraction (for Context correlation)
+	DetectedProject *string `protobuf:"bytes,3,opt,name=detected_project,json=detectedProject,proto3,oneof" json:"detected_project,omitempty"` // e.g. "focusd" extracted from title
+	DetectedFile    *string `protobuf:"bytes,4,opt,name=detected_file,json=detectedFile,proto3,oneof" json:"detected_file,omitempty"`          // e.g. "main.go"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ClassifyApplicationResponse) Reset() {
+	*x = ClassifyApplicationResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyApplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyApplicationResponse) ProtoMessage() {}
+
+func (x *ClassifyApplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyApplicationResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyApplicationResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClassifyApplicationResponse) GetClassification() *ClassificationResult {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+func (x *ClassifyApplicationResponse) GetDetectedCommunicationChannel() string {
+	if x != nil && x.DetectedCommunicationChannel != nil {
+		return *x.DetectedCommunicationChannel
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationResponse) GetDetectedProject() string {
+	if x != nil && x.DetectedProject != nil {
+		return *x.DetectedProject
+	}
+	return ""
+}
+
+func (x *ClassifyApplicationResponse) GetDetectedFile() string {
+	if x != nil && x.DetectedFile != nil {
+		return *x.DetectedFile
+	}
+	return ""
+}
+
+type ClassifyWebsiteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// What the user declared they are doing, see ClassifyApplicationRequest
+	UserMode string `protobuf:"bytes,3,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
+	// Skip the reasoning, see ClassifyApplicationRequest
+	TagsOnly bool `protobuf:"varint,4,opt,name=tags_only,json=tagsOnly,proto3" json:"tags_only,omitempty"`
+	// Language for the reasoning, see ClassifyApplicationRequest
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Seconds to cache this result for, see ClassifyApplicationRequest
+	CacheTtlSeconds int64 `protobuf:"varint,6,opt,name=cache_ttl_seconds,json=cacheTtlSeconds,proto3" json:"cache_ttl_seconds,omitempty"`
+	// Return ranked candidates, see ClassifyApplicationRequest
+	ReturnCandidates bool `protobuf:"varint,7,opt,name=return_candidates,json=returnCandidates,proto3" json:"return_candidates,omitempty"`
+	// Whether the tab is in the foreground, see ClassifyApplicationRequest
+	Foreground *bool `protobuf:"varint,8,opt,name=foreground,proto3,oneof" json:"foreground,omitempty"`
+	// What the user was just doing, see ClassifyApplicationRequest
+	RecentActivity string `protobuf:"bytes,9,opt,name=recent_activity,json=recentActivity,proto3" json:"recent_activity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyWebsiteRequest) Reset() {
+	*x = ClassifyWebsiteRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyWebsiteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyWebsiteRequest) ProtoMessage() {}
+
+func (x *ClassifyWebsiteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyWebsiteRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyWebsiteRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ClassifyWebsiteRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ClassifyWebsiteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ClassifyWebsiteRequest) GetUserMode() string {
+	if x != nil {
+		return x.UserMode
+	}
+	return ""
+}
+
+func (x *ClassifyWebsiteRequest) GetTagsOnly() bool {
+	if x != nil {
+		return x.TagsOnly
+	}
+	return false
+}
+
+func (x *ClassifyWebsiteRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ClassifyWebsiteRequest) GetCacheTtlSeconds() int64 {
+	if x != nil {
+		return x.CacheTtlSeconds
+	}
+	return 0
+}
+
+func (x *ClassifyWebsiteRequest) GetReturnCandidates() bool {
+	if x != nil {
+		return x.ReturnCandidates
+	}
+	return false
+}
+
+func (x *ClassifyWebsiteRequest) GetForeground() bool {
+	if x != nil && x.Foreground != nil {
+		return *x.Foreground
+	}
+	return false
+}
+
+func (x *ClassifyWebsiteRequest) GetRecentActivity() string {
+	if x != nil {
+		return x.RecentActivity
+	}
+	return ""
+}
+
+type ClassifyWebsiteResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Classification *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyWebsiteResponse) Reset() {
+	*x = ClassifyWebsiteResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyWebsiteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyWebsiteResponse) ProtoMessage() {}
+
+func (x *ClassifyWebsiteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyWebsiteResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyWebsiteResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ClassifyWebsiteResponse) GetClassification() *ClassificationResult {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+type ClassifyDocumentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Path            string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                              // "/Users/jane/work/Q3-report.xlsx"
+	ApplicationName string                 `protobuf:"bytes,2,opt,name=application_name,json=applicationName,proto3" json:"application_name,omitempty"` // "Microsoft Excel", optional hint
+	// Language for the reasoning, see ClassifyApplicationRequest
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClassifyDocumentRequest) Reset() {
+	*x = ClassifyDocumentRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyDocumentRequest) ProtoMessage() {}
+
+func (x *ClassifyDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyDocumentRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ClassifyDocumentRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ClassifyDocumentRequest) GetApplicationName() string {
+	if x != nil {
+		return x.ApplicationName
+	}
+	return ""
+}
+
+func (x *ClassifyDocumentRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ClassifyDocumentResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Classification *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyDocumentResponse) Reset() {
+	*x = ClassifyDocumentResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyDocumentResponse) ProtoMessage() {}
+
+func (x *ClassifyDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyDocumentResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClassifyDocumentResponse) GetClassification() *ClassificationResult {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+type ClassifyEmailRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Sender  string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`         // "Jane Doe <jane@acme.com>"
+	Subject string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`       // "Re: Q3 planning"
+	Snippet *string                `protobuf:"bytes,3,opt,name=snippet,proto3,oneof" json:"snippet,omitempty"` // first lines of the body, not part of the cache key
+	// Language for the reasoning, see ClassifyApplicationRequest
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClassifyEmailRequest) Reset() {
+	*x = ClassifyEmailRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyEmailRequest) ProtoMessage() {}
+
+func (x *ClassifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ClassifyEmailRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *ClassifyEmailRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ClassifyEmailRequest) GetSnippet() string {
+	if x != nil && x.Snippet != nil {
+		return *x.Snippet
+	}
+	return ""
+}
+
+func (x *ClassifyEmailRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ClassifyEmailResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Classification *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyEmailResponse) Reset() {
+	*x = ClassifyEmailResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyEmailResponse) ProtoMessage() {}
+
+func (x *ClassifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ClassifyEmailResponse) GetClassification() *ClassificationResult {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+type ClassifyCommandRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Command string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"` // "go test ./..."
+	Cwd     *string                `protobuf:"bytes,2,opt,name=cwd,proto3,oneof" json:"cwd,omitempty"`   // "/Users/jane/src/brain", part of the cache key
+	// Language for the reasoning, see ClassifyApplicationRequest
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClassifyCommandRequest) Reset() {
+	*x = ClassifyCommandRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyCommandRequest) ProtoMessage() {}
+
+func (x *ClassifyCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyCommandRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyCommandRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ClassifyCommandRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ClassifyCommandRequest) GetCwd() string {
+	if x != nil && x.Cwd != nil {
+		return *x.Cwd
+	}
+	return ""
+}
+
+func (x *ClassifyCommandRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ClassifyCommandResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Classification *ClassificationResult  `protobuf:"bytes,1,opt,name=classification,proto3" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassifyCommandResponse) Reset() {
+	*x = ClassifyCommandResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassifyCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyCommandResponse) ProtoMessage() {}
+
+func (x *ClassifyCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyCommandResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyCommandResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClassifyCommandResponse) GetClassification() *ClassificationResult {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+type RateClassificationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Identifies the classified input: bundle id or app name, URL, document path, sender or command
+	Input          string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Classification string `protobuf:"bytes,3,opt,name=classification,proto3" json:"classification,omitempty"`                    // the classification being rated, e.g. "distracting"
+	Agree          bool   `protobuf:"varint,4,opt,name=agree,proto3" json:"agree,omitempty"`                                     // thumbs up when true, thumbs down otherwise
+	PromptVersion  string `protobuf:"bytes,5,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"` // from the rated result, for per-version quality tracking
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RateClassificationRequest) Reset() {
+	*x = RateClassificationRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateClassificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateClassificationRequest) ProtoMessage() {}
+
+func (x *RateClassificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateClassificationRequest.ProtoReflect.Descriptor instead.
+func (*RateClassificationRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RateClassificationRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RateClassificationRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *RateClassificationRequest) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *RateClassificationRequest) GetAgree() bool {
+	if x != nil {
+		return x.Agree
+	}
+	return false
+}
+
+func (x *RateClassificationRequest) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+type RateClassificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateClassificationResponse) Reset() {
+	*x = RateClassificationResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateClassificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateClassificationResponse) ProtoMessage() {}
+
+func (x *RateClassificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateClassificationResponse.ProtoReflect.Descriptor instead.
+func (*RateClassificationResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{27}
+}
+
+type ExportRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRulesRequest) Reset() {
+	*x = ExportRulesRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRulesRequest) ProtoMessage() {}
+
+func (x *ExportRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRulesRequest.ProtoReflect.Descriptor instead.
+func (*ExportRulesRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{28}
+}
+
+type ExportRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RulesJson     string                 `protobuf:"bytes,1,opt,name=rules_json,json=rulesJson,proto3" json:"rules_json,omitempty"` // {"version": 1, "overrides": [...]}
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRulesResponse) Reset() {
+	*x = ExportRulesResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRulesResponse) ProtoMessage() {}
+
+func (x *ExportRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRulesResponse.ProtoReflect.Descriptor instead.
+func (*ExportRulesResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportRulesResponse) GetRulesJson() string {
+	if x != nil {
+		return x.RulesJson
+	}
+	return ""
+}
+
+func (x *ExportRulesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ImportRulesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RulesJson string                 `protobuf:"bytes,1,opt,name=rules_json,json=rulesJson,proto3" json:"rules_json,omitempty"`
+	// What to do with an override for a target the user already has: "skip",
+	// the default, keeps the existing one, "overwrite" replaces it.
+	OnConflict    string `protobuf:"bytes,2,opt,name=on_conflict,json=onConflict,proto3" json:"on_conflict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRulesRequest) Reset() {
+	*x = ImportRulesRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRulesRequest) ProtoMessage() {}
+
+func (x *ImportRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRulesRequest.ProtoReflect.Descriptor instead.
+func (*ImportRulesRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportRulesRequest) GetRulesJson() string {
+	if x != nil {
+		return x.RulesJson
+	}
+	return ""
+}
+
+func (x *ImportRulesRequest) GetOnConflict() string {
+	if x != nil {
+		return x.OnConflict
+	}
+	return ""
+}
+
+type ImportRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"` // new overrides
+	Overwritten   int32                  `protobuf:"varint,2,opt,name=overwritten,proto3" json:"overwritten,omitempty"`
+	Skipped       int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRulesResponse) Reset() {
+	*x = ImportRulesResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRulesResponse) ProtoMessage() {}
+
+func (x *ImportRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRulesResponse.ProtoReflect.Descriptor instead.
+func (*ImportRulesResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportRulesResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportRulesResponse) GetOverwritten() int32 {
+	if x != nil {
+		return x.Overwritten
+	}
+	return 0
+}
+
+func (x *ImportRulesResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type SetPromptAddendumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"` // further capped by PROMPT_ADDENDUM_MAX_LENGTH
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromptAddendumRequest) Reset() {
+	*x = SetPromptAddendumRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPromptAddendumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromptAddendumRequest) ProtoMessage() {}
+
+func (x *SetPromptAddendumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPromptAddendumRequest.ProtoReflect.Descriptor instead.
+func (*SetPromptAddendumRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetPromptAddendumRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *SetPromptAddendumRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SetPromptAddendumResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"` // as stored, trimmed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromptAddendumResponse) Reset() {
+	*x = SetPromptAddendumResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPromptAddendumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromptAddendumResponse) ProtoMessage() {}
+
+func (x *SetPromptAddendumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPromptAddendumResponse.ProtoReflect.Descriptor instead.
+func (*SetPromptAddendumResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetPromptAddendumResponse) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *SetPromptAddendumResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type GetPromptAddendumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptAddendumRequest) Reset() {
+	*x = GetPromptAddendumRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptAddendumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptAddendumRequest) ProtoMessage() {}
+
+func (x *GetPromptAddendumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptAddendumRequest.ProtoReflect.Descriptor instead.
+func (*GetPromptAddendumRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{34}
+}
+
+type GetPromptAddendumResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserText      string                 `protobuf:"bytes,1,opt,name=user_text,json=userText,proto3" json:"user_text,omitempty"`       // the caller's own rules
+	GlobalText    string                 `protobuf:"bytes,2,opt,name=global_text,json=globalText,proto3" json:"global_text,omitempty"` // the organization's rules, applied before the user's
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptAddendumResponse) Reset() {
+	*x = GetPromptAddendumResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptAddendumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptAddendumResponse) ProtoMessage() {}
+
+func (x *GetPromptAddendumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptAddendumResponse.ProtoReflect.Descriptor instead.
+func (*GetPromptAddendumResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetPromptAddendumResponse) GetUserText() string {
+	if x != nil {
+		return x.UserText
+	}
+	return ""
+}
+
+func (x *GetPromptAddendumResponse) GetGlobalText() string {
+	if x != nil {
+		return x.GlobalText
+	}
+	return ""
+}
+
+type GetTaxonomyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaxonomyRequest) Reset() {
+	*x = GetTaxonomyRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaxonomyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaxonomyRequest) ProtoMessage() {}
+
+func (x *GetTaxonomyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaxonomyRequest.ProtoReflect.Descriptor instead.
+func (*GetTaxonomyRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{36}
+}
+
+type TaxonomyEntry struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // "productive", "code-editor"
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Tags only: the classifiers that may return the tag, e.g. "application", "website"
+	Sources []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Tags only: the score a tag contributes, from CLASSIFICATION_TAG_WEIGHTS.
+	// Unweighted tags are 0.
+	Weight        float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxonomyEntry) Reset() {
+	*x = TaxonomyEntry{}
+	mi := &file_brain_v1_server_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxonomyEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxonomyEntry) ProtoMessage() {}
+
+func (x *TaxonomyEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxonomyEntry.ProtoReflect.Descriptor instead.
+func (*TaxonomyEntry) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TaxonomyEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxonomyEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaxonomyEntry) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *TaxonomyEntry) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type GetTaxonomyResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Classifications []*TaxonomyEntry       `protobuf:"bytes,1,rep,name=classifications,proto3" json:"classifications,omitempty"`
+	Tags            []*TaxonomyEntry       `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTaxonomyResponse) Reset() {
+	*x = GetTaxonomyResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaxonomyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaxonomyResponse) ProtoMessage() {}
+
+func (x *GetTaxonomyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaxonomyResponse.ProtoReflect.Descriptor instead.
+func (*GetTaxonomyResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetTaxonomyResponse) GetClassifications() []*TaxonomyEntry {
+	if x != nil {
+		return x.Classifications
+	}
+	return nil
+}
+
+func (x *GetTaxonomyResponse) GetTags() []*TaxonomyEntry {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type FocusSessionSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SessionId       int64                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	StartedAt       int64                  `protobuf:"varint,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt         int64                  `protobuf:"varint,3,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// True when the session outlived the maximum duration and was closed at it
+	Abandoned           bool  `protobuf:"varint,5,opt,name=abandoned,proto3" json:"abandoned,omitempty"`
+	ClassificationCount int32 `protobuf:"varint,6,opt,name=classification_count,json=classificationCount,proto3" json:"classification_count,omitempty"`
+	ProductiveCount     int32 `protobuf:"varint,7,opt,name=productive_count,json=productiveCount,proto3" json:"productive_count,omitempty"`
+	SupportingCount     int32 `protobuf:"varint,8,opt,name=supporting_count,json=supportingCount,proto3" json:"supporting_count,omitempty"`
+	NeutralCount        int32 `protobuf:"varint,9,opt,name=neutral_count,json=neutralCount,proto3" json:"neutral_count,omitempty"`
+	DistractingCount    int32 `protobuf:"varint,10,opt,name=distracting_count,json=distractingCount,proto3" json:"distracting_count,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FocusSessionSummary) Reset() {
+	*x = FocusSessionSummary{}
+	mi := &file_brain_v1_server_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FocusSessionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocusSessionSummary) ProtoMessage() {}
+
+func (x *FocusSessionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocusSessionSummary.ProtoReflect.Descriptor instead.
+func (*FocusSessionSummary) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FocusSessionSummary) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetAbandoned() bool {
+	if x != nil {
+		return x.Abandoned
+	}
+	return false
+}
+
+func (x *FocusSessionSummary) GetClassificationCount() int32 {
+	if x != nil {
+		return x.ClassificationCount
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetProductiveCount() int32 {
+	if x != nil {
+		return x.ProductiveCount
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetSupportingCount() int32 {
+	if x != nil {
+		return x.SupportingCount
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetNeutralCount() int32 {
+	if x != nil {
+		return x.NeutralCount
+	}
+	return 0
+}
+
+func (x *FocusSessionSummary) GetDistractingCount() int32 {
+	if x != nil {
+		return x.DistractingCount
+	}
+	return 0
+}
+
+type StartFocusSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartFocusSessionRequest) Reset() {
+	*x = StartFocusSessionRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartFocusSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartFocusSessionRequest) ProtoMessage() {}
+
+func (x *StartFocusSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartFocusSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartFocusSessionRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{40}
+}
+
+type StartFocusSessionResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int64                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	StartedAt int64                  `protobuf:"varint,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Sessions the start closed, e.g. one left running on another device
+	Closed        []*FocusSessionSummary `protobuf:"bytes,3,rep,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartFocusSessionResponse) Reset() {
+	*x = StartFocusSessionResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartFocusSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartFocusSessionResponse) ProtoMessage() {}
+
+func (x *StartFocusSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartFocusSessionResponse.ProtoReflect.Descriptor instead.
+func (*StartFocusSessionResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StartFocusSessionResponse) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *StartFocusSessionResponse) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *StartFocusSessionResponse) GetClosed() []*FocusSessionSummary {
+	if x != nil {
+		return x.Closed
+	}
+	return nil
+}
+
+type StopFocusSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopFocusSessionRequest) Reset() {
+	*x = StopFocusSessionRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopFocusSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopFocusSessionRequest) ProtoMessage() {}
+
+func (x *StopFocusSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopFocusSessionRequest.ProtoReflect.Descriptor instead.
+func (*StopFocusSessionRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{42}
+}
+
+type StopFocusSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *FocusSessionSummary   `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopFocusSessionResponse) Reset() {
+	*x = StopFocusSessionResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopFocusSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopFocusSessionResponse) ProtoMessage() {}
+
+func (x *StopFocusSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopFocusSessionResponse.ProtoReflect.Descriptor instead.
+func (*StopFocusSessionResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *StopFocusSessionResponse) GetSummary() *FocusSessionSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type ListDetectedProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                          // 0 for the default of 20
+	SinceUnix     int64                  `protobuf:"varint,2,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"` // only projects seen at or after this time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDetectedProjectsRequest) Reset() {
+	*x = ListDetectedProjectsRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDetectedProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDetectedProjectsRequest) ProtoMessage() {}
+
+func (x *ListDetectedProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDetectedProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListDetectedProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListDetectedProjectsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDetectedProjectsRequest) GetSinceUnix() int64 {
+	if x != nil {
+		return x.SinceUnix
+	}
+	return 0
+}
+
+type ListDetectedProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*DetectedProject     `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDetectedProjectsResponse) Reset() {
+	*x = ListDetectedProjectsResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDetectedProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDetectedProjectsResponse) ProtoMessage() {}
+
+func (x *ListDetectedProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDetectedProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListDetectedProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListDetectedProjectsResponse) GetProjects() []*DetectedProject {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type DetectedProject struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // as last detected
+	FirstSeenAt         int64                  `protobuf:"varint,2,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	LastSeenAt          int64                  `protobuf:"varint,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	ClassificationCount int64                  `protobuf:"varint,4,opt,name=classification_count,json=classificationCount,proto3" json:"classification_count,omitempty"`
+	LastKind            string                 `protobuf:"bytes,5,opt,name=last_kind,json=lastKind,proto3" json:"last_kind,omitempty"` // "application", "website", "document", "email" or "command"
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DetectedProject) Reset() {
+	*x = DetectedProject{}
+	mi := &file_brain_v1_server_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectedProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectedProject) ProtoMessage() {}
+
+func (x *DetectedProject) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectedProject.ProtoReflect.Descriptor instead.
+func (*DetectedProject) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DetectedProject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DetectedProject) GetFirstSeenAt() int64 {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetLastSeenAt() int64 {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetClassificationCount() int64 {
+	if x != nil {
+		return x.ClassificationCount
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetLastKind() string {
+	if x != nil {
+		return x.LastKind
+	}
+	return ""
+}
+
+type AgentSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*AgentSessionRequest_RunRequest_
+	//	*AgentSessionRequest_ToolCallResponse_
+	//	*AgentSessionRequest_Heartbeat_
+	//	*AgentSessionRequest_SessionEnd_
+	Message       isAgentSessionRequest_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentSessionRequest) Reset() {
+	*x = AgentSessionRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentSessionRequest) ProtoMessage() {}
+
+func (x *AgentSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentSessionRequest.ProtoReflect.Descriptor instead.
+func (*AgentSessionRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AgentSessionRequest) GetMessage() isAgentSessionRequest_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *AgentSessionRequest) GetRunRequest() *AgentSessionRequest_RunRequest {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionRequest_RunRequest_); ok {
+			return x.RunRequest
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionRequest) GetToolCallResponse() *AgentSessionRequest_ToolCallResponse {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionRequest_ToolCallResponse_); ok {
+			return x.ToolCallResponse
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionRequest) GetHeartbeat() *AgentSessionRequest_Heartbeat {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionRequest_Heartbeat_); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionRequest) GetSessionEnd() *AgentSessionRequest_SessionEnd {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionRequest_SessionEnd_); ok {
+			return x.SessionEnd
+		}
+	}
+	return nil
+}
+
+type isAgentSessionRequest_Message interface {
+	isAgentSessionRequest_Message()
+}
+
+type AgentSessionRequest_RunRequest_ struct {
+	RunRequest *AgentSessionRequest_RunRequest `protobuf:"bytes,1,opt,name=run_request,json=runRequest,proto3,oneof"`
+}
+
+type AgentSessionRequest_ToolCallResponse_ struct {
 	ToolCallResponse *AgentSessionRequest_ToolCallResponse `protobuf:"bytes,2,opt,name=tool_call_response,json=toolCallResponse,proto3,oneof"`
 }
 
-type AgentSessionRequest_Heartbeat_ struct {
-	Heartbeat *AgentSessionRequest_Heartbeat `protobuf:"bytes,3,opt,name=heartbeat,proto3,oneof"`
+type AgentSessionRequest_Heartbeat_ struct {
+	Heartbeat *AgentSessionRequest_Heartbeat `protobuf:"bytes,3,opt,name=heartbeat,proto3,oneof"`
+}
+
+type AgentSessionRequest_SessionEnd_ struct {
+	SessionEnd *AgentSessionRequest_SessionEnd `protobuf:"bytes,4,opt,name=session_end,json=sessionEnd,proto3,oneof"`
+}
+
+func (*AgentSessionRequest_RunRequest_) isAgentSessionRequest_Message() {}
+
+func (*AgentSessionRequest_ToolCallResponse_) isAgentSessionRequest_Message() {}
+
+func (*AgentSessionRequest_Heartbeat_) isAgentSessionRequest_Message() {}
+
+func (*AgentSessionRequest_SessionEnd_) isAgentSessionRequest_Message() {}
+
+type AgentSessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*AgentSessionResponse_RunResponse_
+	//	*AgentSessionResponse_ToolCallRequest_
+	//	*AgentSessionResponse_Error_
+	//	*AgentSessionResponse_HeartbeatAck_
+	//	*AgentSessionResponse_SessionEndAck_
+	Message       isAgentSessionResponse_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentSessionResponse) Reset() {
+	*x = AgentSessionResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentSessionResponse) ProtoMessage() {}
+
+func (x *AgentSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentSessionResponse.ProtoReflect.Descriptor instead.
+func (*AgentSessionResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AgentSessionResponse) GetMessage() isAgentSessionResponse_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *AgentSessionResponse) GetRunResponse() *AgentSessionResponse_RunResponse {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionResponse_RunResponse_); ok {
+			return x.RunResponse
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionResponse) GetToolCallRequest() *AgentSessionResponse_ToolCallRequest {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionResponse_ToolCallRequest_); ok {
+			return x.ToolCallRequest
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionResponse) GetError() *AgentSessionResponse_Error {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionResponse_Error_); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionResponse) GetHeartbeatAck() *AgentSessionResponse_HeartbeatAck {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionResponse_HeartbeatAck_); ok {
+			return x.HeartbeatAck
+		}
+	}
+	return nil
+}
+
+func (x *AgentSessionResponse) GetSessionEndAck() *AgentSessionResponse_SessionEndAck {
+	if x != nil {
+		if x, ok := x.Message.(*AgentSessionResponse_SessionEndAck_); ok {
+			return x.SessionEndAck
+		}
+	}
+	return nil
+}
+
+type isAgentSessionResponse_Message interface {
+	isAgentSessionResponse_Message()
+}
+
+type AgentSessionResponse_RunResponse_ struct {
+	RunResponse *AgentSessionResponse_RunResponse `protobuf:"bytes,1,opt,name=run_response,json=runResponse,proto3,oneof"`
+}
+
+type AgentSessionResponse_ToolCallRequest_ struct {
+	ToolCallRequest *AgentSessionResponse_ToolCallRequest `protobuf:"bytes,2,opt,name=tool_call_request,json=toolCallRequest,proto3,oneof"`
+}
+
+type AgentSessionResponse_Error_ struct {
+	Error *AgentSessionResponse_Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type AgentSessionResponse_HeartbeatAck_ struct {
+	HeartbeatAck *AgentSessionResponse_HeartbeatAck `protobuf:"bytes,4,opt,name=heartbeat_ack,json=heartbeatAck,proto3,oneof"`
+}
+
+type AgentSessionResponse_SessionEndAck_ struct {
+	SessionEndAck *AgentSessionResponse_SessionEndAck `protobuf:"bytes,5,opt,name=session_end_ack,json=sessionEndAck,proto3,oneof"`
+}
+
+func (*AgentSessionResponse_RunResponse_) isAgentSessionResponse_Message() {}
+
+func (*AgentSessionResponse_ToolCallRequest_) isAgentSessionResponse_Message() {}
+
+func (*AgentSessionResponse_Error_) isAgentSessionResponse_Message() {}
+
+func (*AgentSessionResponse_HeartbeatAck_) isAgentSessionResponse_Message() {}
+
+func (*AgentSessionResponse_SessionEndAck_) isAgentSessionResponse_Message() {}
+
+type OAuth2GetAuthorizationURLRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	State    string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// PKCE Fields (Critical for Desktop Security)
+	CodeChallenge       string   `protobuf:"bytes,3,opt,name=code_challenge,json=codeChallenge,proto3" json:"code_challenge,omitempty"`
+	CodeChallengeMethod string   `protobuf:"bytes,4,opt,name=code_challenge_method,json=codeChallengeMethod,proto3" json:"code_challenge_method,omitempty"`
+	Scopes              []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"` // Optional
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) Reset() {
+	*x = OAuth2GetAuthorizationURLRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2GetAuthorizationURLRequest) ProtoMessage() {}
+
+func (x *OAuth2GetAuthorizationURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2GetAuthorizationURLRequest.ProtoReflect.Descriptor instead.
+func (*OAuth2GetAuthorizationURLRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) GetCodeChallenge() string {
+	if x != nil {
+		return x.CodeChallenge
+	}
+	return ""
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) GetCodeChallengeMethod() string {
+	if x != nil {
+		return x.CodeChallengeMethod
+	}
+	return ""
+}
+
+func (x *OAuth2GetAuthorizationURLRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type OAuth2GetAuthorizationURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // Full URL to open in system browser
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2GetAuthorizationURLResponse) Reset() {
+	*x = OAuth2GetAuthorizationURLResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2GetAuthorizationURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2GetAuthorizationURLResponse) ProtoMessage() {}
+
+func (x *OAuth2GetAuthorizationURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2GetAuthorizationURLResponse.ProtoReflect.Descriptor instead.
+func (*OAuth2GetAuthorizationURLResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *OAuth2GetAuthorizationURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type OAuth2ExchangeAuthorizationCodeRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Provider    string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                          // "github"
+	Code        string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                  // The code received via Deep Link
+	RedirectUri string                 `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"` // "focusd://callback"
+	// PKCE Verification
+	// Sidecar sends the secret. Cloud verifies it against the Challenge
+	// sent in Step 1 before completing the exchange.
+	CodeVerifier  string `protobuf:"bytes,4,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) Reset() {
+	*x = OAuth2ExchangeAuthorizationCodeRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2ExchangeAuthorizationCodeRequest) ProtoMessage() {}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2ExchangeAuthorizationCodeRequest.ProtoReflect.Descriptor instead.
+func (*OAuth2ExchangeAuthorizationCodeRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeRequest) GetCodeVerifier() string {
+	if x != nil {
+		return x.CodeVerifier
+	}
+	return ""
+}
+
+type OAuth2ExchangeAuthorizationCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *v1.OAuth2Token        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeResponse) Reset() {
+	*x = OAuth2ExchangeAuthorizationCodeResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2ExchangeAuthorizationCodeResponse) ProtoMessage() {}
+
+func (x *OAuth2ExchangeAuthorizationCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2ExchangeAuthorizationCodeResponse.ProtoReflect.Descriptor instead.
+func (*OAuth2ExchangeAuthorizationCodeResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *OAuth2ExchangeAuthorizationCodeResponse) GetToken() *v1.OAuth2Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type OAuth2RefreshAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2RefreshAccessTokenRequest) Reset() {
+	*x = OAuth2RefreshAccessTokenRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2RefreshAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2RefreshAccessTokenRequest) ProtoMessage() {}
+
+func (x *OAuth2RefreshAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2RefreshAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*OAuth2RefreshAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *OAuth2RefreshAccessTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OAuth2RefreshAccessTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type OAuth2RefreshAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *v1.OAuth2Token        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2RefreshAccessTokenResponse) Reset() {
+	*x = OAuth2RefreshAccessTokenResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2RefreshAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2RefreshAccessTokenResponse) ProtoMessage() {}
+
+func (x *OAuth2RefreshAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2RefreshAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*OAuth2RefreshAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *OAuth2RefreshAccessTokenResponse) GetToken() *v1.OAuth2Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type OAuth2RevokeAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Access or Refresh token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2RevokeAccessTokenRequest) Reset() {
+	*x = OAuth2RevokeAccessTokenRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2RevokeAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2RevokeAccessTokenRequest) ProtoMessage() {}
+
+func (x *OAuth2RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*OAuth2RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *OAuth2RevokeAccessTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OAuth2RevokeAccessTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type OAuth2RevokeAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2RevokeAccessTokenResponse) Reset() {
+	*x = OAuth2RevokeAccessTokenResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2RevokeAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2RevokeAccessTokenResponse) ProtoMessage() {}
+
+func (x *OAuth2RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2RevokeAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*OAuth2RevokeAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *OAuth2RevokeAccessTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetOAuth2StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuth2StatusRequest) Reset() {
+	*x = GetOAuth2StatusRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuth2StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuth2StatusRequest) ProtoMessage() {}
+
+func (x *GetOAuth2StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuth2StatusRequest.ProtoReflect.Descriptor instead.
+func (*GetOAuth2StatusRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{57}
+}
+
+type GetOAuth2StatusResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Providers     []*OAuth2ProviderStatus `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuth2StatusResponse) Reset() {
+	*x = GetOAuth2StatusResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuth2StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuth2StatusResponse) ProtoMessage() {}
+
+func (x *GetOAuth2StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuth2StatusResponse.ProtoReflect.Descriptor instead.
+func (*GetOAuth2StatusResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetOAuth2StatusResponse) GetProviders() []*OAuth2ProviderStatus {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type OAuth2ProviderStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // "github"
+	Linked        bool                   `protobuf:"varint,2,opt,name=linked,proto3" json:"linked,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`                            // scopes granted when the provider was linked
+	ExpiryUnix    int64                  `protobuf:"varint,4,opt,name=expiry_unix,json=expiryUnix,proto3" json:"expiry_unix,omitempty"` // 0 when the token never expires
+	Expired       bool                   `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	ExpiringSoon  bool                   `protobuf:"varint,6,opt,name=expiring_soon,json=expiringSoon,proto3" json:"expiring_soon,omitempty"` // expires within the warning window, refresh or relink
+	LinkedAt      int64                  `protobuf:"varint,7,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`             // Unix timestamp of the last successful exchange
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2ProviderStatus) Reset() {
+	*x = OAuth2ProviderStatus{}
+	mi := &file_brain_v1_server_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2ProviderStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2ProviderStatus) ProtoMessage() {}
+
+func (x *OAuth2ProviderStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2ProviderStatus.ProtoReflect.Descriptor instead.
+func (*OAuth2ProviderStatus) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *OAuth2ProviderStatus) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OAuth2ProviderStatus) GetLinked() bool {
+	if x != nil {
+		return x.Linked
+	}
+	return false
+}
+
+func (x *OAuth2ProviderStatus) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuth2ProviderStatus) GetExpiryUnix() int64 {
+	if x != nil {
+		return x.ExpiryUnix
+	}
+	return 0
+}
+
+func (x *OAuth2ProviderStatus) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+func (x *OAuth2ProviderStatus) GetExpiringSoon() bool {
+	if x != nil {
+		return x.ExpiringSoon
+	}
+	return false
+}
+
+func (x *OAuth2ProviderStatus) GetLinkedAt() int64 {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return 0
+}
+
+type GetGitHubActivityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // GitHub access token from the OAuth2 exchange
+	// "owner/name", or a bare name such as a detected project for a repository
+	// owned by the token's user
+	Repository string `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
+	// How far back to count, defaults to 24 hours
+	WindowHours   int32 `protobuf:"varint,3,opt,name=window_hours,json=windowHours,proto3" json:"window_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGitHubActivityRequest) Reset() {
+	*x = GetGitHubActivityRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGitHubActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGitHubActivityRequest) ProtoMessage() {}
+
+func (x *GetGitHubActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGitHubActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetGitHubActivityRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetGitHubActivityRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetGitHubActivityRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GetGitHubActivityRequest) GetWindowHours() int32 {
+	if x != nil {
+		return x.WindowHours
+	}
+	return 0
+}
+
+type GetGitHubActivityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Repository      string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"` // resolved "owner/name"
+	Login           string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`           // the GitHub user whose commits were counted
+	CommitCount     int32                  `protobuf:"varint,3,opt,name=commit_count,json=commitCount,proto3" json:"commit_count,omitempty"`
+	WindowStartUnix int64                  `protobuf:"varint,4,opt,name=window_start_unix,json=windowStartUnix,proto3" json:"window_start_unix,omitempty"`
+	WindowEndUnix   int64                  `protobuf:"varint,5,opt,name=window_end_unix,json=windowEndUnix,proto3" json:"window_end_unix,omitempty"`
+	Truncated       bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"` // counting stopped at the page limit, the real count is higher
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetGitHubActivityResponse) Reset() {
+	*x = GetGitHubActivityResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGitHubActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGitHubActivityResponse) ProtoMessage() {}
+
+func (x *GetGitHubActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGitHubActivityResponse.ProtoReflect.Descriptor instead.
+func (*GetGitHubActivityResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetGitHubActivityResponse) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GetGitHubActivityResponse) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *GetGitHubActivityResponse) GetCommitCount() int32 {
+	if x != nil {
+		return x.CommitCount
+	}
+	return 0
+}
+
+func (x *GetGitHubActivityResponse) GetWindowStartUnix() int64 {
+	if x != nil {
+		return x.WindowStartUnix
+	}
+	return 0
+}
+
+func (x *GetGitHubActivityResponse) GetWindowEndUnix() int64 {
+	if x != nil {
+		return x.WindowEndUnix
+	}
+	return 0
+}
+
+func (x *GetGitHubActivityResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type SetClassificationWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetClassificationWebhookRequest) Reset() {
+	*x = SetClassificationWebhookRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetClassificationWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClassificationWebhookRequest) ProtoMessage() {}
+
+func (x *SetClassificationWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClassificationWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SetClassificationWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SetClassificationWebhookRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetClassificationWebhookResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// False when the server has no webhook URL configured, so nothing is sent
+	// even while enabled
+	Configured    bool `protobuf:"varint,2,opt,name=configured,proto3" json:"configured,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetClassificationWebhookResponse) Reset() {
+	*x = SetClassificationWebhookResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetClassificationWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClassificationWebhookResponse) ProtoMessage() {}
+
+func (x *SetClassificationWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClassificationWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SetClassificationWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SetClassificationWebhookResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetClassificationWebhookResponse) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+type RunMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vacuum        bool                   `protobuf:"varint,1,opt,name=vacuum,proto3" json:"vacuum,omitempty"` // run VACUUM after purging
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceRequest) Reset() {
+	*x = RunMaintenanceRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceRequest) ProtoMessage() {}
+
+func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RunMaintenanceRequest) GetVacuum() bool {
+	if x != nil {
+		return x.Vacuum
+	}
+	return false
+}
+
+type RunMaintenanceResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CacheRowsRemoved   int64                  `protobuf:"varint,1,opt,name=cache_rows_removed,json=cacheRowsRemoved,proto3" json:"cache_rows_removed,omitempty"`
+	NonceRowsRemoved   int64                  `protobuf:"varint,2,opt,name=nonce_rows_removed,json=nonceRowsRemoved,proto3" json:"nonce_rows_removed,omitempty"`
+	Vacuumed           bool                   `protobuf:"varint,3,opt,name=vacuumed,proto3" json:"vacuumed,omitempty"`
+	SessionRowsRemoved int64                  `protobuf:"varint,4,opt,name=session_rows_removed,json=sessionRowsRemoved,proto3" json:"session_rows_removed,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceResponse) Reset() {
+	*x = RunMaintenanceResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceResponse) ProtoMessage() {}
+
+func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RunMaintenanceResponse) GetCacheRowsRemoved() int64 {
+	if x != nil {
+		return x.CacheRowsRemoved
+	}
+	return 0
+}
+
+func (x *RunMaintenanceResponse) GetNonceRowsRemoved() int64 {
+	if x != nil {
+		return x.NonceRowsRemoved
+	}
+	return 0
+}
+
+func (x *RunMaintenanceResponse) GetVacuumed() bool {
+	if x != nil {
+		return x.Vacuumed
+	}
+	return false
+}
+
+func (x *RunMaintenanceResponse) GetSessionRowsRemoved() int64 {
+	if x != nil {
+		return x.SessionRowsRemoved
+	}
+	return 0
+}
+
+type GlobalOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Kind           string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`     // "application" or "domain"
+	Target         string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // normalized bundle id or app name, or domain
+	Classification string                 `protobuf:"bytes,3,opt,name=classification,proto3" json:"classification,omitempty"`
+	Tags           []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Note           string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GlobalOverride) Reset() {
+	*x = GlobalOverride{}
+	mi := &file_brain_v1_server_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalOverride) ProtoMessage() {}
+
+func (x *GlobalOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalOverride.ProtoReflect.Descriptor instead.
+func (*GlobalOverride) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GlobalOverride) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GlobalOverride) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GlobalOverride) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *GlobalOverride) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GlobalOverride) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *GlobalOverride) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type SetGlobalOverrideRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Kind           string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Target         string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // "com.atlassian.jira", "jira.example.com"
+	Classification string                 `protobuf:"bytes,3,opt,name=classification,proto3" json:"classification,omitempty"`
+	Tags           []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Note           string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetGlobalOverrideRequest) Reset() {
+	*x = SetGlobalOverrideRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGlobalOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGlobalOverrideRequest) ProtoMessage() {}
+
+func (x *SetGlobalOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGlobalOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetGlobalOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetGlobalOverrideRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SetGlobalOverrideRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SetGlobalOverrideRequest) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *SetGlobalOverrideRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SetGlobalOverrideRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type SetGlobalOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Override      *GlobalOverride        `protobuf:"bytes,1,opt,name=override,proto3" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGlobalOverrideResponse) Reset() {
+	*x = SetGlobalOverrideResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGlobalOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGlobalOverrideResponse) ProtoMessage() {}
+
+func (x *SetGlobalOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGlobalOverrideResponse.ProtoReflect.Descriptor instead.
+func (*SetGlobalOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetGlobalOverrideResponse) GetOverride() *GlobalOverride {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+type DeleteGlobalOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGlobalOverrideRequest) Reset() {
+	*x = DeleteGlobalOverrideRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGlobalOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGlobalOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteGlobalOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGlobalOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGlobalOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{69}
 }
 
-type AgentSessionRequest_SessionEnd_ struct {
-	SessionEnd *AgentSessionRequest_SessionEnd `protobuf:"bytes,4,opt,name=session_end,json=sessionEnd,proto3,oneof"`
+func (x *DeleteGlobalOverrideRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
 }
 
-func (*AgentSessionRequest_RunRequest_) isAgentSessionRequest_Message() {}
-
-func (*AgentSessionRequest_ToolCallResponse_) isAgentSessionRequest_Message() {}
-
-func (*AgentSessionRequest_Heartbeat_) isAgentSessionRequest_Message() {}
-
-func (*AgentSessionRequest_SessionEnd_) isAgentSessionRequest_Message() {}
+func (x *DeleteGlobalOverrideRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
 
-type AgentSessionResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Message:
-	//
-	//	*AgentSessionResponse_RunResponse_
-	//	*AgentSessionResponse_ToolCallRequest_
-	//	*AgentSessionResponse_Error_
-	//	*AgentSessionResponse_HeartbeatAck_
-	//	*AgentSessionResponse_SessionEndAck_
-	Message       isAgentSessionResponse_Message `protobuf_oneof:"message"`
+type DeleteGlobalOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // false when there was no such override
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentSessionResponse) Reset() {
-	*x = AgentSessionResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[8]
+func (x *DeleteGlobalOverrideResponse) Reset() {
+	*x = DeleteGlobalOverrideResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentSessionResponse) String() string {
+func (x *DeleteGlobalOverrideResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentSessionResponse) ProtoMessage() {}
+func (*DeleteGlobalOverrideResponse) ProtoMessage() {}
 
-func (x *AgentSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[8]
+func (x *DeleteGlobalOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -674,124 +4396,242 @@ func (x *AgentSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentSessionResponse.ProtoReflect.Descriptor instead.
-func (*AgentSessionResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DeleteGlobalOverrideResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGlobalOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *AgentSessionResponse) GetMessage() isAgentSessionResponse_Message {
+func (x *DeleteGlobalOverrideResponse) GetDeleted() bool {
 	if x != nil {
-		return x.Message
+		return x.Deleted
 	}
-	return nil
+	return false
 }
 
-func (x *AgentSessionResponse) GetRunResponse() *AgentSessionResponse_RunResponse {
+type ListGlobalOverridesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGlobalOverridesRequest) Reset() {
+	*x = ListGlobalOverridesRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGlobalOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGlobalOverridesRequest) ProtoMessage() {}
+
+func (x *ListGlobalOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[71]
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionResponse_RunResponse_); ok {
-			return x.RunResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AgentSessionResponse) GetToolCallRequest() *AgentSessionResponse_ToolCallRequest {
+// Deprecated: Use ListGlobalOverridesRequest.ProtoReflect.Descriptor instead.
+func (*ListGlobalOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{71}
+}
+
+type ListGlobalOverridesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overrides     []*GlobalOverride      `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGlobalOverridesResponse) Reset() {
+	*x = ListGlobalOverridesResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGlobalOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGlobalOverridesResponse) ProtoMessage() {}
+
+func (x *ListGlobalOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[72]
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionResponse_ToolCallRequest_); ok {
-			return x.ToolCallRequest
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AgentSessionResponse) GetError() *AgentSessionResponse_Error {
+// Deprecated: Use ListGlobalOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListGlobalOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListGlobalOverridesResponse) GetOverrides() []*GlobalOverride {
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionResponse_Error_); ok {
-			return x.Error
-		}
+		return x.Overrides
 	}
 	return nil
 }
 
-func (x *AgentSessionResponse) GetHeartbeatAck() *AgentSessionResponse_HeartbeatAck {
+// InvalidateCacheRequest selects cache entries by the classifier that made
+// them and the input they were cached for. At least one criterion is required.
+type InvalidateCacheRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Case-insensitive substring of the cached input, e.g. "github.com/".
+	// Only entries cached with CLASSIFICATION_CACHE_STORE_INPUTS=true match.
+	InputContains string `protobuf:"bytes,2,opt,name=input_contains,json=inputContains,proto3" json:"input_contains,omitempty"`
+	SimilarityKey string `protobuf:"bytes,3,opt,name=similarity_key,json=similarityKey,proto3" json:"similarity_key,omitempty"` // e.g. "domain:github.com" or "app:com.tinyspeck.slackmacgap"
+	DryRun        bool   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                     // count the matching entries without deleting them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateCacheRequest) Reset() {
+	*x = InvalidateCacheRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[73]
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionResponse_HeartbeatAck_); ok {
-			return x.HeartbeatAck
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AgentSessionResponse) GetSessionEndAck() *AgentSessionResponse_SessionEndAck {
+// Deprecated: Use InvalidateCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *InvalidateCacheRequest) GetKind() string {
 	if x != nil {
-		if x, ok := x.Message.(*AgentSessionResponse_SessionEndAck_); ok {
-			return x.SessionEndAck
-		}
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-type isAgentSessionResponse_Message interface {
-	isAgentSessionResponse_Message()
+func (x *InvalidateCacheRequest) GetInputContains() string {
+	if x != nil {
+		return x.InputContains
+	}
+	return ""
 }
 
-type AgentSessionResponse_RunResponse_ struct {
-	RunResponse *AgentSessionResponse_RunResponse `protobuf:"bytes,1,opt,name=run_response,json=runResponse,proto3,oneof"`
+func (x *InvalidateCacheRequest) GetSimilarityKey() string {
+	if x != nil {
+		return x.SimilarityKey
+	}
+	return ""
 }
 
-type AgentSessionResponse_ToolCallRequest_ struct {
-	ToolCallRequest *AgentSessionResponse_ToolCallRequest `protobuf:"bytes,2,opt,name=tool_call_request,json=toolCallRequest,proto3,oneof"`
+func (x *InvalidateCacheRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
 }
 
-type AgentSessionResponse_Error_ struct {
-	Error *AgentSessionResponse_Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+type InvalidateCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RowsRemoved   int64                  `protobuf:"varint,1,opt,name=rows_removed,json=rowsRemoved,proto3" json:"rows_removed,omitempty"` // rows matched when dry_run is set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type AgentSessionResponse_HeartbeatAck_ struct {
-	HeartbeatAck *AgentSessionResponse_HeartbeatAck `protobuf:"bytes,4,opt,name=heartbeat_ack,json=heartbeatAck,proto3,oneof"`
+func (x *InvalidateCacheResponse) Reset() {
+	*x = InvalidateCacheResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type AgentSessionResponse_SessionEndAck_ struct {
-	SessionEndAck *AgentSessionResponse_SessionEndAck `protobuf:"bytes,5,opt,name=session_end_ack,json=sessionEndAck,proto3,oneof"`
+func (x *InvalidateCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentSessionResponse_RunResponse_) isAgentSessionResponse_Message() {}
-
-func (*AgentSessionResponse_ToolCallRequest_) isAgentSessionResponse_Message() {}
+func (*InvalidateCacheResponse) ProtoMessage() {}
 
-func (*AgentSessionResponse_Error_) isAgentSessionResponse_Message() {}
+func (x *InvalidateCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*AgentSessionResponse_HeartbeatAck_) isAgentSessionResponse_Message() {}
+// Deprecated: Use InvalidateCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{74}
+}
 
-func (*AgentSessionResponse_SessionEndAck_) isAgentSessionResponse_Message() {}
+func (x *InvalidateCacheResponse) GetRowsRemoved() int64 {
+	if x != nil {
+		return x.RowsRemoved
+	}
+	return 0
+}
 
-type OAuth2GetAuthorizationURLRequest struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
-	State    string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
-	// PKCE Fields (Critical for Desktop Security)
-	CodeChallenge       string   `protobuf:"bytes,3,opt,name=code_challenge,json=codeChallenge,proto3" json:"code_challenge,omitempty"`
-	CodeChallengeMethod string   `protobuf:"bytes,4,opt,name=code_challenge_method,json=codeChallengeMethod,proto3" json:"code_challenge_method,omitempty"`
-	Scopes              []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"` // Optional
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+// ReplayClassificationsRequest selects the cache entries to replay, newest
+// first. Only entries cached with CLASSIFICATION_CACHE_STORE_INPUTS=true keep
+// the input needed to replay them.
+type ReplayClassificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                             // 0 for 50
+	SinceUnix     int64                  `protobuf:"varint,3,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"`    // only entries cached at or after this time
+	WriteCache    bool                   `protobuf:"varint,4,opt,name=write_cache,json=writeCache,proto3" json:"write_cache,omitempty"` // cache the new results under the current prompts' keys
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) Reset() {
-	*x = OAuth2GetAuthorizationURLRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[9]
+func (x *ReplayClassificationsRequest) Reset() {
+	*x = ReplayClassificationsRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) String() string {
+func (x *ReplayClassificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2GetAuthorizationURLRequest) ProtoMessage() {}
+func (*ReplayClassificationsRequest) ProtoMessage() {}
 
-func (x *OAuth2GetAuthorizationURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[9]
+func (x *ReplayClassificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,68 +4642,126 @@ func (x *OAuth2GetAuthorizationURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2GetAuthorizationURLRequest.ProtoReflect.Descriptor instead.
-func (*OAuth2GetAuthorizationURLRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReplayClassificationsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayClassificationsRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) GetProvider() string {
+func (x *ReplayClassificationsRequest) GetKind() string {
 	if x != nil {
-		return x.Provider
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) GetState() string {
+func (x *ReplayClassificationsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.State
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) GetCodeChallenge() string {
+func (x *ReplayClassificationsRequest) GetSinceUnix() int64 {
 	if x != nil {
-		return x.CodeChallenge
+		return x.SinceUnix
+	}
+	return 0
+}
+
+func (x *ReplayClassificationsRequest) GetWriteCache() bool {
+	if x != nil {
+		return x.WriteCache
+	}
+	return false
+}
+
+// ReplayTransition counts the replayed entries whose classification changed
+// from one value to another
+type ReplayTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayTransition) Reset() {
+	*x = ReplayTransition{}
+	mi := &file_brain_v1_server_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTransition) ProtoMessage() {}
+
+func (x *ReplayTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTransition.ProtoReflect.Descriptor instead.
+func (*ReplayTransition) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ReplayTransition) GetFrom() string {
+	if x != nil {
+		return x.From
 	}
 	return ""
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) GetCodeChallengeMethod() string {
+func (x *ReplayTransition) GetTo() string {
 	if x != nil {
-		return x.CodeChallengeMethod
+		return x.To
 	}
 	return ""
 }
 
-func (x *OAuth2GetAuthorizationURLRequest) GetScopes() []string {
+func (x *ReplayTransition) GetCount() int64 {
 	if x != nil {
-		return x.Scopes
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type OAuth2GetAuthorizationURLResponse struct {
+type ReplayChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // Full URL to open in system browser
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"` // the cached input as JSON
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2GetAuthorizationURLResponse) Reset() {
-	*x = OAuth2GetAuthorizationURLResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[10]
+func (x *ReplayChange) Reset() {
+	*x = ReplayChange{}
+	mi := &file_brain_v1_server_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2GetAuthorizationURLResponse) String() string {
+func (x *ReplayChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2GetAuthorizationURLResponse) ProtoMessage() {}
+func (*ReplayChange) ProtoMessage() {}
 
-func (x *OAuth2GetAuthorizationURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[10]
+func (x *ReplayChange) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,46 +4772,65 @@ func (x *OAuth2GetAuthorizationURLResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2GetAuthorizationURLResponse.ProtoReflect.Descriptor instead.
-func (*OAuth2GetAuthorizationURLResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ReplayChange.ProtoReflect.Descriptor instead.
+func (*ReplayChange) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *OAuth2GetAuthorizationURLResponse) GetUrl() string {
+func (x *ReplayChange) GetKind() string {
 	if x != nil {
-		return x.Url
+		return x.Kind
 	}
 	return ""
 }
 
-type OAuth2ExchangeAuthorizationCodeRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Provider    string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                          // "github"
-	Code        string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                  // The code received via Deep Link
-	RedirectUri string                 `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"` // "focusd://callback"
-	// PKCE Verification
-	// Sidecar sends the secret. Cloud verifies it against the Challenge
-	// sent in Step 1 before completing the exchange.
-	CodeVerifier  string `protobuf:"bytes,4,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier,omitempty"`
+func (x *ReplayChange) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ReplayChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReplayChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ReplayClassificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replayed      int64                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Changed       int64                  `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	Failed        int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`          // entries the model failed on or whose cached answer is unreadable
+	Transitions   []*ReplayTransition    `protobuf:"bytes,4,rep,name=transitions,proto3" json:"transitions,omitempty"` // most common first
+	Changes       []*ReplayChange        `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) Reset() {
-	*x = OAuth2ExchangeAuthorizationCodeRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[11]
+func (x *ReplayClassificationsResponse) Reset() {
+	*x = ReplayClassificationsResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) String() string {
+func (x *ReplayClassificationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2ExchangeAuthorizationCodeRequest) ProtoMessage() {}
+func (*ReplayClassificationsResponse) ProtoMessage() {}
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[11]
+func (x *ReplayClassificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,61 +4841,69 @@ func (x *OAuth2ExchangeAuthorizationCodeRequest) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2ExchangeAuthorizationCodeRequest.ProtoReflect.Descriptor instead.
-func (*OAuth2ExchangeAuthorizationCodeRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ReplayClassificationsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayClassificationsResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) GetProvider() string {
+func (x *ReplayClassificationsResponse) GetReplayed() int64 {
 	if x != nil {
-		return x.Provider
+		return x.Replayed
 	}
-	return ""
+	return 0
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) GetCode() string {
+func (x *ReplayClassificationsResponse) GetChanged() int64 {
 	if x != nil {
-		return x.Code
+		return x.Changed
 	}
-	return ""
+	return 0
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) GetRedirectUri() string {
+func (x *ReplayClassificationsResponse) GetFailed() int64 {
 	if x != nil {
-		return x.RedirectUri
+		return x.Failed
 	}
-	return ""
+	return 0
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeRequest) GetCodeVerifier() string {
+func (x *ReplayClassificationsResponse) GetTransitions() []*ReplayTransition {
 	if x != nil {
-		return x.CodeVerifier
+		return x.Transitions
 	}
-	return ""
+	return nil
 }
 
-type OAuth2ExchangeAuthorizationCodeResponse struct {
+func (x *ReplayClassificationsResponse) GetChanges() []*ReplayChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type MergeUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         *v1.OAuth2Token        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	SourceUserId  int64                  `protobuf:"varint,1,opt,name=source_user_id,json=sourceUserId,proto3" json:"source_user_id,omitempty"` // deleted once merged
+	TargetUserId  int64                  `protobuf:"varint,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeResponse) Reset() {
-	*x = OAuth2ExchangeAuthorizationCodeResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[12]
+func (x *MergeUsersRequest) Reset() {
+	*x = MergeUsersRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeResponse) String() string {
+func (x *MergeUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2ExchangeAuthorizationCodeResponse) ProtoMessage() {}
+func (*MergeUsersRequest) ProtoMessage() {}
 
-func (x *OAuth2ExchangeAuthorizationCodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[12]
+func (x *MergeUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,41 +4914,49 @@ func (x *OAuth2ExchangeAuthorizationCodeResponse) ProtoReflect() protoreflect.Me
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2ExchangeAuthorizationCodeResponse.ProtoReflect.Descriptor instead.
-func (*OAuth2ExchangeAuthorizationCodeResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use MergeUsersRequest.ProtoReflect.Descriptor instead.
+func (*MergeUsersRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *OAuth2ExchangeAuthorizationCodeResponse) GetToken() *v1.OAuth2Token {
+func (x *MergeUsersRequest) GetSourceUserId() int64 {
 	if x != nil {
-		return x.Token
+		return x.SourceUserId
 	}
-	return nil
+	return 0
 }
 
-type OAuth2RefreshAccessTokenRequest struct {
+func (x *MergeUsersRequest) GetTargetUserId() int64 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+type MergeUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RowsMoved     int64                  `protobuf:"varint,1,opt,name=rows_moved,json=rowsMoved,proto3" json:"rows_moved,omitempty"`             // rows reassigned to the target
+	RowsDropped   int64                  `protobuf:"varint,2,opt,name=rows_dropped,json=rowsDropped,proto3" json:"rows_dropped,omitempty"`       // source rows the target already had, e.g. an override of the same app
+	AlreadyMerged bool                   `protobuf:"varint,3,opt,name=already_merged,json=alreadyMerged,proto3" json:"already_merged,omitempty"` // the source no longer exists, nothing changed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2RefreshAccessTokenRequest) Reset() {
-	*x = OAuth2RefreshAccessTokenRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[13]
+func (x *MergeUsersResponse) Reset() {
+	*x = MergeUsersResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2RefreshAccessTokenRequest) String() string {
+func (x *MergeUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2RefreshAccessTokenRequest) ProtoMessage() {}
+func (*MergeUsersResponse) ProtoMessage() {}
 
-func (x *OAuth2RefreshAccessTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[13]
+func (x *MergeUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,47 +4967,54 @@ func (x *OAuth2RefreshAccessTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2RefreshAccessTokenRequest.ProtoReflect.Descriptor instead.
-func (*OAuth2RefreshAccessTokenRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use MergeUsersResponse.ProtoReflect.Descriptor instead.
+func (*MergeUsersResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *OAuth2RefreshAccessTokenRequest) GetProvider() string {
+func (x *MergeUsersResponse) GetRowsMoved() int64 {
 	if x != nil {
-		return x.Provider
+		return x.RowsMoved
 	}
-	return ""
+	return 0
 }
 
-func (x *OAuth2RefreshAccessTokenRequest) GetRefreshToken() string {
+func (x *MergeUsersResponse) GetRowsDropped() int64 {
 	if x != nil {
-		return x.RefreshToken
+		return x.RowsDropped
 	}
-	return ""
+	return 0
 }
 
-type OAuth2RefreshAccessTokenResponse struct {
+func (x *MergeUsersResponse) GetAlreadyMerged() bool {
+	if x != nil {
+		return x.AlreadyMerged
+	}
+	return false
+}
+
+type GetFlagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         *v1.OAuth2Token        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // evaluate the flags for this user, 0 for none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2RefreshAccessTokenResponse) Reset() {
-	*x = OAuth2RefreshAccessTokenResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[14]
+func (x *GetFlagsRequest) Reset() {
+	*x = GetFlagsRequest{}
+	mi := &file_brain_v1_server_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2RefreshAccessTokenResponse) String() string {
+func (x *GetFlagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2RefreshAccessTokenResponse) ProtoMessage() {}
+func (*GetFlagsRequest) ProtoMessage() {}
 
-func (x *OAuth2RefreshAccessTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[14]
+func (x *GetFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1085,41 +5025,46 @@ func (x *OAuth2RefreshAccessTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2RefreshAccessTokenResponse.ProtoReflect.Descriptor instead.
-func (*OAuth2RefreshAccessTokenResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetFlagsRequest.ProtoReflect.Descriptor instead.
+func (*GetFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *OAuth2RefreshAccessTokenResponse) GetToken() *v1.OAuth2Token {
+func (x *GetFlagsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Token
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-type OAuth2RevokeAccessTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Access or Refresh token
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type FeatureFlag struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "reask"
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Setting        string                 `protobuf:"bytes,3,opt,name=setting,proto3" json:"setting,omitempty"`                                        // environment setting turning it on for everyone, e.g. "CLASSIFICATION_REASK"
+	Enabled        bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`                                       // on for everyone
+	RolloutPercent int32                  `protobuf:"varint,5,opt,name=rollout_percent,json=rolloutPercent,proto3" json:"rollout_percent,omitempty"`   // share of users it is on for, from FEATURE_FLAGS
+	UserIds        []int64                `protobuf:"varint,6,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`                 // users it is on for, from FEATURE_FLAGS
+	EnabledForUser bool                   `protobuf:"varint,7,opt,name=enabled_for_user,json=enabledForUser,proto3" json:"enabled_for_user,omitempty"` // the flag's value for the requested user
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *OAuth2RevokeAccessTokenRequest) Reset() {
-	*x = OAuth2RevokeAccessTokenRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[15]
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	mi := &file_brain_v1_server_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2RevokeAccessTokenRequest) String() string {
+func (x *FeatureFlag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2RevokeAccessTokenRequest) ProtoMessage() {}
+func (*FeatureFlag) ProtoMessage() {}
 
-func (x *OAuth2RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[15]
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,47 +5075,82 @@ func (x *OAuth2RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
-func (*OAuth2RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *OAuth2RevokeAccessTokenRequest) GetProvider() string {
+func (x *FeatureFlag) GetName() string {
 	if x != nil {
-		return x.Provider
+		return x.Name
 	}
 	return ""
 }
 
-func (x *OAuth2RevokeAccessTokenRequest) GetToken() string {
+func (x *FeatureFlag) GetDescription() string {
 	if x != nil {
-		return x.Token
+		return x.Description
 	}
 	return ""
 }
 
-type OAuth2RevokeAccessTokenResponse struct {
+func (x *FeatureFlag) GetSetting() string {
+	if x != nil {
+		return x.Setting
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetRolloutPercent() int32 {
+	if x != nil {
+		return x.RolloutPercent
+	}
+	return 0
+}
+
+func (x *FeatureFlag) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetEnabledForUser() bool {
+	if x != nil {
+		return x.EnabledForUser
+	}
+	return false
+}
+
+type GetFlagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OAuth2RevokeAccessTokenResponse) Reset() {
-	*x = OAuth2RevokeAccessTokenResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[16]
+func (x *GetFlagsResponse) Reset() {
+	*x = GetFlagsResponse{}
+	mi := &file_brain_v1_server_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OAuth2RevokeAccessTokenResponse) String() string {
+func (x *GetFlagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OAuth2RevokeAccessTokenResponse) ProtoMessage() {}
+func (*GetFlagsResponse) ProtoMessage() {}
 
-func (x *OAuth2RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[16]
+func (x *GetFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brain_v1_server_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,16 +5161,16 @@ func (x *OAuth2RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OAuth2RevokeAccessTokenResponse.ProtoReflect.Descriptor instead.
-func (*OAuth2RevokeAccessTokenResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetFlagsResponse.ProtoReflect.Descriptor instead.
+func (*GetFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *OAuth2RevokeAccessTokenResponse) GetSuccess() bool {
+func (x *GetFlagsResponse) GetFlags() []*FeatureFlag {
 	if x != nil {
-		return x.Success
+		return x.Flags
 	}
-	return false
+	return nil
 }
 
 // Agent and Tool definitions (sent during handshake from electron → brain)
@@ -1207,7 +5187,7 @@ type AgentSessionRequest_Agent struct {
 
 func (x *AgentSessionRequest_Agent) Reset() {
 	*x = AgentSessionRequest_Agent{}
-	mi := &file_brain_v1_server_proto_msgTypes[17]
+	mi := &file_brain_v1_server_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1219,7 +5199,7 @@ func (x *AgentSessionRequest_Agent) String() string {
 func (*AgentSessionRequest_Agent) ProtoMessage() {}
 
 func (x *AgentSessionRequest_Agent) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[17]
+	mi := &file_brain_v1_server_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,7 +5212,7 @@ func (x *AgentSessionRequest_Agent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionRequest_Agent.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_Agent) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 0}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 0}
 }
 
 func (x *AgentSessionRequest_Agent) GetName() string {
@@ -1279,7 +5259,7 @@ type AgentSessionRequest_TerminateExecution struct {
 
 func (x *AgentSessionRequest_TerminateExecution) Reset() {
 	*x = AgentSessionRequest_TerminateExecution{}
-	mi := &file_brain_v1_server_proto_msgTypes[18]
+	mi := &file_brain_v1_server_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1291,7 +5271,7 @@ func (x *AgentSessionRequest_TerminateExecution) String() string {
 func (*AgentSessionRequest_TerminateExecution) ProtoMessage() {}
 
 func (x *AgentSessionRequest_TerminateExecution) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[18]
+	mi := &file_brain_v1_server_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1304,7 +5284,7 @@ func (x *AgentSessionRequest_TerminateExecution) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use AgentSessionRequest_TerminateExecution.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_TerminateExecution) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 1}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 1}
 }
 
 func (x *AgentSessionRequest_TerminateExecution) GetReason() string {
@@ -1315,17 +5295,20 @@ func (x *AgentSessionRequest_TerminateExecution) GetReason() string {
 }
 
 type AgentSessionRequest_RunRequest struct {
-	state         protoimpl.MessageState       `protogen:"open.v1"`
-	Instruction   string                       `protobuf:"bytes,1,opt,name=instruction,proto3" json:"instruction,omitempty"`
-	Agents        []*AgentSessionRequest_Agent `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
-	UserMessage   string                       `protobuf:"bytes,3,opt,name=user_message,json=userMessage,proto3" json:"user_message,omitempty"`
+	state       protoimpl.MessageState       `protogen:"open.v1"`
+	Instruction string                       `protobuf:"bytes,1,opt,name=instruction,proto3" json:"instruction,omitempty"`
+	Agents      []*AgentSessionRequest_Agent `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
+	UserMessage string                       `protobuf:"bytes,3,opt,name=user_message,json=userMessage,proto3" json:"user_message,omitempty"`
+	// Send the response in partial RunResponses as it is generated
+	// instead of once the run completes
+	Stream        bool `protobuf:"varint,4,opt,name=stream,proto3" json:"stream,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AgentSessionRequest_RunRequest) Reset() {
 	*x = AgentSessionRequest_RunRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[19]
+	mi := &file_brain_v1_server_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1337,7 +5320,7 @@ func (x *AgentSessionRequest_RunRequest) String() string {
 func (*AgentSessionRequest_RunRequest) ProtoMessage() {}
 
 func (x *AgentSessionRequest_RunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[19]
+	mi := &file_brain_v1_server_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,7 +5333,7 @@ func (x *AgentSessionRequest_RunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionRequest_RunRequest.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_RunRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 2}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 2}
 }
 
 func (x *AgentSessionRequest_RunRequest) GetInstruction() string {
@@ -1374,6 +5357,13 @@ func (x *AgentSessionRequest_RunRequest) GetUserMessage() string {
 	return ""
 }
 
+func (x *AgentSessionRequest_RunRequest) GetStream() bool {
+	if x != nil {
+		return x.Stream
+	}
+	return false
+}
+
 // Response to brain's tool call request
 type AgentSessionRequest_ToolCallResponse struct {
 	state     protoimpl.MessageState                      `protogen:"open.v1"`
@@ -1391,7 +5381,7 @@ type AgentSessionRequest_ToolCallResponse struct {
 
 func (x *AgentSessionRequest_ToolCallResponse) Reset() {
 	*x = AgentSessionRequest_ToolCallResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[20]
+	mi := &file_brain_v1_server_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1403,7 +5393,7 @@ func (x *AgentSessionRequest_ToolCallResponse) String() string {
 func (*AgentSessionRequest_ToolCallResponse) ProtoMessage() {}
 
 func (x *AgentSessionRequest_ToolCallResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[20]
+	mi := &file_brain_v1_server_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1416,7 +5406,7 @@ func (x *AgentSessionRequest_ToolCallResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use AgentSessionRequest_ToolCallResponse.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_ToolCallResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 3}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 3}
 }
 
 func (x *AgentSessionRequest_ToolCallResponse) GetRequestId() string {
@@ -1464,7 +5454,7 @@ type AgentSessionRequest_Heartbeat struct {
 
 func (x *AgentSessionRequest_Heartbeat) Reset() {
 	*x = AgentSessionRequest_Heartbeat{}
-	mi := &file_brain_v1_server_proto_msgTypes[21]
+	mi := &file_brain_v1_server_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1476,7 +5466,7 @@ func (x *AgentSessionRequest_Heartbeat) String() string {
 func (*AgentSessionRequest_Heartbeat) ProtoMessage() {}
 
 func (x *AgentSessionRequest_Heartbeat) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[21]
+	mi := &file_brain_v1_server_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1489,7 +5479,7 @@ func (x *AgentSessionRequest_Heartbeat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionRequest_Heartbeat.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_Heartbeat) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 4}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 4}
 }
 
 func (x *AgentSessionRequest_Heartbeat) GetTimestamp() int64 {
@@ -1509,7 +5499,7 @@ type AgentSessionRequest_SessionEnd struct {
 
 func (x *AgentSessionRequest_SessionEnd) Reset() {
 	*x = AgentSessionRequest_SessionEnd{}
-	mi := &file_brain_v1_server_proto_msgTypes[22]
+	mi := &file_brain_v1_server_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1521,7 +5511,7 @@ func (x *AgentSessionRequest_SessionEnd) String() string {
 func (*AgentSessionRequest_SessionEnd) ProtoMessage() {}
 
 func (x *AgentSessionRequest_SessionEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[22]
+	mi := &file_brain_v1_server_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1534,7 +5524,7 @@ func (x *AgentSessionRequest_SessionEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionRequest_SessionEnd.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_SessionEnd) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 5}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 5}
 }
 
 func (x *AgentSessionRequest_SessionEnd) GetReason() string {
@@ -1557,7 +5547,7 @@ type AgentSessionRequest_Agent_Tool struct {
 
 func (x *AgentSessionRequest_Agent_Tool) Reset() {
 	*x = AgentSessionRequest_Agent_Tool{}
-	mi := &file_brain_v1_server_proto_msgTypes[23]
+	mi := &file_brain_v1_server_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1569,7 +5559,7 @@ func (x *AgentSessionRequest_Agent_Tool) String() string {
 func (*AgentSessionRequest_Agent_Tool) ProtoMessage() {}
 
 func (x *AgentSessionRequest_Agent_Tool) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[23]
+	mi := &file_brain_v1_server_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,7 +5572,7 @@ func (x *AgentSessionRequest_Agent_Tool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionRequest_Agent_Tool.ProtoReflect.Descriptor instead.
 func (*AgentSessionRequest_Agent_Tool) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{7, 0, 0}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{47, 0, 0}
 }
 
 func (x *AgentSessionRequest_Agent_Tool) GetName() string {
@@ -1625,7 +5615,7 @@ type AgentSessionResponse_Error struct {
 
 func (x *AgentSessionResponse_Error) Reset() {
 	*x = AgentSessionResponse_Error{}
-	mi := &file_brain_v1_server_proto_msgTypes[24]
+	mi := &file_brain_v1_server_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1637,7 +5627,7 @@ func (x *AgentSessionResponse_Error) String() string {
 func (*AgentSessionResponse_Error) ProtoMessage() {}
 
 func (x *AgentSessionResponse_Error) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[24]
+	mi := &file_brain_v1_server_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,7 +5640,7 @@ func (x *AgentSessionResponse_Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionResponse_Error.ProtoReflect.Descriptor instead.
 func (*AgentSessionResponse_Error) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8, 0}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48, 0}
 }
 
 func (x *AgentSessionResponse_Error) GetCode() string {
@@ -1684,7 +5674,7 @@ type AgentSessionResponse_HeartbeatAck struct {
 
 func (x *AgentSessionResponse_HeartbeatAck) Reset() {
 	*x = AgentSessionResponse_HeartbeatAck{}
-	mi := &file_brain_v1_server_proto_msgTypes[25]
+	mi := &file_brain_v1_server_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1696,7 +5686,7 @@ func (x *AgentSessionResponse_HeartbeatAck) String() string {
 func (*AgentSessionResponse_HeartbeatAck) ProtoMessage() {}
 
 func (x *AgentSessionResponse_HeartbeatAck) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[25]
+	mi := &file_brain_v1_server_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +5699,7 @@ func (x *AgentSessionResponse_HeartbeatAck) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use AgentSessionResponse_HeartbeatAck.ProtoReflect.Descriptor instead.
 func (*AgentSessionResponse_HeartbeatAck) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8, 1}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48, 1}
 }
 
 func (x *AgentSessionResponse_HeartbeatAck) GetTimestamp() int64 {
@@ -1729,7 +5719,7 @@ type AgentSessionResponse_SessionEndAck struct {
 
 func (x *AgentSessionResponse_SessionEndAck) Reset() {
 	*x = AgentSessionResponse_SessionEndAck{}
-	mi := &file_brain_v1_server_proto_msgTypes[26]
+	mi := &file_brain_v1_server_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1741,7 +5731,7 @@ func (x *AgentSessionResponse_SessionEndAck) String() string {
 func (*AgentSessionResponse_SessionEndAck) ProtoMessage() {}
 
 func (x *AgentSessionResponse_SessionEndAck) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[26]
+	mi := &file_brain_v1_server_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1754,7 +5744,7 @@ func (x *AgentSessionResponse_SessionEndAck) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use AgentSessionResponse_SessionEndAck.ProtoReflect.Descriptor instead.
 func (*AgentSessionResponse_SessionEndAck) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8, 2}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48, 2}
 }
 
 func (x *AgentSessionResponse_SessionEndAck) GetAcknowledged() bool {
@@ -1775,7 +5765,7 @@ type AgentSessionResponse_ToolCallRequest struct {
 
 func (x *AgentSessionResponse_ToolCallRequest) Reset() {
 	*x = AgentSessionResponse_ToolCallRequest{}
-	mi := &file_brain_v1_server_proto_msgTypes[27]
+	mi := &file_brain_v1_server_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1787,7 +5777,7 @@ func (x *AgentSessionResponse_ToolCallRequest) String() string {
 func (*AgentSessionResponse_ToolCallRequest) ProtoMessage() {}
 
 func (x *AgentSessionResponse_ToolCallRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[27]
+	mi := &file_brain_v1_server_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1800,7 +5790,7 @@ func (x *AgentSessionResponse_ToolCallRequest) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use AgentSessionResponse_ToolCallRequest.ProtoReflect.Descriptor instead.
 func (*AgentSessionResponse_ToolCallRequest) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8, 3}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48, 3}
 }
 
 func (x *AgentSessionResponse_ToolCallRequest) GetRequestId() string {
@@ -1824,17 +5814,20 @@ func (x *AgentSessionResponse_ToolCallRequest) GetInput() string {
 	return ""
 }
 
-// Response with generated content from the agent
+// Response with generated content from the agent. Streamed runs send
+// partial responses followed by one without partial set; the full
+// response is their contents concatenated.
 type AgentSessionResponse_RunResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Partial       bool                   `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AgentSessionResponse_RunResponse) Reset() {
 	*x = AgentSessionResponse_RunResponse{}
-	mi := &file_brain_v1_server_proto_msgTypes[28]
+	mi := &file_brain_v1_server_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1846,7 +5839,7 @@ func (x *AgentSessionResponse_RunResponse) String() string {
 func (*AgentSessionResponse_RunResponse) ProtoMessage() {}
 
 func (x *AgentSessionResponse_RunResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brain_v1_server_proto_msgTypes[28]
+	mi := &file_brain_v1_server_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1859,7 +5852,7 @@ func (x *AgentSessionResponse_RunResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentSessionResponse_RunResponse.ProtoReflect.Descriptor instead.
 func (*AgentSessionResponse_RunResponse) Descriptor() ([]byte, []int) {
-	return file_brain_v1_server_proto_rawDescGZIP(), []int{8, 4}
+	return file_brain_v1_server_proto_rawDescGZIP(), []int{48, 4}
 }
 
 func (x *AgentSessionResponse_RunResponse) GetContent() string {
@@ -1869,6 +5862,13 @@ func (x *AgentSessionResponse_RunResponse) GetContent() string {
 	return ""
 }
 
+func (x *AgentSessionResponse_RunResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
 var File_brain_v1_server_proto protoreflect.FileDescriptor
 
 const file_brain_v1_server_proto_rawDesc = "" +
@@ -1887,20 +5887,97 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\n" +
 	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12!\n" +
 	"\faccount_role\x18\x03 \x01(\tR\vaccountRole\x122\n" +
-	"\x15remaining_daily_scans\x18\x04 \x01(\x05R\x13remainingDailyScans\"\xce\x02\n" +
+	"\x15remaining_daily_scans\x18\x04 \x01(\x05R\x13remainingDailyScans\"\x15\n" +
+	"\x13ListSessionsRequest\"I\n" +
+	"\x14ListSessionsResponse\x121\n" +
+	"\bsessions\x18\x01 \x03(\v2\x15.brain.v1.SessionInfoR\bsessions\"\xf5\x01\n" +
+	"\vSessionInfo\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
+	"\x12device_fingerprint\x18\x02 \x01(\tR\x11deviceFingerprint\x12\x1f\n" +
+	"\vos_platform\x18\x03 \x01(\tR\n" +
+	"osPlatform\x12\x1f\n" +
+	"\vapp_version\x18\x04 \x01(\tR\n" +
+	"appVersion\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent\">\n" +
+	"\x14RevokeSessionRequest\x12&\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tsessionId\"1\n" +
+	"\x15RevokeSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"=\n" +
+	"\x18RevokeAllSessionsRequest\x12!\n" +
+	"\fkeep_current\x18\x01 \x01(\bR\vkeepCurrent\"5\n" +
+	"\x19RevokeAllSessionsResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\x03R\arevoked\"P\n" +
+	"\x1fVerifyHandshakeSignatureRequest\x12-\n" +
+	"\x12device_fingerprint\x18\x01 \x01(\tR\x11deviceFingerprint\"\x9c\x01\n" +
+	" VerifyHandshakeSignatureResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12)\n" +
+	"\x10expected_payload\x18\x03 \x01(\tR\x0fexpectedPayload\x12\x1f\n" +
+	"\vserver_time\x18\x04 \x01(\x03R\n" +
+	"serverTime\"\xae\x01\n" +
+	"\x13RebindDeviceRequest\x126\n" +
+	"\x12device_fingerprint\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x11deviceFingerprint\x12\x1f\n" +
+	"\vos_platform\x18\x02 \x01(\tR\n" +
+	"osPlatform\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\x03 \x01(\tR\tosVersion\x12\x1f\n" +
+	"\vapp_version\x18\x04 \x01(\tR\n" +
+	"appVersion\";\n" +
+	"\x14RebindDeviceResponse\x12#\n" +
+	"\rsession_token\x18\x01 \x01(\tR\fsessionToken\"\x97\x05\n" +
 	"\x14ClassificationResult\x12&\n" +
 	"\x0eclassification\x18\x01 \x01(\tR\x0eclassification\x12\x1c\n" +
 	"\treasoning\x18\x02 \x01(\tR\treasoning\x12)\n" +
 	"\x10confidence_score\x18\x03 \x01(\x02R\x0fconfidenceScore\x12\x12\n" +
 	"\x04tags\x18\x04 \x03(\tR\x04tags\x12.\n" +
 	"\x10detected_project\x18\x05 \x01(\tH\x00R\x0fdetectedProject\x88\x01\x01\x12I\n" +
-	"\x1edetected_communication_channel\x18\x06 \x01(\tH\x01R\x1cdetectedCommunicationChannel\x88\x01\x01B\x13\n" +
+	"\x1edetected_communication_channel\x18\x06 \x01(\tH\x01R\x1cdetectedCommunicationChannel\x88\x01\x01\x12\x18\n" +
+	"\asignals\x18\a \x03(\tR\asignals\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12%\n" +
+	"\x0eprompt_version\x18\t \x01(\tR\rpromptVersion\x12 \n" +
+	"\vapproximate\x18\n" +
+	" \x01(\bR\vapproximate\x126\n" +
+	"\x06policy\x18\v \x01(\v2\x1e.brain.v1.ClassificationPolicyR\x06policy\x12!\n" +
+	"\foutcome_hash\x18\f \x01(\tR\voutcomeHash\x12A\n" +
+	"\n" +
+	"candidates\x18\r \x03(\v2!.brain.v1.ClassificationCandidateR\n" +
+	"candidates\x120\n" +
+	"\x14raw_confidence_score\x18\x0e \x01(\x02R\x12rawConfidenceScoreB\x13\n" +
 	"\x11_detected_projectB!\n" +
-	"\x1f_detected_communication_channel\"\x9e\x01\n" +
+	"\x1f_detected_communication_channel\"l\n" +
+	"\x17ClassificationCandidate\x12&\n" +
+	"\x0eclassification\x18\x01 \x01(\tR\x0eclassification\x12)\n" +
+	"\x10confidence_score\x18\x02 \x01(\x02R\x0fconfidenceScore\"F\n" +
+	"\x14ClassificationPolicy\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06detail\x18\x02 \x01(\tR\x06detail\"\x8f\x05\n" +
 	"\x1aClassifyApplicationRequest\x12)\n" +
 	"\x10application_name\x18\x01 \x01(\tR\x0fapplicationName\x122\n" +
 	"\x15application_bundle_id\x18\x02 \x01(\tR\x13applicationBundleId\x12!\n" +
-	"\fwindow_title\x18\x03 \x01(\tR\vwindowTitle\"\xd4\x02\n" +
+	"\fwindow_title\x18\x03 \x01(\tR\vwindowTitle\x123\n" +
+	"\x10secondary_titles\x18\x04 \x03(\tB\b\xbaH\x05\x92\x01\x02\x102R\x0fsecondaryTitles\x12\x1d\n" +
+	"\n" +
+	"in_meeting\x18\x05 \x01(\bR\tinMeeting\x12;\n" +
+	"\tuser_mode\x18\x06 \x01(\tB\x1e\xbaH\x1br\x19R\x00R\x05focusR\x05breakR\aneutralR\buserMode\x12\x1b\n" +
+	"\ttags_only\x18\a \x01(\bR\btagsOnly\x12\x1f\n" +
+	"\x06locale\x18\b \x01(\tB\a\xbaH\x04r\x02\x18#R\x06locale\x12*\n" +
+	"\vscreen_text\x18\t \x01(\tB\t\xbaH\x06r\x04\x18\x80\x80\x04R\n" +
+	"screenText\x123\n" +
+	"\x11cache_ttl_seconds\x18\n" +
+	" \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0fcacheTtlSeconds\x12+\n" +
+	"\x11return_candidates\x18\v \x01(\bR\x10returnCandidates\x12#\n" +
+	"\n" +
+	"foreground\x18\f \x01(\bH\x00R\n" +
+	"foreground\x88\x01\x01\x12+\n" +
+	"\fapp_category\x18\r \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\vappCategory\x121\n" +
+	"\x0frecent_activity\x18\x0e \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x0erecentActivityB\r\n" +
+	"\v_foreground\"\xd4\x02\n" +
 	"\x1bClassifyApplicationResponse\x12F\n" +
 	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\x12I\n" +
 	"\x1edetected_communication_channel\x18\x02 \x01(\tH\x00R\x1cdetectedCommunicationChannel\x88\x01\x01\x12.\n" +
@@ -1908,12 +5985,124 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\rdetected_file\x18\x04 \x01(\tH\x02R\fdetectedFile\x88\x01\x01B!\n" +
 	"\x1f_detected_communication_channelB\x13\n" +
 	"\x11_detected_projectB\x10\n" +
-	"\x0e_detected_file\"@\n" +
+	"\x0e_detected_file\"\x84\x03\n" +
 	"\x16ClassifyWebsiteRequest\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\"a\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12;\n" +
+	"\tuser_mode\x18\x03 \x01(\tB\x1e\xbaH\x1br\x19R\x00R\x05focusR\x05breakR\aneutralR\buserMode\x12\x1b\n" +
+	"\ttags_only\x18\x04 \x01(\bR\btagsOnly\x12\x1f\n" +
+	"\x06locale\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06locale\x123\n" +
+	"\x11cache_ttl_seconds\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0fcacheTtlSeconds\x12+\n" +
+	"\x11return_candidates\x18\a \x01(\bR\x10returnCandidates\x12#\n" +
+	"\n" +
+	"foreground\x18\b \x01(\bH\x00R\n" +
+	"foreground\x88\x01\x01\x121\n" +
+	"\x0frecent_activity\x18\t \x01(\tB\b\xbaH\x05r\x03\x18\xd0\x0fR\x0erecentActivityB\r\n" +
+	"\v_foreground\"a\n" +
 	"\x17ClassifyWebsiteResponse\x12F\n" +
-	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\"\x99\n" +
+	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\"\x82\x01\n" +
+	"\x17ClassifyDocumentRequest\x12\x1b\n" +
+	"\x04path\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04path\x12)\n" +
+	"\x10application_name\x18\x02 \x01(\tR\x0fapplicationName\x12\x1f\n" +
+	"\x06locale\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06locale\"b\n" +
+	"\x18ClassifyDocumentResponse\x12F\n" +
+	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\"\x9d\x01\n" +
+	"\x14ClassifyEmailRequest\x12\x1f\n" +
+	"\x06sender\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06sender\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1d\n" +
+	"\asnippet\x18\x03 \x01(\tH\x00R\asnippet\x88\x01\x01\x12\x1f\n" +
+	"\x06locale\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06localeB\n" +
+	"\n" +
+	"\b_snippet\"_\n" +
+	"\x15ClassifyEmailResponse\x12F\n" +
+	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\"\x88\x01\n" +
+	"\x16ClassifyCommandRequest\x12$\n" +
+	"\acommand\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80 R\acommand\x12\x1f\n" +
+	"\x03cwd\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bH\x00R\x03cwd\x88\x01\x01\x12\x1f\n" +
+	"\x06locale\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06localeB\x06\n" +
+	"\x04_cwd\"a\n" +
+	"\x17ClassifyCommandResponse\x12F\n" +
+	"\x0eclassification\x18\x01 \x01(\v2\x1e.brain.v1.ClassificationResultR\x0eclassification\"\xed\x01\n" +
+	"\x19RateClassificationRequest\x12I\n" +
+	"\x04kind\x18\x01 \x01(\tB5\xbaH2r0R\vapplicationR\awebsiteR\bdocumentR\x05emailR\acommandR\x04kind\x12 \n" +
+	"\x05input\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x10R\x05input\x12&\n" +
+	"\x0eclassification\x18\x03 \x01(\tR\x0eclassification\x12\x14\n" +
+	"\x05agree\x18\x04 \x01(\bR\x05agree\x12%\n" +
+	"\x0eprompt_version\x18\x05 \x01(\tR\rpromptVersion\"\x1c\n" +
+	"\x1aRateClassificationResponse\"\x14\n" +
+	"\x12ExportRulesRequest\"J\n" +
+	"\x13ExportRulesResponse\x12\x1d\n" +
+	"\n" +
+	"rules_json\x18\x01 \x01(\tR\trulesJson\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"{\n" +
+	"\x12ImportRulesRequest\x12*\n" +
+	"\n" +
+	"rules_json\x18\x01 \x01(\tB\v\xbaH\br\x06\x10\x01\x18\x80\x80@R\trulesJson\x129\n" +
+	"\von_conflict\x18\x02 \x01(\tB\x18\xbaH\x15r\x13R\x00R\x04skipR\toverwriteR\n" +
+	"onConflict\"m\n" +
+	"\x13ImportRulesResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x12 \n" +
+	"\voverwritten\x18\x02 \x01(\x05R\voverwritten\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\"c\n" +
+	"\x18SetPromptAddendumRequest\x12)\n" +
+	"\x05scope\x18\x01 \x01(\tB\x13\xbaH\x10r\x0eR\x04userR\x06globalR\x05scope\x12\x1c\n" +
+	"\x04text\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80@R\x04text\"E\n" +
+	"\x19SetPromptAddendumResponse\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\x1a\n" +
+	"\x18GetPromptAddendumRequest\"Y\n" +
+	"\x19GetPromptAddendumResponse\x12\x1b\n" +
+	"\tuser_text\x18\x01 \x01(\tR\buserText\x12\x1f\n" +
+	"\vglobal_text\x18\x02 \x01(\tR\n" +
+	"globalText\"\x14\n" +
+	"\x12GetTaxonomyRequest\"w\n" +
+	"\rTaxonomyEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\asources\x18\x03 \x03(\tR\asources\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\"\x85\x01\n" +
+	"\x13GetTaxonomyResponse\x12A\n" +
+	"\x0fclassifications\x18\x01 \x03(\v2\x17.brain.v1.TaxonomyEntryR\x0fclassifications\x12+\n" +
+	"\x04tags\x18\x02 \x03(\v2\x17.brain.v1.TaxonomyEntryR\x04tags\"\x92\x03\n" +
+	"\x13FocusSessionSummary\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x03R\tsessionId\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\x03R\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\x03 \x01(\x03R\aendedAt\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x03R\x0fdurationSeconds\x12\x1c\n" +
+	"\tabandoned\x18\x05 \x01(\bR\tabandoned\x121\n" +
+	"\x14classification_count\x18\x06 \x01(\x05R\x13classificationCount\x12)\n" +
+	"\x10productive_count\x18\a \x01(\x05R\x0fproductiveCount\x12)\n" +
+	"\x10supporting_count\x18\b \x01(\x05R\x0fsupportingCount\x12#\n" +
+	"\rneutral_count\x18\t \x01(\x05R\fneutralCount\x12+\n" +
+	"\x11distracting_count\x18\n" +
+	" \x01(\x05R\x10distractingCount\"\x1a\n" +
+	"\x18StartFocusSessionRequest\"\x90\x01\n" +
+	"\x19StartFocusSessionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x03R\tsessionId\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\x03R\tstartedAt\x125\n" +
+	"\x06closed\x18\x03 \x03(\v2\x1d.brain.v1.FocusSessionSummaryR\x06closed\"\x19\n" +
+	"\x17StopFocusSessionRequest\"S\n" +
+	"\x18StopFocusSessionResponse\x127\n" +
+	"\asummary\x18\x01 \x01(\v2\x1d.brain.v1.FocusSessionSummaryR\asummary\"f\n" +
+	"\x1bListDetectedProjectsRequest\x12\x1f\n" +
+	"\x05limit\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05limit\x12&\n" +
+	"\n" +
+	"since_unix\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\tsinceUnix\"U\n" +
+	"\x1cListDetectedProjectsResponse\x125\n" +
+	"\bprojects\x18\x01 \x03(\v2\x19.brain.v1.DetectedProjectR\bprojects\"\xbb\x01\n" +
+	"\x0fDetectedProject\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\rfirst_seen_at\x18\x02 \x01(\x03R\vfirstSeenAt\x12 \n" +
+	"\flast_seen_at\x18\x03 \x01(\x03R\n" +
+	"lastSeenAt\x121\n" +
+	"\x14classification_count\x18\x04 \x01(\x03R\x13classificationCount\x12\x1b\n" +
+	"\tlast_kind\x18\x05 \x01(\tR\blastKind\"\xb1\n" +
 	"\n" +
 	"\x13AgentSessionRequest\x12K\n" +
 	"\vrun_request\x18\x01 \x01(\v2(.brain.v1.AgentSessionRequest.RunRequestH\x00R\n" +
@@ -1935,12 +6124,13 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\finput_schema\x18\x03 \x01(\tR\vinputSchema\x12#\n" +
 	"\routput_schema\x18\x04 \x01(\tR\foutputSchema\x1a,\n" +
 	"\x12TerminateExecution\x12\x16\n" +
-	"\x06reason\x18\x01 \x01(\tR\x06reason\x1a\x8e\x01\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x1a\xa6\x01\n" +
 	"\n" +
 	"RunRequest\x12 \n" +
 	"\vinstruction\x18\x01 \x01(\tR\vinstruction\x12;\n" +
 	"\x06agents\x18\x02 \x03(\v2#.brain.v1.AgentSessionRequest.AgentR\x06agents\x12!\n" +
-	"\fuser_message\x18\x03 \x01(\tR\vuserMessage\x1a\xb6\x02\n" +
+	"\fuser_message\x18\x03 \x01(\tR\vuserMessage\x12\x16\n" +
+	"\x06stream\x18\x04 \x01(\bR\x06stream\x1a\xb6\x02\n" +
 	"\x10ToolCallResponse\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12M\n" +
@@ -1958,7 +6148,7 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\n" +
 	"SessionEnd\x12\x16\n" +
 	"\x06reason\x18\x01 \x01(\tR\x06reasonB\t\n" +
-	"\amessage\"\xec\x06\n" +
+	"\amessage\"\x86\a\n" +
 	"\x14AgentSessionResponse\x12O\n" +
 	"\frun_response\x18\x01 \x01(\v2*.brain.v1.AgentSessionResponse.RunResponseH\x00R\vrunResponse\x12\\\n" +
 	"\x11tool_call_request\x18\x02 \x01(\v2..brain.v1.AgentSessionResponse.ToolCallRequestH\x00R\x0ftoolCallRequest\x12<\n" +
@@ -1980,9 +6170,10 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1b\n" +
 	"\ttool_name\x18\x02 \x01(\tR\btoolName\x12\x14\n" +
-	"\x05input\x18\x03 \x01(\tR\x05input\x1a'\n" +
+	"\x05input\x18\x03 \x01(\tR\x05input\x1aA\n" +
 	"\vRunResponse\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontentB\t\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\bR\apartialB\t\n" +
 	"\amessage\"\x96\x02\n" +
 	" OAuth2GetAuthorizationURLRequest\x12N\n" +
 	"\bprovider\x18\x01 \x01(\tB2\xbaH/r-R\x06githubR\x05slackR\x04jiraR\x06googleR\x06linearR\x06notionR\bprovider\x12\x1d\n" +
@@ -2008,16 +6199,167 @@ const file_brain_v1_server_proto_rawDesc = "" +
 	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\tR\x05token\";\n" +
 	"\x1fOAuth2RevokeAccessTokenResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xd7\x06\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x18\n" +
+	"\x16GetOAuth2StatusRequest\"W\n" +
+	"\x17GetOAuth2StatusResponse\x12<\n" +
+	"\tproviders\x18\x01 \x03(\v2\x1e.brain.v1.OAuth2ProviderStatusR\tproviders\"\xdf\x01\n" +
+	"\x14OAuth2ProviderStatus\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x16\n" +
+	"\x06linked\x18\x02 \x01(\bR\x06linked\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1f\n" +
+	"\vexpiry_unix\x18\x04 \x01(\x03R\n" +
+	"expiryUnix\x12\x18\n" +
+	"\aexpired\x18\x05 \x01(\bR\aexpired\x12#\n" +
+	"\rexpiring_soon\x18\x06 \x01(\bR\fexpiringSoon\x12\x1b\n" +
+	"\tlinked_at\x18\a \x01(\x03R\blinkedAt\"\x94\x01\n" +
+	"\x18GetGitHubActivityRequest\x12\x1d\n" +
+	"\x05token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05token\x12*\n" +
+	"\n" +
+	"repository\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xc8\x01R\n" +
+	"repository\x12-\n" +
+	"\fwindow_hours\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xd0\x05(\x00R\vwindowHours\"\xe6\x01\n" +
+	"\x19GetGitHubActivityResponse\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12!\n" +
+	"\fcommit_count\x18\x03 \x01(\x05R\vcommitCount\x12*\n" +
+	"\x11window_start_unix\x18\x04 \x01(\x03R\x0fwindowStartUnix\x12&\n" +
+	"\x0fwindow_end_unix\x18\x05 \x01(\x03R\rwindowEndUnix\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\";\n" +
+	"\x1fSetClassificationWebhookRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"\\\n" +
+	" SetClassificationWebhookResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1e\n" +
+	"\n" +
+	"configured\x18\x02 \x01(\bR\n" +
+	"configured\"/\n" +
+	"\x15RunMaintenanceRequest\x12\x16\n" +
+	"\x06vacuum\x18\x01 \x01(\bR\x06vacuum\"\xc2\x01\n" +
+	"\x16RunMaintenanceResponse\x12,\n" +
+	"\x12cache_rows_removed\x18\x01 \x01(\x03R\x10cacheRowsRemoved\x12,\n" +
+	"\x12nonce_rows_removed\x18\x02 \x01(\x03R\x10nonceRowsRemoved\x12\x1a\n" +
+	"\bvacuumed\x18\x03 \x01(\bR\bvacuumed\x120\n" +
+	"\x14session_rows_removed\x18\x04 \x01(\x03R\x12sessionRowsRemoved\"\xab\x01\n" +
+	"\x0eGlobalOverride\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12&\n" +
+	"\x0eclassification\x18\x03 \x01(\tR\x0eclassification\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"\x87\x02\n" +
+	"\x18SetGlobalOverrideRequest\x12.\n" +
+	"\x04kind\x18\x01 \x01(\tB\x1a\xbaH\x17r\x15R\vapplicationR\x06domainR\x04kind\x12\"\n" +
+	"\x06target\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x10R\x06target\x12[\n" +
+	"\x0eclassification\x18\x03 \x01(\tB3\xbaH0r.R\n" +
+	"productiveR\n" +
+	"supportingR\aneutralR\vdistractingR\x0eclassification\x12\x1c\n" +
+	"\x04tags\x18\x04 \x03(\tB\b\xbaH\x05\x92\x01\x02\x10\n" +
+	"R\x04tags\x12\x1c\n" +
+	"\x04note\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x04note\"Q\n" +
+	"\x19SetGlobalOverrideResponse\x124\n" +
+	"\boverride\x18\x01 \x01(\v2\x18.brain.v1.GlobalOverrideR\boverride\"q\n" +
+	"\x1bDeleteGlobalOverrideRequest\x12.\n" +
+	"\x04kind\x18\x01 \x01(\tB\x1a\xbaH\x17r\x15R\vapplicationR\x06domainR\x04kind\x12\"\n" +
+	"\x06target\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x10R\x06target\"8\n" +
+	"\x1cDeleteGlobalOverrideResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\x1c\n" +
+	"\x1aListGlobalOverridesRequest\"U\n" +
+	"\x1bListGlobalOverridesResponse\x126\n" +
+	"\toverrides\x18\x01 \x03(\v2\x18.brain.v1.GlobalOverrideR\toverrides\"\xe0\x01\n" +
+	"\x16InvalidateCacheRequest\x12K\n" +
+	"\x04kind\x18\x01 \x01(\tB7\xbaH4r2R\x00R\vapplicationR\awebsiteR\bdocumentR\x05emailR\acommandR\x04kind\x12/\n" +
+	"\x0einput_contains\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\rinputContains\x12/\n" +
+	"\x0esimilarity_key\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x10R\rsimilarityKey\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"<\n" +
+	"\x17InvalidateCacheResponse\x12!\n" +
+	"\frows_removed\x18\x01 \x01(\x03R\vrowsRemoved\"\xcd\x01\n" +
+	"\x1cReplayClassificationsRequest\x12K\n" +
+	"\x04kind\x18\x01 \x01(\tB7\xbaH4r2R\x00R\vapplicationR\awebsiteR\bdocumentR\x05emailR\acommandR\x04kind\x12 \n" +
+	"\x05limit\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xc8\x01(\x00R\x05limit\x12\x1d\n" +
+	"\n" +
+	"since_unix\x18\x03 \x01(\x03R\tsinceUnix\x12\x1f\n" +
+	"\vwrite_cache\x18\x04 \x01(\bR\n" +
+	"writeCache\"L\n" +
+	"\x10ReplayTransition\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"\\\n" +
+	"\fReplayChange\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\xdd\x01\n" +
+	"\x1dReplayClassificationsResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x03R\breplayed\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\x03R\achanged\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12<\n" +
+	"\vtransitions\x18\x04 \x03(\v2\x1a.brain.v1.ReplayTransitionR\vtransitions\x120\n" +
+	"\achanges\x18\x05 \x03(\v2\x16.brain.v1.ReplayChangeR\achanges\"q\n" +
+	"\x11MergeUsersRequest\x12-\n" +
+	"\x0esource_user_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\fsourceUserId\x12-\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\ftargetUserId\"}\n" +
+	"\x12MergeUsersResponse\x12\x1d\n" +
+	"\n" +
+	"rows_moved\x18\x01 \x01(\x03R\trowsMoved\x12!\n" +
+	"\frows_dropped\x18\x02 \x01(\x03R\vrowsDropped\x12%\n" +
+	"\x0ealready_merged\x18\x03 \x01(\bR\ralreadyMerged\"3\n" +
+	"\x0fGetFlagsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06userId\"\xe5\x01\n" +
+	"\vFeatureFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\asetting\x18\x03 \x01(\tR\asetting\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12'\n" +
+	"\x0frollout_percent\x18\x05 \x01(\x05R\x0erolloutPercent\x12\x19\n" +
+	"\buser_ids\x18\x06 \x03(\x03R\auserIds\x12(\n" +
+	"\x10enabled_for_user\x18\a \x01(\bR\x0eenabledForUser\"?\n" +
+	"\x10GetFlagsResponse\x12+\n" +
+	"\x05flags\x18\x01 \x03(\v2\x15.brain.v1.FeatureFlagR\x05flags2\xb3\x1a\n" +
 	"\fBrainService\x12V\n" +
-	"\x0fDeviceHandshake\x12 .brain.v1.DeviceHandshakeRequest\x1a!.brain.v1.DeviceHandshakeResponse\x12b\n" +
+	"\x0fDeviceHandshake\x12 .brain.v1.DeviceHandshakeRequest\x1a!.brain.v1.DeviceHandshakeResponse\x12q\n" +
+	"\x18VerifyHandshakeSignature\x12).brain.v1.VerifyHandshakeSignatureRequest\x1a*.brain.v1.VerifyHandshakeSignatureResponse\x12M\n" +
+	"\fListSessions\x12\x1d.brain.v1.ListSessionsRequest\x1a\x1e.brain.v1.ListSessionsResponse\x12P\n" +
+	"\rRevokeSession\x12\x1e.brain.v1.RevokeSessionRequest\x1a\x1f.brain.v1.RevokeSessionResponse\x12\\\n" +
+	"\x11RevokeAllSessions\x12\".brain.v1.RevokeAllSessionsRequest\x1a#.brain.v1.RevokeAllSessionsResponse\x12M\n" +
+	"\fRebindDevice\x12\x1d.brain.v1.RebindDeviceRequest\x1a\x1e.brain.v1.RebindDeviceResponse\x12b\n" +
 	"\x13ClassifyApplication\x12$.brain.v1.ClassifyApplicationRequest\x1a%.brain.v1.ClassifyApplicationResponse\x12V\n" +
-	"\x0fClassifyWebsite\x12 .brain.v1.ClassifyWebsiteRequest\x1a!.brain.v1.ClassifyWebsiteResponse\x12Q\n" +
+	"\x0fClassifyWebsite\x12 .brain.v1.ClassifyWebsiteRequest\x1a!.brain.v1.ClassifyWebsiteResponse\x12Y\n" +
+	"\x10ClassifyDocument\x12!.brain.v1.ClassifyDocumentRequest\x1a\".brain.v1.ClassifyDocumentResponse\x12P\n" +
+	"\rClassifyEmail\x12\x1e.brain.v1.ClassifyEmailRequest\x1a\x1f.brain.v1.ClassifyEmailResponse\x12V\n" +
+	"\x0fClassifyCommand\x12 .brain.v1.ClassifyCommandRequest\x1a!.brain.v1.ClassifyCommandResponse\x12_\n" +
+	"\x12RateClassification\x12#.brain.v1.RateClassificationRequest\x1a$.brain.v1.RateClassificationResponse\x12J\n" +
+	"\vExportRules\x12\x1c.brain.v1.ExportRulesRequest\x1a\x1d.brain.v1.ExportRulesResponse\x12J\n" +
+	"\vImportRules\x12\x1c.brain.v1.ImportRulesRequest\x1a\x1d.brain.v1.ImportRulesResponse\x12\\\n" +
+	"\x11SetPromptAddendum\x12\".brain.v1.SetPromptAddendumRequest\x1a#.brain.v1.SetPromptAddendumResponse\x12\\\n" +
+	"\x11GetPromptAddendum\x12\".brain.v1.GetPromptAddendumRequest\x1a#.brain.v1.GetPromptAddendumResponse\x12J\n" +
+	"\vGetTaxonomy\x12\x1c.brain.v1.GetTaxonomyRequest\x1a\x1d.brain.v1.GetTaxonomyResponse\x12\\\n" +
+	"\x11StartFocusSession\x12\".brain.v1.StartFocusSessionRequest\x1a#.brain.v1.StartFocusSessionResponse\x12Y\n" +
+	"\x10StopFocusSession\x12!.brain.v1.StopFocusSessionRequest\x1a\".brain.v1.StopFocusSessionResponse\x12e\n" +
+	"\x14ListDetectedProjects\x12%.brain.v1.ListDetectedProjectsRequest\x1a&.brain.v1.ListDetectedProjectsResponse\x12Q\n" +
 	"\fAgentSession\x12\x1d.brain.v1.AgentSessionRequest\x1a\x1e.brain.v1.AgentSessionResponse(\x010\x01\x12t\n" +
 	"\x19OAuth2GetAuthorizationURL\x12*.brain.v1.OAuth2GetAuthorizationURLRequest\x1a+.brain.v1.OAuth2GetAuthorizationURLResponse\x12\x86\x01\n" +
 	"\x1fOAuth2ExchangeAuthorizationCode\x120.brain.v1.OAuth2ExchangeAuthorizationCodeRequest\x1a1.brain.v1.OAuth2ExchangeAuthorizationCodeResponse\x12q\n" +
 	"\x18OAuth2RefreshAccessToken\x12).brain.v1.OAuth2RefreshAccessTokenRequest\x1a*.brain.v1.OAuth2RefreshAccessTokenResponse\x12n\n" +
-	"\x17OAuth2RevokeAccessToken\x12(.brain.v1.OAuth2RevokeAccessTokenRequest\x1a).brain.v1.OAuth2RevokeAccessTokenResponseB1Z/github.com/focusd-so/brain/gen/brain/v1;brainv1b\x06proto3"
+	"\x17OAuth2RevokeAccessToken\x12(.brain.v1.OAuth2RevokeAccessTokenRequest\x1a).brain.v1.OAuth2RevokeAccessTokenResponse\x12V\n" +
+	"\x0fGetOAuth2Status\x12 .brain.v1.GetOAuth2StatusRequest\x1a!.brain.v1.GetOAuth2StatusResponse\x12\\\n" +
+	"\x11GetGitHubActivity\x12\".brain.v1.GetGitHubActivityRequest\x1a#.brain.v1.GetGitHubActivityResponse\x12q\n" +
+	"\x18SetClassificationWebhook\x12).brain.v1.SetClassificationWebhookRequest\x1a*.brain.v1.SetClassificationWebhookResponse\x12S\n" +
+	"\x0eRunMaintenance\x12\x1f.brain.v1.RunMaintenanceRequest\x1a .brain.v1.RunMaintenanceResponse\x12\\\n" +
+	"\x11SetGlobalOverride\x12\".brain.v1.SetGlobalOverrideRequest\x1a#.brain.v1.SetGlobalOverrideResponse\x12e\n" +
+	"\x14DeleteGlobalOverride\x12%.brain.v1.DeleteGlobalOverrideRequest\x1a&.brain.v1.DeleteGlobalOverrideResponse\x12b\n" +
+	"\x13ListGlobalOverrides\x12$.brain.v1.ListGlobalOverridesRequest\x1a%.brain.v1.ListGlobalOverridesResponse\x12V\n" +
+	"\x0fInvalidateCache\x12 .brain.v1.InvalidateCacheRequest\x1a!.brain.v1.InvalidateCacheResponse\x12h\n" +
+	"\x15ReplayClassifications\x12&.brain.v1.ReplayClassificationsRequest\x1a'.brain.v1.ReplayClassificationsResponse\x12G\n" +
+	"\n" +
+	"MergeUsers\x12\x1b.brain.v1.MergeUsersRequest\x1a\x1c.brain.v1.MergeUsersResponse\x12A\n" +
+	"\bGetFlags\x12\x19.brain.v1.GetFlagsRequest\x1a\x1a.brain.v1.GetFlagsResponseB1Z/github.com/focusd-so/brain/gen/brain/v1;brainv1b\x06proto3"
 
 var (
 	file_brain_v1_server_proto_rawDescOnce sync.Once
@@ -2032,81 +6374,221 @@ func file_brain_v1_server_proto_rawDescGZIP() []byte {
 }
 
 var file_brain_v1_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_brain_v1_server_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_brain_v1_server_proto_msgTypes = make([]protoimpl.MessageInfo, 97)
 var file_brain_v1_server_proto_goTypes = []any{
 	(AgentSessionRequest_ToolCallResponse_Status)(0), // 0: brain.v1.AgentSessionRequest.ToolCallResponse.Status
 	(*DeviceHandshakeRequest)(nil),                   // 1: brain.v1.DeviceHandshakeRequest
 	(*DeviceHandshakeResponse)(nil),                  // 2: brain.v1.DeviceHandshakeResponse
-	(*ClassificationResult)(nil),                     // 3: brain.v1.ClassificationResult
-	(*ClassifyApplicationRequest)(nil),               // 4: brain.v1.ClassifyApplicationRequest
-	(*ClassifyApplicationResponse)(nil),              // 5: brain.v1.ClassifyApplicationResponse
-	(*ClassifyWebsiteRequest)(nil),                   // 6: brain.v1.ClassifyWebsiteRequest
-	(*ClassifyWebsiteResponse)(nil),                  // 7: brain.v1.ClassifyWebsiteResponse
-	(*AgentSessionRequest)(nil),                      // 8: brain.v1.AgentSessionRequest
-	(*AgentSessionResponse)(nil),                     // 9: brain.v1.AgentSessionResponse
-	(*OAuth2GetAuthorizationURLRequest)(nil),         // 10: brain.v1.OAuth2GetAuthorizationURLRequest
-	(*OAuth2GetAuthorizationURLResponse)(nil),        // 11: brain.v1.OAuth2GetAuthorizationURLResponse
-	(*OAuth2ExchangeAuthorizationCodeRequest)(nil),   // 12: brain.v1.OAuth2ExchangeAuthorizationCodeRequest
-	(*OAuth2ExchangeAuthorizationCodeResponse)(nil),  // 13: brain.v1.OAuth2ExchangeAuthorizationCodeResponse
-	(*OAuth2RefreshAccessTokenRequest)(nil),          // 14: brain.v1.OAuth2RefreshAccessTokenRequest
-	(*OAuth2RefreshAccessTokenResponse)(nil),         // 15: brain.v1.OAuth2RefreshAccessTokenResponse
-	(*OAuth2RevokeAccessTokenRequest)(nil),           // 16: brain.v1.OAuth2RevokeAccessTokenRequest
-	(*OAuth2RevokeAccessTokenResponse)(nil),          // 17: brain.v1.OAuth2RevokeAccessTokenResponse
-	(*AgentSessionRequest_Agent)(nil),                // 18: brain.v1.AgentSessionRequest.Agent
-	(*AgentSessionRequest_TerminateExecution)(nil),   // 19: brain.v1.AgentSessionRequest.TerminateExecution
-	(*AgentSessionRequest_RunRequest)(nil),           // 20: brain.v1.AgentSessionRequest.RunRequest
-	(*AgentSessionRequest_ToolCallResponse)(nil),     // 21: brain.v1.AgentSessionRequest.ToolCallResponse
-	(*AgentSessionRequest_Heartbeat)(nil),            // 22: brain.v1.AgentSessionRequest.Heartbeat
-	(*AgentSessionRequest_SessionEnd)(nil),           // 23: brain.v1.AgentSessionRequest.SessionEnd
-	(*AgentSessionRequest_Agent_Tool)(nil),           // 24: brain.v1.AgentSessionRequest.Agent.Tool
-	(*AgentSessionResponse_Error)(nil),               // 25: brain.v1.AgentSessionResponse.Error
-	(*AgentSessionResponse_HeartbeatAck)(nil),        // 26: brain.v1.AgentSessionResponse.HeartbeatAck
-	(*AgentSessionResponse_SessionEndAck)(nil),       // 27: brain.v1.AgentSessionResponse.SessionEndAck
-	(*AgentSessionResponse_ToolCallRequest)(nil),     // 28: brain.v1.AgentSessionResponse.ToolCallRequest
-	(*AgentSessionResponse_RunResponse)(nil),         // 29: brain.v1.AgentSessionResponse.RunResponse
-	nil,                                              // 30: brain.v1.AgentSessionResponse.Error.DetailsEntry
-	(*v1.OAuth2Token)(nil),                           // 31: common.OAuth2Token
+	(*ListSessionsRequest)(nil),                      // 3: brain.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),                     // 4: brain.v1.ListSessionsResponse
+	(*SessionInfo)(nil),                              // 5: brain.v1.SessionInfo
+	(*RevokeSessionRequest)(nil),                     // 6: brain.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),                    // 7: brain.v1.RevokeSessionResponse
+	(*RevokeAllSessionsRequest)(nil),                 // 8: brain.v1.RevokeAllSessionsRequest
+	(*RevokeAllSessionsResponse)(nil),                // 9: brain.v1.RevokeAllSessionsResponse
+	(*VerifyHandshakeSignatureRequest)(nil),          // 10: brain.v1.VerifyHandshakeSignatureRequest
+	(*VerifyHandshakeSignatureResponse)(nil),         // 11: brain.v1.VerifyHandshakeSignatureResponse
+	(*RebindDeviceRequest)(nil),                      // 12: brain.v1.RebindDeviceRequest
+	(*RebindDeviceResponse)(nil),                     // 13: brain.v1.RebindDeviceResponse
+	(*ClassificationResult)(nil),                     // 14: brain.v1.ClassificationResult
+	(*ClassificationCandidate)(nil),                  // 15: brain.v1.ClassificationCandidate
+	(*ClassificationPolicy)(nil),                     // 16: brain.v1.ClassificationPolicy
+	(*ClassifyApplicationRequest)(nil),               // 17: brain.v1.ClassifyApplicationRequest
+	(*ClassifyApplicationResponse)(nil),              // 18: brain.v1.ClassifyApplicationResponse
+	(*ClassifyWebsiteRequest)(nil),                   // 19: brain.v1.ClassifyWebsiteRequest
+	(*ClassifyWebsiteResponse)(nil),                  // 20: brain.v1.ClassifyWebsiteResponse
+	(*ClassifyDocumentRequest)(nil),                  // 21: brain.v1.ClassifyDocumentRequest
+	(*ClassifyDocumentResponse)(nil),                 // 22: brain.v1.ClassifyDocumentResponse
+	(*ClassifyEmailRequest)(nil),                     // 23: brain.v1.ClassifyEmailRequest
+	(*ClassifyEmailResponse)(nil),                    // 24: brain.v1.ClassifyEmailResponse
+	(*ClassifyCommandRequest)(nil),                   // 25: brain.v1.ClassifyCommandRequest
+	(*ClassifyCommandResponse)(nil),                  // 26: brain.v1.ClassifyCommandResponse
+	(*RateClassificationRequest)(nil),                // 27: brain.v1.RateClassificationRequest
+	(*RateClassificationResponse)(nil),               // 28: brain.v1.RateClassificationResponse
+	(*ExportRulesRequest)(nil),                       // 29: brain.v1.ExportRulesRequest
+	(*ExportRulesResponse)(nil),                      // 30: brain.v1.ExportRulesResponse
+	(*ImportRulesRequest)(nil),                       // 31: brain.v1.ImportRulesRequest
+	(*ImportRulesResponse)(nil),                      // 32: brain.v1.ImportRulesResponse
+	(*SetPromptAddendumRequest)(nil),                 // 33: brain.v1.SetPromptAddendumRequest
+	(*SetPromptAddendumResponse)(nil),                // 34: brain.v1.SetPromptAddendumResponse
+	(*GetPromptAddendumRequest)(nil),                 // 35: brain.v1.GetPromptAddendumRequest
+	(*GetPromptAddendumResponse)(nil),                // 36: brain.v1.GetPromptAddendumResponse
+	(*GetTaxonomyRequest)(nil),                       // 37: brain.v1.GetTaxonomyRequest
+	(*TaxonomyEntry)(nil),                            // 38: brain.v1.TaxonomyEntry
+	(*GetTaxonomyResponse)(nil),                      // 39: brain.v1.GetTaxonomyResponse
+	(*FocusSessionSummary)(nil),                      // 40: brain.v1.FocusSessionSummary
+	(*StartFocusSessionRequest)(nil),                 // 41: brain.v1.StartFocusSessionRequest
+	(*StartFocusSessionResponse)(nil),                // 42: brain.v1.StartFocusSessionResponse
+	(*StopFocusSessionRequest)(nil),                  // 43: brain.v1.StopFocusSessionRequest
+	(*StopFocusSessionResponse)(nil),                 // 44: brain.v1.StopFocusSessionResponse
+	(*ListDetectedProjectsRequest)(nil),              // 45: brain.v1.ListDetectedProjectsRequest
+	(*ListDetectedProjectsResponse)(nil),             // 46: brain.v1.ListDetectedProjectsResponse
+	(*DetectedProject)(nil),                          // 47: brain.v1.DetectedProject
+	(*AgentSessionRequest)(nil),                      // 48: brain.v1.AgentSessionRequest
+	(*AgentSessionResponse)(nil),                     // 49: brain.v1.AgentSessionResponse
+	(*OAuth2GetAuthorizationURLRequest)(nil),         // 50: brain.v1.OAuth2GetAuthorizationURLRequest
+	(*OAuth2GetAuthorizationURLResponse)(nil),        // 51: brain.v1.OAuth2GetAuthorizationURLResponse
+	(*OAuth2ExchangeAuthorizationCodeRequest)(nil),   // 52: brain.v1.OAuth2ExchangeAuthorizationCodeRequest
+	(*OAuth2ExchangeAuthorizationCodeResponse)(nil),  // 53: brain.v1.OAuth2ExchangeAuthorizationCodeResponse
+	(*OAuth2RefreshAccessTokenRequest)(nil),          // 54: brain.v1.OAuth2RefreshAccessTokenRequest
+	(*OAuth2RefreshAccessTokenResponse)(nil),         // 55: brain.v1.OAuth2RefreshAccessTokenResponse
+	(*OAuth2RevokeAccessTokenRequest)(nil),           // 56: brain.v1.OAuth2RevokeAccessTokenRequest
+	(*OAuth2RevokeAccessTokenResponse)(nil),          // 57: brain.v1.OAuth2RevokeAccessTokenResponse
+	(*GetOAuth2StatusRequest)(nil),                   // 58: brain.v1.GetOAuth2StatusRequest
+	(*GetOAuth2StatusResponse)(nil),                  // 59: brain.v1.GetOAuth2StatusResponse
+	(*OAuth2ProviderStatus)(nil),                     // 60: brain.v1.OAuth2ProviderStatus
+	(*GetGitHubActivityRequest)(nil),                 // 61: brain.v1.GetGitHubActivityRequest
+	(*GetGitHubActivityResponse)(nil),                // 62: brain.v1.GetGitHubActivityResponse
+	(*SetClassificationWebhookRequest)(nil),          // 63: brain.v1.SetClassificationWebhookRequest
+	(*SetClassificationWebhookResponse)(nil),         // 64: brain.v1.SetClassificationWebhookResponse
+	(*RunMaintenanceRequest)(nil),                    // 65: brain.v1.RunMaintenanceRequest
+	(*RunMaintenanceResponse)(nil),                   // 66: brain.v1.RunMaintenanceResponse
+	(*GlobalOverride)(nil),                           // 67: brain.v1.GlobalOverride
+	(*SetGlobalOverrideRequest)(nil),                 // 68: brain.v1.SetGlobalOverrideRequest
+	(*SetGlobalOverrideResponse)(nil),                // 69: brain.v1.SetGlobalOverrideResponse
+	(*DeleteGlobalOverrideRequest)(nil),              // 70: brain.v1.DeleteGlobalOverrideRequest
+	(*DeleteGlobalOverrideResponse)(nil),             // 71: brain.v1.DeleteGlobalOverrideResponse
+	(*ListGlobalOverridesRequest)(nil),               // 72: brain.v1.ListGlobalOverridesRequest
+	(*ListGlobalOverridesResponse)(nil),              // 73: brain.v1.ListGlobalOverridesResponse
+	(*InvalidateCacheRequest)(nil),                   // 74: brain.v1.InvalidateCacheRequest
+	(*InvalidateCacheResponse)(nil),                  // 75: brain.v1.InvalidateCacheResponse
+	(*ReplayClassificationsRequest)(nil),             // 76: brain.v1.ReplayClassificationsRequest
+	(*ReplayTransition)(nil),                         // 77: brain.v1.ReplayTransition
+	(*ReplayChange)(nil),                             // 78: brain.v1.ReplayChange
+	(*ReplayClassificationsResponse)(nil),            // 79: brain.v1.ReplayClassificationsResponse
+	(*MergeUsersRequest)(nil),                        // 80: brain.v1.MergeUsersRequest
+	(*MergeUsersResponse)(nil),                       // 81: brain.v1.MergeUsersResponse
+	(*GetFlagsRequest)(nil),                          // 82: brain.v1.GetFlagsRequest
+	(*FeatureFlag)(nil),                              // 83: brain.v1.FeatureFlag
+	(*GetFlagsResponse)(nil),                         // 84: brain.v1.GetFlagsResponse
+	(*AgentSessionRequest_Agent)(nil),                // 85: brain.v1.AgentSessionRequest.Agent
+	(*AgentSessionRequest_TerminateExecution)(nil),   // 86: brain.v1.AgentSessionRequest.TerminateExecution
+	(*AgentSessionRequest_RunRequest)(nil),           // 87: brain.v1.AgentSessionRequest.RunRequest
+	(*AgentSessionRequest_ToolCallResponse)(nil),     // 88: brain.v1.AgentSessionRequest.ToolCallResponse
+	(*AgentSessionRequest_Heartbeat)(nil),            // 89: brain.v1.AgentSessionRequest.Heartbeat
+	(*AgentSessionRequest_SessionEnd)(nil),           // 90: brain.v1.AgentSessionRequest.SessionEnd
+	(*AgentSessionRequest_Agent_Tool)(nil),           // 91: brain.v1.AgentSessionRequest.Agent.Tool
+	(*AgentSessionResponse_Error)(nil),               // 92: brain.v1.AgentSessionResponse.Error
+	(*AgentSessionResponse_HeartbeatAck)(nil),        // 93: brain.v1.AgentSessionResponse.HeartbeatAck
+	(*AgentSessionResponse_SessionEndAck)(nil),       // 94: brain.v1.AgentSessionResponse.SessionEndAck
+	(*AgentSessionResponse_ToolCallRequest)(nil),     // 95: brain.v1.AgentSessionResponse.ToolCallRequest
+	(*AgentSessionResponse_RunResponse)(nil),         // 96: brain.v1.AgentSessionResponse.RunResponse
+	nil,                                              // 97: brain.v1.AgentSessionResponse.Error.DetailsEntry
+	(*v1.OAuth2Token)(nil),                           // 98: common.OAuth2Token
 }
 var file_brain_v1_server_proto_depIdxs = []int32{
-	3,  // 0: brain.v1.ClassifyApplicationResponse.classification:type_name -> brain.v1.ClassificationResult
-	3,  // 1: brain.v1.ClassifyWebsiteResponse.classification:type_name -> brain.v1.ClassificationResult
-	20, // 2: brain.v1.AgentSessionRequest.run_request:type_name -> brain.v1.AgentSessionRequest.RunRequest
-	21, // 3: brain.v1.AgentSessionRequest.tool_call_response:type_name -> brain.v1.AgentSessionRequest.ToolCallResponse
-	22, // 4: brain.v1.AgentSessionRequest.heartbeat:type_name -> brain.v1.AgentSessionRequest.Heartbeat
-	23, // 5: brain.v1.AgentSessionRequest.session_end:type_name -> brain.v1.AgentSessionRequest.SessionEnd
-	29, // 6: brain.v1.AgentSessionResponse.run_response:type_name -> brain.v1.AgentSessionResponse.RunResponse
-	28, // 7: brain.v1.AgentSessionResponse.tool_call_request:type_name -> brain.v1.AgentSessionResponse.ToolCallRequest
-	25, // 8: brain.v1.AgentSessionResponse.error:type_name -> brain.v1.AgentSessionResponse.Error
-	26, // 9: brain.v1.AgentSessionResponse.heartbeat_ack:type_name -> brain.v1.AgentSessionResponse.HeartbeatAck
-	27, // 10: brain.v1.AgentSessionResponse.session_end_ack:type_name -> brain.v1.AgentSessionResponse.SessionEndAck
-	31, // 11: brain.v1.OAuth2ExchangeAuthorizationCodeResponse.token:type_name -> common.OAuth2Token
-	31, // 12: brain.v1.OAuth2RefreshAccessTokenResponse.token:type_name -> common.OAuth2Token
-	24, // 13: brain.v1.AgentSessionRequest.Agent.tools:type_name -> brain.v1.AgentSessionRequest.Agent.Tool
-	18, // 14: brain.v1.AgentSessionRequest.Agent.sub_agents:type_name -> brain.v1.AgentSessionRequest.Agent
-	18, // 15: brain.v1.AgentSessionRequest.RunRequest.agents:type_name -> brain.v1.AgentSessionRequest.Agent
-	0,  // 16: brain.v1.AgentSessionRequest.ToolCallResponse.status:type_name -> brain.v1.AgentSessionRequest.ToolCallResponse.Status
-	30, // 17: brain.v1.AgentSessionResponse.Error.details:type_name -> brain.v1.AgentSessionResponse.Error.DetailsEntry
-	1,  // 18: brain.v1.BrainService.DeviceHandshake:input_type -> brain.v1.DeviceHandshakeRequest
-	4,  // 19: brain.v1.BrainService.ClassifyApplication:input_type -> brain.v1.ClassifyApplicationRequest
-	6,  // 20: brain.v1.BrainService.ClassifyWebsite:input_type -> brain.v1.ClassifyWebsiteRequest
-	8,  // 21: brain.v1.BrainService.AgentSession:input_type -> brain.v1.AgentSessionRequest
-	10, // 22: brain.v1.BrainService.OAuth2GetAuthorizationURL:input_type -> brain.v1.OAuth2GetAuthorizationURLRequest
-	12, // 23: brain.v1.BrainService.OAuth2ExchangeAuthorizationCode:input_type -> brain.v1.OAuth2ExchangeAuthorizationCodeRequest
-	14, // 24: brain.v1.BrainService.OAuth2RefreshAccessToken:input_type -> brain.v1.OAuth2RefreshAccessTokenRequest
-	16, // 25: brain.v1.BrainService.OAuth2RevokeAccessToken:input_type -> brain.v1.OAuth2RevokeAccessTokenRequest
-	2,  // 26: brain.v1.BrainService.DeviceHandshake:output_type -> brain.v1.DeviceHandshakeResponse
-	5,  // 27: brain.v1.BrainService.ClassifyApplication:output_type -> brain.v1.ClassifyApplicationResponse
-	7,  // 28: brain.v1.BrainService.ClassifyWebsite:output_type -> brain.v1.ClassifyWebsiteResponse
-	9,  // 29: brain.v1.BrainService.AgentSession:output_type -> brain.v1.AgentSessionResponse
-	11, // 30: brain.v1.BrainService.OAuth2GetAuthorizationURL:output_type -> brain.v1.OAuth2GetAuthorizationURLResponse
-	13, // 31: brain.v1.BrainService.OAuth2ExchangeAuthorizationCode:output_type -> brain.v1.OAuth2ExchangeAuthorizationCodeResponse
-	15, // 32: brain.v1.BrainService.OAuth2RefreshAccessToken:output_type -> brain.v1.OAuth2RefreshAccessTokenResponse
-	17, // 33: brain.v1.BrainService.OAuth2RevokeAccessToken:output_type -> brain.v1.OAuth2RevokeAccessTokenResponse
-	26, // [26:34] is the sub-list for method output_type
-	18, // [18:26] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	5,  // 0: brain.v1.ListSessionsResponse.sessions:type_name -> brain.v1.SessionInfo
+	16, // 1: brain.v1.ClassificationResult.policy:type_name -> brain.v1.ClassificationPolicy
+	15, // 2: brain.v1.ClassificationResult.candidates:type_name -> brain.v1.ClassificationCandidate
+	14, // 3: brain.v1.ClassifyApplicationResponse.classification:type_name -> brain.v1.ClassificationResult
+	14, // 4: brain.v1.ClassifyWebsiteResponse.classification:type_name -> brain.v1.ClassificationResult
+	14, // 5: brain.v1.ClassifyDocumentResponse.classification:type_name -> brain.v1.ClassificationResult
+	14, // 6: brain.v1.ClassifyEmailResponse.classification:type_name -> brain.v1.ClassificationResult
+	14, // 7: brain.v1.ClassifyCommandResponse.classification:type_name -> brain.v1.ClassificationResult
+	38, // 8: brain.v1.GetTaxonomyResponse.classifications:type_name -> brain.v1.TaxonomyEntry
+	38, // 9: brain.v1.GetTaxonomyResponse.tags:type_name -> brain.v1.TaxonomyEntry
+	40, // 10: brain.v1.StartFocusSessionResponse.closed:type_name -> brain.v1.FocusSessionSummary
+	40, // 11: brain.v1.StopFocusSessionResponse.summary:type_name -> brain.v1.FocusSessionSummary
+	47, // 12: brain.v1.ListDetectedProjectsResponse.projects:type_name -> brain.v1.DetectedProject
+	87, // 13: brain.v1.AgentSessionRequest.run_request:type_name -> brain.v1.AgentSessionRequest.RunRequest
+	88, // 14: brain.v1.AgentSessionRequest.tool_call_response:type_name -> brain.v1.AgentSessionRequest.ToolCallResponse
+	89, // 15: brain.v1.AgentSessionRequest.heartbeat:type_name -> brain.v1.AgentSessionRequest.Heartbeat
+	90, // 16: brain.v1.AgentSessionRequest.session_end:type_name -> brain.v1.AgentSessionRequest.SessionEnd
+	96, // 17: brain.v1.AgentSessionResponse.run_response:type_name -> brain.v1.AgentSessionResponse.RunResponse
+	95, // 18: brain.v1.AgentSessionResponse.tool_call_request:type_name -> brain.v1.AgentSessionResponse.ToolCallRequest
+	92, // 19: brain.v1.AgentSessionResponse.error:type_name -> brain.v1.AgentSessionResponse.Error
+	93, // 20: brain.v1.AgentSessionResponse.heartbeat_ack:type_name -> brain.v1.AgentSessionResponse.HeartbeatAck
+	94, // 21: brain.v1.AgentSessionResponse.session_end_ack:type_name -> brain.v1.AgentSessionResponse.SessionEndAck
+	98, // 22: brain.v1.OAuth2ExchangeAuthorizationCodeResponse.token:type_name -> common.OAuth2Token
+	98, // 23: brain.v1.OAuth2RefreshAccessTokenResponse.token:type_name -> common.OAuth2Token
+	60, // 24: brain.v1.GetOAuth2StatusResponse.providers:type_name -> brain.v1.OAuth2ProviderStatus
+	67, // 25: brain.v1.SetGlobalOverrideResponse.override:type_name -> brain.v1.GlobalOverride
+	67, // 26: brain.v1.ListGlobalOverridesResponse.overrides:type_name -> brain.v1.GlobalOverride
+	77, // 27: brain.v1.ReplayClassificationsResponse.transitions:type_name -> brain.v1.ReplayTransition
+	78, // 28: brain.v1.ReplayClassificationsResponse.changes:type_name -> brain.v1.ReplayChange
+	83, // 29: brain.v1.GetFlagsResponse.flags:type_name -> brain.v1.FeatureFlag
+	91, // 30: brain.v1.AgentSessionRequest.Agent.tools:type_name -> brain.v1.AgentSessionRequest.Agent.Tool
+	85, // 31: brain.v1.AgentSessionRequest.Agent.sub_agents:type_name -> brain.v1.AgentSessionRequest.Agent
+	85, // 32: brain.v1.AgentSessionRequest.RunRequest.agents:type_name -> brain.v1.AgentSessionRequest.Agent
+	0,  // 33: brain.v1.AgentSessionRequest.ToolCallResponse.status:type_name -> brain.v1.AgentSessionRequest.ToolCallResponse.Status
+	97, // 34: brain.v1.AgentSessionResponse.Error.details:type_name -> brain.v1.AgentSessionResponse.Error.DetailsEntry
+	1,  // 35: brain.v1.BrainService.DeviceHandshake:input_type -> brain.v1.DeviceHandshakeRequest
+	10, // 36: brain.v1.BrainService.VerifyHandshakeSignature:input_type -> brain.v1.VerifyHandshakeSignatureRequest
+	3,  // 37: brain.v1.BrainService.ListSessions:input_type -> brain.v1.ListSessionsRequest
+	6,  // 38: brain.v1.BrainService.RevokeSession:input_type -> brain.v1.RevokeSessionRequest
+	8,  // 39: brain.v1.BrainService.RevokeAllSessions:input_type -> brain.v1.RevokeAllSessionsRequest
+	12, // 40: brain.v1.BrainService.RebindDevice:input_type -> brain.v1.RebindDeviceRequest
+	17, // 41: brain.v1.BrainService.ClassifyApplication:input_type -> brain.v1.ClassifyApplicationRequest
+	19, // 42: brain.v1.BrainService.ClassifyWebsite:input_type -> brain.v1.ClassifyWebsiteRequest
+	21, // 43: brain.v1.BrainService.ClassifyDocument:input_type -> brain.v1.ClassifyDocumentRequest
+	23, // 44: brain.v1.BrainService.ClassifyEmail:input_type -> brain.v1.ClassifyEmailRequest
+	25, // 45: brain.v1.BrainService.ClassifyCommand:input_type -> brain.v1.ClassifyCommandRequest
+	27, // 46: brain.v1.BrainService.RateClassification:input_type -> brain.v1.RateClassificationRequest
+	29, // 47: brain.v1.BrainService.ExportRules:input_type -> brain.v1.ExportRulesRequest
+	31, // 48: brain.v1.BrainService.ImportRules:input_type -> brain.v1.ImportRulesRequest
+	33, // 49: brain.v1.BrainService.SetPromptAddendum:input_type -> brain.v1.SetPromptAddendumRequest
+	35, // 50: brain.v1.BrainService.GetPromptAddendum:input_type -> brain.v1.GetPromptAddendumRequest
+	37, // 51: brain.v1.BrainService.GetTaxonomy:input_type -> brain.v1.GetTaxonomyRequest
+	41, // 52: brain.v1.BrainService.StartFocusSession:input_type -> brain.v1.StartFocusSessionRequest
+	43, // 53: brain.v1.BrainService.StopFocusSession:input_type -> brain.v1.StopFocusSessionRequest
+	45, // 54: brain.v1.BrainService.ListDetectedProjects:input_type -> brain.v1.ListDetectedProjectsRequest
+	48, // 55: brain.v1.BrainService.AgentSession:input_type -> brain.v1.AgentSessionRequest
+	50, // 56: brain.v1.BrainService.OAuth2GetAuthorizationURL:input_type -> brain.v1.OAuth2GetAuthorizationURLRequest
+	52, // 57: brain.v1.BrainService.OAuth2ExchangeAuthorizationCode:input_type -> brain.v1.OAuth2ExchangeAuthorizationCodeRequest
+	54, // 58: brain.v1.BrainService.OAuth2RefreshAccessToken:input_type -> brain.v1.OAuth2RefreshAccessTokenRequest
+	56, // 59: brain.v1.BrainService.OAuth2RevokeAccessToken:input_type -> brain.v1.OAuth2RevokeAccessTokenRequest
+	58, // 60: brain.v1.BrainService.GetOAuth2Status:input_type -> brain.v1.GetOAuth2StatusRequest
+	61, // 61: brain.v1.BrainService.GetGitHubActivity:input_type -> brain.v1.GetGitHubActivityRequest
+	63, // 62: brain.v1.BrainService.SetClassificationWebhook:input_type -> brain.v1.SetClassificationWebhookRequest
+	65, // 63: brain.v1.BrainService.RunMaintenance:input_type -> brain.v1.RunMaintenanceRequest
+	68, // 64: brain.v1.BrainService.SetGlobalOverride:input_type -> brain.v1.SetGlobalOverrideRequest
+	70, // 65: brain.v1.BrainService.DeleteGlobalOverride:input_type -> brain.v1.DeleteGlobalOverrideRequest
+	72, // 66: brain.v1.BrainService.ListGlobalOverrides:input_type -> brain.v1.ListGlobalOverridesRequest
+	74, // 67: brain.v1.BrainService.InvalidateCache:input_type -> brain.v1.InvalidateCacheRequest
+	76, // 68: brain.v1.BrainService.ReplayClassifications:input_type -> brain.v1.ReplayClassificationsRequest
+	80, // 69: brain.v1.BrainService.MergeUsers:input_type -> brain.v1.MergeUsersRequest
+	82, // 70: brain.v1.BrainService.GetFlags:input_type -> brain.v1.GetFlagsRequest
+	2,  // 71: brain.v1.BrainService.DeviceHandshake:output_type -> brain.v1.DeviceHandshakeResponse
+	11, // 72: brain.v1.BrainService.VerifyHandshakeSignature:output_type -> brain.v1.VerifyHandshakeSignatureResponse
+	4,  // 73: brain.v1.BrainService.ListSessions:output_type -> brain.v1.ListSessionsResponse
+	7,  // 74: brain.v1.BrainService.RevokeSession:output_type -> brain.v1.RevokeSessionResponse
+	9,  // 75: brain.v1.BrainService.RevokeAllSessions:output_type -> brain.v1.RevokeAllSessionsResponse
+	13, // 76: brain.v1.BrainService.RebindDevice:output_type -> brain.v1.RebindDeviceResponse
+	18, // 77: brain.v1.BrainService.ClassifyApplication:output_type -> brain.v1.ClassifyApplicationResponse
+	20, // 78: brain.v1.BrainService.ClassifyWebsite:output_type -> brain.v1.ClassifyWebsiteResponse
+	22, // 79: brain.v1.BrainService.ClassifyDocument:output_type -> brain.v1.ClassifyDocumentResponse
+	24, // 80: brain.v1.BrainService.ClassifyEmail:output_type -> brain.v1.ClassifyEmailResponse
+	26, // 81: brain.v1.BrainService.ClassifyCommand:output_type -> brain.v1.ClassifyCommandResponse
+	28, // 82: brain.v1.BrainService.RateClassification:output_type -> brain.v1.RateClassificationResponse
+	30, // 83: brain.v1.BrainService.ExportRules:output_type -> brain.v1.ExportRulesResponse
+	32, // 84: brain.v1.BrainService.ImportRules:output_type -> brain.v1.ImportRulesResponse
+	34, // 85: brain.v1.BrainService.SetPromptAddendum:output_type -> brain.v1.SetPromptAddendumResponse
+	36, // 86: brain.v1.BrainService.GetPromptAddendum:output_type -> brain.v1.GetPromptAddendumResponse
+	39, // 87: brain.v1.BrainService.GetTaxonomy:output_type -> brain.v1.GetTaxonomyResponse
+	42, // 88: brain.v1.BrainService.StartFocusSession:output_type -> brain.v1.StartFocusSessionResponse
+	44, // 89: brain.v1.BrainService.StopFocusSession:output_type -> brain.v1.StopFocusSessionResponse
+	46, // 90: brain.v1.BrainService.ListDetectedProjects:output_type -> brain.v1.ListDetectedProjectsResponse
+	49, // 91: brain.v1.BrainService.AgentSession:output_type -> brain.v1.AgentSessionResponse
+	51, // 92: brain.v1.BrainService.OAuth2GetAuthorizationURL:output_type -> brain.v1.OAuth2GetAuthorizationURLResponse
+	53, // 93: brain.v1.BrainService.OAuth2ExchangeAuthorizationCode:output_type -> brain.v1.OAuth2ExchangeAuthorizationCodeResponse
+	55, // 94: brain.v1.BrainService.OAuth2RefreshAccessToken:output_type -> brain.v1.OAuth2RefreshAccessTokenResponse
+	57, // 95: brain.v1.BrainService.OAuth2RevokeAccessToken:output_type -> brain.v1.OAuth2RevokeAccessTokenResponse
+	59, // 96: brain.v1.BrainService.GetOAuth2Status:output_type -> brain.v1.GetOAuth2StatusResponse
+	62, // 97: brain.v1.BrainService.GetGitHubActivity:output_type -> brain.v1.GetGitHubActivityResponse
+	64, // 98: brain.v1.BrainService.SetClassificationWebhook:output_type -> brain.v1.SetClassificationWebhookResponse
+	66, // 99: brain.v1.BrainService.RunMaintenance:output_type -> brain.v1.RunMaintenanceResponse
+	69, // 100: brain.v1.BrainService.SetGlobalOverride:output_type -> brain.v1.SetGlobalOverrideResponse
+	71, // 101: brain.v1.BrainService.DeleteGlobalOverride:output_type -> brain.v1.DeleteGlobalOverrideResponse
+	73, // 102: brain.v1.BrainService.ListGlobalOverrides:output_type -> brain.v1.ListGlobalOverridesResponse
+	75, // 103: brain.v1.BrainService.InvalidateCache:output_type -> brain.v1.InvalidateCacheResponse
+	79, // 104: brain.v1.BrainService.ReplayClassifications:output_type -> brain.v1.ReplayClassificationsResponse
+	81, // 105: brain.v1.BrainService.MergeUsers:output_type -> brain.v1.MergeUsersResponse
+	84, // 106: brain.v1.BrainService.GetFlags:output_type -> brain.v1.GetFlagsResponse
+	71, // [71:107] is the sub-list for method output_type
+	35, // [35:71] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_brain_v1_server_proto_init() }
@@ -2114,15 +6596,19 @@ func file_brain_v1_server_proto_init() {
 	if File_brain_v1_server_proto != nil {
 		return
 	}
-	file_brain_v1_server_proto_msgTypes[2].OneofWrappers = []any{}
-	file_brain_v1_server_proto_msgTypes[4].OneofWrappers = []any{}
-	file_brain_v1_server_proto_msgTypes[7].OneofWrappers = []any{
+	file_brain_v1_server_proto_msgTypes[13].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[16].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[17].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[18].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[22].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[24].OneofWrappers = []any{}
+	file_brain_v1_server_proto_msgTypes[47].OneofWrappers = []any{
 		(*AgentSessionRequest_RunRequest_)(nil),
 		(*AgentSessionRequest_ToolCallResponse_)(nil),
 		(*AgentSessionRequest_Heartbeat_)(nil),
 		(*AgentSessionRequest_SessionEnd_)(nil),
 	}
-	file_brain_v1_server_proto_msgTypes[8].OneofWrappers = []any{
+	file_brain_v1_server_proto_msgTypes[48].OneofWrappers = []any{
 		(*AgentSessionResponse_RunResponse_)(nil),
 		(*AgentSessionResponse_ToolCallRequest_)(nil),
 		(*AgentSessionResponse_Error_)(nil),
@@ -2135,7 +6621,7 @@ func file_brain_v1_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_brain_v1_server_proto_rawDesc), len(file_brain_v1_server_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   30,
+			NumMessages:   97,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,7 @@ type User struct {
 	Role                  string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	OsInfo                string                 `protobuf:"bytes,4,opt,name=os_info,json=osInfo,proto3" json:"os_info,omitempty"`
 	CreatedAt             int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ClassificationWebhook bool                   `protobuf:"varint,6,opt,name=classification_webhook,json=classificationWebhook,proto3" json:"classification_webhook,omitempty"` // opted in to the classification webhook
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -98,30 +99,718 @@ func (x *User) GetCreatedAt() int64 {
 	return 0
 }
 
+func (x *User) GetClassificationWebhook() bool {
+	if x != nil {
+		return x.ClassificationWebhook
+	}
+	return false
+}
+
 type Nonce struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Nonce) Reset() {
+	*x = Nonce{}
+	mi := &file_common_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Nonce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nonce) ProtoMessage() {}
+
+func (x *Nonce) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nonce.ProtoReflect.Descriptor instead.
+func (*Nonce) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Nonce) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *Nonce) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Nonce) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Session records a minted session token so users can list and revoke their
+// sessions. Tokens are checked against it on every authenticated request.
+type Session struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Jti               string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti,omitempty"`
+	UserId            int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceFingerprint string                 `protobuf:"bytes,3,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"`
+	OsPlatform        string                 `protobuf:"bytes,4,opt,name=os_platform,json=osPlatform,proto3" json:"os_platform,omitempty"` // from the handshake, helps users recognise the device
+	AppVersion        string                 `protobuf:"bytes,5,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt         int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RevokedAt         int64                  `protobuf:"varint,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // 0 while the session is active
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_common_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Session) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
+}
+
+func (x *Session) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Session) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+func (x *Session) GetOsPlatform() string {
+	if x != nil {
+		return x.OsPlatform
+	}
+	return ""
+}
+
+func (x *Session) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Session) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Session) GetRevokedAt() int64 {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return 0
+}
+
+// PromptHistory caches AI prompt/response pairs for reuse
+type PromptHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromptHash    string                 `protobuf:"bytes,1,opt,name=prompt_hash,json=promptHash,proto3" json:"prompt_hash,omitempty"`
+	ResponseJson  string                 `protobuf:"bytes,2,opt,name=response_json,json=responseJson,proto3" json:"response_json,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastAccessed  int64                  `protobuf:"varint,5,opt,name=last_accessed,json=lastAccessed,proto3" json:"last_accessed,omitempty"`   // bumped on cache hits, drives LRU eviction
+	SimilarityKey string                 `protobuf:"bytes,6,opt,name=similarity_key,json=similarityKey,proto3" json:"similarity_key,omitempty"` // e.g. "app:com.tinyspeck.slackmacgap", drives the approximate fallback
+	OriginalUrl   string                 `protobuf:"bytes,7,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`       // website URL as sent by the client, only with CLASSIFICATION_CACHE_STORE_URLS
+	NormalizedUrl string                 `protobuf:"bytes,8,opt,name=normalized_url,json=normalizedUrl,proto3" json:"normalized_url,omitempty"` // website URL the cache key was built from
+	Kind          string                 `protobuf:"bytes,9,opt,name=kind,proto3" json:"kind,omitempty"`                                        // classifier that cached the entry, e.g. "website"; empty for agent replies
+	Input         string                 `protobuf:"bytes,10,opt,name=input,proto3" json:"input,omitempty"`                                     // cache key input as JSON, matched by InvalidateCache
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptHistory) Reset() {
+	*x = PromptHistory{}
+	mi := &file_common_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptHistory) ProtoMessage() {}
+
+func (x *PromptHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptHistory.ProtoReflect.Descriptor instead.
+func (*PromptHistory) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PromptHistory) GetPromptHash() string {
+	if x != nil {
+		return x.PromptHash
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetResponseJson() string {
+	if x != nil {
+		return x.ResponseJson
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PromptHistory) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *PromptHistory) GetLastAccessed() int64 {
+	if x != nil {
+		return x.LastAccessed
+	}
+	return 0
+}
+
+func (x *PromptHistory) GetSimilarityKey() string {
+	if x != nil {
+		return x.SimilarityKey
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetNormalizedUrl() string {
+	if x != nil {
+		return x.NormalizedUrl
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PromptHistory) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+// LinkedProvider records that a user linked an OAuth2 provider. Only token
+// metadata is kept, the tokens themselves stay on the client.
+type LinkedProvider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Scopes        string                 `protobuf:"bytes,4,opt,name=scopes,proto3" json:"scopes,omitempty"`                            // comma-separated scopes granted by the provider
+	ExpiryUnix    int64                  `protobuf:"varint,5,opt,name=expiry_unix,json=expiryUnix,proto3" json:"expiry_unix,omitempty"` // 0 when the access token never expires
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkedProvider) Reset() {
+	*x = LinkedProvider{}
+	mi := &file_common_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedProvider) ProtoMessage() {}
+
+func (x *LinkedProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedProvider.ProtoReflect.Descriptor instead.
+func (*LinkedProvider) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LinkedProvider) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LinkedProvider) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LinkedProvider) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkedProvider) GetScopes() string {
+	if x != nil {
+		return x.Scopes
+	}
+	return ""
+}
+
+func (x *LinkedProvider) GetExpiryUnix() int64 {
+	if x != nil {
+		return x.ExpiryUnix
+	}
+	return 0
+}
+
+func (x *LinkedProvider) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *LinkedProvider) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ClassificationVote is a user's thumbs up/down on the classification of an
+// input. A later vote by the same user on the same input replaces the earlier one.
+type ClassificationVote struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InputKey       string                 `protobuf:"bytes,3,opt,name=input_key,json=inputKey,proto3" json:"input_key,omitempty"` // e.g. "website:github.com"
+	Classification string                 `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
+	Agree          bool                   `protobuf:"varint,5,opt,name=agree,proto3" json:"agree,omitempty"`
+	PromptVersion  string                 `protobuf:"bytes,6,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassificationVote) Reset() {
+	*x = ClassificationVote{}
+	mi := &file_common_v1_common_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassificationVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassificationVote) ProtoMessage() {}
+
+func (x *ClassificationVote) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassificationVote.ProtoReflect.Descriptor instead.
+func (*ClassificationVote) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClassificationVote) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ClassificationVote) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ClassificationVote) GetInputKey() string {
+	if x != nil {
+		return x.InputKey
+	}
+	return ""
+}
+
+func (x *ClassificationVote) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *ClassificationVote) GetAgree() bool {
+	if x != nil {
+		return x.Agree
+	}
+	return false
+}
+
+func (x *ClassificationVote) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *ClassificationVote) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ClassificationVote) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ClassificationOverride pins the classification of an application or domain
+// for one user. It is served instead of asking the model.
+type ClassificationOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind           string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`     // "application" or "domain"
+	Target         string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"` // lowercase bundle id or app name, or a domain
+	Classification string                 `protobuf:"bytes,5,opt,name=classification,proto3" json:"classification,omitempty"`
+	Tags           string                 `protobuf:"bytes,6,opt,name=tags,proto3" json:"tags,omitempty"` // comma-separated
+	CreatedAt      int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClassificationOverride) Reset() {
+	*x = ClassificationOverride{}
+	mi := &file_common_v1_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassificationOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassificationOverride) ProtoMessage() {}
+
+func (x *ClassificationOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassificationOverride.ProtoReflect.Descriptor instead.
+func (*ClassificationOverride) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClassificationOverride) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ClassificationOverride) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ClassificationOverride) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ClassificationOverride) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ClassificationOverride) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *ClassificationOverride) GetTags() string {
+	if x != nil {
+		return x.Tags
+	}
+	return ""
+}
+
+func (x *ClassificationOverride) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ClassificationOverride) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// GlobalClassificationOverride pins the classification of an application or
+// domain for every user, e.g. a company policy. A user's own override still wins.
+type GlobalClassificationOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind           string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`     // "application" or "domain"
+	Target         string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"` // lowercase bundle id or app name, or a domain
+	Classification string                 `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
+	Tags           string                 `protobuf:"bytes,5,opt,name=tags,proto3" json:"tags,omitempty"` // comma-separated
+	Note           string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"` // why the override exists, for operators
+	CreatedAt      int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GlobalClassificationOverride) Reset() {
+	*x = GlobalClassificationOverride{}
+	mi := &file_common_v1_common_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalClassificationOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalClassificationOverride) ProtoMessage() {}
+
+func (x *GlobalClassificationOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalClassificationOverride.ProtoReflect.Descriptor instead.
+func (*GlobalClassificationOverride) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GlobalClassificationOverride) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GlobalClassificationOverride) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GlobalClassificationOverride) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GlobalClassificationOverride) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *GlobalClassificationOverride) GetTags() string {
+	if x != nil {
+		return x.Tags
+	}
+	return ""
+}
+
+func (x *GlobalClassificationOverride) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *GlobalClassificationOverride) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *GlobalClassificationOverride) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// FocusSession is a span of time a user explicitly set aside for focused work
+type FocusSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       int64                  `protobuf:"varint,4,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"` // 0 while the session is open
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Nonce) Reset() {
-	*x = Nonce{}
-	mi := &file_common_v1_common_proto_msgTypes[1]
+func (x *FocusSession) Reset() {
+	*x = FocusSession{}
+	mi := &file_common_v1_common_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Nonce) String() string {
+func (x *FocusSession) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Nonce) ProtoMessage() {}
+func (*FocusSession) ProtoMessage() {}
 
-func (x *Nonce) ProtoReflect() protoreflect.Message {
-	mi := &file_common_v1_common_proto_msgTypes[1]
+func (x *FocusSession) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,58 +821,152 @@ func (x *Nonce) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Nonce.ProtoReflect.Descriptor instead.
-func (*Nonce) Descriptor() ([]byte, []int) {
-	return file_common_v1_common_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use FocusSession.ProtoReflect.Descriptor instead.
+func (*FocusSession) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Nonce) GetNonce() string {
+func (x *FocusSession) GetId() int64 {
 	if x != nil {
-		return x.Nonce
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FocusSession) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FocusSession) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *FocusSession) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+// FocusSessionEvent is a classification made during a focus session
+type FocusSessionEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId      int64                  `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Kind           string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // "application", "website", "document", "email" or "command"
+	Classification string                 `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	OutcomeHash    string                 `protobuf:"bytes,6,opt,name=outcome_hash,json=outcomeHash,proto3" json:"outcome_hash,omitempty"` // see ClassificationResult.outcome_hash
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FocusSessionEvent) Reset() {
+	*x = FocusSessionEvent{}
+	mi := &file_common_v1_common_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FocusSessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocusSessionEvent) ProtoMessage() {}
+
+func (x *FocusSessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocusSessionEvent.ProtoReflect.Descriptor instead.
+func (*FocusSessionEvent) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FocusSessionEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FocusSessionEvent) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *FocusSessionEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *Nonce) GetCreatedAt() int64 {
+func (x *FocusSessionEvent) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *FocusSessionEvent) GetCreatedAt() int64 {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *Nonce) GetExpiresAt() int64 {
+func (x *FocusSessionEvent) GetOutcomeHash() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.OutcomeHash
 	}
-	return 0
+	return ""
 }
 
-// PromptHistory caches AI prompt/response pairs for reuse
-type PromptHistory struct {
+// PromptAddendum holds rules appended to the classification prompts, either
+// a user's own or the organization's global ones
+type PromptAddendum struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PromptHash    string                 `protobuf:"bytes,1,opt,name=prompt_hash,json=promptHash,proto3" json:"prompt_hash,omitempty"`
-	ResponseJson  string                 `protobuf:"bytes,2,opt,name=response_json,json=responseJson,proto3" json:"response_json,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`                  // "user" or "global"
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 0 for the global scope
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptHistory) Reset() {
-	*x = PromptHistory{}
-	mi := &file_common_v1_common_proto_msgTypes[2]
+func (x *PromptAddendum) Reset() {
+	*x = PromptAddendum{}
+	mi := &file_common_v1_common_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptHistory) String() string {
+func (x *PromptAddendum) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptHistory) ProtoMessage() {}
+func (*PromptAddendum) ProtoMessage() {}
 
-func (x *PromptHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_common_v1_common_proto_msgTypes[2]
+func (x *PromptAddendum) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,39 +977,148 @@ func (x *PromptHistory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptHistory.ProtoReflect.Descriptor instead.
-func (*PromptHistory) Descriptor() ([]byte, []int) {
-	return file_common_v1_common_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use PromptAddendum.ProtoReflect.Descriptor instead.
+func (*PromptAddendum) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PromptHistory) GetPromptHash() string {
+func (x *PromptAddendum) GetId() int64 {
 	if x != nil {
-		return x.PromptHash
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PromptAddendum) GetScope() string {
+	if x != nil {
+		return x.Scope
 	}
 	return ""
 }
 
-func (x *PromptHistory) GetResponseJson() string {
+func (x *PromptAddendum) GetUserId() int64 {
 	if x != nil {
-		return x.ResponseJson
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PromptAddendum) GetText() string {
+	if x != nil {
+		return x.Text
 	}
 	return ""
 }
 
-func (x *PromptHistory) GetCreatedAt() int64 {
+func (x *PromptAddendum) GetUpdatedAt() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.UpdatedAt
 	}
 	return 0
 }
 
-func (x *PromptHistory) GetExpiresAt() int64 {
+// DetectedProject is a project a user's classifications named, one row per
+// canonical name
+type DetectedProject struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId              int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CanonicalName       string                 `protobuf:"bytes,3,opt,name=canonical_name,json=canonicalName,proto3" json:"canonical_name,omitempty"` // e.g. "focusd-backend", dedupes spelling variants
+	Name                string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                                        // name as last detected, e.g. "Focusd Backend"
+	FirstSeenAt         int64                  `protobuf:"varint,5,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	LastSeenAt          int64                  `protobuf:"varint,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	ClassificationCount int64                  `protobuf:"varint,7,opt,name=classification_count,json=classificationCount,proto3" json:"classification_count,omitempty"`
+	LastKind            string                 `protobuf:"bytes,8,opt,name=last_kind,json=lastKind,proto3" json:"last_kind,omitempty"` // kind of the last classification naming it, e.g. "application"
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DetectedProject) Reset() {
+	*x = DetectedProject{}
+	mi := &file_common_v1_common_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectedProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectedProject) ProtoMessage() {}
+
+func (x *DetectedProject) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v1_common_proto_msgTypes[11]
 	if x != nil {
-		return x.ExpiresAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectedProject.ProtoReflect.Descriptor instead.
+func (*DetectedProject) Descriptor() ([]byte, []int) {
+	return file_common_v1_common_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DetectedProject) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetCanonicalName() string {
+	if x != nil {
+		return x.CanonicalName
+	}
+	return ""
+}
+
+func (x *DetectedProject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DetectedProject) GetFirstSeenAt() int64 {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetLastSeenAt() int64 {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+func (x *DetectedProject) GetClassificationCount() int64 {
+	if x != nil {
+		return x.ClassificationCount
 	}
 	return 0
 }
 
+func (x *DetectedProject) GetLastKind() string {
+	if x != nil {
+		return x.LastKind
+	}
+	return ""
+}
+
 type OAuth2Token struct {
 	state        protoimpl.MessageState `protogen:"open.v1"`
 	AccessToken  string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
@@ -241,7 +1133,7 @@ type OAuth2Token struct {
 
 func (x *OAuth2Token) Reset() {
 	*x = OAuth2Token{}
-	mi := &file_common_v1_common_proto_msgTypes[3]
+	mi := &file_common_v1_common_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +1145,7 @@ func (x *OAuth2Token) String() string {
 func (*OAuth2Token) ProtoMessage() {}
 
 func (x *OAuth2Token) ProtoReflect() protoreflect.Message {
-	mi := &file_common_v1_common_proto_msgTypes[3]
+	mi := &file_common_v1_common_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +1158,7 @@ func (x *OAuth2Token) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OAuth2Token.ProtoReflect.Descriptor instead.
 func (*OAuth2Token) Descriptor() ([]byte, []int) {
-	return file_common_v1_common_proto_rawDescGZIP(), []int{3}
+	return file_common_v1_common_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *OAuth2Token) GetAccessToken() string {
@@ -308,7 +1200,7 @@ var File_common_v1_common_proto protoreflect.FileDescriptor
 
 const file_common_v1_common_proto_rawDesc = "" +
 	"\n" +
-	"\x16common/v1/common.proto\x12\x06common\x1a\x12options/gorm.proto\"\xd7\x01\n" +
+	"\x16common/v1/common.proto\x12\x06common\x1a\x12options/gorm.proto\"\x8e\x02\n" +
 	"\x04User\x12\x1a\n" +
 	"\x02id\x18\x01 \x01(\x03B\n" +
 	"\xba\xb9\x19\x06\n" +
@@ -320,7 +1212,8 @@ const file_common_v1_common_proto_rawDesc = "" +
 	"\aos_info\x18\x04 \x01(\tR\x06osInfo\x12'\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\x03B\b\xba\xb9\x19\x04\n" +
-	"\x02@\x01R\tcreatedAt:\x06\xba\xb9\x19\x02\b\x01\"\x81\x01\n" +
+	"\x02@\x01R\tcreatedAt\x125\n" +
+	"\x16classification_webhook\x18\x06 \x01(\bR\x15classificationWebhook:\x06\xba\xb9\x19\x02\b\x01\"\x81\x01\n" +
 	"\x05Nonce\x12\x1e\n" +
 	"\x05nonce\x18\x01 \x01(\tB\b\xba\xb9\x19\x04\n" +
 	"\x020\x01R\x05nonce\x12'\n" +
@@ -329,7 +1222,25 @@ const file_common_v1_common_proto_rawDesc = "" +
 	"\x02@\x01R\tcreatedAt\x12'\n" +
 	"\n" +
 	"expires_at\x18\x03 \x01(\x03B\b\xba\xb9\x19\x04\n" +
-	"\x02@\x01R\texpiresAt:\x06\xba\xb9\x19\x02\b\x01\"\xc9\x01\n" +
+	"\x02@\x01R\texpiresAt:\x06\xba\xb9\x19\x02\b\x01\"\xc4\x02\n" +
+	"\aSession\x12\x1a\n" +
+	"\x03jti\x18\x01 \x01(\tB\b\xba\xb9\x19\x04\n" +
+	"\x02(\x01R\x03jti\x123\n" +
+	"\auser_id\x18\x02 \x01(\x03B\x1a\xba\xb9\x19\x16\n" +
+	"\x14@\x01R\x10idx_session_userR\x06userId\x12-\n" +
+	"\x12device_fingerprint\x18\x03 \x01(\tR\x11deviceFingerprint\x12\x1f\n" +
+	"\vos_platform\x18\x04 \x01(\tR\n" +
+	"osPlatform\x12\x1f\n" +
+	"\vapp_version\x18\x05 \x01(\tR\n" +
+	"appVersion\x12'\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12'\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\x03R\trevokedAt:\x06\xba\xb9\x19\x02\b\x01\"\x88\x04\n" +
 	"\rPromptHistory\x12)\n" +
 	"\vprompt_hash\x18\x01 \x01(\tB\b\xba\xb9\x19\x04\n" +
 	"\x02(\x01R\n" +
@@ -342,7 +1253,146 @@ const file_common_v1_common_proto_rawDesc = "" +
 	"\x02@\x01R\tcreatedAt\x12'\n" +
 	"\n" +
 	"expires_at\x18\x04 \x01(\x03B\b\xba\xb9\x19\x04\n" +
-	"\x02@\x01R\texpiresAt:\x06\xba\xb9\x19\x02\b\x01\"\x85\x02\n" +
+	"\x02@\x01R\texpiresAt\x12M\n" +
+	"\rlast_accessed\x18\x05 \x01(\x03B(\xba\xb9\x19$\n" +
+	"\"R idx_prompt_history_last_accessedR\flastAccessed\x12P\n" +
+	"\x0esimilarity_key\x18\x06 \x01(\tB)\xba\xb9\x19%\n" +
+	"#R!idx_prompt_history_similarity_keyR\rsimilarityKey\x12/\n" +
+	"\foriginal_url\x18\a \x01(\tB\f\xba\xb9\x19\b\n" +
+	"\x06\x12\x04TEXTR\voriginalUrl\x123\n" +
+	"\x0enormalized_url\x18\b \x01(\tB\f\xba\xb9\x19\b\n" +
+	"\x06\x12\x04TEXTR\rnormalizedUrl\x12\x12\n" +
+	"\x04kind\x18\t \x01(\tR\x04kind\x12\"\n" +
+	"\x05input\x18\n" +
+	" \x01(\tB\f\xba\xb9\x19\b\n" +
+	"\x06\x12\x04TEXTR\x05input:\x06\xba\xb9\x19\x02\b\x01\"\xce\x02\n" +
+	"\x0eLinkedProvider\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12D\n" +
+	"\auser_id\x18\x02 \x01(\x03B+\xba\xb9\x19'\n" +
+	"%@\x01Z!idx_linked_provider_user_providerR\x06userId\x12G\n" +
+	"\bprovider\x18\x03 \x01(\tB+\xba\xb9\x19'\n" +
+	"%@\x01Z!idx_linked_provider_user_providerR\bprovider\x12\x16\n" +
+	"\x06scopes\x18\x04 \x01(\tR\x06scopes\x12\x1f\n" +
+	"\vexpiry_unix\x18\x05 \x01(\x03R\n" +
+	"expiryUnix\x12'\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12'\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tupdatedAt:\x06\xba\xb9\x19\x02\b\x01\"\x81\x03\n" +
+	"\x12ClassificationVote\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12E\n" +
+	"\auser_id\x18\x02 \x01(\x03B,\xba\xb9\x19(\n" +
+	"&@\x01Z\"idx_classification_vote_user_inputR\x06userId\x12I\n" +
+	"\tinput_key\x18\x03 \x01(\tB,\xba\xb9\x19(\n" +
+	"&@\x01Z\"idx_classification_vote_user_inputR\binputKey\x12&\n" +
+	"\x0eclassification\x18\x04 \x01(\tR\x0eclassification\x12\x14\n" +
+	"\x05agree\x18\x05 \x01(\bR\x05agree\x12%\n" +
+	"\x0eprompt_version\x18\x06 \x01(\tR\rpromptVersion\x12'\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12'\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tupdatedAt:\x06\xba\xb9\x19\x02\b\x01\"\xb2\x03\n" +
+	"\x16ClassificationOverride\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12J\n" +
+	"\auser_id\x18\x02 \x01(\x03B1\xba\xb9\x19-\n" +
+	"+@\x01Z'idx_classification_override_user_targetR\x06userId\x12E\n" +
+	"\x04kind\x18\x03 \x01(\tB1\xba\xb9\x19-\n" +
+	"+@\x01Z'idx_classification_override_user_targetR\x04kind\x12I\n" +
+	"\x06target\x18\x04 \x01(\tB1\xba\xb9\x19-\n" +
+	"+@\x01Z'idx_classification_override_user_targetR\x06target\x120\n" +
+	"\x0eclassification\x18\x05 \x01(\tB\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\x0eclassification\x12\x12\n" +
+	"\x04tags\x18\x06 \x01(\tR\x04tags\x12'\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12'\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tupdatedAt:\x06\xba\xb9\x19\x02\b\x01\"\x84\x03\n" +
+	"\x1cGlobalClassificationOverride\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12G\n" +
+	"\x04kind\x18\x02 \x01(\tB3\xba\xb9\x19/\n" +
+	"-@\x01Z)idx_global_classification_override_targetR\x04kind\x12K\n" +
+	"\x06target\x18\x03 \x01(\tB3\xba\xb9\x19/\n" +
+	"-@\x01Z)idx_global_classification_override_targetR\x06target\x120\n" +
+	"\x0eclassification\x18\x04 \x01(\tB\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\x0eclassification\x12\x12\n" +
+	"\x04tags\x18\x05 \x01(\tR\x04tags\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12'\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12'\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tupdatedAt:\x06\xba\xb9\x19\x02\b\x01\"\xb1\x01\n" +
+	"\fFocusSession\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x129\n" +
+	"\auser_id\x18\x02 \x01(\x03B \xba\xb9\x19\x1c\n" +
+	"\x1a@\x01R\x16idx_focus_session_userR\x06userId\x12'\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\x04 \x01(\x03R\aendedAt:\x06\xba\xb9\x19\x02\b\x01\"\x93\x02\n" +
+	"\x11FocusSessionEvent\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12H\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\x03B)\xba\xb9\x19%\n" +
+	"#@\x01R\x1fidx_focus_session_event_sessionR\tsessionId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x120\n" +
+	"\x0eclassification\x18\x04 \x01(\tB\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\x0eclassification\x12'\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tcreatedAt\x12!\n" +
+	"\foutcome_hash\x18\x06 \x01(\tR\voutcomeHash:\x06\xba\xb9\x19\x02\b\x01\"\x84\x02\n" +
+	"\x0ePromptAddendum\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12>\n" +
+	"\x05scope\x18\x02 \x01(\tB(\xba\xb9\x19$\n" +
+	"\"@\x01Z\x1eidx_prompt_addendum_scope_userR\x05scope\x12A\n" +
+	"\auser_id\x18\x03 \x01(\x03B(\xba\xb9\x19$\n" +
+	"\"@\x01Z\x1eidx_prompt_addendum_scope_userR\x06userId\x12\"\n" +
+	"\x04text\x18\x04 \x01(\tB\x0e\xba\xb9\x19\n" +
+	"\n" +
+	"\b\x12\x04TEXT@\x01R\x04text\x12'\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\tupdatedAt:\x06\xba\xb9\x19\x02\b\x01\"\x99\x03\n" +
+	"\x0fDetectedProject\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\x03B\n" +
+	"\xba\xb9\x19\x06\n" +
+	"\x04(\x01H\x01R\x02id\x12@\n" +
+	"\auser_id\x18\x02 \x01(\x03B'\xba\xb9\x19#\n" +
+	"!@\x01Z\x1didx_detected_project_user_keyR\x06userId\x12N\n" +
+	"\x0ecanonical_name\x18\x03 \x01(\tB'\xba\xb9\x19#\n" +
+	"!@\x01Z\x1didx_detected_project_user_keyR\rcanonicalName\x12\x1c\n" +
+	"\x04name\x18\x04 \x01(\tB\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\x04name\x12,\n" +
+	"\rfirst_seen_at\x18\x05 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\vfirstSeenAt\x12*\n" +
+	"\flast_seen_at\x18\x06 \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\n" +
+	"lastSeenAt\x12;\n" +
+	"\x14classification_count\x18\a \x01(\x03B\b\xba\xb9\x19\x04\n" +
+	"\x02@\x01R\x13classificationCount\x12\x1b\n" +
+	"\tlast_kind\x18\b \x01(\tR\blastKind:\x06\xba\xb9\x19\x02\b\x01\"\x85\x02\n" +
 	"\vOAuth2Token\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
 	"\n" +
@@ -368,21 +1418,30 @@ func file_common_v1_common_proto_rawDescGZIP() []byte {
 	return file_common_v1_common_proto_rawDescData
 }
 
-var file_common_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_common_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_common_v1_common_proto_goTypes = []any{
-	(*User)(nil),          // 0: common.User
-	(*Nonce)(nil),         // 1: common.Nonce
-	(*PromptHistory)(nil), // 2: common.PromptHistory
-	(*OAuth2Token)(nil),   // 3: common.OAuth2Token
-	nil,                   // 4: common.OAuth2Token.ExtraEntry
+	(*User)(nil),                         // 0: common.User
+	(*Nonce)(nil),                        // 1: common.Nonce
+	(*Session)(nil),                      // 2: common.Session
+	(*PromptHistory)(nil),                // 3: common.PromptHistory
+	(*LinkedProvider)(nil),               // 4: common.LinkedProvider
+	(*ClassificationVote)(nil),           // 5: common.ClassificationVote
+	(*ClassificationOverride)(nil),       // 6: common.ClassificationOverride
+	(*GlobalClassificationOverride)(nil), // 7: common.GlobalClassificationOverride
+	(*FocusSession)(nil),                 // 8: common.FocusSession
+	(*FocusSessionEvent)(nil),            // 9: common.FocusSessionEvent
+	(*PromptAddendum)(nil),               // 10: common.PromptAddendum
+	(*DetectedProject)(nil),              // 11: common.DetectedProject
+	(*OAuth2Token)(nil),                  // 12: common.OAuth2Token
+	nil,                                  // 13: common.OAuth2Token.ExtraEntry
 }
 var file_common_v1_common_proto_depIdxs = []int32{
-	4, // 0: common.OAuth2Token.extra:type_name -> common.OAuth2Token.ExtraEntry
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: common.OAuth2Token.extra:type_name -> common.OAuth2Token.ExtraEntry
+	1,  // [1:1] is the sub-list for method output_type
+	1,  // [1:1] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_common_v1_common_proto_init() }
@@ -396,7 +1455,7 @@ func file_common_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_v1_common_proto_rawDesc), len(file_common_v1_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,7 @@ import (
 )
 
 type UserORM struct {
+	ClassificationWebhook bool
 	CreatedAt             int64  `gorm:"not null"`
 	DeviceFingerprintHash string `gorm:"unique"`
 	Id                    int64  `gorm:"primaryKey;autoIncrement"`
@@ -36,6 +37,7 @@ func (m *User) ToORM(ctx context.Context) (UserORM, error) {
 	to.Role = m.Role
 	to.OsInfo = m.OsInfo
 	to.CreatedAt = m.CreatedAt
+	to.ClassificationWebhook = m.ClassificationWebhook
 	if posthook, ok := interface{}(m).(UserWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -57,6 +59,7 @@ func (m *UserORM) ToPB(ctx context.Context) (User, error) {
 	to.Role = m.Role
 	to.OsInfo = m.OsInfo
 	to.CreatedAt = m.CreatedAt
+	to.ClassificationWebhook = m.ClassificationWebhook
 	if posthook, ok := interface{}(m).(UserWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -158,11 +161,104 @@ type NonceWithAfterToPB interface {
 	AfterToPB(context.Context, *Nonce) error
 }
 
+type SessionORM struct {
+	AppVersion        string
+	CreatedAt         int64 `gorm:"not null"`
+	DeviceFingerprint string
+	ExpiresAt         int64  `gorm:"not null"`
+	Jti               string `gorm:"primaryKey"`
+	OsPlatform        string
+	RevokedAt         int64
+	UserId            int64 `gorm:"not null;index:idx_session_user"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (SessionORM) TableName() string {
+	return "sessions"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *Session) ToORM(ctx context.Context) (SessionORM, error) {
+	to := SessionORM{}
+	var err error
+	if prehook, ok := interface{}(m).(SessionWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Jti = m.Jti
+	to.UserId = m.UserId
+	to.DeviceFingerprint = m.DeviceFingerprint
+	to.OsPlatform = m.OsPlatform
+	to.AppVersion = m.AppVersion
+	to.CreatedAt = m.CreatedAt
+	to.ExpiresAt = m.ExpiresAt
+	to.RevokedAt = m.RevokedAt
+	if posthook, ok := interface{}(m).(SessionWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *SessionORM) ToPB(ctx context.Context) (Session, error) {
+	to := Session{}
+	var err error
+	if prehook, ok := interface{}(m).(SessionWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Jti = m.Jti
+	to.UserId = m.UserId
+	to.DeviceFingerprint = m.DeviceFingerprint
+	to.OsPlatform = m.OsPlatform
+	to.AppVersion = m.AppVersion
+	to.CreatedAt = m.CreatedAt
+	to.ExpiresAt = m.ExpiresAt
+	to.RevokedAt = m.RevokedAt
+	if posthook, ok := interface{}(m).(SessionWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type Session the arg will be the target, the caller the one being converted from
+
+// SessionBeforeToORM called before default ToORM code
+type SessionWithBeforeToORM interface {
+	BeforeToORM(context.Context, *SessionORM) error
+}
+
+// SessionAfterToORM called after default ToORM code
+type SessionWithAfterToORM interface {
+	AfterToORM(context.Context, *SessionORM) error
+}
+
+// SessionBeforeToPB called before default ToPB code
+type SessionWithBeforeToPB interface {
+	BeforeToPB(context.Context, *Session) error
+}
+
+// SessionAfterToPB called after default ToPB code
+type SessionWithAfterToPB interface {
+	AfterToPB(context.Context, *Session) error
+}
+
 type PromptHistoryORM struct {
-	CreatedAt    int64  `gorm:"not null"`
-	ExpiresAt    int64  `gorm:"not null"`
-	PromptHash   string `gorm:"primaryKey"`
-	ResponseJson string `gorm:"type:TEXT;not null"`
+	CreatedAt     int64  `gorm:"not null"`
+	ExpiresAt     int64  `gorm:"not null"`
+	Input         string `gorm:"type:TEXT"`
+	Kind          string
+	LastAccessed  int64  `gorm:"index:idx_prompt_history_last_accessed"`
+	NormalizedUrl string `gorm:"type:TEXT"`
+	OriginalUrl   string `gorm:"type:TEXT"`
+	PromptHash    string `gorm:"primaryKey"`
+	ResponseJson  string `gorm:"type:TEXT;not null"`
+	SimilarityKey string `gorm:"index:idx_prompt_history_similarity_key"`
 }
 
 // TableName overrides the default tablename generated by GORM
@@ -184,57 +280,3734 @@ func (m *PromptHistory) ToORM(ctx context.Context) (PromptHistoryORM, error) {
 	to.ResponseJson = m.ResponseJson
 	to.CreatedAt = m.CreatedAt
 	to.ExpiresAt = m.ExpiresAt
+	to.LastAccessed = m.LastAccessed
+	to.SimilarityKey = m.SimilarityKey
+	to.OriginalUrl = m.OriginalUrl
+	to.NormalizedUrl = m.NormalizedUrl
+	to.Kind = m.Kind
+	to.Input = m.Input
 	if posthook, ok := interface{}(m).(PromptHistoryWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
-	return to, err
-}
-
-// ToPB runs the BeforeToPB hook if present, converts the fields of this
-// object to PB format, runs the AfterToPB hook, then returns the PB object
-func (m *PromptHistoryORM) ToPB(ctx context.Context) (PromptHistory, error) {
-	to := PromptHistory{}
-	var err error
-	if prehook, ok := interface{}(m).(PromptHistoryWithBeforeToPB); ok {
-		if err = prehook.BeforeToPB(ctx, &to); err != nil {
-			return to, err
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *PromptHistoryORM) ToPB(ctx context.Context) (PromptHistory, error) {
+	to := PromptHistory{}
+	var err error
+	if prehook, ok := interface{}(m).(PromptHistoryWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PromptHash = m.PromptHash
+	to.ResponseJson = m.ResponseJson
+	to.CreatedAt = m.CreatedAt
+	to.ExpiresAt = m.ExpiresAt
+	to.LastAccessed = m.LastAccessed
+	to.SimilarityKey = m.SimilarityKey
+	to.OriginalUrl = m.OriginalUrl
+	to.NormalizedUrl = m.NormalizedUrl
+	to.Kind = m.Kind
+	to.Input = m.Input
+	if posthook, ok := interface{}(m).(PromptHistoryWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type PromptHistory the arg will be the target, the caller the one being converted from
+
+// PromptHistoryBeforeToORM called before default ToORM code
+type PromptHistoryWithBeforeToORM interface {
+	BeforeToORM(context.Context, *PromptHistoryORM) error
+}
+
+// PromptHistoryAfterToORM called after default ToORM code
+type PromptHistoryWithAfterToORM interface {
+	AfterToORM(context.Context, *PromptHistoryORM) error
+}
+
+// PromptHistoryBeforeToPB called before default ToPB code
+type PromptHistoryWithBeforeToPB interface {
+	BeforeToPB(context.Context, *PromptHistory) error
+}
+
+// PromptHistoryAfterToPB called after default ToPB code
+type PromptHistoryWithAfterToPB interface {
+	AfterToPB(context.Context, *PromptHistory) error
+}
+
+type LinkedProviderORM struct {
+	CreatedAt  int64 `gorm:"not null"`
+	ExpiryUnix int64
+	Id         int64  `gorm:"primaryKey;autoIncrement"`
+	Provider   string `gorm:"not null;uniqueIndex:idx_linked_provider_user_provider"`
+	Scopes     string
+	UpdatedAt  int64 `gorm:"not null"`
+	UserId     int64 `gorm:"not null;uniqueIndex:idx_linked_provider_user_provider"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (LinkedProviderORM) TableName() string {
+	return "linked_providers"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *LinkedProvider) ToORM(ctx context.Context) (LinkedProviderORM, error) {
+	to := LinkedProviderORM{}
+	var err error
+	if prehook, ok := interface{}(m).(LinkedProviderWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.Provider = m.Provider
+	to.Scopes = m.Scopes
+	to.ExpiryUnix = m.ExpiryUnix
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(LinkedProviderWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *LinkedProviderORM) ToPB(ctx context.Context) (LinkedProvider, error) {
+	to := LinkedProvider{}
+	var err error
+	if prehook, ok := interface{}(m).(LinkedProviderWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.Provider = m.Provider
+	to.Scopes = m.Scopes
+	to.ExpiryUnix = m.ExpiryUnix
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(LinkedProviderWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type LinkedProvider the arg will be the target, the caller the one being converted from
+
+// LinkedProviderBeforeToORM called before default ToORM code
+type LinkedProviderWithBeforeToORM interface {
+	BeforeToORM(context.Context, *LinkedProviderORM) error
+}
+
+// LinkedProviderAfterToORM called after default ToORM code
+type LinkedProviderWithAfterToORM interface {
+	AfterToORM(context.Context, *LinkedProviderORM) error
+}
+
+// LinkedProviderBeforeToPB called before default ToPB code
+type LinkedProviderWithBeforeToPB interface {
+	BeforeToPB(context.Context, *LinkedProvider) error
+}
+
+// LinkedProviderAfterToPB called after default ToPB code
+type LinkedProviderWithAfterToPB interface {
+	AfterToPB(context.Context, *LinkedProvider) error
+}
+
+type ClassificationVoteORM struct {
+	Agree          bool
+	Classification string
+	CreatedAt      int64  `gorm:"not null"`
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	InputKey       string `gorm:"not null;uniqueIndex:idx_classification_vote_user_input"`
+	PromptVersion  string
+	UpdatedAt      int64 `gorm:"not null"`
+	UserId         int64 `gorm:"not null;uniqueIndex:idx_classification_vote_user_input"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (ClassificationVoteORM) TableName() string {
+	return "classification_votes"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *ClassificationVote) ToORM(ctx context.Context) (ClassificationVoteORM, error) {
+	to := ClassificationVoteORM{}
+	var err error
+	if prehook, ok := interface{}(m).(ClassificationVoteWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.InputKey = m.InputKey
+	to.Classification = m.Classification
+	to.Agree = m.Agree
+	to.PromptVersion = m.PromptVersion
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(ClassificationVoteWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *ClassificationVoteORM) ToPB(ctx context.Context) (ClassificationVote, error) {
+	to := ClassificationVote{}
+	var err error
+	if prehook, ok := interface{}(m).(ClassificationVoteWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.InputKey = m.InputKey
+	to.Classification = m.Classification
+	to.Agree = m.Agree
+	to.PromptVersion = m.PromptVersion
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(ClassificationVoteWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type ClassificationVote the arg will be the target, the caller the one being converted from
+
+// ClassificationVoteBeforeToORM called before default ToORM code
+type ClassificationVoteWithBeforeToORM interface {
+	BeforeToORM(context.Context, *ClassificationVoteORM) error
+}
+
+// ClassificationVoteAfterToORM called after default ToORM code
+type ClassificationVoteWithAfterToORM interface {
+	AfterToORM(context.Context, *ClassificationVoteORM) error
+}
+
+// ClassificationVoteBeforeToPB called before default ToPB code
+type ClassificationVoteWithBeforeToPB interface {
+	BeforeToPB(context.Context, *ClassificationVote) error
+}
+
+// ClassificationVoteAfterToPB called after default ToPB code
+type ClassificationVoteWithAfterToPB interface {
+	AfterToPB(context.Context, *ClassificationVote) error
+}
+
+type ClassificationOverrideORM struct {
+	Classification string `gorm:"not null"`
+	CreatedAt      int64  `gorm:"not null"`
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	Kind           string `gorm:"not null;uniqueIndex:idx_classification_override_user_target"`
+	Tags           string
+	Target         string `gorm:"not null;uniqueIndex:idx_classification_override_user_target"`
+	UpdatedAt      int64  `gorm:"not null"`
+	UserId         int64  `gorm:"not null;uniqueIndex:idx_classification_override_user_target"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (ClassificationOverrideORM) TableName() string {
+	return "classification_overrides"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *ClassificationOverride) ToORM(ctx context.Context) (ClassificationOverrideORM, error) {
+	to := ClassificationOverrideORM{}
+	var err error
+	if prehook, ok := interface{}(m).(ClassificationOverrideWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.Kind = m.Kind
+	to.Target = m.Target
+	to.Classification = m.Classification
+	to.Tags = m.Tags
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(ClassificationOverrideWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *ClassificationOverrideORM) ToPB(ctx context.Context) (ClassificationOverride, error) {
+	to := ClassificationOverride{}
+	var err error
+	if prehook, ok := interface{}(m).(ClassificationOverrideWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.Kind = m.Kind
+	to.Target = m.Target
+	to.Classification = m.Classification
+	to.Tags = m.Tags
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(ClassificationOverrideWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type ClassificationOverride the arg will be the target, the caller the one being converted from
+
+// ClassificationOverrideBeforeToORM called before default ToORM code
+type ClassificationOverrideWithBeforeToORM interface {
+	BeforeToORM(context.Context, *ClassificationOverrideORM) error
+}
+
+// ClassificationOverrideAfterToORM called after default ToORM code
+type ClassificationOverrideWithAfterToORM interface {
+	AfterToORM(context.Context, *ClassificationOverrideORM) error
+}
+
+// ClassificationOverrideBeforeToPB called before default ToPB code
+type ClassificationOverrideWithBeforeToPB interface {
+	BeforeToPB(context.Context, *ClassificationOverride) error
+}
+
+// ClassificationOverrideAfterToPB called after default ToPB code
+type ClassificationOverrideWithAfterToPB interface {
+	AfterToPB(context.Context, *ClassificationOverride) error
+}
+
+type GlobalClassificationOverrideORM struct {
+	Classification string `gorm:"not null"`
+	CreatedAt      int64  `gorm:"not null"`
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	Kind           string `gorm:"not null;uniqueIndex:idx_global_classification_override_target"`
+	Note           string
+	Tags           string
+	Target         string `gorm:"not null;uniqueIndex:idx_global_classification_override_target"`
+	UpdatedAt      int64  `gorm:"not null"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (GlobalClassificationOverrideORM) TableName() string {
+	return "global_classification_overrides"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *GlobalClassificationOverride) ToORM(ctx context.Context) (GlobalClassificationOverrideORM, error) {
+	to := GlobalClassificationOverrideORM{}
+	var err error
+	if prehook, ok := interface{}(m).(GlobalClassificationOverrideWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.Kind = m.Kind
+	to.Target = m.Target
+	to.Classification = m.Classification
+	to.Tags = m.Tags
+	to.Note = m.Note
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(GlobalClassificationOverrideWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *GlobalClassificationOverrideORM) ToPB(ctx context.Context) (GlobalClassificationOverride, error) {
+	to := GlobalClassificationOverride{}
+	var err error
+	if prehook, ok := interface{}(m).(GlobalClassificationOverrideWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.Kind = m.Kind
+	to.Target = m.Target
+	to.Classification = m.Classification
+	to.Tags = m.Tags
+	to.Note = m.Note
+	to.CreatedAt = m.CreatedAt
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(GlobalClassificationOverrideWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type GlobalClassificationOverride the arg will be the target, the caller the one being converted from
+
+// GlobalClassificationOverrideBeforeToORM called before default ToORM code
+type GlobalClassificationOverrideWithBeforeToORM interface {
+	BeforeToORM(context.Context, *GlobalClassificationOverrideORM) error
+}
+
+// GlobalClassificationOverrideAfterToORM called after default ToORM code
+type GlobalClassificationOverrideWithAfterToORM interface {
+	AfterToORM(context.Context, *GlobalClassificationOverrideORM) error
+}
+
+// GlobalClassificationOverrideBeforeToPB called before default ToPB code
+type GlobalClassificationOverrideWithBeforeToPB interface {
+	BeforeToPB(context.Context, *GlobalClassificationOverride) error
+}
+
+// GlobalClassificationOverrideAfterToPB called after default ToPB code
+type GlobalClassificationOverrideWithAfterToPB interface {
+	AfterToPB(context.Context, *GlobalClassificationOverride) error
+}
+
+type FocusSessionORM struct {
+	EndedAt   int64
+	Id        int64 `gorm:"primaryKey;autoIncrement"`
+	StartedAt int64 `gorm:"not null"`
+	UserId    int64 `gorm:"not null;index:idx_focus_session_user"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (FocusSessionORM) TableName() string {
+	return "focus_sessions"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *FocusSession) ToORM(ctx context.Context) (FocusSessionORM, error) {
+	to := FocusSessionORM{}
+	var err error
+	if prehook, ok := interface{}(m).(FocusSessionWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.StartedAt = m.StartedAt
+	to.EndedAt = m.EndedAt
+	if posthook, ok := interface{}(m).(FocusSessionWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *FocusSessionORM) ToPB(ctx context.Context) (FocusSession, error) {
+	to := FocusSession{}
+	var err error
+	if prehook, ok := interface{}(m).(FocusSessionWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.StartedAt = m.StartedAt
+	to.EndedAt = m.EndedAt
+	if posthook, ok := interface{}(m).(FocusSessionWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type FocusSession the arg will be the target, the caller the one being converted from
+
+// FocusSessionBeforeToORM called before default ToORM code
+type FocusSessionWithBeforeToORM interface {
+	BeforeToORM(context.Context, *FocusSessionORM) error
+}
+
+// FocusSessionAfterToORM called after default ToORM code
+type FocusSessionWithAfterToORM interface {
+	AfterToORM(context.Context, *FocusSessionORM) error
+}
+
+// FocusSessionBeforeToPB called before default ToPB code
+type FocusSessionWithBeforeToPB interface {
+	BeforeToPB(context.Context, *FocusSession) error
+}
+
+// FocusSessionAfterToPB called after default ToPB code
+type FocusSessionWithAfterToPB interface {
+	AfterToPB(context.Context, *FocusSession) error
+}
+
+type FocusSessionEventORM struct {
+	Classification string `gorm:"not null"`
+	CreatedAt      int64  `gorm:"not null"`
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	Kind           string
+	OutcomeHash    string
+	SessionId      int64 `gorm:"not null;index:idx_focus_session_event_session"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (FocusSessionEventORM) TableName() string {
+	return "focus_session_events"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *FocusSessionEvent) ToORM(ctx context.Context) (FocusSessionEventORM, error) {
+	to := FocusSessionEventORM{}
+	var err error
+	if prehook, ok := interface{}(m).(FocusSessionEventWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.SessionId = m.SessionId
+	to.Kind = m.Kind
+	to.Classification = m.Classification
+	to.CreatedAt = m.CreatedAt
+	to.OutcomeHash = m.OutcomeHash
+	if posthook, ok := interface{}(m).(FocusSessionEventWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *FocusSessionEventORM) ToPB(ctx context.Context) (FocusSessionEvent, error) {
+	to := FocusSessionEvent{}
+	var err error
+	if prehook, ok := interface{}(m).(FocusSessionEventWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.SessionId = m.SessionId
+	to.Kind = m.Kind
+	to.Classification = m.Classification
+	to.CreatedAt = m.CreatedAt
+	to.OutcomeHash = m.OutcomeHash
+	if posthook, ok := interface{}(m).(FocusSessionEventWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type FocusSessionEvent the arg will be the target, the caller the one being converted from
+
+// FocusSessionEventBeforeToORM called before default ToORM code
+type FocusSessionEventWithBeforeToORM interface {
+	BeforeToORM(context.Context, *FocusSessionEventORM) error
+}
+
+// FocusSessionEventAfterToORM called after default ToORM code
+type FocusSessionEventWithAfterToORM interface {
+	AfterToORM(context.Context, *FocusSessionEventORM) error
+}
+
+// FocusSessionEventBeforeToPB called before default ToPB code
+type FocusSessionEventWithBeforeToPB interface {
+	BeforeToPB(context.Context, *FocusSessionEvent) error
+}
+
+// FocusSessionEventAfterToPB called after default ToPB code
+type FocusSessionEventWithAfterToPB interface {
+	AfterToPB(context.Context, *FocusSessionEvent) error
+}
+
+type PromptAddendumORM struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement"`
+	Scope     string `gorm:"not null;uniqueIndex:idx_prompt_addendum_scope_user"`
+	Text      string `gorm:"type:TEXT;not null"`
+	UpdatedAt int64  `gorm:"not null"`
+	UserId    int64  `gorm:"not null;uniqueIndex:idx_prompt_addendum_scope_user"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (PromptAddendumORM) TableName() string {
+	return "prompt_addendums"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *PromptAddendum) ToORM(ctx context.Context) (PromptAddendumORM, error) {
+	to := PromptAddendumORM{}
+	var err error
+	if prehook, ok := interface{}(m).(PromptAddendumWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.Scope = m.Scope
+	to.UserId = m.UserId
+	to.Text = m.Text
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(PromptAddendumWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *PromptAddendumORM) ToPB(ctx context.Context) (PromptAddendum, error) {
+	to := PromptAddendum{}
+	var err error
+	if prehook, ok := interface{}(m).(PromptAddendumWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.Scope = m.Scope
+	to.UserId = m.UserId
+	to.Text = m.Text
+	to.UpdatedAt = m.UpdatedAt
+	if posthook, ok := interface{}(m).(PromptAddendumWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type PromptAddendum the arg will be the target, the caller the one being converted from
+
+// PromptAddendumBeforeToORM called before default ToORM code
+type PromptAddendumWithBeforeToORM interface {
+	BeforeToORM(context.Context, *PromptAddendumORM) error
+}
+
+// PromptAddendumAfterToORM called after default ToORM code
+type PromptAddendumWithAfterToORM interface {
+	AfterToORM(context.Context, *PromptAddendumORM) error
+}
+
+// PromptAddendumBeforeToPB called before default ToPB code
+type PromptAddendumWithBeforeToPB interface {
+	BeforeToPB(context.Context, *PromptAddendum) error
+}
+
+// PromptAddendumAfterToPB called after default ToPB code
+type PromptAddendumWithAfterToPB interface {
+	AfterToPB(context.Context, *PromptAddendum) error
+}
+
+type DetectedProjectORM struct {
+	CanonicalName       string `gorm:"not null;uniqueIndex:idx_detected_project_user_key"`
+	ClassificationCount int64  `gorm:"not null"`
+	FirstSeenAt         int64  `gorm:"not null"`
+	Id                  int64  `gorm:"primaryKey;autoIncrement"`
+	LastKind            string
+	LastSeenAt          int64  `gorm:"not null"`
+	Name                string `gorm:"not null"`
+	UserId              int64  `gorm:"not null;uniqueIndex:idx_detected_project_user_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (DetectedProjectORM) TableName() string {
+	return "detected_projects"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *DetectedProject) ToORM(ctx context.Context) (DetectedProjectORM, error) {
+	to := DetectedProjectORM{}
+	var err error
+	if prehook, ok := interface{}(m).(DetectedProjectWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.CanonicalName = m.CanonicalName
+	to.Name = m.Name
+	to.FirstSeenAt = m.FirstSeenAt
+	to.LastSeenAt = m.LastSeenAt
+	to.ClassificationCount = m.ClassificationCount
+	to.LastKind = m.LastKind
+	if posthook, ok := interface{}(m).(DetectedProjectWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *DetectedProjectORM) ToPB(ctx context.Context) (DetectedProject, error) {
+	to := DetectedProject{}
+	var err error
+	if prehook, ok := interface{}(m).(DetectedProjectWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Id = m.Id
+	to.UserId = m.UserId
+	to.CanonicalName = m.CanonicalName
+	to.Name = m.Name
+	to.FirstSeenAt = m.FirstSeenAt
+	to.LastSeenAt = m.LastSeenAt
+	to.ClassificationCount = m.ClassificationCount
+	to.LastKind = m.LastKind
+	if posthook, ok := interface{}(m).(DetectedProjectWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type DetectedProject the arg will be the target, the caller the one being converted from
+
+// DetectedProjectBeforeToORM called before default ToORM code
+type DetectedProjectWithBeforeToORM interface {
+	BeforeToORM(context.Context, *DetectedProjectORM) error
+}
+
+// DetectedProjectAfterToORM called after default ToORM code
+type DetectedProjectWithAfterToORM interface {
+	AfterToORM(context.Context, *DetectedProjectORM) error
+}
+
+// DetectedProjectBeforeToPB called before default ToPB code
+type DetectedProjectWithBeforeToPB interface {
+	BeforeToPB(context.Context, *DetectedProject) error
+}
+
+// DetectedProjectAfterToPB called after default ToPB code
+type DetectedProjectWithAfterToPB interface {
+	AfterToPB(context.Context, *DetectedProject) error
+}
+
+// DefaultCreateUser executes a basic gorm create call
+func DefaultCreateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type UserORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := UserORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(UserORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type UserORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteUser(ctx context.Context, in *User, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&UserORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type UserORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteUserSet(ctx context.Context, in []*User, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&UserORM{})).(UserORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&UserORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&UserORM{})).(UserORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type UserORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*User, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*User, *gorm.DB) error
+}
+
+// DefaultStrictUpdateUser clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateUser")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &UserORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type UserORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchUser executes a basic gorm update call with patch behavior
+func DefaultPatchUser(ctx context.Context, in *User, updateMask *field_mask.FieldMask, db *gorm.DB) (*User, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj User
+	var err error
+	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadUser(ctx, &User{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskUser(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateUser(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(UserWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type UserWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type UserWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type UserWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type UserWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *User, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetUser executes a bulk gorm update call with patch behavior
+func DefaultPatchSetUser(ctx context.Context, objects []*User, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*User, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*User, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchUser(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskUser patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskUser(ctx context.Context, patchee *User, patcher *User, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*User, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"DeviceFingerprintHash" {
+			patchee.DeviceFingerprintHash = patcher.DeviceFingerprintHash
+			continue
+		}
+		if f == prefix+"Role" {
+			patchee.Role = patcher.Role
+			continue
+		}
+		if f == prefix+"OsInfo" {
+			patchee.OsInfo = patcher.OsInfo
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"ClassificationWebhook" {
+			patchee.ClassificationWebhook = patcher.ClassificationWebhook
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListUser executes a gorm list call
+func DefaultListUser(ctx context.Context, db *gorm.DB) ([]*User, error) {
+	in := User{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []UserORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(UserORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*User{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type UserORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type UserORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]UserORM) error
+}
+
+// DefaultCreateNonce executes a basic gorm create call
+func DefaultCreateNonce(ctx context.Context, in *Nonce, db *gorm.DB) (*Nonce, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(NonceORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type NonceORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type NonceORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+// DefaultApplyFieldMaskNonce patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskNonce(ctx context.Context, patchee *Nonce, patcher *Nonce, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*Nonce, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Nonce" {
+			patchee.Nonce = patcher.Nonce
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"ExpiresAt" {
+			patchee.ExpiresAt = patcher.ExpiresAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListNonce executes a gorm list call
+func DefaultListNonce(ctx context.Context, db *gorm.DB) ([]*Nonce, error) {
+	in := Nonce{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	ormResponse := []NonceORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(NonceORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*Nonce{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type NonceORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type NonceORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type NonceORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]NonceORM) error
+}
+
+// DefaultCreateSession executes a basic gorm create call
+func DefaultCreateSession(ctx context.Context, in *Session, db *gorm.DB) (*Session, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type SessionORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadSession(ctx context.Context, in *Session, db *gorm.DB) (*Session, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Jti == "" {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := SessionORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(SessionORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type SessionORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteSession(ctx context.Context, in *Session, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Jti == "" {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&SessionORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type SessionORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteSessionSet(ctx context.Context, in []*Session, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []string{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Jti == "" {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Jti)
+	}
+	if hook, ok := (interface{}(&SessionORM{})).(SessionORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("jti in (?)", keys).Delete(&SessionORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&SessionORM{})).(SessionORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type SessionORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*Session, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*Session, *gorm.DB) error
+}
+
+// DefaultStrictUpdateSession clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateSession(ctx context.Context, in *Session, db *gorm.DB) (*Session, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateSession")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &SessionORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("jti=?", ormObj.Jti).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type SessionORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchSession executes a basic gorm update call with patch behavior
+func DefaultPatchSession(ctx context.Context, in *Session, updateMask *field_mask.FieldMask, db *gorm.DB) (*Session, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj Session
+	var err error
+	if hook, ok := interface{}(&pbObj).(SessionWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&pbObj).(SessionWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskSession(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(SessionWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateSession(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(SessionWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type SessionWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *Session, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type SessionWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *Session, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type SessionWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *Session, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type SessionWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *Session, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetSession executes a bulk gorm update call with patch behavior
+func DefaultPatchSetSession(ctx context.Context, objects []*Session, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*Session, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*Session, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchSession(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskSession patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskSession(ctx context.Context, patchee *Session, patcher *Session, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*Session, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Jti" {
+			patchee.Jti = patcher.Jti
+			continue
+		}
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"DeviceFingerprint" {
+			patchee.DeviceFingerprint = patcher.DeviceFingerprint
+			continue
+		}
+		if f == prefix+"OsPlatform" {
+			patchee.OsPlatform = patcher.OsPlatform
+			continue
+		}
+		if f == prefix+"AppVersion" {
+			patchee.AppVersion = patcher.AppVersion
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"ExpiresAt" {
+			patchee.ExpiresAt = patcher.ExpiresAt
+			continue
+		}
+		if f == prefix+"RevokedAt" {
+			patchee.RevokedAt = patcher.RevokedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListSession executes a gorm list call
+func DefaultListSession(ctx context.Context, db *gorm.DB) ([]*Session, error) {
+	in := Session{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("jti")
+	ormResponse := []SessionORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(SessionORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*Session{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type SessionORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type SessionORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]SessionORM) error
+}
+
+// DefaultCreatePromptHistory executes a basic gorm create call
+func DefaultCreatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type PromptHistoryORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadPromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.PromptHash == "" {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := PromptHistoryORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(PromptHistoryORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type PromptHistoryORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeletePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.PromptHash == "" {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&PromptHistoryORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type PromptHistoryORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeletePromptHistorySet(ctx context.Context, in []*PromptHistory, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []string{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.PromptHash == "" {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.PromptHash)
+	}
+	if hook, ok := (interface{}(&PromptHistoryORM{})).(PromptHistoryORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("prompt_hash in (?)", keys).Delete(&PromptHistoryORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&PromptHistoryORM{})).(PromptHistoryORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type PromptHistoryORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*PromptHistory, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*PromptHistory, *gorm.DB) error
+}
+
+// DefaultStrictUpdatePromptHistory clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdatePromptHistory")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &PromptHistoryORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("prompt_hash=?", ormObj.PromptHash).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type PromptHistoryORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchPromptHistory executes a basic gorm update call with patch behavior
+func DefaultPatchPromptHistory(ctx context.Context, in *PromptHistory, updateMask *field_mask.FieldMask, db *gorm.DB) (*PromptHistory, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj PromptHistory
+	var err error
+	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskPromptHistory(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdatePromptHistory(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(PromptHistoryWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type PromptHistoryWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetPromptHistory executes a bulk gorm update call with patch behavior
+func DefaultPatchSetPromptHistory(ctx context.Context, objects []*PromptHistory, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*PromptHistory, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*PromptHistory, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchPromptHistory(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskPromptHistory patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskPromptHistory(ctx context.Context, patchee *PromptHistory, patcher *PromptHistory, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*PromptHistory, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"PromptHash" {
+			patchee.PromptHash = patcher.PromptHash
+			continue
+		}
+		if f == prefix+"ResponseJson" {
+			patchee.ResponseJson = patcher.ResponseJson
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"ExpiresAt" {
+			patchee.ExpiresAt = patcher.ExpiresAt
+			continue
+		}
+		if f == prefix+"LastAccessed" {
+			patchee.LastAccessed = patcher.LastAccessed
+			continue
+		}
+		if f == prefix+"SimilarityKey" {
+			patchee.SimilarityKey = patcher.SimilarityKey
+			continue
+		}
+		if f == prefix+"OriginalUrl" {
+			patchee.OriginalUrl = patcher.OriginalUrl
+			continue
+		}
+		if f == prefix+"NormalizedUrl" {
+			patchee.NormalizedUrl = patcher.NormalizedUrl
+			continue
+		}
+		if f == prefix+"Kind" {
+			patchee.Kind = patcher.Kind
+			continue
+		}
+		if f == prefix+"Input" {
+			patchee.Input = patcher.Input
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListPromptHistory executes a gorm list call
+func DefaultListPromptHistory(ctx context.Context, db *gorm.DB) ([]*PromptHistory, error) {
+	in := PromptHistory{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("prompt_hash")
+	ormResponse := []PromptHistoryORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*PromptHistory{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type PromptHistoryORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptHistoryORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]PromptHistoryORM) error
+}
+
+// DefaultCreateLinkedProvider executes a basic gorm create call
+func DefaultCreateLinkedProvider(ctx context.Context, in *LinkedProvider, db *gorm.DB) (*LinkedProvider, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type LinkedProviderORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadLinkedProvider(ctx context.Context, in *LinkedProvider, db *gorm.DB) (*LinkedProvider, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := LinkedProviderORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(LinkedProviderORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type LinkedProviderORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteLinkedProvider(ctx context.Context, in *LinkedProvider, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&LinkedProviderORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type LinkedProviderORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteLinkedProviderSet(ctx context.Context, in []*LinkedProvider, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&LinkedProviderORM{})).(LinkedProviderORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&LinkedProviderORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&LinkedProviderORM{})).(LinkedProviderORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type LinkedProviderORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*LinkedProvider, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*LinkedProvider, *gorm.DB) error
+}
+
+// DefaultStrictUpdateLinkedProvider clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateLinkedProvider(ctx context.Context, in *LinkedProvider, db *gorm.DB) (*LinkedProvider, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateLinkedProvider")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &LinkedProviderORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type LinkedProviderORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchLinkedProvider executes a basic gorm update call with patch behavior
+func DefaultPatchLinkedProvider(ctx context.Context, in *LinkedProvider, updateMask *field_mask.FieldMask, db *gorm.DB) (*LinkedProvider, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj LinkedProvider
+	var err error
+	if hook, ok := interface{}(&pbObj).(LinkedProviderWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadLinkedProvider(ctx, &LinkedProvider{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(LinkedProviderWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskLinkedProvider(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(LinkedProviderWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateLinkedProvider(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(LinkedProviderWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type LinkedProviderWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *LinkedProvider, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *LinkedProvider, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *LinkedProvider, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *LinkedProvider, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetLinkedProvider executes a bulk gorm update call with patch behavior
+func DefaultPatchSetLinkedProvider(ctx context.Context, objects []*LinkedProvider, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*LinkedProvider, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*LinkedProvider, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchLinkedProvider(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskLinkedProvider patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskLinkedProvider(ctx context.Context, patchee *LinkedProvider, patcher *LinkedProvider, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*LinkedProvider, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"Provider" {
+			patchee.Provider = patcher.Provider
+			continue
+		}
+		if f == prefix+"Scopes" {
+			patchee.Scopes = patcher.Scopes
+			continue
+		}
+		if f == prefix+"ExpiryUnix" {
+			patchee.ExpiryUnix = patcher.ExpiryUnix
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListLinkedProvider executes a gorm list call
+func DefaultListLinkedProvider(ctx context.Context, db *gorm.DB) ([]*LinkedProvider, error) {
+	in := LinkedProvider{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []LinkedProviderORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(LinkedProviderORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*LinkedProvider{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type LinkedProviderORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type LinkedProviderORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]LinkedProviderORM) error
+}
+
+// DefaultCreateClassificationVote executes a basic gorm create call
+func DefaultCreateClassificationVote(ctx context.Context, in *ClassificationVote, db *gorm.DB) (*ClassificationVote, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type ClassificationVoteORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadClassificationVote(ctx context.Context, in *ClassificationVote, db *gorm.DB) (*ClassificationVote, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := ClassificationVoteORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(ClassificationVoteORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type ClassificationVoteORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteClassificationVote(ctx context.Context, in *ClassificationVote, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&ClassificationVoteORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type ClassificationVoteORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteClassificationVoteSet(ctx context.Context, in []*ClassificationVote, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&ClassificationVoteORM{})).(ClassificationVoteORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&ClassificationVoteORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&ClassificationVoteORM{})).(ClassificationVoteORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type ClassificationVoteORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*ClassificationVote, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*ClassificationVote, *gorm.DB) error
+}
+
+// DefaultStrictUpdateClassificationVote clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateClassificationVote(ctx context.Context, in *ClassificationVote, db *gorm.DB) (*ClassificationVote, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateClassificationVote")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &ClassificationVoteORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type ClassificationVoteORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchClassificationVote executes a basic gorm update call with patch behavior
+func DefaultPatchClassificationVote(ctx context.Context, in *ClassificationVote, updateMask *field_mask.FieldMask, db *gorm.DB) (*ClassificationVote, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj ClassificationVote
+	var err error
+	if hook, ok := interface{}(&pbObj).(ClassificationVoteWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadClassificationVote(ctx, &ClassificationVote{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(ClassificationVoteWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskClassificationVote(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(ClassificationVoteWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateClassificationVote(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(ClassificationVoteWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type ClassificationVoteWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *ClassificationVote, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *ClassificationVote, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *ClassificationVote, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *ClassificationVote, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetClassificationVote executes a bulk gorm update call with patch behavior
+func DefaultPatchSetClassificationVote(ctx context.Context, objects []*ClassificationVote, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*ClassificationVote, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*ClassificationVote, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchClassificationVote(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskClassificationVote patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskClassificationVote(ctx context.Context, patchee *ClassificationVote, patcher *ClassificationVote, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*ClassificationVote, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"InputKey" {
+			patchee.InputKey = patcher.InputKey
+			continue
+		}
+		if f == prefix+"Classification" {
+			patchee.Classification = patcher.Classification
+			continue
+		}
+		if f == prefix+"Agree" {
+			patchee.Agree = patcher.Agree
+			continue
+		}
+		if f == prefix+"PromptVersion" {
+			patchee.PromptVersion = patcher.PromptVersion
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListClassificationVote executes a gorm list call
+func DefaultListClassificationVote(ctx context.Context, db *gorm.DB) ([]*ClassificationVote, error) {
+	in := ClassificationVote{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []ClassificationVoteORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationVoteORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*ClassificationVote{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type ClassificationVoteORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationVoteORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]ClassificationVoteORM) error
+}
+
+// DefaultCreateClassificationOverride executes a basic gorm create call
+func DefaultCreateClassificationOverride(ctx context.Context, in *ClassificationOverride, db *gorm.DB) (*ClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type ClassificationOverrideORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadClassificationOverride(ctx context.Context, in *ClassificationOverride, db *gorm.DB) (*ClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := ClassificationOverrideORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(ClassificationOverrideORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type ClassificationOverrideORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteClassificationOverride(ctx context.Context, in *ClassificationOverride, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&ClassificationOverrideORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type ClassificationOverrideORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteClassificationOverrideSet(ctx context.Context, in []*ClassificationOverride, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&ClassificationOverrideORM{})).(ClassificationOverrideORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&ClassificationOverrideORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&ClassificationOverrideORM{})).(ClassificationOverrideORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type ClassificationOverrideORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*ClassificationOverride, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*ClassificationOverride, *gorm.DB) error
+}
+
+// DefaultStrictUpdateClassificationOverride clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateClassificationOverride(ctx context.Context, in *ClassificationOverride, db *gorm.DB) (*ClassificationOverride, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateClassificationOverride")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &ClassificationOverrideORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type ClassificationOverrideORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchClassificationOverride executes a basic gorm update call with patch behavior
+func DefaultPatchClassificationOverride(ctx context.Context, in *ClassificationOverride, updateMask *field_mask.FieldMask, db *gorm.DB) (*ClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj ClassificationOverride
+	var err error
+	if hook, ok := interface{}(&pbObj).(ClassificationOverrideWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadClassificationOverride(ctx, &ClassificationOverride{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(ClassificationOverrideWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskClassificationOverride(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(ClassificationOverrideWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateClassificationOverride(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(ClassificationOverrideWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type ClassificationOverrideWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *ClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *ClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *ClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *ClassificationOverride, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetClassificationOverride executes a bulk gorm update call with patch behavior
+func DefaultPatchSetClassificationOverride(ctx context.Context, objects []*ClassificationOverride, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*ClassificationOverride, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*ClassificationOverride, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchClassificationOverride(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskClassificationOverride patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskClassificationOverride(ctx context.Context, patchee *ClassificationOverride, patcher *ClassificationOverride, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*ClassificationOverride, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"Kind" {
+			patchee.Kind = patcher.Kind
+			continue
+		}
+		if f == prefix+"Target" {
+			patchee.Target = patcher.Target
+			continue
+		}
+		if f == prefix+"Classification" {
+			patchee.Classification = patcher.Classification
+			continue
+		}
+		if f == prefix+"Tags" {
+			patchee.Tags = patcher.Tags
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListClassificationOverride executes a gorm list call
+func DefaultListClassificationOverride(ctx context.Context, db *gorm.DB) ([]*ClassificationOverride, error) {
+	in := ClassificationOverride{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []ClassificationOverrideORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(ClassificationOverrideORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*ClassificationOverride{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type ClassificationOverrideORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type ClassificationOverrideORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]ClassificationOverrideORM) error
+}
+
+// DefaultCreateGlobalClassificationOverride executes a basic gorm create call
+func DefaultCreateGlobalClassificationOverride(ctx context.Context, in *GlobalClassificationOverride, db *gorm.DB) (*GlobalClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type GlobalClassificationOverrideORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadGlobalClassificationOverride(ctx context.Context, in *GlobalClassificationOverride, db *gorm.DB) (*GlobalClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := GlobalClassificationOverrideORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(GlobalClassificationOverrideORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type GlobalClassificationOverrideORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteGlobalClassificationOverride(ctx context.Context, in *GlobalClassificationOverride, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&GlobalClassificationOverrideORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type GlobalClassificationOverrideORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteGlobalClassificationOverrideSet(ctx context.Context, in []*GlobalClassificationOverride, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&GlobalClassificationOverrideORM{})).(GlobalClassificationOverrideORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&GlobalClassificationOverrideORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&GlobalClassificationOverrideORM{})).(GlobalClassificationOverrideORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type GlobalClassificationOverrideORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*GlobalClassificationOverride, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*GlobalClassificationOverride, *gorm.DB) error
+}
+
+// DefaultStrictUpdateGlobalClassificationOverride clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateGlobalClassificationOverride(ctx context.Context, in *GlobalClassificationOverride, db *gorm.DB) (*GlobalClassificationOverride, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateGlobalClassificationOverride")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &GlobalClassificationOverrideORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type GlobalClassificationOverrideORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchGlobalClassificationOverride executes a basic gorm update call with patch behavior
+func DefaultPatchGlobalClassificationOverride(ctx context.Context, in *GlobalClassificationOverride, updateMask *field_mask.FieldMask, db *gorm.DB) (*GlobalClassificationOverride, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj GlobalClassificationOverride
+	var err error
+	if hook, ok := interface{}(&pbObj).(GlobalClassificationOverrideWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadGlobalClassificationOverride(ctx, &GlobalClassificationOverride{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(GlobalClassificationOverrideWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskGlobalClassificationOverride(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(GlobalClassificationOverrideWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateGlobalClassificationOverride(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(GlobalClassificationOverrideWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type GlobalClassificationOverrideWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *GlobalClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *GlobalClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *GlobalClassificationOverride, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *GlobalClassificationOverride, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetGlobalClassificationOverride executes a bulk gorm update call with patch behavior
+func DefaultPatchSetGlobalClassificationOverride(ctx context.Context, objects []*GlobalClassificationOverride, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*GlobalClassificationOverride, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*GlobalClassificationOverride, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchGlobalClassificationOverride(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskGlobalClassificationOverride patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskGlobalClassificationOverride(ctx context.Context, patchee *GlobalClassificationOverride, patcher *GlobalClassificationOverride, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*GlobalClassificationOverride, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"Kind" {
+			patchee.Kind = patcher.Kind
+			continue
+		}
+		if f == prefix+"Target" {
+			patchee.Target = patcher.Target
+			continue
+		}
+		if f == prefix+"Classification" {
+			patchee.Classification = patcher.Classification
+			continue
+		}
+		if f == prefix+"Tags" {
+			patchee.Tags = patcher.Tags
+			continue
+		}
+		if f == prefix+"Note" {
+			patchee.Note = patcher.Note
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListGlobalClassificationOverride executes a gorm list call
+func DefaultListGlobalClassificationOverride(ctx context.Context, db *gorm.DB) ([]*GlobalClassificationOverride, error) {
+	in := GlobalClassificationOverride{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []GlobalClassificationOverrideORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(GlobalClassificationOverrideORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*GlobalClassificationOverride{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type GlobalClassificationOverrideORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type GlobalClassificationOverrideORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]GlobalClassificationOverrideORM) error
+}
+
+// DefaultCreateFocusSession executes a basic gorm create call
+func DefaultCreateFocusSession(ctx context.Context, in *FocusSession, db *gorm.DB) (*FocusSession, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type FocusSessionORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadFocusSession(ctx context.Context, in *FocusSession, db *gorm.DB) (*FocusSession, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := FocusSessionORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(FocusSessionORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type FocusSessionORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteFocusSession(ctx context.Context, in *FocusSession, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&FocusSessionORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type FocusSessionORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeleteFocusSessionSet(ctx context.Context, in []*FocusSession, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
+		}
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&FocusSessionORM{})).(FocusSessionORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where("id in (?)", keys).Delete(&FocusSessionORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := (interface{}(&FocusSessionORM{})).(FocusSessionORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
+}
+
+type FocusSessionORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*FocusSession, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*FocusSession, *gorm.DB) error
+}
+
+// DefaultStrictUpdateFocusSession clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateFocusSession(ctx context.Context, in *FocusSession, db *gorm.DB) (*FocusSession, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateFocusSession")
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lockedRow := &FocusSessionORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pbResponse, err
+}
+
+type FocusSessionORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
+}
+
+// DefaultPatchFocusSession executes a basic gorm update call with patch behavior
+func DefaultPatchFocusSession(ctx context.Context, in *FocusSession, updateMask *field_mask.FieldMask, db *gorm.DB) (*FocusSession, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	var pbObj FocusSession
+	var err error
+	if hook, ok := interface{}(&pbObj).(FocusSessionWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadFocusSession(ctx, &FocusSession{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(FocusSessionWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := DefaultApplyFieldMaskFocusSession(ctx, &pbObj, in, updateMask, "", db); err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&pbObj).(FocusSessionWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := DefaultStrictUpdateFocusSession(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(FocusSessionWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
+}
+
+type FocusSessionWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *FocusSession, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *FocusSession, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *FocusSession, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *FocusSession, *field_mask.FieldMask, *gorm.DB) error
+}
+
+// DefaultPatchSetFocusSession executes a bulk gorm update call with patch behavior
+func DefaultPatchSetFocusSession(ctx context.Context, objects []*FocusSession, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*FocusSession, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*FocusSession, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchFocusSession(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskFocusSession patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskFocusSession(ctx context.Context, patchee *FocusSession, patcher *FocusSession, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*FocusSession, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
+			continue
+		}
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"StartedAt" {
+			patchee.StartedAt = patcher.StartedAt
+			continue
+		}
+		if f == prefix+"EndedAt" {
+			patchee.EndedAt = patcher.EndedAt
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListFocusSession executes a gorm list call
+func DefaultListFocusSession(ctx context.Context, db *gorm.DB) ([]*FocusSession, error) {
+	in := FocusSession{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []FocusSessionORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
 		}
 	}
-	to.PromptHash = m.PromptHash
-	to.ResponseJson = m.ResponseJson
-	to.CreatedAt = m.CreatedAt
-	to.ExpiresAt = m.ExpiresAt
-	if posthook, ok := interface{}(m).(PromptHistoryWithAfterToPB); ok {
-		err = posthook.AfterToPB(ctx, &to)
+	pbResponse := []*FocusSession{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
 	}
-	return to, err
-}
-
-// The following are interfaces you can implement for special behavior during ORM/PB conversions
-// of type PromptHistory the arg will be the target, the caller the one being converted from
-
-// PromptHistoryBeforeToORM called before default ToORM code
-type PromptHistoryWithBeforeToORM interface {
-	BeforeToORM(context.Context, *PromptHistoryORM) error
+	return pbResponse, nil
 }
 
-// PromptHistoryAfterToORM called after default ToORM code
-type PromptHistoryWithAfterToORM interface {
-	AfterToORM(context.Context, *PromptHistoryORM) error
+type FocusSessionORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-
-// PromptHistoryBeforeToPB called before default ToPB code
-type PromptHistoryWithBeforeToPB interface {
-	BeforeToPB(context.Context, *PromptHistory) error
+type FocusSessionORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-
-// PromptHistoryAfterToPB called after default ToPB code
-type PromptHistoryWithAfterToPB interface {
-	AfterToPB(context.Context, *PromptHistory) error
+type FocusSessionORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]FocusSessionORM) error
 }
 
-// DefaultCreateUser executes a basic gorm create call
-func DefaultCreateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+// DefaultCreateFocusSessionEvent executes a basic gorm create call
+func DefaultCreateFocusSessionEvent(ctx context.Context, in *FocusSessionEvent, db *gorm.DB) (*FocusSessionEvent, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
@@ -242,7 +4015,7 @@ func DefaultCreateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeCreate_); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeCreate_); ok {
 		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
 			return nil, err
 		}
@@ -250,7 +4023,7 @@ func DefaultCreateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error
 	if err = db.Omit().Create(&ormObj).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithAfterCreate_); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithAfterCreate_); ok {
 		if err = hook.AfterCreate_(ctx, db); err != nil {
 			return nil, err
 		}
@@ -259,14 +4032,14 @@ func DefaultCreateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error
 	return &pbResponse, err
 }
 
-type UserORMWithBeforeCreate_ interface {
+type FocusSessionEventORMWithBeforeCreate_ interface {
 	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterCreate_ interface {
+type FocusSessionEventORMWithAfterCreate_ interface {
 	AfterCreate_(context.Context, *gorm.DB) error
 }
 
-func DefaultReadUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+func DefaultReadFocusSessionEvent(ctx context.Context, in *FocusSessionEvent, db *gorm.DB) (*FocusSessionEvent, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
@@ -277,21 +4050,21 @@ func DefaultReadUser(ctx context.Context, in *User, db *gorm.DB) (*User, error)
 	if ormObj.Id == 0 {
 		return nil, errors.EmptyIdError
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeReadApplyQuery); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeReadApplyQuery); ok {
 		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeReadFind); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeReadFind); ok {
 		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	ormResponse := UserORM{}
+	ormResponse := FocusSessionEventORM{}
 	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormResponse).(UserORMWithAfterReadFind); ok {
+	if hook, ok := interface{}(&ormResponse).(FocusSessionEventORMWithAfterReadFind); ok {
 		if err = hook.AfterReadFind(ctx, db); err != nil {
 			return nil, err
 		}
@@ -300,17 +4073,17 @@ func DefaultReadUser(ctx context.Context, in *User, db *gorm.DB) (*User, error)
 	return &pbResponse, err
 }
 
-type UserORMWithBeforeReadApplyQuery interface {
+type FocusSessionEventORMWithBeforeReadApplyQuery interface {
 	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithBeforeReadFind interface {
+type FocusSessionEventORMWithBeforeReadFind interface {
 	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterReadFind interface {
+type FocusSessionEventORMWithAfterReadFind interface {
 	AfterReadFind(context.Context, *gorm.DB) error
 }
 
-func DefaultDeleteUser(ctx context.Context, in *User, db *gorm.DB) error {
+func DefaultDeleteFocusSessionEvent(ctx context.Context, in *FocusSessionEvent, db *gorm.DB) error {
 	if in == nil {
 		return errors.NilArgumentError
 	}
@@ -321,29 +4094,29 @@ func DefaultDeleteUser(ctx context.Context, in *User, db *gorm.DB) error {
 	if ormObj.Id == 0 {
 		return errors.EmptyIdError
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeDelete_); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeDelete_); ok {
 		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
 			return err
 		}
 	}
-	err = db.Where(&ormObj).Delete(&UserORM{}).Error
+	err = db.Where(&ormObj).Delete(&FocusSessionEventORM{}).Error
 	if err != nil {
 		return err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithAfterDelete_); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithAfterDelete_); ok {
 		err = hook.AfterDelete_(ctx, db)
 	}
 	return err
 }
 
-type UserORMWithBeforeDelete_ interface {
+type FocusSessionEventORMWithBeforeDelete_ interface {
 	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterDelete_ interface {
+type FocusSessionEventORMWithAfterDelete_ interface {
 	AfterDelete_(context.Context, *gorm.DB) error
 }
 
-func DefaultDeleteUserSet(ctx context.Context, in []*User, db *gorm.DB) error {
+func DefaultDeleteFocusSessionEventSet(ctx context.Context, in []*FocusSessionEvent, db *gorm.DB) error {
 	if in == nil {
 		return errors.NilArgumentError
 	}
@@ -359,45 +4132,45 @@ func DefaultDeleteUserSet(ctx context.Context, in []*User, db *gorm.DB) error {
 		}
 		keys = append(keys, ormObj.Id)
 	}
-	if hook, ok := (interface{}(&UserORM{})).(UserORMWithBeforeDeleteSet); ok {
+	if hook, ok := (interface{}(&FocusSessionEventORM{})).(FocusSessionEventORMWithBeforeDeleteSet); ok {
 		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
 			return err
 		}
 	}
-	err = db.Where("id in (?)", keys).Delete(&UserORM{}).Error
+	err = db.Where("id in (?)", keys).Delete(&FocusSessionEventORM{}).Error
 	if err != nil {
 		return err
 	}
-	if hook, ok := (interface{}(&UserORM{})).(UserORMWithAfterDeleteSet); ok {
+	if hook, ok := (interface{}(&FocusSessionEventORM{})).(FocusSessionEventORMWithAfterDeleteSet); ok {
 		err = hook.AfterDeleteSet(ctx, in, db)
 	}
 	return err
 }
 
-type UserORMWithBeforeDeleteSet interface {
-	BeforeDeleteSet(context.Context, []*User, *gorm.DB) (*gorm.DB, error)
+type FocusSessionEventORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*FocusSessionEvent, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterDeleteSet interface {
-	AfterDeleteSet(context.Context, []*User, *gorm.DB) error
+type FocusSessionEventORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*FocusSessionEvent, *gorm.DB) error
 }
 
-// DefaultStrictUpdateUser clears / replaces / appends first level 1:many children and then executes a gorm update call
-func DefaultStrictUpdateUser(ctx context.Context, in *User, db *gorm.DB) (*User, error) {
+// DefaultStrictUpdateFocusSessionEvent clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateFocusSessionEvent(ctx context.Context, in *FocusSessionEvent, db *gorm.DB) (*FocusSessionEvent, error) {
 	if in == nil {
-		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateUser")
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateFocusSessionEvent")
 	}
 	ormObj, err := in.ToORM(ctx)
 	if err != nil {
 		return nil, err
 	}
-	lockedRow := &UserORM{}
+	lockedRow := &FocusSessionEventORM{}
 	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeStrictUpdateCleanup); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeStrictUpdateCleanup); ok {
 		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeStrictUpdateSave); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeStrictUpdateSave); ok {
 		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
@@ -405,7 +4178,7 @@ func DefaultStrictUpdateUser(ctx context.Context, in *User, db *gorm.DB) (*User,
 	if err = db.Omit().Save(&ormObj).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithAfterStrictUpdateSave); ok {
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithAfterStrictUpdateSave); ok {
 		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
@@ -417,51 +4190,51 @@ func DefaultStrictUpdateUser(ctx context.Context, in *User, db *gorm.DB) (*User,
 	return &pbResponse, err
 }
 
-type UserORMWithBeforeStrictUpdateCleanup interface {
+type FocusSessionEventORMWithBeforeStrictUpdateCleanup interface {
 	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithBeforeStrictUpdateSave interface {
+type FocusSessionEventORMWithBeforeStrictUpdateSave interface {
 	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterStrictUpdateSave interface {
+type FocusSessionEventORMWithAfterStrictUpdateSave interface {
 	AfterStrictUpdateSave(context.Context, *gorm.DB) error
 }
 
-// DefaultPatchUser executes a basic gorm update call with patch behavior
-func DefaultPatchUser(ctx context.Context, in *User, updateMask *field_mask.FieldMask, db *gorm.DB) (*User, error) {
+// DefaultPatchFocusSessionEvent executes a basic gorm update call with patch behavior
+func DefaultPatchFocusSessionEvent(ctx context.Context, in *FocusSessionEvent, updateMask *field_mask.FieldMask, db *gorm.DB) (*FocusSessionEvent, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
-	var pbObj User
+	var pbObj FocusSessionEvent
 	var err error
-	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchRead); ok {
+	if hook, ok := interface{}(&pbObj).(FocusSessionEventWithBeforePatchRead); ok {
 		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	pbReadRes, err := DefaultReadUser(ctx, &User{Id: in.GetId()}, db)
+	pbReadRes, err := DefaultReadFocusSessionEvent(ctx, &FocusSessionEvent{Id: in.GetId()}, db)
 	if err != nil {
 		return nil, err
 	}
 	pbObj = *pbReadRes
-	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchApplyFieldMask); ok {
+	if hook, ok := interface{}(&pbObj).(FocusSessionEventWithBeforePatchApplyFieldMask); ok {
 		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	if _, err := DefaultApplyFieldMaskUser(ctx, &pbObj, in, updateMask, "", db); err != nil {
+	if _, err := DefaultApplyFieldMaskFocusSessionEvent(ctx, &pbObj, in, updateMask, "", db); err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&pbObj).(UserWithBeforePatchSave); ok {
+	if hook, ok := interface{}(&pbObj).(FocusSessionEventWithBeforePatchSave); ok {
 		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse, err := DefaultStrictUpdateUser(ctx, &pbObj, db)
+	pbResponse, err := DefaultStrictUpdateFocusSessionEvent(ctx, &pbObj, db)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(pbResponse).(UserWithAfterPatchSave); ok {
+	if hook, ok := interface{}(pbResponse).(FocusSessionEventWithAfterPatchSave); ok {
 		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
@@ -469,28 +4242,28 @@ func DefaultPatchUser(ctx context.Context, in *User, updateMask *field_mask.Fiel
 	return pbResponse, nil
 }
 
-type UserWithBeforePatchRead interface {
-	BeforePatchRead(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type FocusSessionEventWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *FocusSessionEvent, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type UserWithBeforePatchApplyFieldMask interface {
-	BeforePatchApplyFieldMask(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type FocusSessionEventWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *FocusSessionEvent, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type UserWithBeforePatchSave interface {
-	BeforePatchSave(context.Context, *User, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type FocusSessionEventWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *FocusSessionEvent, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type UserWithAfterPatchSave interface {
-	AfterPatchSave(context.Context, *User, *field_mask.FieldMask, *gorm.DB) error
+type FocusSessionEventWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *FocusSessionEvent, *field_mask.FieldMask, *gorm.DB) error
 }
 
-// DefaultPatchSetUser executes a bulk gorm update call with patch behavior
-func DefaultPatchSetUser(ctx context.Context, objects []*User, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*User, error) {
+// DefaultPatchSetFocusSessionEvent executes a bulk gorm update call with patch behavior
+func DefaultPatchSetFocusSessionEvent(ctx context.Context, objects []*FocusSessionEvent, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*FocusSessionEvent, error) {
 	if len(objects) != len(updateMasks) {
 		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
 	}
 
-	results := make([]*User, 0, len(objects))
+	results := make([]*FocusSessionEvent, 0, len(objects))
 	for i, patcher := range objects {
-		pbResponse, err := DefaultPatchUser(ctx, patcher, updateMasks[i], db)
+		pbResponse, err := DefaultPatchFocusSessionEvent(ctx, patcher, updateMasks[i], db)
 		if err != nil {
 			return nil, err
 		}
@@ -501,8 +4274,8 @@ func DefaultPatchSetUser(ctx context.Context, objects []*User, updateMasks []*fi
 	return results, nil
 }
 
-// DefaultApplyFieldMaskUser patches an pbObject with patcher according to a field mask.
-func DefaultApplyFieldMaskUser(ctx context.Context, patchee *User, patcher *User, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*User, error) {
+// DefaultApplyFieldMaskFocusSessionEvent patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskFocusSessionEvent(ctx context.Context, patchee *FocusSessionEvent, patcher *FocusSessionEvent, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*FocusSessionEvent, error) {
 	if patcher == nil {
 		return nil, nil
 	} else if patchee == nil {
@@ -514,113 +4287,352 @@ func DefaultApplyFieldMaskUser(ctx context.Context, patchee *User, patcher *User
 			patchee.Id = patcher.Id
 			continue
 		}
-		if f == prefix+"DeviceFingerprintHash" {
-			patchee.DeviceFingerprintHash = patcher.DeviceFingerprintHash
+		if f == prefix+"SessionId" {
+			patchee.SessionId = patcher.SessionId
 			continue
 		}
-		if f == prefix+"Role" {
-			patchee.Role = patcher.Role
+		if f == prefix+"Kind" {
+			patchee.Kind = patcher.Kind
+			continue
+		}
+		if f == prefix+"Classification" {
+			patchee.Classification = patcher.Classification
+			continue
+		}
+		if f == prefix+"CreatedAt" {
+			patchee.CreatedAt = patcher.CreatedAt
+			continue
+		}
+		if f == prefix+"OutcomeHash" {
+			patchee.OutcomeHash = patcher.OutcomeHash
 			continue
 		}
-		if f == prefix+"OsInfo" {
-			patchee.OsInfo = patcher.OsInfo
-			continue
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListFocusSessionEvent executes a gorm list call
+func DefaultListFocusSessionEvent(ctx context.Context, db *gorm.DB) ([]*FocusSessionEvent, error) {
+	in := FocusSessionEvent{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("id")
+	ormResponse := []FocusSessionEventORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FocusSessionEventORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*FocusSessionEvent{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type FocusSessionEventORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionEventORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type FocusSessionEventORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]FocusSessionEventORM) error
+}
+
+// DefaultCreatePromptAddendum executes a basic gorm create call
+func DefaultCreatePromptAddendum(ctx context.Context, in *PromptAddendum, db *gorm.DB) (*PromptAddendum, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Omit().Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type PromptAddendumORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm.DB) error
+}
+
+func DefaultReadPromptAddendum(ctx context.Context, in *PromptAddendum, db *gorm.DB) (*PromptAddendum, error) {
+	if in == nil {
+		return nil, errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ormObj.Id == 0 {
+		return nil, errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeReadApplyQuery); ok {
+		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeReadFind); ok {
+		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	ormResponse := PromptAddendumORM{}
+	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormResponse).(PromptAddendumORMWithAfterReadFind); ok {
+		if err = hook.AfterReadFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormResponse.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type PromptAddendumORMWithBeforeReadApplyQuery interface {
+	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumORMWithBeforeReadFind interface {
+	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumORMWithAfterReadFind interface {
+	AfterReadFind(context.Context, *gorm.DB) error
+}
+
+func DefaultDeletePromptAddendum(ctx context.Context, in *PromptAddendum, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return err
+	}
+	if ormObj.Id == 0 {
+		return errors.EmptyIdError
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeDelete_); ok {
+		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
+			return err
+		}
+	}
+	err = db.Where(&ormObj).Delete(&PromptAddendumORM{}).Error
+	if err != nil {
+		return err
+	}
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithAfterDelete_); ok {
+		err = hook.AfterDelete_(ctx, db)
+	}
+	return err
+}
+
+type PromptAddendumORMWithBeforeDelete_ interface {
+	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumORMWithAfterDelete_ interface {
+	AfterDelete_(context.Context, *gorm.DB) error
+}
+
+func DefaultDeletePromptAddendumSet(ctx context.Context, in []*PromptAddendum, db *gorm.DB) error {
+	if in == nil {
+		return errors.NilArgumentError
+	}
+	var err error
+	keys := []int64{}
+	for _, obj := range in {
+		ormObj, err := obj.ToORM(ctx)
+		if err != nil {
+			return err
+		}
+		if ormObj.Id == 0 {
+			return errors.EmptyIdError
 		}
-		if f == prefix+"CreatedAt" {
-			patchee.CreatedAt = patcher.CreatedAt
-			continue
+		keys = append(keys, ormObj.Id)
+	}
+	if hook, ok := (interface{}(&PromptAddendumORM{})).(PromptAddendumORMWithBeforeDeleteSet); ok {
+		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
+			return err
 		}
 	}
+	err = db.Where("id in (?)", keys).Delete(&PromptAddendumORM{}).Error
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return patchee, nil
+	if hook, ok := (interface{}(&PromptAddendumORM{})).(PromptAddendumORMWithAfterDeleteSet); ok {
+		err = hook.AfterDeleteSet(ctx, in, db)
+	}
+	return err
 }
 
-// DefaultListUser executes a gorm list call
-func DefaultListUser(ctx context.Context, db *gorm.DB) ([]*User, error) {
-	in := User{}
+type PromptAddendumORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*PromptAddendum, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*PromptAddendum, *gorm.DB) error
+}
+
+// DefaultStrictUpdatePromptAddendum clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdatePromptAddendum(ctx context.Context, in *PromptAddendum, db *gorm.DB) (*PromptAddendum, error) {
+	if in == nil {
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdatePromptAddendum")
+	}
 	ormObj, err := in.ToORM(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeListApplyQuery); ok {
-		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+	lockedRow := &PromptAddendumORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeStrictUpdateCleanup); ok {
+		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithBeforeListFind); ok {
-		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeStrictUpdateSave); ok {
+		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	db = db.Where(&ormObj)
-	db = db.Order("id")
-	ormResponse := []UserORM{}
-	if err := db.Find(&ormResponse).Error; err != nil {
+	if err = db.Omit().Save(&ormObj).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(UserORMWithAfterListFind); ok {
-		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithAfterStrictUpdateSave); ok {
+		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse := []*User{}
-	for _, responseEntry := range ormResponse {
-		temp, err := responseEntry.ToPB(ctx)
-		if err != nil {
-			return nil, err
-		}
-		pbResponse = append(pbResponse, &temp)
+	pbResponse, err := ormObj.ToPB(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return pbResponse, nil
+	return &pbResponse, err
 }
 
-type UserORMWithBeforeListApplyQuery interface {
-	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
+type PromptAddendumORMWithBeforeStrictUpdateCleanup interface {
+	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithBeforeListFind interface {
-	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
+type PromptAddendumORMWithBeforeStrictUpdateSave interface {
+	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type UserORMWithAfterListFind interface {
-	AfterListFind(context.Context, *gorm.DB, *[]UserORM) error
+type PromptAddendumORMWithAfterStrictUpdateSave interface {
+	AfterStrictUpdateSave(context.Context, *gorm.DB) error
 }
 
-// DefaultCreateNonce executes a basic gorm create call
-func DefaultCreateNonce(ctx context.Context, in *Nonce, db *gorm.DB) (*Nonce, error) {
+// DefaultPatchPromptAddendum executes a basic gorm update call with patch behavior
+func DefaultPatchPromptAddendum(ctx context.Context, in *PromptAddendum, updateMask *field_mask.FieldMask, db *gorm.DB) (*PromptAddendum, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
-	ormObj, err := in.ToORM(ctx)
+	var pbObj PromptAddendum
+	var err error
+	if hook, ok := interface{}(&pbObj).(PromptAddendumWithBeforePatchRead); ok {
+		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	pbReadRes, err := DefaultReadPromptAddendum(ctx, &PromptAddendum{Id: in.GetId()}, db)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeCreate_); ok {
-		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(PromptAddendumWithBeforePatchApplyFieldMask); ok {
+		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	if err = db.Omit().Create(&ormObj).Error; err != nil {
+	if _, err := DefaultApplyFieldMaskPromptAddendum(ctx, &pbObj, in, updateMask, "", db); err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(NonceORMWithAfterCreate_); ok {
-		if err = hook.AfterCreate_(ctx, db); err != nil {
+	if hook, ok := interface{}(&pbObj).(PromptAddendumWithBeforePatchSave); ok {
+		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse, err := ormObj.ToPB(ctx)
-	return &pbResponse, err
+	pbResponse, err := DefaultStrictUpdatePromptAddendum(ctx, &pbObj, db)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(pbResponse).(PromptAddendumWithAfterPatchSave); ok {
+		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
+			return nil, err
+		}
+	}
+	return pbResponse, nil
 }
 
-type NonceORMWithBeforeCreate_ interface {
-	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
+type PromptAddendumWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *PromptAddendum, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type NonceORMWithAfterCreate_ interface {
-	AfterCreate_(context.Context, *gorm.DB) error
+type PromptAddendumWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *PromptAddendum, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *PromptAddendum, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+}
+type PromptAddendumWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *PromptAddendum, *field_mask.FieldMask, *gorm.DB) error
 }
 
-// DefaultApplyFieldMaskNonce patches an pbObject with patcher according to a field mask.
-func DefaultApplyFieldMaskNonce(ctx context.Context, patchee *Nonce, patcher *Nonce, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*Nonce, error) {
+// DefaultPatchSetPromptAddendum executes a bulk gorm update call with patch behavior
+func DefaultPatchSetPromptAddendum(ctx context.Context, objects []*PromptAddendum, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*PromptAddendum, error) {
+	if len(objects) != len(updateMasks) {
+		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
+	}
+
+	results := make([]*PromptAddendum, 0, len(objects))
+	for i, patcher := range objects {
+		pbResponse, err := DefaultPatchPromptAddendum(ctx, patcher, updateMasks[i], db)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pbResponse)
+	}
+
+	return results, nil
+}
+
+// DefaultApplyFieldMaskPromptAddendum patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskPromptAddendum(ctx context.Context, patchee *PromptAddendum, patcher *PromptAddendum, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*PromptAddendum, error) {
 	if patcher == nil {
 		return nil, nil
 	} else if patchee == nil {
@@ -628,16 +4640,24 @@ func DefaultApplyFieldMaskNonce(ctx context.Context, patchee *Nonce, patcher *No
 	}
 	var err error
 	for _, f := range updateMask.Paths {
-		if f == prefix+"Nonce" {
-			patchee.Nonce = patcher.Nonce
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
 			continue
 		}
-		if f == prefix+"CreatedAt" {
-			patchee.CreatedAt = patcher.CreatedAt
+		if f == prefix+"Scope" {
+			patchee.Scope = patcher.Scope
 			continue
 		}
-		if f == prefix+"ExpiresAt" {
-			patchee.ExpiresAt = patcher.ExpiresAt
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
+			continue
+		}
+		if f == prefix+"Text" {
+			patchee.Text = patcher.Text
+			continue
+		}
+		if f == prefix+"UpdatedAt" {
+			patchee.UpdatedAt = patcher.UpdatedAt
 			continue
 		}
 	}
@@ -647,34 +4667,35 @@ func DefaultApplyFieldMaskNonce(ctx context.Context, patchee *Nonce, patcher *No
 	return patchee, nil
 }
 
-// DefaultListNonce executes a gorm list call
-func DefaultListNonce(ctx context.Context, db *gorm.DB) ([]*Nonce, error) {
-	in := Nonce{}
+// DefaultListPromptAddendum executes a gorm list call
+func DefaultListPromptAddendum(ctx context.Context, db *gorm.DB) ([]*PromptAddendum, error) {
+	in := PromptAddendum{}
 	ormObj, err := in.ToORM(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeListApplyQuery); ok {
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeListApplyQuery); ok {
 		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(NonceORMWithBeforeListFind); ok {
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithBeforeListFind); ok {
 		if db, err = hook.BeforeListFind(ctx, db); err != nil {
 			return nil, err
 		}
 	}
 	db = db.Where(&ormObj)
-	ormResponse := []NonceORM{}
+	db = db.Order("id")
+	ormResponse := []PromptAddendumORM{}
 	if err := db.Find(&ormResponse).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(NonceORMWithAfterListFind); ok {
+	if hook, ok := interface{}(&ormObj).(PromptAddendumORMWithAfterListFind); ok {
 		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse := []*Nonce{}
+	pbResponse := []*PromptAddendum{}
 	for _, responseEntry := range ormResponse {
 		temp, err := responseEntry.ToPB(ctx)
 		if err != nil {
@@ -685,18 +4706,18 @@ func DefaultListNonce(ctx context.Context, db *gorm.DB) ([]*Nonce, error) {
 	return pbResponse, nil
 }
 
-type NonceORMWithBeforeListApplyQuery interface {
+type PromptAddendumORMWithBeforeListApplyQuery interface {
 	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type NonceORMWithBeforeListFind interface {
+type PromptAddendumORMWithBeforeListFind interface {
 	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type NonceORMWithAfterListFind interface {
-	AfterListFind(context.Context, *gorm.DB, *[]NonceORM) error
+type PromptAddendumORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]PromptAddendumORM) error
 }
 
-// DefaultCreatePromptHistory executes a basic gorm create call
-func DefaultCreatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+// DefaultCreateDetectedProject executes a basic gorm create call
+func DefaultCreateDetectedProject(ctx context.Context, in *DetectedProject, db *gorm.DB) (*DetectedProject, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
@@ -704,7 +4725,7 @@ func DefaultCreatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeCreate_); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeCreate_); ok {
 		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
 			return nil, err
 		}
@@ -712,7 +4733,7 @@ func DefaultCreatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm
 	if err = db.Omit().Create(&ormObj).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterCreate_); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithAfterCreate_); ok {
 		if err = hook.AfterCreate_(ctx, db); err != nil {
 			return nil, err
 		}
@@ -721,14 +4742,14 @@ func DefaultCreatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm
 	return &pbResponse, err
 }
 
-type PromptHistoryORMWithBeforeCreate_ interface {
+type DetectedProjectORMWithBeforeCreate_ interface {
 	BeforeCreate_(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterCreate_ interface {
+type DetectedProjectORMWithAfterCreate_ interface {
 	AfterCreate_(context.Context, *gorm.DB) error
 }
 
-func DefaultReadPromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+func DefaultReadDetectedProject(ctx context.Context, in *DetectedProject, db *gorm.DB) (*DetectedProject, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
@@ -736,24 +4757,24 @@ func DefaultReadPromptHistory(ctx context.Context, in *PromptHistory, db *gorm.D
 	if err != nil {
 		return nil, err
 	}
-	if ormObj.PromptHash == "" {
+	if ormObj.Id == 0 {
 		return nil, errors.EmptyIdError
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeReadApplyQuery); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeReadApplyQuery); ok {
 		if db, err = hook.BeforeReadApplyQuery(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeReadFind); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeReadFind); ok {
 		if db, err = hook.BeforeReadFind(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	ormResponse := PromptHistoryORM{}
+	ormResponse := DetectedProjectORM{}
 	if err = db.Where(&ormObj).First(&ormResponse).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormResponse).(PromptHistoryORMWithAfterReadFind); ok {
+	if hook, ok := interface{}(&ormResponse).(DetectedProjectORMWithAfterReadFind); ok {
 		if err = hook.AfterReadFind(ctx, db); err != nil {
 			return nil, err
 		}
@@ -762,17 +4783,17 @@ func DefaultReadPromptHistory(ctx context.Context, in *PromptHistory, db *gorm.D
 	return &pbResponse, err
 }
 
-type PromptHistoryORMWithBeforeReadApplyQuery interface {
+type DetectedProjectORMWithBeforeReadApplyQuery interface {
 	BeforeReadApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithBeforeReadFind interface {
+type DetectedProjectORMWithBeforeReadFind interface {
 	BeforeReadFind(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterReadFind interface {
+type DetectedProjectORMWithAfterReadFind interface {
 	AfterReadFind(context.Context, *gorm.DB) error
 }
 
-func DefaultDeletePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) error {
+func DefaultDeleteDetectedProject(ctx context.Context, in *DetectedProject, db *gorm.DB) error {
 	if in == nil {
 		return errors.NilArgumentError
 	}
@@ -780,86 +4801,86 @@ func DefaultDeletePromptHistory(ctx context.Context, in *PromptHistory, db *gorm
 	if err != nil {
 		return err
 	}
-	if ormObj.PromptHash == "" {
+	if ormObj.Id == 0 {
 		return errors.EmptyIdError
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeDelete_); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeDelete_); ok {
 		if db, err = hook.BeforeDelete_(ctx, db); err != nil {
 			return err
 		}
 	}
-	err = db.Where(&ormObj).Delete(&PromptHistoryORM{}).Error
+	err = db.Where(&ormObj).Delete(&DetectedProjectORM{}).Error
 	if err != nil {
 		return err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterDelete_); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithAfterDelete_); ok {
 		err = hook.AfterDelete_(ctx, db)
 	}
 	return err
 }
 
-type PromptHistoryORMWithBeforeDelete_ interface {
+type DetectedProjectORMWithBeforeDelete_ interface {
 	BeforeDelete_(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterDelete_ interface {
+type DetectedProjectORMWithAfterDelete_ interface {
 	AfterDelete_(context.Context, *gorm.DB) error
 }
 
-func DefaultDeletePromptHistorySet(ctx context.Context, in []*PromptHistory, db *gorm.DB) error {
+func DefaultDeleteDetectedProjectSet(ctx context.Context, in []*DetectedProject, db *gorm.DB) error {
 	if in == nil {
 		return errors.NilArgumentError
 	}
 	var err error
-	keys := []string{}
+	keys := []int64{}
 	for _, obj := range in {
 		ormObj, err := obj.ToORM(ctx)
 		if err != nil {
 			return err
 		}
-		if ormObj.PromptHash == "" {
+		if ormObj.Id == 0 {
 			return errors.EmptyIdError
 		}
-		keys = append(keys, ormObj.PromptHash)
+		keys = append(keys, ormObj.Id)
 	}
-	if hook, ok := (interface{}(&PromptHistoryORM{})).(PromptHistoryORMWithBeforeDeleteSet); ok {
+	if hook, ok := (interface{}(&DetectedProjectORM{})).(DetectedProjectORMWithBeforeDeleteSet); ok {
 		if db, err = hook.BeforeDeleteSet(ctx, in, db); err != nil {
 			return err
 		}
 	}
-	err = db.Where("prompt_hash in (?)", keys).Delete(&PromptHistoryORM{}).Error
+	err = db.Where("id in (?)", keys).Delete(&DetectedProjectORM{}).Error
 	if err != nil {
 		return err
 	}
-	if hook, ok := (interface{}(&PromptHistoryORM{})).(PromptHistoryORMWithAfterDeleteSet); ok {
+	if hook, ok := (interface{}(&DetectedProjectORM{})).(DetectedProjectORMWithAfterDeleteSet); ok {
 		err = hook.AfterDeleteSet(ctx, in, db)
 	}
 	return err
 }
 
-type PromptHistoryORMWithBeforeDeleteSet interface {
-	BeforeDeleteSet(context.Context, []*PromptHistory, *gorm.DB) (*gorm.DB, error)
+type DetectedProjectORMWithBeforeDeleteSet interface {
+	BeforeDeleteSet(context.Context, []*DetectedProject, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterDeleteSet interface {
-	AfterDeleteSet(context.Context, []*PromptHistory, *gorm.DB) error
+type DetectedProjectORMWithAfterDeleteSet interface {
+	AfterDeleteSet(context.Context, []*DetectedProject, *gorm.DB) error
 }
 
-// DefaultStrictUpdatePromptHistory clears / replaces / appends first level 1:many children and then executes a gorm update call
-func DefaultStrictUpdatePromptHistory(ctx context.Context, in *PromptHistory, db *gorm.DB) (*PromptHistory, error) {
+// DefaultStrictUpdateDetectedProject clears / replaces / appends first level 1:many children and then executes a gorm update call
+func DefaultStrictUpdateDetectedProject(ctx context.Context, in *DetectedProject, db *gorm.DB) (*DetectedProject, error) {
 	if in == nil {
-		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdatePromptHistory")
+		return nil, fmt.Errorf("Nil argument to DefaultStrictUpdateDetectedProject")
 	}
 	ormObj, err := in.ToORM(ctx)
 	if err != nil {
 		return nil, err
 	}
-	lockedRow := &PromptHistoryORM{}
-	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("prompt_hash=?", ormObj.PromptHash).First(lockedRow)
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeStrictUpdateCleanup); ok {
+	lockedRow := &DetectedProjectORM{}
+	db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("id=?", ormObj.Id).First(lockedRow)
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeStrictUpdateCleanup); ok {
 		if db, err = hook.BeforeStrictUpdateCleanup(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeStrictUpdateSave); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeStrictUpdateSave); ok {
 		if db, err = hook.BeforeStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
@@ -867,7 +4888,7 @@ func DefaultStrictUpdatePromptHistory(ctx context.Context, in *PromptHistory, db
 	if err = db.Omit().Save(&ormObj).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterStrictUpdateSave); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithAfterStrictUpdateSave); ok {
 		if err = hook.AfterStrictUpdateSave(ctx, db); err != nil {
 			return nil, err
 		}
@@ -879,46 +4900,51 @@ func DefaultStrictUpdatePromptHistory(ctx context.Context, in *PromptHistory, db
 	return &pbResponse, err
 }
 
-type PromptHistoryORMWithBeforeStrictUpdateCleanup interface {
+type DetectedProjectORMWithBeforeStrictUpdateCleanup interface {
 	BeforeStrictUpdateCleanup(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithBeforeStrictUpdateSave interface {
+type DetectedProjectORMWithBeforeStrictUpdateSave interface {
 	BeforeStrictUpdateSave(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterStrictUpdateSave interface {
+type DetectedProjectORMWithAfterStrictUpdateSave interface {
 	AfterStrictUpdateSave(context.Context, *gorm.DB) error
 }
 
-// DefaultPatchPromptHistory executes a basic gorm update call with patch behavior
-func DefaultPatchPromptHistory(ctx context.Context, in *PromptHistory, updateMask *field_mask.FieldMask, db *gorm.DB) (*PromptHistory, error) {
+// DefaultPatchDetectedProject executes a basic gorm update call with patch behavior
+func DefaultPatchDetectedProject(ctx context.Context, in *DetectedProject, updateMask *field_mask.FieldMask, db *gorm.DB) (*DetectedProject, error) {
 	if in == nil {
 		return nil, errors.NilArgumentError
 	}
-	var pbObj PromptHistory
+	var pbObj DetectedProject
 	var err error
-	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchRead); ok {
+	if hook, ok := interface{}(&pbObj).(DetectedProjectWithBeforePatchRead); ok {
 		if db, err = hook.BeforePatchRead(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchApplyFieldMask); ok {
+	pbReadRes, err := DefaultReadDetectedProject(ctx, &DetectedProject{Id: in.GetId()}, db)
+	if err != nil {
+		return nil, err
+	}
+	pbObj = *pbReadRes
+	if hook, ok := interface{}(&pbObj).(DetectedProjectWithBeforePatchApplyFieldMask); ok {
 		if db, err = hook.BeforePatchApplyFieldMask(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	if _, err := DefaultApplyFieldMaskPromptHistory(ctx, &pbObj, in, updateMask, "", db); err != nil {
+	if _, err := DefaultApplyFieldMaskDetectedProject(ctx, &pbObj, in, updateMask, "", db); err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&pbObj).(PromptHistoryWithBeforePatchSave); ok {
+	if hook, ok := interface{}(&pbObj).(DetectedProjectWithBeforePatchSave); ok {
 		if db, err = hook.BeforePatchSave(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse, err := DefaultStrictUpdatePromptHistory(ctx, &pbObj, db)
+	pbResponse, err := DefaultStrictUpdateDetectedProject(ctx, &pbObj, db)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(pbResponse).(PromptHistoryWithAfterPatchSave); ok {
+	if hook, ok := interface{}(pbResponse).(DetectedProjectWithAfterPatchSave); ok {
 		if err = hook.AfterPatchSave(ctx, in, updateMask, db); err != nil {
 			return nil, err
 		}
@@ -926,28 +4952,28 @@ func DefaultPatchPromptHistory(ctx context.Context, in *PromptHistory, updateMas
 	return pbResponse, nil
 }
 
-type PromptHistoryWithBeforePatchRead interface {
-	BeforePatchRead(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type DetectedProjectWithBeforePatchRead interface {
+	BeforePatchRead(context.Context, *DetectedProject, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryWithBeforePatchApplyFieldMask interface {
-	BeforePatchApplyFieldMask(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type DetectedProjectWithBeforePatchApplyFieldMask interface {
+	BeforePatchApplyFieldMask(context.Context, *DetectedProject, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryWithBeforePatchSave interface {
-	BeforePatchSave(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
+type DetectedProjectWithBeforePatchSave interface {
+	BeforePatchSave(context.Context, *DetectedProject, *field_mask.FieldMask, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryWithAfterPatchSave interface {
-	AfterPatchSave(context.Context, *PromptHistory, *field_mask.FieldMask, *gorm.DB) error
+type DetectedProjectWithAfterPatchSave interface {
+	AfterPatchSave(context.Context, *DetectedProject, *field_mask.FieldMask, *gorm.DB) error
 }
 
-// DefaultPatchSetPromptHistory executes a bulk gorm update call with patch behavior
-func DefaultPatchSetPromptHistory(ctx context.Context, objects []*PromptHistory, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*PromptHistory, error) {
+// DefaultPatchSetDetectedProject executes a bulk gorm update call with patch behavior
+func DefaultPatchSetDetectedProject(ctx context.Context, objects []*DetectedProject, updateMasks []*field_mask.FieldMask, db *gorm.DB) ([]*DetectedProject, error) {
 	if len(objects) != len(updateMasks) {
 		return nil, fmt.Errorf(errors.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))
 	}
 
-	results := make([]*PromptHistory, 0, len(objects))
+	results := make([]*DetectedProject, 0, len(objects))
 	for i, patcher := range objects {
-		pbResponse, err := DefaultPatchPromptHistory(ctx, patcher, updateMasks[i], db)
+		pbResponse, err := DefaultPatchDetectedProject(ctx, patcher, updateMasks[i], db)
 		if err != nil {
 			return nil, err
 		}
@@ -958,8 +4984,8 @@ func DefaultPatchSetPromptHistory(ctx context.Context, objects []*PromptHistory,
 	return results, nil
 }
 
-// DefaultApplyFieldMaskPromptHistory patches an pbObject with patcher according to a field mask.
-func DefaultApplyFieldMaskPromptHistory(ctx context.Context, patchee *PromptHistory, patcher *PromptHistory, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*PromptHistory, error) {
+// DefaultApplyFieldMaskDetectedProject patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskDetectedProject(ctx context.Context, patchee *DetectedProject, patcher *DetectedProject, updateMask *field_mask.FieldMask, prefix string, db *gorm.DB) (*DetectedProject, error) {
 	if patcher == nil {
 		return nil, nil
 	} else if patchee == nil {
@@ -967,20 +4993,36 @@ func DefaultApplyFieldMaskPromptHistory(ctx context.Context, patchee *PromptHist
 	}
 	var err error
 	for _, f := range updateMask.Paths {
-		if f == prefix+"PromptHash" {
-			patchee.PromptHash = patcher.PromptHash
+		if f == prefix+"Id" {
+			patchee.Id = patcher.Id
 			continue
 		}
-		if f == prefix+"ResponseJson" {
-			patchee.ResponseJson = patcher.ResponseJson
+		if f == prefix+"UserId" {
+			patchee.UserId = patcher.UserId
 			continue
 		}
-		if f == prefix+"CreatedAt" {
-			patchee.CreatedAt = patcher.CreatedAt
+		if f == prefix+"CanonicalName" {
+			patchee.CanonicalName = patcher.CanonicalName
 			continue
 		}
-		if f == prefix+"ExpiresAt" {
-			patchee.ExpiresAt = patcher.ExpiresAt
+		if f == prefix+"Name" {
+			patchee.Name = patcher.Name
+			continue
+		}
+		if f == prefix+"FirstSeenAt" {
+			patchee.FirstSeenAt = patcher.FirstSeenAt
+			continue
+		}
+		if f == prefix+"LastSeenAt" {
+			patchee.LastSeenAt = patcher.LastSeenAt
+			continue
+		}
+		if f == prefix+"ClassificationCount" {
+			patchee.ClassificationCount = patcher.ClassificationCount
+			continue
+		}
+		if f == prefix+"LastKind" {
+			patchee.LastKind = patcher.LastKind
 			continue
 		}
 	}
@@ -990,35 +5032,35 @@ func DefaultApplyFieldMaskPromptHistory(ctx context.Context, patchee *PromptHist
 	return patchee, nil
 }
 
-// DefaultListPromptHistory executes a gorm list call
-func DefaultListPromptHistory(ctx context.Context, db *gorm.DB) ([]*PromptHistory, error) {
-	in := PromptHistory{}
+// DefaultListDetectedProject executes a gorm list call
+func DefaultListDetectedProject(ctx context.Context, db *gorm.DB) ([]*DetectedProject, error) {
+	in := DetectedProject{}
 	ormObj, err := in.ToORM(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeListApplyQuery); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeListApplyQuery); ok {
 		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
 			return nil, err
 		}
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithBeforeListFind); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithBeforeListFind); ok {
 		if db, err = hook.BeforeListFind(ctx, db); err != nil {
 			return nil, err
 		}
 	}
 	db = db.Where(&ormObj)
-	db = db.Order("prompt_hash")
-	ormResponse := []PromptHistoryORM{}
+	db = db.Order("id")
+	ormResponse := []DetectedProjectORM{}
 	if err := db.Find(&ormResponse).Error; err != nil {
 		return nil, err
 	}
-	if hook, ok := interface{}(&ormObj).(PromptHistoryORMWithAfterListFind); ok {
+	if hook, ok := interface{}(&ormObj).(DetectedProjectORMWithAfterListFind); ok {
 		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
 			return nil, err
 		}
 	}
-	pbResponse := []*PromptHistory{}
+	pbResponse := []*DetectedProject{}
 	for _, responseEntry := range ormResponse {
 		temp, err := responseEntry.ToPB(ctx)
 		if err != nil {
@@ -1029,12 +5071,12 @@ func DefaultListPromptHistory(ctx context.Context, db *gorm.DB) ([]*PromptHistor
 	return pbResponse, nil
 }
 
-type PromptHistoryORMWithBeforeListApplyQuery interface {
+type DetectedProjectORMWithBeforeListApplyQuery interface {
 	BeforeListApplyQuery(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithBeforeListFind interface {
+type DetectedProjectORMWithBeforeListFind interface {
 	BeforeListFind(context.Context, *gorm.DB) (*gorm.DB, error)
 }
-type PromptHistoryORMWithAfterListFind interface {
-	AfterListFind(context.Context, *gorm.DB, *[]PromptHistoryORM) error
+type DetectedProjectORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm.DB, *[]DetectedProjectORM) error
 }
@@ -2,15 +2,20 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+	"github.com/focusd-so/brain/internal/secrets"
 	"github.com/o1egl/paseto"
 )
 
@@ -18,20 +23,28 @@ import (
 // 1. CONFIGURATION & KEYS
 // ---------------------------------------------------------
 
+// ErrKeysNotConfigured means PASETO_KEYS is missing or unusable, a server
+// misconfiguration rather than a problem with the caller's token
+var ErrKeysNotConfigured = errors.New("PASETO_KEYS not configured")
+
 // KeyManager handles rotation. Keys are stored in env var:
 // PASETO_KEYS="HEX_KEY_NEW,HEX_KEY_OLD"
 type KeyManager struct{}
 
 func (km KeyManager) GetActiveKey() ([]byte, error) {
-	keys := strings.Split(os.Getenv("PASETO_KEYS"), ",")
+	keys := strings.Split(secrets.Get("PASETO_KEYS"), ",")
 	if len(keys) == 0 || keys[0] == "" {
-		return nil, errors.New("PASETO_KEYS not configured")
+		return nil, ErrKeysNotConfigured
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(keys[0]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hex key: %v", ErrKeysNotConfigured, err)
 	}
-	return hex.DecodeString(strings.TrimSpace(keys[0]))
+	return key, nil
 }
 
 func (km KeyManager) GetAllKeys() ([][]byte, error) {
-	rawKeys := strings.Split(os.Getenv("PASETO_KEYS"), ",")
+	rawKeys := strings.Split(secrets.Get("PASETO_KEYS"), ",")
 	var parsedKeys [][]byte
 
 	for _, k := range rawKeys {
@@ -40,17 +53,32 @@ func (km KeyManager) GetAllKeys() ([][]byte, error) {
 		}
 		b, err := hex.DecodeString(strings.TrimSpace(k))
 		if err != nil {
-			return nil, fmt.Errorf("invalid hex key: %v", err)
+			return nil, fmt.Errorf("%w: invalid hex key: %v", ErrKeysNotConfigured, err)
 		}
 		parsedKeys = append(parsedKeys, b)
 	}
 
 	if len(parsedKeys) == 0 {
-		return nil, errors.New("no valid keys found")
+		return nil, fmt.Errorf("%w: no valid keys found", ErrKeysNotConfigured)
 	}
 	return parsedKeys, nil
 }
 
+// CheckKeys verifies PASETO_KEYS holds usable v2.local keys, so a server
+// that could not validate any token fails at startup instead
+func CheckKeys() error {
+	keys, err := KeyManager{}.GetAllKeys()
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if len(key) != 32 {
+			return fmt.Errorf("%w: key %d is %d bytes, want 32", ErrKeysNotConfigured, i, len(key))
+		}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------
 // 2. DATA STRUCTURES
 // ---------------------------------------------------------
@@ -60,11 +88,31 @@ type UserClaims struct {
 	UserID    int64     `json:"sub"`
 	Role      string    `json:"role"` // "anonymous" or "pro"
 	ExpiresAt time.Time `json:"exp"`
+	TokenID   string    `json:"jti,omitempty"` // identifies the session for listing and revocation
+	// DeviceHash binds the token to the device fingerprint presented at handshake
+	DeviceHash string `json:"dfp,omitempty"`
+}
+
+// Bounds of the clock skew tolerated on token expiry, set via TOKEN_EXPIRY_LEEWAY
+const (
+	DefaultExpiryLeeway = 30 * time.Second
+	maxExpiryLeeway     = 5 * time.Minute
+)
+
+// ExpiryLeeway returns how long past ExpiresAt a token is still accepted, so
+// small client/server clock skew does not reject it at the boundary. Invalid
+// or negative values fall back to the default, larger ones are capped.
+func ExpiryLeeway() time.Duration {
+	leeway, err := time.ParseDuration(os.Getenv("TOKEN_EXPIRY_LEEWAY"))
+	if err != nil || leeway < 0 {
+		return DefaultExpiryLeeway
+	}
+	return min(leeway, maxExpiryLeeway)
 }
 
-// Valid checks if token is expired
+// Valid checks if token is expired, allowing for ExpiryLeeway
 func (c *UserClaims) Valid() error {
-	if time.Now().After(c.ExpiresAt) {
+	if time.Now().After(c.ExpiresAt.Add(ExpiryLeeway())) {
 		return errors.New("token expired")
 	}
 	return nil
@@ -76,21 +124,54 @@ func (c *UserClaims) Valid() error {
 
 // MintToken creates a new encrypted PASETO token
 func MintToken(userID int64, role string) (string, error) {
+	token, _, err := MintSession(userID, role)
+	return token, err
+}
+
+// MintSession creates a new encrypted PASETO token and returns its claims,
+// including the random token ID callers track the session by
+func MintSession(userID int64, role string) (string, *UserClaims, error) {
+	return MintBoundSession(userID, role, "")
+}
+
+// MintBoundSession is MintSession for a token bound to the device fingerprint,
+// only the fingerprint's hash is stored in the token. An empty fingerprint
+// mints an unbound token.
+func MintBoundSession(userID int64, role, fingerprint string) (string, *UserClaims, error) {
 	km := KeyManager{}
 	key, err := km.GetActiveKey()
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token id: %w", err)
 	}
 
 	now := time.Now()
-	claims := UserClaims{
+	claims := &UserClaims{
 		UserID:    userID,
 		Role:      role,
 		ExpiresAt: now.Add(24 * time.Hour), // 24h Session
+		TokenID:   hex.EncodeToString(tokenID),
+	}
+	if fingerprint != "" {
+		claims.DeviceHash = HashFingerprint(fingerprint)
 	}
 
 	// Sign & Encrypt (v2.local)
-	return paseto.NewV2().Encrypt(key, claims, nil)
+	token, err := paseto.NewV2().Encrypt(key, claims, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+// HashFingerprint returns the hex SHA-256 of a device fingerprint
+func HashFingerprint(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(sum[:])
 }
 
 // ValidateToken decrypts the token trying all available keys
@@ -126,14 +207,97 @@ func ValidateToken(tokenStr string) (*UserClaims, error) {
 
 type authKey struct{}
 
+// publicProcedures are served without a session token
+var publicProcedures = map[string]bool{
+	brainv1connect.BrainServiceDeviceHandshakeProcedure:          true,
+	brainv1connect.BrainServiceVerifyHandshakeSignatureProcedure: true,
+}
+
+// DeviceFingerprintHeader carries the caller's device fingerprint, checked
+// against bound tokens when device binding is enforced
+const DeviceFingerprintHeader = "X-Device-Fingerprint"
+
+// SessionChecker rejects tokens whose session was revoked or is unknown
+type SessionChecker interface {
+	CheckSession(ctx context.Context, claims *UserClaims) error
+}
+
+// InterceptorOption configures the auth interceptor
+type InterceptorOption func(*authInterceptor)
+
+// WithSessionChecker checks every validated token against the session store
+func WithSessionChecker(sessions SessionChecker) InterceptorOption {
+	return func(i *authInterceptor) {
+		i.sessions = sessions
+	}
+}
+
+// WithDeviceBinding rejects bound tokens presented without the fingerprint
+// they were minted for. Tokens minted without a fingerprint still pass.
+func WithDeviceBinding() InterceptorOption {
+	return func(i *authInterceptor) {
+		i.deviceBinding = true
+	}
+}
+
 // authInterceptor implements the connect.Interceptor interface
-type authInterceptor struct{}
+type authInterceptor struct {
+	sessions      SessionChecker
+	deviceBinding bool
+}
+
+// tokenError maps a token validation failure onto a Connect error. Missing
+// keys are the server's fault, so the client is not told its token is bad.
+func tokenError(err error) *connect.Error {
+	if errors.Is(err, ErrKeysNotConfigured) {
+		slog.Error("cannot validate tokens", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("server authentication is misconfigured"))
+	}
+	return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired session"))
+}
+
+// CheckDevice verifies a fingerprint against the token's bound fingerprint.
+// Tokens minted without a fingerprint pass. Callers outside the interceptor
+// use it to apply the same binding to their own routes.
+func CheckDevice(claims *UserClaims, fingerprint string) error {
+	if claims.DeviceHash == "" {
+		return nil
+	}
+	if fingerprint == "" || subtle.ConstantTimeCompare([]byte(HashFingerprint(fingerprint)), []byte(claims.DeviceHash)) != 1 {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("token not bound to this device"))
+	}
+	return nil
+}
+
+// checkDevice applies CheckDevice when device binding is enforced
+func (i *authInterceptor) checkDevice(claims *UserClaims, fingerprint string) error {
+	if !i.deviceBinding {
+		return nil
+	}
+	return CheckDevice(claims, fingerprint)
+}
+
+// checkSession rejects revoked sessions when a SessionChecker is configured.
+// Connect errors from the checker (e.g. an unavailable store) pass through.
+func (i *authInterceptor) checkSession(ctx context.Context, claims *UserClaims) error {
+	if i.sessions == nil {
+		return nil
+	}
+	if err := i.sessions.CheckSession(ctx, claims); err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return connectErr
+		}
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired session"))
+	}
+	return nil
+}
 
 // WrapUnary implements unary RPC authentication
 func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 		// 1. Skip Auth for specific public endpoints (like Handshake)
-		if req.Spec().Procedure == brainv1connect.BrainServiceDeviceHandshakeProcedure {
+		if publicProcedures[req.Spec().Procedure] {
 			return next(ctx, req)
 		}
 
@@ -150,11 +314,17 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 		// 3. Validate PASETO
 		claims, err := ValidateToken(token)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired session"))
+			return nil, tokenError(err)
+		}
+		if err := i.checkSession(ctx, claims); err != nil {
+			return nil, err
+		}
+		if err := i.checkDevice(claims, req.Header().Get(DeviceFingerprintHeader)); err != nil {
+			return nil, err
 		}
 
 		// 4. Inject Claims into Context
-		ctx = context.WithValue(ctx, authKey{}, claims)
+		ctx = WithUser(ctx, claims)
 
 		return next(ctx, req)
 	}
@@ -169,7 +339,7 @@ func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc)
 func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
 		// 1. Skip Auth for specific public endpoints (like Handshake)
-		if conn.Spec().Procedure == brainv1connect.BrainServiceDeviceHandshakeProcedure {
+		if publicProcedures[conn.Spec().Procedure] {
 			return next(ctx, conn)
 		}
 
@@ -186,19 +356,29 @@ func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc
 		// 3. Validate PASETO
 		claims, err := ValidateToken(token)
 		if err != nil {
-			return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired session"))
+			return tokenError(err)
+		}
+		if err := i.checkSession(ctx, claims); err != nil {
+			return err
+		}
+		if err := i.checkDevice(claims, conn.RequestHeader().Get(DeviceFingerprintHeader)); err != nil {
+			return err
 		}
 
 		// 4. Inject Claims into Context
-		ctx = context.WithValue(ctx, authKey{}, claims)
+		ctx = WithUser(ctx, claims)
 
 		return next(ctx, conn)
 	}
 }
 
 // NewAuthInterceptor creates a ConnectRPC interceptor for both unary and streaming
-func NewAuthInterceptor() connect.Interceptor {
-	return &authInterceptor{}
+func NewAuthInterceptor(opts ...InterceptorOption) connect.Interceptor {
+	i := &authInterceptor{}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
 }
 
 // NewStreamAuthInterceptor is deprecated - use NewAuthInterceptor which handles both
@@ -207,6 +387,11 @@ func NewStreamAuthInterceptor() connect.Interceptor {
 	return NewAuthInterceptor()
 }
 
+// WithUser returns a copy of ctx carrying the given claims
+func WithUser(ctx context.Context, claims *UserClaims) context.Context {
+	return context.WithValue(ctx, authKey{}, claims)
+}
+
 // GetUser extracts user data from context in your API handlers
 func GetUser(ctx context.Context) (*UserClaims, bool) {
 	u, ok := ctx.Value(authKey{}).(*UserClaims)
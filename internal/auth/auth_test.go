@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserClaimsValid_ExpiryLeeway(t *testing.T) {
+	for name, tc := range map[string]struct {
+		leeway  string
+		expired time.Duration
+		wantErr bool
+	}{
+		"not expired":              {expired: -time.Minute},
+		"just past, within leeway": {expired: 10 * time.Second},
+		"well past":                {expired: time.Minute, wantErr: true},
+		"configured leeway":        {leeway: "2m", expired: time.Minute},
+		"leeway disabled":          {leeway: "0s", expired: time.Second, wantErr: true},
+		"leeway capped":            {leeway: "24h", expired: time.Hour, wantErr: true},
+		"invalid leeway":           {leeway: "soon", expired: 10 * time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TOKEN_EXPIRY_LEEWAY", tc.leeway)
+			claims := &UserClaims{ExpiresAt: time.Now().Add(-tc.expired)}
+			if err := claims.Valid(); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
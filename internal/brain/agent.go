@@ -6,17 +6,20 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
-	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"connectrpc.com/connect"
 	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/google/uuid"
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
-	"google.golang.org/adk/model/gemini"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
@@ -24,12 +27,59 @@ import (
 	"google.golang.org/genai"
 )
 
+// defaultAgentMaxResponseBytes caps how much agent output is buffered for a single run
+const defaultAgentMaxResponseBytes = 1 << 20
+
+// Default caps on what a run request may define, overridable via
+// AGENT_MAX_SUBAGENTS and AGENT_MAX_TOOLS_PER_AGENT
+const (
+	defaultAgentMaxSubagents     = 16
+	defaultAgentMaxToolsPerAgent = 32
+)
+
+// agentTruncatedMarker is appended to responses cut at the size cap
+const agentTruncatedMarker = "\n\n[response truncated]"
+
 type AgentSession struct {
 	mu         *sync.Mutex
 	toolsQueue map[string]chan *brainv1.AgentSessionRequest_ToolCallResponse
 }
 
+// agentStream is the part of the bidi stream the agent session needs
+type agentStream interface {
+	Send(*brainv1.AgentSessionResponse) error
+	Receive() (*brainv1.AgentSessionRequest, error)
+}
+
 func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStream[brainv1.AgentSessionRequest, brainv1.AgentSessionResponse]) error {
+	return s.runAgentSession(ctx, stream)
+}
+
+// agentUserID scopes agent sessions to the authenticated user
+func agentUserID(ctx context.Context) string {
+	if user, ok := auth.GetUser(ctx); ok {
+		return strconv.FormatInt(user.UserID, 10)
+	}
+	return "user"
+}
+
+// checkAgentLimits rejects run requests defining more subagents, or more tools
+// on one agent, than configured. A non-positive limit disables its check.
+func checkAgentLimits(runReq *brainv1.AgentSessionRequest_RunRequest) error {
+	agents := runReq.GetAgents()
+	if limit := envInt("AGENT_MAX_SUBAGENTS", defaultAgentMaxSubagents); limit > 0 && len(agents) > limit {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("too many agents: %d, at most %d allowed", len(agents), limit))
+	}
+	limit := envInt("AGENT_MAX_TOOLS_PER_AGENT", defaultAgentMaxToolsPerAgent)
+	for _, agent := range agents {
+		if tools := len(agent.GetTools()); limit > 0 && tools > limit {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent %q has too many tools: %d, at most %d allowed", agent.GetName(), tools, limit))
+		}
+	}
+	return nil
+}
+
+func (s *ServiceImpl) runAgentSession(ctx context.Context, stream agentStream) error {
 	a := &AgentSession{
 		toolsQueue: make(map[string]chan *brainv1.AgentSessionRequest_ToolCallResponse),
 		mu:         &sync.Mutex{},
@@ -45,15 +95,33 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 		slog.Error("AgentSession: missing run request")
 		return fmt.Errorf("missing run request")
 	}
+	if err := checkAgentLimits(message.GetRunRequest()); err != nil {
+		slog.Warn("AgentSession: run request over limits", "error", err)
+		return err
+	}
 
-	model, err := gemini.NewModel(ctx, "gemini-2.5-pro", &genai.ClientConfig{
-		APIKey: os.Getenv("GEMINI_API_KEY"),
-	})
+	model, err := s.newAgentModel(ctx)
 	if err != nil {
 		slog.Error("AgentSession: failed to create model", "error", err)
-		log.Fatalf("Failed to create model: %v", err)
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to create model: %w", err))
+	}
+
+	// Identical runs may be answered from the cache when it is enabled
+	userID := agentUserID(ctx)
+	var cacheKey string
+	if ttl := agentCacheTTL(); ttl > 0 && s.gormDB != nil {
+		cacheKey, err = agentCacheKey(userID, model.Name(), message.GetRunRequest())
+		if err != nil {
+			slog.Warn("AgentSession: response cache disabled for run", "error", err)
+		} else if cached, ok := s.cachedAgentResponse(ctx, cacheKey); ok {
+			slog.Info("AgentSession: serving cached response", "response_length", len(cached))
+			return sendAgentRunResponse(stream, cached)
+		}
 	}
 
+	// Tool calls reach the client, whose state the output may depend on
+	var toolCalls atomic.Int32
+
 	subAgents := []agent.Agent{}
 
 	for _, agent := range message.GetRunRequest().GetAgents() {
@@ -99,6 +167,7 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 				}
 
 				requestID := uuid.New().String()
+				toolCalls.Add(1)
 
 				// Send tool call request to client
 				if err := stream.Send(&brainv1.AgentSessionResponse{
@@ -197,9 +266,9 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 	}
 
 	slog.Info("AgentSession: creating runner")
-	sessService := session.InMemoryService()
+	sessService := s.newAgentSessions()
 	r, err := runner.New(runner.Config{
-		AppName:        "focusd",
+		AppName:        s.agentAppName,
 		Agent:          rootAgent,
 		SessionService: sessService,
 	})
@@ -220,10 +289,10 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 
 	// Generate session ID and create session
 	sessionID := uuid.New().String()
-	slog.Info("AgentSession: creating session", "session_id", sessionID)
+	slog.Info("AgentSession: creating session", "session_id", sessionID, "user_id", userID)
 	_, err = sessService.Create(ctx, &session.CreateRequest{
-		AppName:   "focusd",
-		UserID:    "user",
+		AppName:   s.agentAppName,
+		UserID:    userID,
 		SessionID: sessionID,
 	})
 	if err != nil {
@@ -231,39 +300,95 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
-	// Run the agent and collect events
+	// Run the agent and collect events. Streaming runs ask the model for
+	// partial responses and send each part as it arrives; the text is still
+	// gathered, up to the cap, for the cache.
 	slog.Info("AgentSession: starting agent run")
-	var responseText string
-	for event, err := range r.Run(ctx, "user", sessionID, userMsg, agent.RunConfig{}) {
+	streaming := message.GetRunRequest().GetStream()
+	runConfig := agent.RunConfig{}
+	if streaming {
+		runConfig.StreamingMode = agent.StreamingModeSSE
+	}
+	maxResponseBytes := envInt("AGENT_MAX_RESPONSE_BYTES", defaultAgentMaxResponseBytes)
+	var responseText strings.Builder
+	truncated, sentPartial := false, false
+run:
+	for event, err := range r.Run(ctx, userID, sessionID, userMsg, runConfig) {
 		if err != nil {
 			slog.Error("AgentSession: error during agent run", "error", err)
 			return fmt.Errorf("error during agent run: %w", err)
 		}
 
+		// A streamed response ends with an event aggregating its partials, already sent
+		if streaming && !event.Partial && sentPartial {
+			sentPartial = false
+			continue
+		}
+		sentPartial = sentPartial || event.Partial
+
 		// Collect response content from events (event embeds LLMResponse)
 		if event.Content != nil {
 			for _, part := range event.Content.Parts {
 				if part.Text != "" {
 					slog.Debug("AgentSession: received content part", "text_length", len(part.Text))
-					responseText += part.Text
+
+					text := part.Text
+					// Stop the run once the response outgrows the cap rather than buffering it all
+					if maxResponseBytes > 0 && responseText.Len()+len(text) > maxResponseBytes {
+						text = truncateBytes(text, maxResponseBytes-responseText.Len()) + agentTruncatedMarker
+						slog.Warn("AgentSession: response exceeded maximum size, truncating", "max_bytes", maxResponseBytes)
+						truncated = true
+					}
+					responseText.WriteString(text)
+					if streaming {
+						if err := sendAgentRunChunk(stream, text, true); err != nil {
+							return err
+						}
+					}
+					if truncated {
+						break run
+					}
 				}
 			}
 		}
 	}
 
-	// Send the generated content back to the client
-	slog.Info("AgentSession: agent run completed", "response_length", len(responseText))
-	slog.Info("AgentSession: sending run response to client")
+	slog.Info("AgentSession: agent run completed", "response_length", responseText.Len())
+	if cacheKey != "" && toolCalls.Load() == 0 && !truncated {
+		s.storeAgentResponse(ctx, cacheKey, responseText.String(), agentCacheTTL())
+	}
+
+	// Streamed content was already sent, an empty final response ends it
+	if streaming {
+		return sendAgentRunResponse(stream, "")
+	}
+	return sendAgentRunResponse(stream, responseText.String())
+}
+
+// sendAgentRunChunk sends content as a run response, marked partial when
+// more content follows
+func sendAgentRunChunk(stream agentStream, content string, partial bool) error {
 	if err := stream.Send(&brainv1.AgentSessionResponse{
 		Message: &brainv1.AgentSessionResponse_RunResponse_{
 			RunResponse: &brainv1.AgentSessionResponse_RunResponse{
-				Content: responseText,
+				Content: content,
+				Partial: partial,
 			},
 		},
 	}); err != nil {
 		slog.Error("AgentSession: failed to send run response", "error", err)
 		return fmt.Errorf("failed to send run response: %w", err)
 	}
+	return nil
+}
+
+// sendAgentRunResponse sends the final response and acknowledges the end of the session
+func sendAgentRunResponse(stream agentStream, responseText string) error {
+	// Send the generated content back to the client
+	slog.Info("AgentSession: sending run response to client")
+	if err := sendAgentRunChunk(stream, responseText, false); err != nil {
+		return err
+	}
 
 	// Send session end acknowledgment
 	slog.Info("AgentSession: sending session end acknowledgment")
@@ -282,3 +407,14 @@ func (s *ServiceImpl) AgentSession(ctx context.Context, stream *connect.BidiStre
 	slog.Info("AgentSession: session completed successfully")
 	return nil
 }
+
+// truncateBytes cuts s to at most n bytes without splitting a UTF-8 sequence
+func truncateBytes(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,62 @@
+package brain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// agentCacheTTL returns how long final agent responses are cached, set via
+// AGENT_RESPONSE_CACHE_TTL. The default of 0 disables the cache.
+func agentCacheTTL() time.Duration {
+	return envDuration("AGENT_RESPONSE_CACHE_TTL", 0)
+}
+
+// agentCacheKey hashes everything that shapes an agent run: the user, the
+// model and the run request. Entries share the classification cache table,
+// the prefix keeps them apart.
+func agentCacheKey(userID, modelName string, req *brainv1.AgentSessionRequest_RunRequest) (string, error) {
+	// Streaming only changes delivery, so both modes share entries
+	req = proto.CloneOf(req)
+	req.Stream = false
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode run request: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(userID + "\x00" + modelName + "\x00"))
+	h.Write(data)
+	return "agent:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cachedAgentResponse returns the unexpired response cached under key
+func (s *ServiceImpl) cachedAgentResponse(ctx context.Context, key string) (string, bool) {
+	var cache commonv1.PromptHistoryORM
+	err := s.gormDB.WithContext(ctx).
+		Where("prompt_hash = ? AND expires_at > ?", key, time.Now().Unix()).
+		Limit(1).
+		Find(&cache).Error
+	if err != nil {
+		slog.Warn("AgentSession: failed to read response cache", "error", err)
+		return "", false
+	}
+	return cache.ResponseJson, cache.PromptHash != ""
+}
+
+// storeAgentResponse caches a final response. Callers only store runs that
+// invoked no tools, whose output may depend on client state.
+func (s *ServiceImpl) storeAgentResponse(ctx context.Context, key, response string, ttl time.Duration) {
+	entry := newCacheEntry(key, response, ttl)
+	if err := s.gormDB.WithContext(ctx).Save(&entry).Error; err != nil {
+		slog.Warn("AgentSession: failed to cache response", "error", err)
+	}
+}
@@ -0,0 +1,56 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func runAgent(t *testing.T, svc *ServiceImpl, ctx context.Context, userMessage string) string {
+	t.Helper()
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{newRunRequest(userMessage)}}
+	if err := svc.runAgentSession(ctx, stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+	return stream.runResponse()
+}
+
+func TestAgentSession_CachesIdenticalToolFreeRuns(t *testing.T) {
+	t.Setenv("AGENT_RESPONSE_CACHE_TTL", "1h")
+
+	llm := &fakeLLM{reply: "hello"}
+	svc, _ := newTestAgentService(llm)
+	svc.gormDB = newTestDB(t)
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 42})
+
+	if got := runAgent(t, svc, ctx, "hi"); got != "hello" {
+		t.Fatalf("unexpected response %q", got)
+	}
+	llm.reply = "changed"
+	if got := runAgent(t, svc, ctx, "hi"); got != "hello" || llm.calls != 1 {
+		t.Fatalf("expected the cached response without a model call, got %q after %d calls", got, llm.calls)
+	}
+
+	// A different message or user runs the agent again
+	if got := runAgent(t, svc, ctx, "hi again"); got != "changed" {
+		t.Fatalf("expected a fresh run for another message, got %q", got)
+	}
+	other := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 43})
+	if got := runAgent(t, svc, other, "hi"); got != "changed" {
+		t.Fatalf("expected a fresh run for another user, got %q", got)
+	}
+}
+
+func TestAgentSession_CacheDisabledByDefault(t *testing.T) {
+	llm := &fakeLLM{reply: "hello"}
+	svc, _ := newTestAgentService(llm)
+	svc.gormDB = newTestDB(t)
+
+	runAgent(t, svc, context.Background(), "hi")
+	runAgent(t, svc, context.Background(), "hi")
+	if llm.calls != 2 {
+		t.Fatalf("expected both runs to call the model, got %d calls", llm.calls)
+	}
+}
@@ -0,0 +1,452 @@
+package brain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/gemini"
+	"google.golang.org/genai"
+
+	"github.com/focusd-so/brain/internal/secrets"
+)
+
+// Agent model providers selectable via AGENT_MODEL_PROVIDER
+const (
+	agentProviderGemini    = "gemini"
+	agentProviderOpenAI    = "openai"
+	agentProviderAnthropic = "anthropic"
+)
+
+const (
+	defaultAgentGeminiModel     = "gemini-2.5-pro"
+	defaultAgentMaxOutputTokens = 4096
+	defaultOpenAIBaseURL        = "https://api.openai.com/v1"
+	defaultAnthropicBaseURL     = "https://api.anthropic.com"
+)
+
+// selectAgentModel creates the agent model for the configured provider. Gemini is
+// the default; OpenAI-compatible and Anthropic endpoints require AGENT_MODEL.
+// AGENT_MODEL may list several models by priority, later ones are tried when
+// an earlier one is unavailable.
+func selectAgentModel(ctx context.Context) (model.LLM, error) {
+	provider := strings.ToLower(envString("AGENT_MODEL_PROVIDER", agentProviderGemini))
+	names := splitModels(os.Getenv("AGENT_MODEL"))
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	models := make([]model.LLM, 0, len(names))
+	for _, name := range names {
+		m, err := newAgentProviderModel(ctx, provider, name)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, m)
+	}
+	if len(models) == 1 {
+		return models[0], nil
+	}
+	return &fallbackLLM{models: models}, nil
+}
+
+// newAgentProviderModel creates a single model of the given provider
+func newAgentProviderModel(ctx context.Context, provider, modelName string) (model.LLM, error) {
+	switch provider {
+	case agentProviderGemini:
+		if modelName == "" {
+			modelName = defaultAgentGeminiModel
+		}
+		return gemini.NewModel(ctx, modelName, &genai.ClientConfig{
+			APIKey: secrets.Get("GEMINI_API_KEY"),
+		})
+
+	case agentProviderOpenAI:
+		apiKey := secrets.Get("OPENAI_API_KEY")
+		if apiKey == "" || modelName == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY and AGENT_MODEL must be set for the openai provider")
+		}
+		return &openAIModel{
+			name:    modelName,
+			baseURL: strings.TrimSuffix(envString("OPENAI_BASE_URL", defaultOpenAIBaseURL), "/"),
+			apiKey:  apiKey,
+			client:  &http.Client{Timeout: 2 * time.Minute},
+		}, nil
+
+	case agentProviderAnthropic:
+		apiKey := secrets.Get("ANTHROPIC_API_KEY")
+		if apiKey == "" || modelName == "" {
+			return nil, fmt.Errorf("ANTHROPIC_API_KEY and AGENT_MODEL must be set for the anthropic provider")
+		}
+		return &anthropicModel{
+			name:    modelName,
+			baseURL: strings.TrimSuffix(envString("ANTHROPIC_BASE_URL", defaultAnthropicBaseURL), "/"),
+			apiKey:  apiKey,
+			client:  &http.Client{Timeout: 2 * time.Minute},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown agent model provider %q", provider)
+	}
+}
+
+// ---------------------------------------------------------
+// OPENAI-COMPATIBLE (chat completions)
+// ---------------------------------------------------------
+
+type openAIModel struct {
+	name    string
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content,omitempty"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAITool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Parameters  any    `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+type openAIRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+	Tools    []openAITool    `json:"tools,omitempty"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (m *openAIModel) Name() string {
+	return m.name
+}
+
+// GenerateContent translates the request into a chat completion call. Streaming
+// is not supported, a single complete response is always yielded.
+func (m *openAIModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		body := openAIRequest{Model: m.name}
+
+		if system := systemInstruction(req); system != "" {
+			body.Messages = append(body.Messages, openAIMessage{Role: "system", Content: system})
+		}
+
+		for _, content := range req.Contents {
+			body.Messages = append(body.Messages, toOpenAIMessages(content)...)
+		}
+
+		for _, decl := range functionDeclarations(req) {
+			var t openAITool
+			t.Type = "function"
+			t.Function.Name = decl.Name
+			t.Function.Description = decl.Description
+			t.Function.Parameters = declarationSchema(decl)
+			body.Tools = append(body.Tools, t)
+		}
+
+		var resp openAIResponse
+		if err := postJSON(ctx, m.client, m.baseURL+"/chat/completions", map[string]string{
+			"Authorization": "Bearer " + m.apiKey,
+		}, body, &resp); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		if len(resp.Choices) == 0 {
+			yield(nil, fmt.Errorf("empty response from openai-compatible model"))
+			return
+		}
+
+		msg := resp.Choices[0].Message
+		content := &genai.Content{Role: genai.RoleModel}
+		if msg.Content != "" {
+			content.Parts = append(content.Parts, genai.NewPartFromText(msg.Content))
+		}
+		for _, call := range msg.ToolCalls {
+			args := map[string]any{}
+			if call.Function.Arguments != "" {
+				if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+					yield(nil, fmt.Errorf("invalid tool call arguments: %w", err))
+					return
+				}
+			}
+			content.Parts = append(content.Parts, &genai.Part{
+				FunctionCall: &genai.FunctionCall{ID: call.ID, Name: call.Function.Name, Args: args},
+			})
+		}
+
+		yield(&model.LLMResponse{Content: content, TurnComplete: true}, nil)
+	}
+}
+
+func toOpenAIMessages(content *genai.Content) []openAIMessage {
+	role := "user"
+	if content.Role == genai.RoleModel {
+		role = "assistant"
+	}
+
+	var messages []openAIMessage
+	msg := openAIMessage{Role: role}
+	for _, part := range content.Parts {
+		switch {
+		case part.Text != "":
+			msg.Content += part.Text
+		case part.FunctionCall != nil:
+			args, _ := json.Marshal(part.FunctionCall.Args)
+			var call openAIToolCall
+			call.ID = part.FunctionCall.ID
+			call.Type = "function"
+			call.Function.Name = part.FunctionCall.Name
+			call.Function.Arguments = string(args)
+			msg.ToolCalls = append(msg.ToolCalls, call)
+		case part.FunctionResponse != nil:
+			output, _ := json.Marshal(part.FunctionResponse.Response)
+			messages = append(messages, openAIMessage{
+				Role:       "tool",
+				ToolCallID: part.FunctionResponse.ID,
+				Content:    string(output),
+			})
+		}
+	}
+
+	if msg.Content != "" || len(msg.ToolCalls) > 0 {
+		messages = append([]openAIMessage{msg}, messages...)
+	}
+	return messages
+}
+
+// ---------------------------------------------------------
+// ANTHROPIC (messages API)
+// ---------------------------------------------------------
+
+type anthropicModel struct {
+	name    string
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+type anthropicBlock struct {
+	Type      string         `json:"type"`
+	Text      string         `json:"text,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Input     map[string]any `json:"input,omitempty"`
+	ToolUseID string         `json:"tool_use_id,omitempty"`
+	Content   string         `json:"content,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string           `json:"role"`
+	Content []anthropicBlock `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Tools     []anthropicTool    `json:"tools,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []anthropicBlock `json:"content"`
+}
+
+func (m *anthropicModel) Name() string {
+	return m.name
+}
+
+// GenerateContent translates the request into a messages API call. Streaming
+// is not supported, a single complete response is always yielded.
+func (m *anthropicModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		body := anthropicRequest{
+			Model:     m.name,
+			MaxTokens: envInt("AGENT_MAX_OUTPUT_TOKENS", defaultAgentMaxOutputTokens),
+			System:    systemInstruction(req),
+		}
+
+		for _, content := range req.Contents {
+			role := "user"
+			if content.Role == genai.RoleModel {
+				role = "assistant"
+			}
+
+			msg := anthropicMessage{Role: role}
+			for _, part := range content.Parts {
+				switch {
+				case part.Text != "":
+					msg.Content = append(msg.Content, anthropicBlock{Type: "text", Text: part.Text})
+				case part.FunctionCall != nil:
+					msg.Content = append(msg.Content, anthropicBlock{
+						Type:  "tool_use",
+						ID:    part.FunctionCall.ID,
+						Name:  part.FunctionCall.Name,
+						Input: part.FunctionCall.Args,
+					})
+				case part.FunctionResponse != nil:
+					output, _ := json.Marshal(part.FunctionResponse.Response)
+					msg.Content = append(msg.Content, anthropicBlock{
+						Type:      "tool_result",
+						ToolUseID: part.FunctionResponse.ID,
+						Content:   string(output),
+					})
+				}
+			}
+			if len(msg.Content) > 0 {
+				body.Messages = append(body.Messages, msg)
+			}
+		}
+
+		for _, decl := range functionDeclarations(req) {
+			schema := declarationSchema(decl)
+			if schema == nil {
+				schema = map[string]any{"type": "object"}
+			}
+			body.Tools = append(body.Tools, anthropicTool{
+				Name:        decl.Name,
+				Description: decl.Description,
+				InputSchema: schema,
+			})
+		}
+
+		var resp anthropicResponse
+		if err := postJSON(ctx, m.client, m.baseURL+"/v1/messages", map[string]string{
+			"x-api-key":         m.apiKey,
+			"anthropic-version": "2023-06-01",
+		}, body, &resp); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		content := &genai.Content{Role: genai.RoleModel}
+		for _, block := range resp.Content {
+			switch block.Type {
+			case "text":
+				content.Parts = append(content.Parts, genai.NewPartFromText(block.Text))
+			case "tool_use":
+				content.Parts = append(content.Parts, &genai.Part{
+					FunctionCall: &genai.FunctionCall{ID: block.ID, Name: block.Name, Args: block.Input},
+				})
+			}
+		}
+
+		yield(&model.LLMResponse{Content: content, TurnComplete: true}, nil)
+	}
+}
+
+// ---------------------------------------------------------
+// SHARED HELPERS
+// ---------------------------------------------------------
+
+// systemInstruction flattens the request's system instruction into plain text
+func systemInstruction(req *model.LLMRequest) string {
+	if req.Config == nil || req.Config.SystemInstruction == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range req.Config.SystemInstruction.Parts {
+		if part.Text != "" {
+			parts = append(parts, part.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// functionDeclarations collects every tool declaration in the request
+func functionDeclarations(req *model.LLMRequest) []*genai.FunctionDeclaration {
+	if req.Config == nil {
+		return nil
+	}
+	var decls []*genai.FunctionDeclaration
+	for _, t := range req.Config.Tools {
+		if t != nil {
+			decls = append(decls, t.FunctionDeclarations...)
+		}
+	}
+	return decls
+}
+
+// declarationSchema prefers the raw JSON schema ADK attaches to function tools
+func declarationSchema(decl *genai.FunctionDeclaration) any {
+	if decl.ParametersJsonSchema != nil {
+		return decl.ParametersJsonSchema
+	}
+	if decl.Parameters != nil {
+		return decl.Parameters
+	}
+	return nil
+}
+
+// postJSON sends body as JSON and decodes a successful JSON response into out
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal model request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create model request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("model request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read model response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &modelAPIError{StatusCode: resp.StatusCode, Body: truncateBytes(string(respBody), 512)}
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode model response: %w", err)
+	}
+	return nil
+}
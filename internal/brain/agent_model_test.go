@@ -0,0 +1,79 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestSelectAgentModel_OpenAIRequiresModel(t *testing.T) {
+	t.Setenv("AGENT_MODEL_PROVIDER", "openai")
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("AGENT_MODEL", "")
+
+	if _, err := selectAgentModel(context.Background()); err == nil {
+		t.Fatal("expected error when AGENT_MODEL is unset")
+	}
+}
+
+func TestSelectAgentModel_UnknownProvider(t *testing.T) {
+	t.Setenv("AGENT_MODEL_PROVIDER", "mystery")
+
+	if _, err := selectAgentModel(context.Background()); err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+}
+
+func TestSelectAgentModel_Anthropic(t *testing.T) {
+	t.Setenv("AGENT_MODEL_PROVIDER", "anthropic")
+	t.Setenv("ANTHROPIC_API_KEY", "test")
+	t.Setenv("AGENT_MODEL", "claude-sonnet-4-5")
+
+	llm, err := selectAgentModel(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := llm.Name(); got != "claude-sonnet-4-5" {
+		t.Errorf("expected model name claude-sonnet-4-5, got %q", got)
+	}
+}
+
+func TestAgentSession_OpenAICompatibleModel(t *testing.T) {
+	var gotModel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" || r.Header.Get("Authorization") != "Bearer test" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gotModel = req.Model
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi from openai"}}]}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("AGENT_MODEL_PROVIDER", "openai")
+	t.Setenv("AGENT_MODEL", "gpt-test")
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("OPENAI_BASE_URL", srv.URL)
+
+	svc := NewServiceImpl(nil)
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{newRunRequest("hi")}}
+
+	if err := svc.runAgentSession(context.Background(), stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+	if gotModel != "gpt-test" {
+		t.Errorf("expected request for gpt-test, got %q", gotModel)
+	}
+	if got := stream.runResponse(); got != "hi from openai" {
+		t.Errorf("expected run response %q, got %q", "hi from openai", got)
+	}
+}
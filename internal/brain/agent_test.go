@@ -0,0 +1,265 @@
+package brain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+)
+
+// fakeAgentStream feeds a fixed set of requests and records responses
+type fakeAgentStream struct {
+	mu        sync.Mutex
+	requests  []*brainv1.AgentSessionRequest
+	responses []*brainv1.AgentSessionResponse
+}
+
+func (f *fakeAgentStream) Receive() (*brainv1.AgentSessionRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeAgentStream) Send(resp *brainv1.AgentSessionResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func (f *fakeAgentStream) runResponse() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, resp := range f.responses {
+		if rr := resp.GetRunResponse(); rr != nil {
+			return rr.GetContent()
+		}
+	}
+	return ""
+}
+
+// fakeLLM replies with a fixed text
+type fakeLLM struct {
+	reply string
+	calls int
+}
+
+func (f *fakeLLM) Name() string { return "fake-model" }
+
+func (f *fakeLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	f.calls++
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(&model.LLMResponse{
+			Content:      genai.NewContentFromText(f.reply, genai.RoleModel),
+			TurnComplete: true,
+		}, nil)
+	}
+}
+
+// recordingSessions captures session create requests
+type recordingSessions struct {
+	session.Service
+	created []*session.CreateRequest
+}
+
+func (r *recordingSessions) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	r.created = append(r.created, req)
+	return r.Service.Create(ctx, req)
+}
+
+func newRunRequest(userMessage string) *brainv1.AgentSessionRequest {
+	return &brainv1.AgentSessionRequest{
+		Message: &brainv1.AgentSessionRequest_RunRequest_{
+			RunRequest: &brainv1.AgentSessionRequest_RunRequest{
+				Instruction: "You are helpful.",
+				UserMessage: userMessage,
+			},
+		},
+	}
+}
+
+func newTestAgentService(llm model.LLM) (*ServiceImpl, *recordingSessions) {
+	sessions := &recordingSessions{Service: session.InMemoryService()}
+	svc := NewServiceImpl(nil)
+	svc.newAgentModel = func(ctx context.Context) (model.LLM, error) { return llm, nil }
+	svc.newAgentSessions = func() session.Service { return sessions }
+	return svc, sessions
+}
+
+func TestAgentSession_ScopesSessionToAuthenticatedUser(t *testing.T) {
+	t.Setenv("AGENT_APP_NAME", "focusd-test")
+
+	svc, sessions := newTestAgentService(&fakeLLM{reply: "hello"})
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{newRunRequest("hi")}}
+
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 42, Role: "pro"})
+	if err := svc.runAgentSession(ctx, stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+
+	if len(sessions.created) != 1 {
+		t.Fatalf("expected one session, got %d", len(sessions.created))
+	}
+	if got := sessions.created[0].UserID; got != "42" {
+		t.Errorf("expected session user id 42, got %q", got)
+	}
+	if got := sessions.created[0].AppName; got != "focusd-test" {
+		t.Errorf("expected app name focusd-test, got %q", got)
+	}
+	if got := stream.runResponse(); got != "hello" {
+		t.Errorf("expected run response %q, got %q", "hello", got)
+	}
+}
+
+func TestAgentSession_TruncatesOversizedResponse(t *testing.T) {
+	t.Setenv("AGENT_MAX_RESPONSE_BYTES", "10")
+
+	svc, _ := newTestAgentService(&fakeLLM{reply: "0123456789abcdefghij"})
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{newRunRequest("hi")}}
+
+	if err := svc.runAgentSession(context.Background(), stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+
+	if got, want := stream.runResponse(), "0123456789"+agentTruncatedMarker; got != want {
+		t.Fatalf("expected truncated response %q, got %q", want, got)
+	}
+}
+
+func TestTruncateBytes_KeepsRunesIntact(t *testing.T) {
+	if got := truncateBytes("héllo", 2); got != "h" {
+		t.Fatalf("expected cut before multi-byte rune, got %q", got)
+	}
+	if got := truncateBytes("hello", 10); got != "hello" {
+		t.Fatalf("expected short string untouched, got %q", got)
+	}
+}
+
+// withAgents adds agents, each with the given number of tools, to a run request
+func withAgents(req *brainv1.AgentSessionRequest, agents, tools int) *brainv1.AgentSessionRequest {
+	run := req.GetRunRequest()
+	for a := range agents {
+		agent := &brainv1.AgentSessionRequest_Agent{Name: fmt.Sprintf("agent_%d", a), Instruction: "Help."}
+		for i := range tools {
+			agent.Tools = append(agent.Tools, &brainv1.AgentSessionRequest_Agent_Tool{Name: fmt.Sprintf("tool_%d_%d", a, i), Description: "A tool."})
+		}
+		run.Agents = append(run.Agents, agent)
+	}
+	return req
+}
+
+func TestAgentSession_EnforcesAgentAndToolLimits(t *testing.T) {
+	t.Setenv("AGENT_MAX_SUBAGENTS", "2")
+	t.Setenv("AGENT_MAX_TOOLS_PER_AGENT", "3")
+
+	svc, _ := newTestAgentService(&fakeLLM{reply: "done"})
+	within := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{withAgents(newRunRequest("hi"), 2, 3)}}
+	if err := svc.runAgentSession(context.Background(), within); err != nil {
+		t.Fatalf("expected a run within limits to succeed, got %v", err)
+	}
+	if got := within.runResponse(); got != "done" {
+		t.Fatalf("unexpected response %q", got)
+	}
+
+	for name, req := range map[string]*brainv1.AgentSessionRequest{
+		"too many agents": withAgents(newRunRequest("hi"), 3, 1),
+		"too many tools":  withAgents(newRunRequest("hi"), 1, 4),
+	} {
+		err := svc.runAgentSession(context.Background(), &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{req}})
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: expected invalid_argument, got %v", name, err)
+		}
+	}
+}
+
+// chunkedLLM replies with its text split over several responses when asked
+// to stream, ending like Gemini with one aggregating the text. Otherwise it
+// only sends that aggregate.
+type chunkedLLM struct {
+	chunks []string
+}
+
+func (f *chunkedLLM) Name() string { return "fake-model" }
+
+func (f *chunkedLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if stream {
+			for _, chunk := range f.chunks {
+				if !yield(&model.LLMResponse{Content: genai.NewContentFromText(chunk, genai.RoleModel), Partial: true}, nil) {
+					return
+				}
+			}
+		}
+		yield(&model.LLMResponse{
+			Content:      genai.NewContentFromText(strings.Join(f.chunks, ""), genai.RoleModel),
+			TurnComplete: true,
+		}, nil)
+	}
+}
+
+func TestAgentSession_StreamsPartialResponses(t *testing.T) {
+	t.Setenv("AGENT_MAX_RESPONSE_BYTES", "12")
+
+	svc, _ := newTestAgentService(&chunkedLLM{chunks: []string{"first ", "second ", "third"}})
+	req := newRunRequest("hi")
+	req.GetRunRequest().Stream = true
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{req}}
+
+	if err := svc.runAgentSession(context.Background(), stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+
+	var chunks []string
+	var partial []bool
+	for _, resp := range stream.responses {
+		if rr := resp.GetRunResponse(); rr != nil {
+			chunks = append(chunks, rr.GetContent())
+			partial = append(partial, rr.GetPartial())
+		}
+	}
+	// Each part is flushed as it arrives and the run stops at the cap
+	want := []string{"first ", "second" + agentTruncatedMarker, ""}
+	if fmt.Sprint(chunks) != fmt.Sprint(want) {
+		t.Fatalf("expected chunks %q, got %q", want, chunks)
+	}
+	if fmt.Sprint(partial) != fmt.Sprint([]bool{true, true, false}) {
+		t.Fatalf("expected only the last run response to be final, got %v", partial)
+	}
+}
+
+func TestAgentSession_StreamSkipsAggregatedResponse(t *testing.T) {
+	svc, _ := newTestAgentService(&chunkedLLM{chunks: []string{"first ", "second"}})
+	req := newRunRequest("hi")
+	req.GetRunRequest().Stream = true
+	stream := &fakeAgentStream{requests: []*brainv1.AgentSessionRequest{req}}
+
+	if err := svc.runAgentSession(context.Background(), stream); err != nil {
+		t.Fatalf("agent session failed: %v", err)
+	}
+
+	var chunks []string
+	for _, resp := range stream.responses {
+		if rr := resp.GetRunResponse(); rr != nil {
+			chunks = append(chunks, rr.GetContent())
+		}
+	}
+	// The text arrives once, as partials, not again as the final aggregate
+	if want := []string{"first ", "second", ""}; fmt.Sprint(chunks) != fmt.Sprint(want) {
+		t.Fatalf("expected chunks %q, got %q", want, chunks)
+	}
+}
@@ -0,0 +1,228 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/coder/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// agentWSProtocol is the WebSocket subprotocol agent clients negotiate.
+// Browsers can't set headers on the upgrade request, so they offer the
+// session token alongside it as a "bearer.<token>" subprotocol.
+const (
+	agentWSProtocol    = "brain.v1.agent"
+	agentWSTokenPrefix = "bearer."
+)
+
+// wsHandlerConn adapts a WebSocket upgrade request to a Connect streaming
+// handler conn, so the session runs behind the same interceptors as the RPC.
+// The connection is only accepted once the interceptors let the call through.
+type wsHandlerConn struct {
+	w       http.ResponseWriter
+	r       *http.Request
+	header  http.Header
+	conn    *websocket.Conn
+	origins []string
+}
+
+// newWSHandlerConn carries the bearer subprotocol as the Authorization header
+// and ?fingerprint= as the device fingerprint header, unless they are set.
+// Tokens in the URL are ignored, they leak into logs and history.
+func newWSHandlerConn(w http.ResponseWriter, r *http.Request, origins []string) *wsHandlerConn {
+	header := r.Header.Clone()
+	if header.Get("Authorization") == "" {
+		for _, protocol := range websocketProtocols(r) {
+			if token, ok := strings.CutPrefix(protocol, agentWSTokenPrefix); ok {
+				header.Set("Authorization", "Bearer "+token)
+				break
+			}
+		}
+	}
+	if header.Get(auth.DeviceFingerprintHeader) == "" {
+		if fingerprint := r.URL.Query().Get("fingerprint"); fingerprint != "" {
+			header.Set(auth.DeviceFingerprintHeader, fingerprint)
+		}
+	}
+	return &wsHandlerConn{w: w, r: r, header: header, origins: origins}
+}
+
+// websocketProtocols lists the subprotocols offered on the upgrade request
+func websocketProtocols(r *http.Request) []string {
+	var protocols []string
+	for _, value := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(value, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+// accept upgrades the request. Only agentWSProtocol is ever selected, so the
+// token subprotocol is not echoed back.
+func (c *wsHandlerConn) accept() error {
+	conn, err := websocket.Accept(c.w, c.r, &websocket.AcceptOptions{
+		Subprotocols:   []string{agentWSProtocol},
+		OriginPatterns: c.origins,
+	})
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	return nil
+}
+
+func (c *wsHandlerConn) Spec() connect.Spec {
+	return connect.Spec{
+		Procedure:  brainv1connect.BrainServiceAgentSessionProcedure,
+		StreamType: connect.StreamTypeBidi,
+	}
+}
+
+func (c *wsHandlerConn) Peer() connect.Peer {
+	return connect.Peer{Addr: c.r.RemoteAddr, Query: c.r.URL.Query()}
+}
+
+func (c *wsHandlerConn) RequestHeader() http.Header { return c.header }
+
+// ResponseHeader and ResponseTrailer have nowhere to go once upgraded
+func (c *wsHandlerConn) ResponseHeader() http.Header  { return c.w.Header() }
+func (c *wsHandlerConn) ResponseTrailer() http.Header { return http.Header{} }
+
+// Send writes one protojson-encoded message per text frame
+func (c *wsHandlerConn) Send(msg any) error {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", msg)
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.conn.Write(c.r.Context(), websocket.MessageText, data)
+}
+
+// Receive reads one protojson-encoded message per text frame
+func (c *wsHandlerConn) Receive(msg any) error {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", msg)
+	}
+	_, data, err := c.conn.Read(c.r.Context())
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, m)
+}
+
+// connAgentStream reads and writes agent messages on a streaming handler
+// conn, including one wrapped by interceptors
+type connAgentStream struct {
+	conn connect.StreamingHandlerConn
+}
+
+func (s *connAgentStream) Send(resp *brainv1.AgentSessionResponse) error {
+	return s.conn.Send(resp)
+}
+
+func (s *connAgentStream) Receive() (*brainv1.AgentSessionRequest, error) {
+	var req brainv1.AgentSessionRequest
+	if err := s.conn.Receive(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// wsErrorStatus maps an error raised before the upgrade onto an HTTP status
+func wsErrorStatus(err error) int {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// AgentWebSocketHandler serves AgentSession over WebSocket for browser clients
+// that can't use Connect bidi streaming. Sessions run behind the given
+// interceptors, which should be the ones the RPC is mounted with. The session
+// token comes from the Authorization header or a "bearer.<token>"
+// subprotocol offered with agentWSProtocol; the fingerprint may be passed as
+// ?fingerprint=.
+func AgentWebSocketHandler(s *ServiceImpl, interceptors ...connect.Interceptor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var originPatterns []string
+		if origins := envString("AGENT_WS_ORIGINS", ""); origins != "" {
+			originPatterns = strings.Split(origins, ",")
+		}
+		wc := newWSHandlerConn(w, r, originPatterns)
+
+		handler := func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+			if err := wc.accept(); err != nil {
+				slog.Error("AgentWebSocket: failed to accept connection", "error", err)
+				return nil
+			}
+			return s.runAgentSession(ctx, &connAgentStream{conn: conn})
+		}
+		// Like connect, the first interceptor is the outermost
+		var next connect.StreamingHandlerFunc = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i].WrapStreamingHandler(next)
+		}
+
+		err := next(r.Context(), wc)
+		if wc.conn == nil {
+			// Rejected by an interceptor before the upgrade
+			if err != nil {
+				http.Error(w, errorMessage(err), wsErrorStatus(err))
+			}
+			return
+		}
+		defer wc.conn.CloseNow()
+
+		if err != nil {
+			slog.Error("AgentWebSocket: session failed", "error", err)
+			reason := err.Error()
+			// Close reasons are limited to 123 bytes by the protocol
+			if len(reason) > 123 {
+				reason = truncateBytes(reason, 123)
+			}
+			wc.conn.Close(websocket.StatusInternalError, reason)
+			return
+		}
+
+		if err := wc.conn.Close(websocket.StatusNormalClosure, ""); err != nil && !errors.Is(err, context.Canceled) {
+			slog.Debug("AgentWebSocket: close failed", "error", err)
+		}
+	})
+}
+
+// errorMessage is the message of a Connect error, or the error text otherwise
+func errorMessage(err error) string {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr.Message()
+	}
+	return err.Error()
+}
@@ -0,0 +1,217 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/coder/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestAgentWebSocket_FullTurn(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	token, claims, err := auth.MintSession(7, "pro")
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+
+	svc, sessions := newTestAgentService(&fakeLLM{reply: "hello over ws"})
+	svc.gormDB = newTestDB(t)
+	if err := svc.recordSession(context.Background(), claims, &brainv1.DeviceHandshakeRequest{}); err != nil {
+		t.Fatalf("failed to record session: %v", err)
+	}
+	srv := httptest.NewServer(AgentWebSocketHandler(svc, wsAuth(svc)))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), &websocket.DialOptions{
+		Subprotocols: []string{agentWSProtocol, agentWSTokenPrefix + token},
+	})
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.CloseNow()
+	if conn.Subprotocol() != agentWSProtocol {
+		t.Fatalf("expected subprotocol %q, got %q", agentWSProtocol, conn.Subprotocol())
+	}
+
+	data, err := protojson.Marshal(newRunRequest("hi"))
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	if err := conn.Write(ctx, websocket.MessageText, data); err != nil {
+		t.Fatalf("failed to send run request: %v", err)
+	}
+
+	var responses []*brainv1.AgentSessionResponse
+	for len(responses) < 2 {
+		_, frame, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("failed to read response: %v", err)
+		}
+		var resp brainv1.AgentSessionResponse
+		if err := protojson.Unmarshal(frame, &resp); err != nil {
+			t.Fatalf("invalid response frame: %v", err)
+		}
+		responses = append(responses, &resp)
+	}
+
+	if got := responses[0].GetRunResponse().GetContent(); got != "hello over ws" {
+		t.Errorf("expected run response %q, got %q", "hello over ws", got)
+	}
+	if !responses[1].GetSessionEndAck().GetAcknowledged() {
+		t.Errorf("expected session end ack, got %v", responses[1])
+	}
+	if len(sessions.created) != 1 || sessions.created[0].UserID != "7" {
+		t.Errorf("expected session scoped to user 7, got %+v", sessions.created)
+	}
+}
+
+// wsAuth is the auth interceptor the RPC path checks sessions with
+func wsAuth(svc *ServiceImpl, opts ...auth.InterceptorOption) connect.Interceptor {
+	return auth.NewAuthInterceptor(append(opts, auth.WithSessionChecker(svc))...)
+}
+
+func TestAgentWebSocket_RejectsMissingToken(t *testing.T) {
+	svc, _ := newTestAgentService(&fakeLLM{reply: "unused"})
+	srv := httptest.NewServer(AgentWebSocketHandler(svc, wsAuth(svc)))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAgentWebSocket_RejectsMismatchedFingerprint(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	token, claims, err := auth.MintBoundSession(7, "pro", "laptop-fingerprint")
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+
+	svc, _ := newTestAgentService(&fakeLLM{reply: "unused"})
+	svc.gormDB = newTestDB(t)
+	if err := svc.recordSession(context.Background(), claims, &brainv1.DeviceHandshakeRequest{}); err != nil {
+		t.Fatalf("failed to record session: %v", err)
+	}
+	srv := httptest.NewServer(AgentWebSocketHandler(svc, wsAuth(svc, auth.WithDeviceBinding())))
+	defer srv.Close()
+
+	cases := []struct {
+		name        string
+		fingerprint string
+		wantStatus  int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"mismatched", "stolen-elsewhere", http.StatusUnauthorized},
+		// The matching fingerprint passes auth and fails only the upgrade
+		{"matching", "laptop-fingerprint", http.StatusUpgradeRequired},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tc.fingerprint != "" {
+				req.Header.Set(auth.DeviceFingerprintHeader, tc.fingerprint)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAgentWebSocket_IgnoresQueryToken(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	token, claims, err := auth.MintSession(7, "pro")
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+
+	svc, _ := newTestAgentService(&fakeLLM{reply: "unused"})
+	svc.gormDB = newTestDB(t)
+	if err := svc.recordSession(context.Background(), claims, &brainv1.DeviceHandshakeRequest{}); err != nil {
+		t.Fatalf("failed to record session: %v", err)
+	}
+	srv := httptest.NewServer(AgentWebSocketHandler(svc, wsAuth(svc)))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?token=" + token)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// procedureRecorder records the procedures it sees and rejects them when deny is set
+type procedureRecorder struct {
+	connect.Interceptor
+	deny       bool
+	procedures []string
+}
+
+func (r *procedureRecorder) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		r.procedures = append(r.procedures, conn.Spec().Procedure)
+		if r.deny {
+			return connect.NewError(connect.CodePermissionDenied, errors.New("denied"))
+		}
+		return next(ctx, conn)
+	}
+}
+
+func TestAgentWebSocket_RunsInterceptors(t *testing.T) {
+	svc, _ := newTestAgentService(&fakeLLM{reply: "unused"})
+	outer, inner := &procedureRecorder{deny: true}, &procedureRecorder{}
+	srv := httptest.NewServer(AgentWebSocketHandler(svc, outer, inner))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The outer interceptor sees the RPC's procedure and stops the call before the upgrade
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+	if fmt.Sprint(outer.procedures) != fmt.Sprint([]string{brainv1connect.BrainServiceAgentSessionProcedure}) {
+		t.Errorf("expected the AgentSession procedure, got %v", outer.procedures)
+	}
+	if len(inner.procedures) != 0 {
+		t.Errorf("expected the inner interceptor to be skipped, got %v", inner.procedures)
+	}
+}
@@ -0,0 +1,79 @@
+package brain
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// appCategoryPrefix starts the categories macOS apps declare in LSApplicationCategoryType
+const appCategoryPrefix = "public.app-category."
+
+// appCategoryConfidenceBoost is added to the confidence of results that agree
+// with the category the OS declares
+const appCategoryConfidenceBoost = 0.1
+
+// appCategoryPattern matches a normalized category such as "developer-tools"
+var appCategoryPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+// appCategoryAliases map the freedesktop.org main categories Linux clients
+// send to their macOS names
+var appCategoryAliases = map[string]string{
+	"development": "developer-tools",
+	"office":      "productivity",
+	"game":        "games",
+	"audiovideo":  "entertainment",
+}
+
+// appCategoryLeanings are the classifications an OS category suggests. Games
+// subcategories such as "action-games" lean like "games".
+var appCategoryLeanings = map[string]string{
+	"developer-tools":   "productive",
+	"productivity":      "productive",
+	"business":          "productive",
+	"education":         "supporting",
+	"reference":         "supporting",
+	"music":             "supporting",
+	"games":             "distracting",
+	"entertainment":     "distracting",
+	"social-networking": "distracting",
+	"sports":            "distracting",
+}
+
+// normalizeAppCategory turns an OS category into its short lowercase form,
+// e.g. "public.app-category.developer-tools" into "developer-tools", or ""
+// when it does not look like a category
+func normalizeAppCategory(raw string) string {
+	category := strings.ToLower(strings.TrimSpace(raw))
+	category = strings.TrimPrefix(category, appCategoryPrefix)
+	category = strings.NewReplacer(" ", "-", "_", "-").Replace(category)
+	if alias, ok := appCategoryAliases[category]; ok {
+		category = alias
+	}
+	if !appCategoryPattern.MatchString(category) {
+		return ""
+	}
+	return category
+}
+
+// appCategoryLeaning returns the classification a normalized category
+// suggests, "" when it suggests none
+func appCategoryLeaning(category string) string {
+	if strings.HasSuffix(category, "-games") {
+		return appCategoryLeanings["games"]
+	}
+	return appCategoryLeanings[category]
+}
+
+// biasAppCategory raises the confidence of results that agree with the
+// category the OS declares for the app. Disagreeing results are left to the
+// model, which saw the category and outweighed it.
+func biasAppCategory(classification string, confidence float32, contextData map[string]string) float32 {
+	category := contextData["app_category"]
+	if category == "" || appCategoryLeaning(category) != classification {
+		return confidence
+	}
+	boosted := min(confidence+appCategoryConfidenceBoost, 1)
+	slog.Debug("confidence raised by app category", "app_category", category, "classification", classification, "confidence", boosted)
+	return boosted
+}
@@ -0,0 +1,91 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// promptKind returns the classifier a base prompt belongs to, "" for others
+func promptKind(prompt string) string {
+	for _, src := range taxonomySources {
+		if src.prompt == prompt {
+			return src.source
+		}
+	}
+	return ""
+}
+
+// cacheInput renders the cache key input stored with an entry so it can be
+// invalidated by content or replayed. The input holds window titles, email
+// senders and subjects and document paths in plaintext in a table shared by
+// all users, where otherwise only its hash is kept, so it is stored only when
+// CLASSIFICATION_CACHE_STORE_INPUTS is set. Entries cached without it can
+// still be invalidated by kind or similarity key.
+func cacheInput(keyData map[string]string) string {
+	if !envBool("CLASSIFICATION_CACHE_STORE_INPUTS", false) {
+		return ""
+	}
+	input, _ := json.Marshal(keyData)
+	return string(input)
+}
+
+// likeEscaper escapes the LIKE wildcards of a literal substring
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// InvalidateCache deletes the cache entries matching the request
+func (s *ServiceImpl) InvalidateCache(ctx context.Context, req *connect.Request[brainv1.InvalidateCacheRequest]) (*connect.Response[brainv1.InvalidateCacheResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Msg.Kind == "" && req.Msg.InputContains == "" && req.Msg.SimilarityKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one criterion is required"))
+	}
+
+	match := func(db *gorm.DB) *gorm.DB {
+		if req.Msg.Kind != "" {
+			db = db.Where("kind = ?", req.Msg.Kind)
+		}
+		if req.Msg.InputContains != "" {
+			db = db.Where(`LOWER(input) LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(strings.ToLower(req.Msg.InputContains))+"%")
+		}
+		if req.Msg.SimilarityKey != "" {
+			db = db.Where("similarity_key = ?", strings.ToLower(req.Msg.SimilarityKey))
+		}
+		return db
+	}
+
+	if req.Msg.DryRun {
+		var count int64
+		if err := match(s.gormDB.WithContext(ctx).Model(&commonv1.PromptHistoryORM{})).Count(&count).Error; err != nil {
+			return nil, dbError("failed to count cache entries", err)
+		}
+		return connect.NewResponse(&brainv1.InvalidateCacheResponse{RowsRemoved: count}), nil
+	}
+
+	// Delete in batches like maintenance, so traffic is never blocked on one long delete
+	var total int64
+	for {
+		batch := match(s.gormDB.Model(&commonv1.PromptHistoryORM{})).Select("prompt_hash").Limit(maintenanceBatchSize)
+		result := s.gormDB.WithContext(ctx).Where("prompt_hash IN (?)", batch).Delete(&commonv1.PromptHistoryORM{})
+		if result.Error != nil {
+			return nil, dbError("failed to invalidate cache entries", result.Error)
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < maintenanceBatchSize {
+			break
+		}
+	}
+
+	slog.Info("cache invalidated", "kind", req.Msg.Kind, "input_contains", req.Msg.InputContains, "similarity_key", req.Msg.SimilarityKey, "rows_removed", total)
+	return connect.NewResponse(&brainv1.InvalidateCacheResponse{RowsRemoved: total}), nil
+}
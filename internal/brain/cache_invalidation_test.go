@@ -0,0 +1,106 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestInvalidateCache_Selective(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+	t.Setenv("CLASSIFICATION_CACHE_STORE_INPUTS", "true")
+
+	models := &switchableModels{}
+	db := newTestDB(t)
+	svc := NewServiceImpl(db)
+	useTestModels(svc, models)
+	classifyWebsite := func(url string) {
+		t.Helper()
+		if _, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url})); err != nil {
+			t.Fatalf("classification of %s failed: %v", url, err)
+		}
+		svc.pendingStores.Wait()
+	}
+
+	classifyWebsite("https://github.example.invalid/focusd/brain")
+	classifyWebsite("https://github.example.invalid/focusd/app")
+	classifyWebsite("https://docs.example.invalid/guide")
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "GitHub Desktop", WindowTitle: "github.example.invalid/focusd"})); err != nil {
+		t.Fatalf("application classification failed: %v", err)
+	}
+	svc.pendingStores.Wait()
+
+	var stored commonv1.PromptHistoryORM
+	if err := db.Where("kind = ?", "application").First(&stored).Error; err != nil || stored.Input == "" {
+		t.Fatalf("expected the application entry to record its kind and input, got %+v (%v)", stored, err)
+	}
+
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	invalidate := func(req *brainv1.InvalidateCacheRequest) int64 {
+		t.Helper()
+		resp, err := svc.InvalidateCache(admin, connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("invalidation failed: %v", err)
+		}
+		return resp.Msg.RowsRemoved
+	}
+
+	if got := invalidate(&brainv1.InvalidateCacheRequest{Kind: "website", InputContains: "GITHUB.example", DryRun: true}); got != 2 {
+		t.Fatalf("expected a dry run to match 2 entries, got %d", got)
+	}
+	if got := invalidate(&brainv1.InvalidateCacheRequest{Kind: "website", InputContains: "GITHUB.example"}); got != 2 {
+		t.Fatalf("expected 2 website entries removed, got %d", got)
+	}
+
+	var left int64
+	db.Model(&commonv1.PromptHistoryORM{}).Count(&left)
+	if left != 2 {
+		t.Fatalf("expected the other site and the application to stay cached, got %d entries", left)
+	}
+
+	// Invalidated inputs go back to the model, others still hit the cache
+	calls := models.calls
+	classifyWebsite("https://docs.example.invalid/guide")
+	classifyWebsite("https://github.example.invalid/focusd/brain")
+	if models.calls != calls+1 {
+		t.Fatalf("expected only the invalidated entry to be classified again, got %d calls", models.calls-calls)
+	}
+
+	if got := invalidate(&brainv1.InvalidateCacheRequest{SimilarityKey: "domain:docs.example.invalid"}); got != 1 {
+		t.Fatalf("expected the similarity key to match 1 entry, got %d", got)
+	}
+	if got := invalidate(&brainv1.InvalidateCacheRequest{InputContains: "100%_"}); got != 0 {
+		t.Fatalf("expected wildcards to match literally, got %d", got)
+	}
+}
+
+func TestCacheInput_OptIn(t *testing.T) {
+	keyData := map[string]string{"title": "Re: salary review"}
+	if got := cacheInput(keyData); got != "" {
+		t.Fatalf("expected no plaintext input by default, got %q", got)
+	}
+
+	t.Setenv("CLASSIFICATION_CACHE_STORE_INPUTS", "true")
+	if got := cacheInput(keyData); got != `{"title":"Re: salary review"}` {
+		t.Fatalf("expected the input once opted in, got %q", got)
+	}
+}
+
+func TestInvalidateCache_Guards(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: "pro"})
+	if _, err := svc.InvalidateCache(user, connect.NewRequest(&brainv1.InvalidateCacheRequest{Kind: "website"})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	if _, err := svc.InvalidateCache(admin, connect.NewRequest(&brainv1.InvalidateCacheRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument without a criterion, got %v", err)
+	}
+}
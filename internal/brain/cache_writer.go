@@ -0,0 +1,163 @@
+package brain
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// defaultCacheBatchSize is how many pending cache rows trigger an early flush
+const defaultCacheBatchSize = 50
+
+// cacheWriter buffers cache stores and writes them as batched upserts, either
+// every interval or once batchSize rows are pending, to cut write
+// amplification against Turso.
+type cacheWriter struct {
+	db        *gorm.DB
+	batchSize int
+	evictor   *cacheEvictor
+
+	mu       sync.Mutex
+	pending  map[string]commonv1.PromptHistoryORM
+	flushing map[string]commonv1.PromptHistoryORM // taken from pending, not yet written
+
+	flushCh   chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newCacheWriter starts a writer flushing every interval and applying the row
+// cap through evictor, which may be nil
+func newCacheWriter(db *gorm.DB, interval time.Duration, batchSize int, evictor *cacheEvictor) *cacheWriter {
+	if batchSize <= 0 {
+		batchSize = defaultCacheBatchSize
+	}
+
+	w := &cacheWriter{
+		db:        db,
+		batchSize: batchSize,
+		evictor:   evictor,
+		pending:   make(map[string]commonv1.PromptHistoryORM),
+		flushCh:   make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go w.run(interval)
+	return w
+}
+
+// add queues an entry, later stores for the same hash replace earlier ones
+func (w *cacheWriter) add(entry commonv1.PromptHistoryORM) {
+	w.mu.Lock()
+	w.pending[entry.PromptHash] = entry
+	full := len(w.pending) >= w.batchSize
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// get returns the response of an unexpired entry not yet written, so repeats
+// within the flush interval don't miss the cache
+func (w *cacheWriter) get(hash string, now int64) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entry, ok := w.pending[hash]
+	if !ok {
+		entry, ok = w.flushing[hash]
+	}
+	if !ok || entry.ExpiresAt <= now {
+		return "", false
+	}
+	return entry.ResponseJson, true
+}
+
+func (w *cacheWriter) run(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushCh:
+			w.flush()
+		case <-w.stop:
+			w.flush()
+			return
+		}
+	}
+}
+
+// flush writes every pending entry in a single upsert
+func (w *cacheWriter) flush() {
+	w.mu.Lock()
+	if len(w.pending) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	entries := make([]commonv1.PromptHistoryORM, 0, len(w.pending))
+	for _, entry := range w.pending {
+		entries = append(entries, entry)
+	}
+	w.flushing = w.pending
+	w.pending = make(map[string]commonv1.PromptHistoryORM)
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		w.flushing = nil
+		w.mu.Unlock()
+	}()
+
+	// The entries hold model results already paid for, so a failed batch is
+	// retried once and then written entry by entry rather than dropped
+	upsert := func() error {
+		return w.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entries).Error
+	}
+	if err := upsert(); err != nil {
+		slog.Warn("failed to flush cache batch, retrying", "error", err, "count", len(entries))
+		if err := upsert(); err != nil {
+			slog.Error("failed to flush cache batch, storing entries one by one", "error", err, "count", len(entries))
+			w.storeEach(entries)
+		}
+	}
+
+	if err := w.evictor.maybeEvict(w.db); err != nil {
+		slog.Error("failed to evict cache entries", "error", err)
+	}
+	slog.Debug("flushed cache batch", "count", len(entries))
+}
+
+// storeEach upserts entries one at a time, dropping only those that fail
+func (w *cacheWriter) storeEach(entries []commonv1.PromptHistoryORM) {
+	lost := 0
+	for i := range entries {
+		if err := w.db.Save(&entries[i]).Error; err != nil {
+			lost++
+			slog.Error("failed to store cache entry", "error", err, "prompt_hash", entries[i].PromptHash)
+		}
+	}
+	if lost > 0 {
+		slog.Error("cache entries lost", "count", lost)
+	}
+}
+
+// Close flushes anything still pending and stops the writer. Later calls
+// wait for the same shutdown.
+func (w *cacheWriter) Close() {
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
@@ -0,0 +1,141 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"gorm.io/gorm"
+)
+
+func TestCacheWriter_BatchesClassificationStores(t *testing.T) {
+	db := newTestDB(t)
+
+	var inserts atomic.Int32
+	if err := db.Callback().Create().After("gorm:create").Register("test:count_inserts", func(tx *gorm.DB) {
+		if tx.Statement.Table == "prompt_histories" {
+			inserts.Add(1)
+		}
+	}); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	writer := newCacheWriter(db, time.Hour, 100, nil)
+	cs := &ClassificationService{db: db, models: fakeModels{text: `{"classification":"neutral"}`}, model: "gemini-test", writer: writer}
+
+	for _, title := range []string{"a", "b", "c"} {
+		if _, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"title": title}); err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+	}
+
+	// Nothing is written until the writer flushes on shutdown
+	writer.Close()
+
+	var count int64
+	db.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	if count != 3 {
+		t.Fatalf("expected 3 cached rows, got %d", count)
+	}
+	if got := inserts.Load(); got != 1 {
+		t.Fatalf("expected a single batched insert, got %d", got)
+	}
+}
+
+func TestCacheWriter_ServesPendingEntries(t *testing.T) {
+	db := newTestDB(t)
+	writer := newCacheWriter(db, time.Hour, 100, nil)
+	defer writer.Close()
+
+	models := &sequenceModels{replies: []string{`{"classification":"neutral"}`}}
+	cs := &ClassificationService{db: db, models: models, model: "gemini-test", writer: writer}
+	for range 3 {
+		if _, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"title": "a"}); err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+	}
+	if models.calls != 1 {
+		t.Fatalf("expected repeats before the flush to hit the pending entry, got %d model calls", models.calls)
+	}
+
+	// Expired entries are not served even before they are written
+	writer.add(newCacheEntry("expired", "{}", -time.Minute))
+	if _, ok := writer.get("expired", time.Now().Unix()); ok {
+		t.Fatal("expected an expired pending entry not to be served")
+	}
+}
+
+func TestCacheWriter_CloseTwice(t *testing.T) {
+	db := newTestDB(t)
+	writer := newCacheWriter(db, time.Hour, 100, nil)
+	writer.add(newCacheEntry("a", "{}", time.Hour))
+
+	writer.Close()
+	writer.Close()
+
+	var count int64
+	db.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected the pending entry flushed once, got %d rows", count)
+	}
+}
+
+func TestCacheWriter_FlushesWhenBatchFills(t *testing.T) {
+	db := newTestDB(t)
+	writer := newCacheWriter(db, time.Hour, 2, nil)
+	defer writer.Close()
+
+	writer.add(newCacheEntry("a", "{}", time.Hour))
+	writer.add(newCacheEntry("b", "{}", time.Hour))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var count int64
+		db.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+		if count == 2 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected batch to flush once full, got %d rows", count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCacheWriter_KeepsEntriesWhenBatchFails(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		failures int32
+	}{
+		{"retried batch", 1},
+		{"entry by entry", 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			// Fail the first creates, as a transient database error would
+			var creates atomic.Int32
+			if err := db.Callback().Create().Before("gorm:create").Register("test:fail_creates", func(tx *gorm.DB) {
+				if creates.Add(1) <= tc.failures {
+					tx.AddError(errors.New("database is locked"))
+				}
+			}); err != nil {
+				t.Fatalf("failed to register callback: %v", err)
+			}
+
+			writer := newCacheWriter(db, time.Hour, 100, nil)
+			writer.add(newCacheEntry("a", "{}", time.Hour))
+			writer.add(newCacheEntry("b", "{}", time.Hour))
+			writer.Close()
+
+			var count int64
+			db.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+			if count != 2 {
+				t.Fatalf("expected both entries stored despite the failed batch, got %d", count)
+			}
+		})
+	}
+}
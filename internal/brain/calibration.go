@@ -0,0 +1,83 @@
+package brain
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// calibrationPoint maps a confidence the model reported to the accuracy
+// observed for it
+type calibrationPoint struct {
+	raw        float64
+	calibrated float64
+}
+
+// confidenceCalibration parses CLASSIFICATION_CONFIDENCE_CALIBRATION, comma
+// separated raw:calibrated pairs (e.g. "0.5:0.45,0.9:0.7,1:0.8") read off
+// the share of votes agreeing with results at each reported confidence.
+// Invalid pairs are skipped, an empty table disables calibration.
+func confidenceCalibration() []calibrationPoint {
+	var points []calibrationPoint
+	for _, pair := range strings.Split(envString("CLASSIFICATION_CONFIDENCE_CALIBRATION", ""), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		rawValue, calibratedValue, _ := strings.Cut(pair, ":")
+		raw, rawErr := strconv.ParseFloat(strings.TrimSpace(rawValue), 64)
+		calibrated, calibratedErr := strconv.ParseFloat(strings.TrimSpace(calibratedValue), 64)
+		if rawErr != nil || calibratedErr != nil || raw < 0 || raw > 1 || calibrated < 0 || calibrated > 1 {
+			slog.Warn("invalid confidence calibration point, skipping", "key", "CLASSIFICATION_CONFIDENCE_CALIBRATION", "value", pair)
+			continue
+		}
+		points = append(points, calibrationPoint{raw: raw, calibrated: calibrated})
+	}
+	slices.SortStableFunc(points, func(a, b calibrationPoint) int { return cmp.Compare(a.raw, b.raw) })
+	return slices.CompactFunc(points, func(a, b calibrationPoint) bool { return a.raw == b.raw })
+}
+
+// formatConfidenceCalibration renders points as CLASSIFICATION_CONFIDENCE_CALIBRATION
+func formatConfidenceCalibration(points []calibrationPoint) string {
+	pairs := make([]string, 0, len(points))
+	for _, p := range points {
+		pairs = append(pairs, strconv.FormatFloat(p.raw, 'g', -1, 64)+":"+strconv.FormatFloat(p.calibrated, 'g', -1, 64))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// calibrateConfidence interpolates linearly between the two points around
+// raw. Confidences outside the table take the nearest point's value.
+func calibrateConfidence(points []calibrationPoint, raw float64) float64 {
+	if len(points) == 0 {
+		return raw
+	}
+	i, _ := slices.BinarySearchFunc(points, raw, func(p calibrationPoint, raw float64) int { return cmp.Compare(p.raw, raw) })
+	switch {
+	case i == 0:
+		return points[0].calibrated
+	case i == len(points):
+		return points[len(points)-1].calibrated
+	}
+	lo, hi := points[i-1], points[i]
+	return lo.calibrated + (raw-lo.raw)*(hi.calibrated-lo.calibrated)/(hi.raw-lo.raw)
+}
+
+// calibrate keeps the model's confidence as the raw score and maps the
+// confidence of result and its candidates through the configured table.
+// Only model results are calibrated; overrides and local rules are certain.
+func calibrate(result *brainv1.ClassificationResult) *brainv1.ClassificationResult {
+	result.RawConfidenceScore = result.ConfidenceScore
+	points := confidenceCalibration()
+	if len(points) == 0 {
+		return result
+	}
+	result.ConfidenceScore = float32(calibrateConfidence(points, float64(result.ConfidenceScore)))
+	for _, candidate := range result.Candidates {
+		candidate.ConfidenceScore = float32(calibrateConfidence(points, float64(candidate.ConfidenceScore)))
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package brain
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestCalibrateConfidence(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CONFIDENCE_CALIBRATION", "1:0.8, 0.5:0.4,bogus,0.9:1.5,0.9:0.7")
+
+	points := confidenceCalibration()
+	if got, want := formatConfidenceCalibration(points), "0.5:0.4,0.9:0.7,1:0.8"; got != want {
+		t.Fatalf("expected table %q, got %q", want, got)
+	}
+	for raw, want := range map[float64]float64{
+		0.2:  0.4,
+		0.5:  0.4,
+		0.7:  0.55,
+		0.95: 0.75,
+		1:    0.8,
+	} {
+		if got := calibrateConfidence(points, raw); math.Abs(got-want) > 1e-9 {
+			t.Errorf("calibrateConfidence(%v) = %v, want %v", raw, got, want)
+		}
+	}
+	if got := calibrateConfidence(nil, 0.93); got != 0.93 {
+		t.Fatalf("expected no table to keep the raw confidence, got %v", got)
+	}
+}
+
+func TestClassifyApplication_CalibratesModelConfidence(t *testing.T) {
+	models := fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":1}`}
+	classify := func(t *testing.T) *brainv1.ClassificationResult {
+		svc := newPolicyTestService(t, models)
+		resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go"}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.Classification
+	}
+
+	if result := classify(t); result.ConfidenceScore != 1 || result.RawConfidenceScore != 1 {
+		t.Fatalf("expected uncalibrated confidence by default, got %v (raw %v)", result.ConfidenceScore, result.RawConfidenceScore)
+	}
+
+	t.Setenv("CLASSIFICATION_CONFIDENCE_CALIBRATION", "0.5:0.4,1:0.8")
+	result := classify(t)
+	if result.ConfidenceScore != 0.8 || result.RawConfidenceScore != 1 {
+		t.Fatalf("expected confidence 0.8 calibrated from 1, got %v (raw %v)", result.ConfidenceScore, result.RawConfidenceScore)
+	}
+}
+
+func TestClassifyWebsite_LocalRulesAreNotCalibrated(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CONFIDENCE_CALIBRATION", "0.5:0.4,1:0.8")
+	t.Setenv("CLASSIFICATION_DEVELOPER_MODE", "true")
+
+	svc := newPolicyTestService(t, fakeModels{})
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "http://localhost:3000/"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if resp.Msg.Classification.ConfidenceScore != 1 {
+		t.Fatalf("expected local rules to keep full confidence, got %v", resp.Msg.Classification.ConfidenceScore)
+	}
+}
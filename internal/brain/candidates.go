@@ -0,0 +1,79 @@
+package brain
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// maxClassificationCandidates is how many ranked alternatives are returned
+const maxClassificationCandidates = 2
+
+// candidatesInstructions ask for ranked alternatives for clients that want
+// to handle ambiguous inputs themselves
+const candidatesInstructions = `
+# Candidates
+
+The input sets **return_candidates**: also return "candidates", an array of up to 2 objects with the keys "classification" and "confidence_score", the most likely classifications ranked from most to least confident. The first candidate must match "classification" and "confidence_score".
+`
+
+// classificationCandidate is one ranked alternative in a model response
+type classificationCandidate struct {
+	Classification  string  `json:"classification"`
+	ConfidenceScore float64 `json:"confidence_score"`
+}
+
+// rankCandidates orders candidates by confidence, dropping unknown and
+// repeated labels. Without usable candidates the primary answer is the only one.
+func rankCandidates(candidates []classificationCandidate, primary string, confidence float64) []*brainv1.ClassificationCandidate {
+	candidates = slices.Clone(candidates)
+	slices.SortStableFunc(candidates, func(a, b classificationCandidate) int {
+		return cmp.Compare(b.ConfidenceScore, a.ConfidenceScore)
+	})
+
+	var ranked []*brainv1.ClassificationCandidate
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		label := strings.ToLower(strings.TrimSpace(c.Classification))
+		if !classificationLabels[label] || seen[label] {
+			continue
+		}
+		seen[label] = true
+		ranked = append(ranked, &brainv1.ClassificationCandidate{
+			Classification:  label,
+			ConfidenceScore: float32(min(max(c.ConfidenceScore, 0), 1)),
+		})
+		if len(ranked) == maxClassificationCandidates {
+			break
+		}
+	}
+	if len(ranked) == 0 && primary != "" {
+		ranked = append(ranked, &brainv1.ClassificationCandidate{Classification: primary, ConfidenceScore: float32(confidence)})
+	}
+	return ranked
+}
+
+// coerceCandidates keeps the candidates of a lenient response that have a
+// label and a usable score
+func coerceCandidates(value any) []any {
+	list, _ := value.([]any)
+	var out []any
+	for _, item := range list {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		label, ok := fields["classification"].(string)
+		if !ok {
+			continue
+		}
+		score, ok := coerceScore(fields["confidence_score"])
+		if !ok {
+			continue
+		}
+		out = append(out, map[string]any{"classification": label, "confidence_score": score})
+	}
+	return out
+}
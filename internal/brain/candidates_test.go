@@ -0,0 +1,84 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestClassifyApplication_ReturnsRankedCandidates(t *testing.T) {
+	// Out of order, with an unknown label and a quoted score
+	recorder := &inputRecorder{text: `{"classification":"neutral","reasoning":"Could be either.","tags":["other"],"confidence_score":0.4,
+		"candidates":[{"classification":"neutral","confidence_score":0.4},{"classification":"maybe","confidence_score":0.9},{"classification":"productive","confidence_score":"0.55"}]}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:  "Terminal",
+		WindowTitle:      "ssh prod-db",
+		ReturnCandidates: true,
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if recorder.input["return_candidates"] != "true" {
+		t.Fatalf("expected the model to be asked for candidates, got %v", recorder.input)
+	}
+
+	result := resp.Msg.GetClassification()
+	candidates := result.GetCandidates()
+	if len(candidates) != 2 || candidates[0].GetClassification() != "productive" || candidates[1].GetClassification() != "neutral" {
+		t.Fatalf("expected productive then neutral, got %v", candidates)
+	}
+	if candidates[0].GetConfidenceScore() < candidates[1].GetConfidenceScore() {
+		t.Fatalf("expected candidates ranked by confidence, got %v", candidates)
+	}
+	if result.GetClassification() != "productive" || result.GetConfidenceScore() != candidates[0].GetConfidenceScore() {
+		t.Fatalf("expected the primary fields to follow the top candidate, got %v", result)
+	}
+}
+
+func TestClassify_NoValidCandidates(t *testing.T) {
+	// Lenient parsing accepts a response without a label or a known candidate
+	svc := newPolicyTestService(t, fakeModels{text: `{"candidates":[{"classification":"maybe"}]}`})
+
+	app, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Terminal", WindowTitle: "ssh prod-db", ReturnCandidates: true}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := app.Msg.GetClassification().GetCandidates(); len(got) != 0 {
+		t.Fatalf("expected no candidates, got %v", got)
+	}
+
+	site, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "https://example.invalid/", Title: "Example", ReturnCandidates: true}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := site.Msg.GetClassification().GetCandidates(); len(got) != 0 {
+		t.Fatalf("expected no candidates, got %v", got)
+	}
+}
+
+func TestClassifyApplication_CandidatesOptIn(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`})
+
+	plain, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := plain.Msg.GetClassification().GetCandidates(); len(got) != 0 {
+		t.Fatalf("expected no candidates unless requested, got %v", got)
+	}
+
+	// A response without candidates still yields the primary answer as one
+	withCandidates, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go", ReturnCandidates: true}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := withCandidates.Msg.GetClassification().GetCandidates(); len(got) != 1 || got[0].GetClassification() != "productive" {
+		t.Fatalf("expected the primary answer as the only candidate, got %v", got)
+	}
+}
@@ -0,0 +1,130 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Circuit breaker states, reported on /readyz and /debug/vars
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half-open"
+)
+
+// Default breaker tuning, overridable via CLASSIFICATION_BREAKER_THRESHOLD
+// (0 disables the breaker) and CLASSIFICATION_BREAKER_COOLDOWN
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// errCircuitOpen is returned instead of calling Gemini while the breaker is open
+var errCircuitOpen = errors.New("gemini circuit breaker open")
+
+// geminiCircuit is exported on /debug/vars as the breaker's current state
+var geminiCircuit = expvar.NewString("gemini_circuit")
+
+// circuitBreaker stops calling Gemini after threshold consecutive failures,
+// so a sustained outage is served from the degraded path at once instead of
+// every request waiting on a failing call. After the cooldown a single trial
+// call decides whether to close again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	geminiCircuit.Set(circuitClosed)
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     circuitClosed,
+	}
+}
+
+// allow reports whether a model call may proceed. Once the cooldown passed,
+// an open breaker lets one trial call through and holds back the rest.
+func (b *circuitBreaker) allow() bool {
+	if b == nil || b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.setState(circuitHalfOpen)
+		return true
+	case circuitHalfOpen:
+		return false
+	}
+	return true
+}
+
+// record feeds back the outcome of an allowed call. Cancellations and safety
+// blocks say nothing about Gemini's health and don't count.
+func (b *circuitBreaker) record(err error) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, errSafetyBlocked):
+		// An abandoned trial frees the slot for the next request
+		if b.state == circuitHalfOpen {
+			b.setState(circuitOpen)
+		}
+	case err == nil:
+		b.failures = 0
+		if b.state != circuitClosed {
+			slog.Info("gemini recovered, closing circuit breaker")
+			b.setState(circuitClosed)
+		}
+	case b.state == circuitHalfOpen:
+		slog.Warn("gemini trial call failed, circuit breaker stays open", "error", err)
+		b.openedAt = b.now()
+		b.setState(circuitOpen)
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			slog.Warn("gemini failing, opening circuit breaker", "failures", b.failures, "cooldown", b.cooldown, "error", err)
+			b.failures = 0
+			b.openedAt = b.now()
+			b.setState(circuitOpen)
+		}
+	}
+}
+
+// State returns the breaker state, "closed" when disabled
+func (b *circuitBreaker) State() string {
+	if b == nil || b.threshold <= 0 {
+		return circuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *circuitBreaker) setState(state string) {
+	b.state = state
+	geminiCircuit.Set(state)
+}
@@ -0,0 +1,134 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// switchableModels fails with err while it is set, counting calls
+type switchableModels struct {
+	err   error
+	calls int
+}
+
+func (m *switchableModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestCircuitBreaker_OpensShortCircuitsAndRecovers(t *testing.T) {
+	t.Setenv("CLASSIFICATION_BREAKER_THRESHOLD", "2")
+	t.Setenv("CLASSIFICATION_BREAKER_COOLDOWN", "1m")
+
+	models := &switchableModels{err: genai.APIError{Code: http.StatusServiceUnavailable, Status: "UNAVAILABLE"}}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+	now := time.Now()
+	svc.breaker.now = func() time.Time { return now }
+
+	classify := func(name string) error {
+		t.Helper()
+		_, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: name}))
+		return err
+	}
+
+	// Sustained failures open the breaker
+	for _, name := range []string{"Code", "Xcode"} {
+		if err := classify(name); err == nil {
+			t.Fatalf("%s: expected the model failure", name)
+		}
+	}
+	if state := svc.breaker.State(); state != circuitOpen {
+		t.Fatalf("expected the breaker to open, got %s", state)
+	}
+
+	// Open, requests fail fast without calling Gemini
+	if err := classify("Zed"); connect.CodeOf(err) != connect.CodeUnavailable || !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected unavailable from the open breaker, got %v", err)
+	}
+	if models.calls != 2 {
+		t.Fatalf("expected no model call while open, got %d calls", models.calls)
+	}
+	if status := readiness(t, svc); status.Status != "degraded" || status.Circuit != circuitOpen {
+		t.Fatalf("expected readiness to report the open breaker, got %+v", status)
+	}
+
+	// After the cooldown a trial call reaches the recovered model and closes the breaker
+	models.err = nil
+	now = now.Add(time.Minute)
+	if err := classify("Zed"); err != nil {
+		t.Fatalf("expected the trial call to succeed, got %v", err)
+	}
+	if state := svc.breaker.State(); state != circuitClosed {
+		t.Fatalf("expected the breaker to close, got %s", state)
+	}
+	if err := classify("Sublime Text"); err != nil {
+		t.Fatalf("expected classification after recovery, got %v", err)
+	}
+	if models.calls != 4 {
+		t.Fatalf("expected calls to reach the model again, got %d calls", models.calls)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAllowsOneTrial(t *testing.T) {
+	breaker := newCircuitBreaker(1, time.Minute)
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(errors.New("boom"))
+	if breaker.allow() {
+		t.Fatal("expected the open breaker to refuse calls")
+	}
+
+	now = now.Add(time.Minute)
+	if !breaker.allow() {
+		t.Fatal("expected a trial call after the cooldown")
+	}
+	if breaker.allow() {
+		t.Fatal("expected only one trial call while half-open")
+	}
+
+	// A failed trial restarts the cooldown
+	breaker.record(errors.New("still down"))
+	if breaker.State() != circuitOpen || breaker.allow() {
+		t.Fatalf("expected the breaker to reopen, got %s", breaker.State())
+	}
+	if geminiCircuit.Value() != circuitOpen {
+		t.Fatalf("expected the gemini_circuit metric to follow, got %q", geminiCircuit.Value())
+	}
+}
+
+func TestCircuitBreaker_IgnoresCancellationsAndSafetyBlocks(t *testing.T) {
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.record(context.Canceled)
+	breaker.record(errSafetyBlocked)
+	if state := breaker.State(); state != circuitClosed {
+		t.Fatalf("expected the breaker to stay closed, got %s", state)
+	}
+}
+
+func readiness(t *testing.T, svc *ServiceImpl) ReadinessStatus {
+	t.Helper()
+	probe := newGeminiProbe(func(ctx context.Context) error { return nil }, time.Hour)
+	rec := httptest.NewRecorder()
+	ReadinessHandler(probe, svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var status ReadinessStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	return status
+}
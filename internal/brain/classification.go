@@ -1,30 +1,91 @@
 package brain
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/genai"
 	"gorm.io/gorm"
 
 	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
 	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+	"github.com/focusd-so/brain/internal/secrets"
 )
 
 // Cache TTL: 24 hours in seconds
 const cacheTTLSeconds = 86400
 
+// requestTTLKey carries the cache TTL a request asked for
+type requestTTLKey struct{}
+
+// withRequestTTL returns a copy of ctx asking for results to be cached for at
+// most seconds. Zero keeps the configured TTL.
+func withRequestTTL(ctx context.Context, seconds int64) context.Context {
+	if seconds <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestTTLKey{}, time.Duration(seconds)*time.Second)
+}
+
+// cacheTTL returns how long a result stored for the caller stays fresh. The
+// global CLASSIFICATION_CACHE_TTL can be overridden per role with
+// CLASSIFICATION_CACHE_TTL_<ROLE>, e.g. CLASSIFICATION_CACHE_TTL_PRO=6h.
+// The role only affects expiry, never the cache key or content. A TTL the
+// request asked for can only shorten that, and is also capped at
+// CLASSIFICATION_MAX_REQUEST_CACHE_TTL.
+func cacheTTL(ctx context.Context) time.Duration {
+	ttl := envDuration("CLASSIFICATION_CACHE_TTL", cacheTTLSeconds*time.Second)
+	if user, ok := auth.GetUser(ctx); ok && user.Role != "" {
+		ttl = envDuration("CLASSIFICATION_CACHE_TTL_"+strings.ToUpper(user.Role), ttl)
+	}
+	if requested, ok := ctx.Value(requestTTLKey{}).(time.Duration); ok {
+		return min(requested, envDuration("CLASSIFICATION_MAX_REQUEST_CACHE_TTL", cacheTTLSeconds*time.Second), ttl)
+	}
+	return ttl
+}
+
+// Default caps for fetched metadata so large og tags don't bloat the prompt
+const (
+	defaultDescriptionMaxLength = 300
+	defaultKeywordsMaxLength    = 200
+)
+
+// Classification model and prompt version, overridable via CLASSIFICATION_MODEL
+// and CLASSIFICATION_PROMPT_VERSION. Bump the version whenever the prompts change.
+const (
+	defaultClassificationModel = "gemini-2.5-flash"
+	defaultPromptVersion       = "v9"
+)
+
+// defaultMaxTags caps how many tags a result keeps, overridable via CLASSIFICATION_MAX_TAGS
+const defaultMaxTags = 4
+
+// defaultMaxCachedResponseBytes caps a stored model response, overridable via CLASSIFICATION_CACHE_MAX_RESPONSE_BYTES
+const defaultMaxCachedResponseBytes = 16 << 10
+
+// defaultMetadataTimeout bounds the whole metadata fetch, including the retry
+const defaultMetadataTimeout = 200 * time.Millisecond
+
 // Prompts for classification
 const promptDesktop = `
 You are a Productivity Analyst. Your job is to analyze desktop application entries and classify them based on their impact on focus and productivity.
@@ -33,6 +94,14 @@ You will receive:
 - **name** (string): The desktop application's name  
 - **title** (string, optional): The active window or document title  
 - **bundle_id** (string, optional): The app's unique identifier  
+- **tabs** (string, optional): Titles of the app's other open tabs or windows, one per line  
+- **screen_text** (string, optional): Text read from the app's window, trimmed; when present it shows what the user is actually looking at  
+- **in_meeting** (string, optional): "true" when the user's calendar shows them in a meeting right now  
+- **user_mode** (string, optional): What the user declared they are doing, "focus" or "break"  
+- **feed** (string, optional): Set for RSS/Atom feed readers, "technical" when the feed reads as engineering or tech content, "general" otherwise  
+- **foreground** (string, optional): "true" when the app is the one the user is looking at, "false" when it plays in the background  
+- **app_category** (string, optional): The category the OS declares for the app, e.g. "developer-tools", "productivity", "games" or "social-networking"  
+- **recent_activity** (string, optional): A short summary of what the user was doing just before, e.g. "coding in VS Code for the last hour"  
 
 You must immediately reply **only with a single, raw JSON object**.  
 Do **not** wrap the JSON in markdown fences, do **not** add explanations, and do **not** output anything except the JSON object.
@@ -66,6 +135,7 @@ The JSON object you return must contain exactly these keys:
   "music",
   "time-sink",
   "supporting-audio",
+  "spoken-audio",
   "code-editor",
   "design-tool",
   "other"
@@ -127,6 +197,19 @@ Examples:
 
 Tag with **supporting-audio**.
 
+**Spoken-audio focus content:**
+- Podcast apps: Apple Podcasts, Pocket Casts, Overcast
+- Audiobook apps: Audible, Libby
+- YouTube / Safari / Chrome **when the title clearly indicates a podcast episode or audiobook played in the background**
+  (e.g. "Episode 212", "Podcast", "Audiobook", "Full Audiobook", "Chapter 3")
+
+Examples:
+- "The Changelog #560 – Podcast"
+- "Atomic Habits – Full Audiobook"
+
+Tag with **supporting-audio** and **spoken-audio**.
+Video entertainment (vlogs, trailers, reactions, shows) stays **distracting** even when it has spoken content.
+
 ---
 
 ## **neutral**
@@ -174,6 +257,7 @@ Use when the app or window title indicates entertainment, social media, or atten
 - **news** — general news consumption
 - **time-sink** — infinite scroll or addictive feeds
 - **supporting-audio** — music or ambient sound aiding focus
+- **spoken-audio** — podcasts or audiobooks listened to in the background
 - **code-editor** — IDEs and text editors used for coding
 - **design-tool** — Figma, Sketch, design software
 - **music** — music players, youtube playing music, spotify or apply music
@@ -342,6 +426,199 @@ Notion + roadmap, tasks, planning → productive
 Notion + personal journal → neutral
 Notion + recipes or travel planning → distracting
 
+---
+
+# Tabbed Sessions
+
+When **tabs** are present, the **title** is still the active window and drives the classification.
+Use the tabs to weigh the overall session:
+
+- Mostly work tabs with one or two social or news tabs → keep the active title's classification and mention the stray tabs in the reasoning
+- A work title surrounded mostly by social media, video or news tabs → lower the confidence_score, or choose **distracting** when the work title looks incidental
+- Never let tabs turn a distracting active title into productive
+
+### Example
+**Input**
+- name: "Google Chrome"
+- title: "Pull Request #42 · focusd-so/brain · GitHub"
+- tabs: "Go Documentation\nX / Home"
+
+**Output**
+{
+  "classification": "productive",
+  "reasoning": "Reviewing a pull request with documentation open; one social tab is a minor distraction.",
+  "tags": ["work", "research"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.8
+}
+
+---
+
+# Meetings
+
+When **in_meeting** is "true", the user is in a scheduled meeting:
+
+- Video call apps (Zoom, Google Meet, Microsoft Teams, Webex) → **productive**, the call is the meeting
+- Chat apps (Slack, Discord, Teams chat) → **supporting**, usually sharing links or notes for the meeting
+- Notes, docs and slides → classify as usual, they are often being presented
+- Clearly unrelated entertainment → still **distracting**
+
+Without **in_meeting**, judge video call apps from the title alone.
+
+### Example
+**Input**
+- name: "zoom.us"
+- title: "Zoom Meeting"
+- in_meeting: "true"
+
+**Output**
+{
+  "classification": "productive",
+  "reasoning": "Attending a scheduled meeting on Zoom.",
+  "tags": ["work", "communication"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.9
+}
+
+---
+
+# Feeds
+
+When **feed** is set, the user is reading RSS/Atom feeds in a feed reader such as NetNewsWire, Reeder or Feedly:
+
+- **technical** — engineering blogs, release notes and programming news keep the user current for their work → **supporting**, tags "research" and "content-consumption"
+- **general** — headlines and general news are endless scrolling → **distracting**, tags "news" and "time-sink"
+
+A title naming a specific technical article beats the feed's overall topic.
+
+### Example
+**Input**
+- name: "NetNewsWire"
+- title: "Go Blog: Range over function types"
+- feed: "technical"
+
+**Output**
+{
+  "classification": "supporting",
+  "reasoning": "Reading a Go engineering article in a feed reader.",
+  "tags": ["research", "content-consumption"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.8
+}
+
+---
+
+# OS App Category
+
+When **app_category** is set, it is the category the app's developer declared to the OS. Treat it as a strong signal for apps you do not recognize:
+
+- "developer-tools", "productivity", "business" → likely **productive**
+- "education", "reference", "music" → likely **supporting**
+- "games" and its subcategories, e.g. "action-games", "entertainment", "social-networking", "sports" → likely **distracting**
+
+The window title still wins when it clearly says otherwise, e.g. a game's level editor used for work. Other categories carry no hint.
+
+### Example
+**Input**
+- name: "Zed Preview"
+- title: "main.rs — focusd"
+- app_category: "developer-tools"
+
+**Output**
+{
+  "classification": "productive",
+  "reasoning": "An app the OS lists as a developer tool, editing a source file.",
+  "tags": ["work", "code-editor"],
+  "detected_project": "focusd",
+  "detected_communication_channel": null,
+  "confidence_score": 0.85
+}
+
+---
+
+# Foreground and Background Media
+
+When **foreground** is set for a media app, or a browser playing media, it tells whether the user is watching:
+
+- **"false"** — the media plays in the background while the user works elsewhere. Music, ambient sound, podcasts and audiobooks → **supporting**, tag "supporting-audio", even when it is a music video.
+- **"true"** — the user is looking at it. Videos, music videos included → **distracting**, tag "entertainment". A music player's own window, e.g. picking a playlist, stays **supporting**.
+
+Without **foreground**, judge from the title alone.
+
+### Example
+**Input**
+- name: "Google Chrome"
+- title: "Daft Punk - Get Lucky (Official Video) - YouTube"
+- foreground: "false"
+
+**Output**
+{
+  "classification": "supporting",
+  "reasoning": "A music video playing in the background while the user works elsewhere.",
+  "tags": ["supporting-audio", "music"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.8
+}
+
+---
+
+# Declared Mode
+
+When **user_mode** is set, the user told us what they are doing:
+
+- **break** — the user chose to step away. Games, videos and social apps → **neutral**, not **distracting**; the break is intentional. Work apps classify as usual.
+- **focus** — the user committed to deep work. Be stricter: anything that is not clearly work or supporting it → **distracting**.
+
+Without **user_mode**, classify as usual.
+
+### Example
+**Input**
+- name: "Steam"
+- title: "Steam"
+- user_mode: "break"
+
+**Output**
+{
+  "classification": "neutral",
+  "reasoning": "Gaming during a break the user declared.",
+  "tags": ["entertainment"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.8
+}
+
+---
+
+# Recent Activity
+
+When **recent_activity** is set, it summarizes what the user was doing just before. Use it to judge the moment, not the app alone:
+
+- After a long stretch of productive work, a quick visit to a social, news or video app reads as a short break → **neutral**, not **distracting**.
+- When the recent activity is already mostly distracting, another distracting app continues the pattern → **distracting**, with higher confidence.
+- Work apps classify as usual whatever came before.
+
+Without **recent_activity**, classify from the app alone.
+
+### Example
+**Input**
+- name: "Google Chrome"
+- title: "r/golang - Reddit"
+- recent_activity: "In a focus session for 70 minutes. Last 60 minutes: 48 productive, 2 neutral. Most recently productive, 1 minute ago."
+
+**Output**
+{
+  "classification": "neutral",
+  "reasoning": "A quick Reddit check after an hour of focused work reads as a short break.",
+  "tags": ["social-media"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.7
+}
+
 Always choose the classification that most accurately reflects how the app affects the user's focus at that moment.
 
 REMINDER: output must be a valid JSON object with no markdown fences, no explanations, and no other text.
@@ -382,6 +659,7 @@ The JSON object you return must contain exactly these keys:
 	"news",
 	"time-sink",
 	"supporting-audio",
+	"spoken-audio",
 	"other"
 ]
 
@@ -428,8 +706,12 @@ Use when the site helps maintain focus:
 - ambient noise  
 - lofi playlists  
 - audio-only pages intended to reduce distraction  
+- podcasts and audiobooks when the title indicates background listening (e.g. "Episode 212", "Podcast", "Full Audiobook")
 
-Examples: Spotify playlist, YouTube Playing music, Brain.fm.
+Examples: Spotify playlist, YouTube Playing music, Brain.fm, Pocket Casts episode, "Atomic Habits – Full Audiobook" on YouTube.
+
+Tag spoken content with both **supporting-audio** and **spoken-audio**.
+Video entertainment (vlogs, trailers, reactions, shows) stays **distracting** even when it has spoken content.
 
 ---
 
@@ -478,6 +760,7 @@ Examples: Reddit, Instagram, TikTok, CNN.
 - **news** — general news sites  
 - **time-sink** — infinite scroll, high-distraction feeds  
 - **supporting-audio** — music or ambient sound used for focus  
+- **spoken-audio** — podcasts or audiobooks listened to in the background  
 - **code-editor** — web-based IDEs and code editors
 - **other** — when none of the above meaningfully apply
 
@@ -683,48 +966,195 @@ Infer the communication channel name from URL patterns and page titles.
 	"confidence_score": 1
 }
 
+### Example 13 — YouTube podcast episode played in the background
+{
+	"classification": "supporting",
+	"reasoning": "A podcast episode used as background listening rather than video entertainment.",
+	"tags": ["supporting-audio", "spoken-audio"],
+	"detected_project": null,
+	"detected_communication_channel": null,
+	"confidence_score": 0.8
+}
+
 ---
 
 Use metadata, page title, and URL patterns to improve accuracy.
+
+---
+
+## Feeds
+
+The input may include **feed** when the URL is an RSS/Atom feed or a web feed reader (Feedly, Inoreader, NewsBlur):
+
+- **technical** — engineering blogs, release notes and programming news → **supporting**, tags "research" and "content-consumption"
+- **general** — headlines and general news → **distracting**, tags "news" and "time-sink"
+
+### Example — General news feed (feed: "general")
+{
+	"classification": "distracting",
+	"reasoning": "Scrolling a general news feed.",
+	"tags": ["news", "time-sink"],
+	"detected_project": null,
+	"detected_communication_channel": null,
+	"confidence_score": 0.8
+}
+
+---
+
+## Foreground and Background Media
+
+The input may include **foreground**, whether the tab is the one the user is looking at:
+
+- **"false"** — the page plays in the background while the user works elsewhere. Music, ambient sound, podcasts and audiobooks → **supporting**, tag "supporting-audio", even on video sites.
+- **"true"** — the user is watching. Videos, music videos included → **distracting**, tag "entertainment".
+
+Without **foreground**, judge from the URL and title alone.
+
+### Example — YouTube music video watched in the foreground (foreground: "true")
+{
+	"classification": "distracting",
+	"reasoning": "Watching a music video rather than listening in the background.",
+	"tags": ["entertainment"],
+	"detected_project": null,
+	"detected_communication_channel": null,
+	"confidence_score": 0.8
+}
+
+---
+
+## Declared Mode
+
+The input may include **user_mode**, what the user declared they are doing:
+
+- **break** — the user chose to step away. Entertainment, social media and news → **neutral**, not **distracting**; the break is intentional. Work sites classify as usual.
+- **focus** — the user committed to deep work. Be stricter: sites that are not clearly work or supporting it → **distracting**.
+
+Without **user_mode**, classify as usual.
+
+### Example — YouTube video during a declared break (user_mode: "break")
+{
+	"classification": "neutral",
+	"reasoning": "Watching a video during a break the user declared.",
+	"tags": ["entertainment", "content-consumption"],
+	"detected_project": null,
+	"detected_communication_channel": null,
+	"confidence_score": 0.8
+}
+
+---
+
+## Recent Activity
+
+The input may include **recent_activity**, a short summary of what the user was doing just before:
+
+- After a long stretch of productive work, a quick look at social media, news or videos reads as a short break → **neutral**, not **distracting**.
+- When the recent activity is already mostly distracting, another distracting site continues the pattern → **distracting**, with higher confidence.
+- Work sites classify as usual whatever came before.
+
+Without **recent_activity**, judge from the URL and title alone.
+
+### Example — Reddit after an hour of coding (recent_activity: "In a focus session for 70 minutes. Last 60 minutes: 48 productive.")
+{
+	"classification": "neutral",
+	"reasoning": "A quick Reddit check after an hour of focused work reads as a short break.",
+	"tags": ["social-media"],
+	"detected_project": null,
+	"detected_communication_channel": null,
+	"confidence_score": 0.7
+}
 `
 
 // ClassificationResult represents the AI response structure for applications
 type ClassificationResult struct {
-	Classification               string   `json:"classification"`
-	Reasoning                    string   `json:"reasoning"`
-	Tags                         []string `json:"tags"`
-	DetectedProject              *string  `json:"detected_project"`
-	DetectedCommunicationChannel *string  `json:"detected_communication_channel"`
-	ConfidenceScore              float32  `json:"confidence_score"`
+	Classification               string                    `json:"classification"`
+	Reasoning                    string                    `json:"reasoning"`
+	Tags                         []string                  `json:"tags"`
+	DetectedProject              *string                   `json:"detected_project"`
+	DetectedCommunicationChannel *string                   `json:"detected_communication_channel"`
+	ConfidenceScore              float32                   `json:"confidence_score"`
+	Candidates                   []classificationCandidate `json:"candidates,omitempty"`  // only with return_candidates
+	Approximate                  bool                      `json:"approximate,omitempty"` // set by the fallback, never by the model
+	Model                        string                    `json:"model,omitempty"`       // set when a fallback model answered
 }
 
 // WebsiteClassificationResult represents the AI response structure for websites
 type WebsiteClassificationResult struct {
-	Classification               string   `json:"classification"`
-	Reasoning                    string   `json:"reasoning"`
-	Tags                         []string `json:"tags"`
-	DetectedProject              *string  `json:"detected_project"`
-	DetectedCommunicationChannel *string  `json:"detected_communication_channel"`
-	ConfidenceScore              float64  `json:"confidence_score"`
+	Classification               string                    `json:"classification"`
+	Reasoning                    string                    `json:"reasoning"`
+	Tags                         []string                  `json:"tags"`
+	DetectedProject              *string                   `json:"detected_project"`
+	DetectedCommunicationChannel *string                   `json:"detected_communication_channel"`
+	ConfidenceScore              float64                   `json:"confidence_score"`
+	Candidates                   []classificationCandidate `json:"candidates,omitempty"`  // only with return_candidates
+	Approximate                  bool                      `json:"approximate,omitempty"` // set by the fallback, never by the model
+	Model                        string                    `json:"model,omitempty"`       // set when a fallback model answered
+}
+
+// contentGenerator is the part of the Gemini models API classification uses
+type contentGenerator interface {
+	GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error)
 }
 
 // ClassificationService handles AI-powered classification
 type ClassificationService struct {
-	db     *gorm.DB
-	client *genai.Client
+	db             *gorm.DB
+	models         contentGenerator
+	model          string
+	fallbackModels []string // tried in order when the model is unavailable
+	variant        classificationVariant
+	writer         *cacheWriter
+	reads          *readRouter
+	breaker        *circuitBreaker
+	limiter        *geminiLimiter
+	pending        *sync.WaitGroup
+	flags          *Flags
+	debug          *geminiDebugSink // nil never logs calls
+	evictor        *cacheEvictor
 }
 
 // NewClassificationService creates a new classification service
 func NewClassificationService(db *gorm.DB) (*ClassificationService, error) {
-	ctx := context.Background()
+	client, err := newGeminiClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	models := classificationModels()
+	return &ClassificationService{
+		db:             db,
+		models:         client.Models,
+		model:          models[0],
+		fallbackModels: models[1:],
+	}, nil
+}
+
+// classificationModels returns the prioritized CLASSIFICATION_MODEL list
+func classificationModels() []string {
+	if models := splitModels(os.Getenv("CLASSIFICATION_MODEL")); len(models) > 0 {
+		return models
+	}
+	return []string{defaultClassificationModel}
+}
+
+// classificationModel returns the primary classification model
+func classificationModel() string {
+	return classificationModels()[0]
+}
 
+// classificationPromptVersion returns the configured prompt version label
+func classificationPromptVersion() string {
+	return envString("CLASSIFICATION_PROMPT_VERSION", defaultPromptVersion)
+}
+
+// newGeminiClient creates a Gemini API client from the environment
+func newGeminiClient(ctx context.Context) (*genai.Client, error) {
 	// Try GOOGLE_API_KEY first, then GEMINI_API_KEY
-	apiKey := os.Getenv("GOOGLE_API_KEY")
+	apiKey := secrets.Get("GOOGLE_API_KEY")
 	if apiKey == "" {
-		apiKey = os.Getenv("GEMINI_API_KEY")
+		apiKey = secrets.Get("GEMINI_API_KEY")
 	}
 	if apiKey == "" {
-		return nil, fmt.Errorf("GOOGLE_API_KEY or GEMINI_API_KEY environment variable not set")
+		return nil, errMissingAPIKey
 	}
 
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
@@ -735,47 +1165,192 @@ func NewClassificationService(db *gorm.DB) (*ClassificationService, error) {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
 
-	return &ClassificationService{
-		db:     db,
-		client: client,
-	}, nil
+	return client, nil
+}
+
+// Secondary titles beyond these caps are dropped or truncated before reaching the model
+const (
+	maxSecondaryTitles      = 10
+	maxSecondaryTitleLength = 200
+)
+
+// declaredMode returns the user mode the prompts act on, "focus" or "break".
+// Neutral is the default behaviour and returns "" so it shares cache entries
+// with requests that declared nothing.
+func declaredMode(mode string) string {
+	switch mode {
+	case "focus", "break":
+		return mode
+	}
+	return ""
+}
+
+// tagsOnlyInstructions trim the answer for clients that only need the label
+// and tags. Reasoning is the bulk of the output, so skipping it saves tokens
+// and latency. It stays in the response as "" for the strict schema.
+const tagsOnlyInstructions = `
+# Tags Only
+
+The input sets **tags_only**: return "reasoning" as an empty string and omit "detected_project" and "detected_communication_channel". Return "classification", "tags" and "confidence_score" as usual.
+`
+
+// applicationContext builds the classification input for an application
+// request. The cache key covers the active window, the options that change
+// the answer and a hash of the secondary tabs, which can change it too. The
+// key holds the canonical title, the model still sees the raw one.
+func applicationContext(req *brainv1.ClassifyApplicationRequest) (keyData, contextData map[string]string) {
+	keyData = map[string]string{
+		"name":      req.GetApplicationName(),
+		"title":     canonicalTitle(req.GetWindowTitle()),
+		"bundle_id": req.GetApplicationBundleId(),
+	}
+
+	// Options join the key only when set, so requests without them keep
+	// hitting existing entries
+	if req.GetInMeeting() {
+		keyData["in_meeting"] = "true"
+	}
+	if mode := declaredMode(req.GetUserMode()); mode != "" {
+		keyData["user_mode"] = mode
+	}
+	if req.GetTagsOnly() {
+		keyData["tags_only"] = "true"
+	}
+	if req.GetReturnCandidates() {
+		keyData["return_candidates"] = "true"
+	}
+	withForeground(req.Foreground, keyData)
+	if category := normalizeAppCategory(req.GetAppCategory()); category != "" {
+		keyData["app_category"] = category
+	}
+
+	var tabs []string
+	seen := map[string]bool{strings.TrimSpace(req.GetWindowTitle()): true}
+	for _, title := range req.GetSecondaryTitles() {
+		title = strings.TrimSpace(title)
+		if title == "" || seen[title] {
+			continue
+		}
+		seen[title] = true
+		tabs = append(tabs, truncateRunes(title, maxSecondaryTitleLength))
+		if len(tabs) == maxSecondaryTitles {
+			break
+		}
+	}
+
+	contextData = maps.Clone(keyData)
+	contextData["title"] = req.GetWindowTitle()
+	if len(tabs) > 0 {
+		keyData["tabs"] = tabsKey(tabs)
+		contextData["tabs"] = strings.Join(tabs, "\n")
+	}
+	if text := screenText(req.GetScreenText()); text != "" {
+		contextData["screen_text"] = text
+	}
+	if isFeedReader(req.GetApplicationName(), req.GetApplicationBundleId()) {
+		contextData["feed"] = feedTopic(contextData)
+	}
+	return keyData, contextData
+}
+
+// tabsKey identifies a set of tabs in the cache key without storing their
+// titles. Reordered tabs share the key.
+func tabsKey(tabs []string) string {
+	tabs = slices.Clone(tabs)
+	slices.Sort(tabs)
+	hash := sha256.Sum256([]byte(strings.Join(tabs, "\n")))
+	return hex.EncodeToString(hash[:])
 }
 
 // ClassifyApplication classifies a desktop application
 func (s *ServiceImpl) ClassifyApplication(ctx context.Context, req *connect.Request[brainv1.ClassifyApplicationRequest]) (*connect.Response[brainv1.ClassifyApplicationResponse], error) {
-	cs, err := NewClassificationService(s.gormDB)
-	if err != nil {
-		slog.Error("failed to create classification service", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("classification service error: %w", err))
+	keyData, contextData := applicationContext(req.Msg)
+	withLocale(reasoningLocale(req.Msg.Locale, req.Header()), keyData, contextData)
+	// What the user was just doing informs the model, results are then never cached
+	if activity := s.recentActivity(ctx, req.Msg.RecentActivity); activity != "" {
+		contextData["recent_activity"] = activity
+	}
+	ctx = withRequestTTL(ctx, req.Msg.CacheTtlSeconds)
+
+	// User, then global overrides beat the cache and the model
+	if result := s.override(ctx, "application", classificationSignals(contextData), req.Msg.ApplicationBundleId, req.Msg.ApplicationName); result != nil {
+		return connect.NewResponse(&brainv1.ClassifyApplicationResponse{Classification: result}), nil
+	}
+
+	// Configured focus music services need no model
+	if result := s.supportingMediaResult(ctx, classificationSignals(contextData), "", req.Msg.ApplicationBundleId, req.Msg.ApplicationName); result != nil {
+		return connect.NewResponse(&brainv1.ClassifyApplicationResponse{Classification: result}), nil
 	}
 
-	contextData := map[string]string{
-		"name":      req.Msg.ApplicationName,
-		"title":     req.Msg.WindowTitle,
-		"bundle_id": req.Msg.ApplicationBundleId,
+	// Thin inputs rarely classify well, so they may skip the model entirely
+	thin, err := thinApplication(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	if thin != nil {
+		thin.Signals = classificationSignals(contextData)
+		return connect.NewResponse(&brainv1.ClassifyApplicationResponse{Classification: thin}), nil
 	}
 
-	result, err := cs.classifyWithCache(ctx, promptDesktop, contextData)
+	variant := selectVariant(ctx)
+	// Only requests the model would see alike are merged
+	result, err := s.coalescer.do(coalesceKey(ctx, promptDesktop, contextData), func() (string, error) {
+		cs, err := s.classificationService(variant)
+		if err != nil {
+			slog.Error("failed to create classification service", "error", err)
+			return "", classificationError("classification service error", err)
+		}
+
+		result, err := cs.classifyWithCacheKey(ctx, promptDesktop, keyData, contextData)
+		if err != nil {
+			slog.Error("classification failed", "error", err)
+			return "", classificationError("classification failed", err)
+		}
+		return result, nil
+	})
+	if errors.Is(err, errSafetyBlocked) {
+		return connect.NewResponse(&brainv1.ClassifyApplicationResponse{Classification: safetyBlockedResult(variant)}), nil
+	}
 	if err != nil {
-		slog.Error("classification failed", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("classification failed: %w", err))
+		return nil, err
 	}
 
 	var classification ClassificationResult
-	if err := json.Unmarshal([]byte(result), &classification); err != nil {
+	if err := parseClassification(result, &classification); err != nil {
 		slog.Error("failed to parse classification result", "error", err, "result", result)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse classification: %w", err))
 	}
+	var candidates []*brainv1.ClassificationCandidate
+	if req.Msg.ReturnCandidates {
+		// The primary fields follow the top candidate
+		candidates = rankCandidates(classification.Candidates, classification.Classification, float64(classification.ConfidenceScore))
+		if len(candidates) > 0 {
+			classification.Classification, classification.ConfidenceScore = candidates[0].Classification, candidates[0].ConfidenceScore
+		}
+	}
+	classification.Classification = biasAmbiguousSearch(classification.Classification, contextData)
+	classification.Tags = biasFeedTags(classification.Tags, contextData)
+	classification.Classification, classification.Tags = biasMediaFocus(classification.Classification, classification.Tags, contextData)
+	classification.ConfidenceScore = biasAppCategory(classification.Classification, classification.ConfidenceScore, contextData)
+	variant.logResult(ctx, "application", classification.Classification, classification.ConfidenceScore)
+	if req.Msg.TagsOnly {
+		classification.Reasoning = ""
+	}
 
 	response := &brainv1.ClassifyApplicationResponse{
-		Classification: &brainv1.ClassificationResult{
+		Classification: calibrate(&brainv1.ClassificationResult{
 			Classification:               classification.Classification,
 			Reasoning:                    classification.Reasoning,
-			Tags:                         classification.Tags,
+			Tags:                         normalizeTags(classification.Tags),
 			ConfidenceScore:              classification.ConfidenceScore,
 			DetectedProject:              classification.DetectedProject,
 			DetectedCommunicationChannel: classification.DetectedCommunicationChannel,
-		},
+			Signals:                      classificationSignals(contextData),
+			Model:                        cmp.Or(classification.Model, variant.Model),
+			PromptVersion:                variant.PromptVersion,
+			Approximate:                  classification.Approximate,
+			Candidates:                   candidates,
+		}),
 	}
 
 	if classification.DetectedProject != nil {
@@ -791,95 +1366,374 @@ func (s *ServiceImpl) ClassifyApplication(ctx context.Context, req *connect.Requ
 
 // ClassifyWebsite classifies a website URL
 func (s *ServiceImpl) ClassifyWebsite(ctx context.Context, req *connect.Request[brainv1.ClassifyWebsiteRequest]) (*connect.Response[brainv1.ClassifyWebsiteResponse], error) {
-	cs, err := NewClassificationService(s.gormDB)
-	if err != nil {
-		slog.Error("failed to create classification service", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("classification service error: %w", err))
+	// Variants of one page share a cache entry
+	pageURL := normalizeWebsiteURL(req.Msg.Url)
+	if err := checkURLLength(pageURL); err != nil {
+		return nil, err
+	}
+	if err := s.checkHTTPS(ctx, pageURL); err != nil {
+		return nil, err
 	}
+	ctx = withRequestTTL(ctx, req.Msg.CacheTtlSeconds)
+	ctx = withCacheURLs(ctx, req.Msg.Url, pageURL)
 
-	// Fetch website metadata with timeout
-	metadata := fetchWebsiteMetadata(req.Msg.Url)
+	// Coalesce on the request so repeated focus events skip the metadata fetch too
+	requestData := map[string]string{
+		"url":   pageURL,
+		"title": req.Msg.Title,
+	}
+	mode := declaredMode(req.Msg.UserMode)
+	if mode != "" {
+		requestData["user_mode"] = mode
+	}
+	if req.Msg.TagsOnly {
+		requestData["tags_only"] = "true"
+	}
+	if req.Msg.ReturnCandidates {
+		requestData["return_candidates"] = "true"
+	}
+	withForeground(req.Msg.Foreground, requestData)
+	if isFeedURL(pageURL) {
+		requestData["feed"] = feedTopic(requestData)
+	}
+	locale := reasoningLocale(req.Msg.Locale, req.Header())
+	withLocale(locale, requestData)
+
+	host := websiteHost(pageURL)
+	if isDenylistedDomain(host) {
+		slog.Info("website classification restricted", "host", host, "policy", policyDenylistedDomain)
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{
+			Classification: restrictedResult(policyDenylistedDomain, "This site is excluded from classification."),
+		}), nil
+	}
+
+	// Never fetch internal addresses from the server, classify from the URL and title alone
+	var policy *brainv1.ClassificationPolicy
+	if isPrivateHost(ctx, host) {
+		slog.Info("website metadata fetch restricted", "host", host, "policy", policyPrivateIP)
+		policy = &brainv1.ClassificationPolicy{
+			Reason: policyPrivateIP,
+			Detail: "Page details were not fetched because the site is on a private network.",
+		}
+	}
+
+	// User, then global overrides beat url rules, the cache and the model
+	if result := s.override(ctx, "domain", classificationSignals(requestData), domainTargets(host)...); result != nil {
+		result.Policy = policy
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{Classification: result}), nil
+	}
 
-	contextData := map[string]string{
-		"url": req.Msg.Url,
+	// Developers' own dev servers are work, decided without fetching them
+	if result := s.localDevResult(ctx, host, classificationSignals(requestData)); result != nil {
+		slog.Debug("website classified as local development server", "host", host)
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{Classification: result}), nil
 	}
 
-	// Add title from request or fetched metadata
-	if req.Msg.Title != "" {
-		contextData["title"] = req.Msg.Title
-	} else if metadata.Title != "" {
-		contextData["title"] = metadata.Title
+	// Configured focus music services are decided locally too
+	if result := s.supportingMediaResult(ctx, classificationSignals(requestData), host); result != nil {
+		result.Policy = policy
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{Classification: result}), nil
 	}
 
-	if metadata.Description != "" {
-		contextData["description"] = metadata.Description
+	// Purely path-based distinctions are decided locally without a model call
+	if rule := matchURLRule(pageURL); rule != nil {
+		slog.Debug("website classified by url rule", "host", host, "classification", rule.Classification)
+		result := rule.result(classificationSignals(requestData))
+		result.Policy = policy
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{Classification: result}), nil
 	}
-	if metadata.Keywords != "" {
-		contextData["keywords"] = metadata.Keywords
+
+	activity := s.recentActivity(ctx, req.Msg.RecentActivity)
+	variant := selectVariant(ctx)
+	coalesceData := requestData
+	if activity != "" {
+		coalesceData = maps.Clone(requestData)
+		coalesceData["recent_activity"] = activity
 	}
+	result, err := s.coalescer.do(coalesceKey(ctx, promptWebsite, coalesceData), func() (string, error) {
+		cs, err := s.classificationService(variant)
+		if err != nil {
+			slog.Error("failed to create classification service", "error", err)
+			return "", classificationError("classification service error", err)
+		}
+
+		// Fetch website metadata with timeout
+		var metadata WebsiteMetadata
+		if policy == nil {
+			fetchCtx, span := startSpan(ctx, "website.fetch_metadata", attribute.String("website.host", host))
+			metadata = capMetadata(fetchWebsiteMetadataContext(fetchCtx, pageURL))
+			span.End()
+		}
 
-	result, err := cs.classifyWithCache(ctx, promptWebsite, contextData)
+		contextData := map[string]string{
+			"url": pageURL,
+		}
+
+		// Add title from request or fetched metadata
+		if req.Msg.Title != "" {
+			contextData["title"] = req.Msg.Title
+		} else if metadata.Title != "" {
+			contextData["title"] = metadata.Title
+		}
+
+		if metadata.Description != "" {
+			contextData["description"] = metadata.Description
+		}
+		if metadata.Keywords != "" {
+			contextData["keywords"] = metadata.Keywords
+		}
+
+		// Feeds served from ordinary paths are recognised by their content type
+		if feed := requestData["feed"]; feed != "" {
+			contextData["feed"] = feed
+		} else if metadata.Feed {
+			contextData["feed"] = feedTopic(contextData)
+		}
+		if mode != "" {
+			contextData["user_mode"] = mode
+		}
+		if req.Msg.TagsOnly {
+			contextData["tags_only"] = "true"
+		}
+		if req.Msg.ReturnCandidates {
+			contextData["return_candidates"] = "true"
+		}
+		withForeground(req.Msg.Foreground, contextData)
+		withLocale(locale, contextData)
+
+		// What the user was just doing informs the model, results are then never cached
+		if activity != "" {
+			contextData["recent_activity"] = activity
+		}
+
+		result, err := cs.classifyWithCache(ctx, promptWebsite, contextData)
+		if err != nil {
+			slog.Error("classification failed", "error", err)
+			return "", classificationError("classification failed", err)
+		}
+		return result, nil
+	})
+	if errors.Is(err, errSafetyBlocked) {
+		return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{Classification: safetyBlockedResult(variant)}), nil
+	}
 	if err != nil {
-		slog.Error("classification failed", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("classification failed: %w", err))
+		return nil, err
 	}
 
 	var classification WebsiteClassificationResult
-	if err := json.Unmarshal([]byte(result), &classification); err != nil {
+	if err := parseClassification(result, &classification); err != nil {
 		slog.Error("failed to parse classification result", "error", err, "result", result)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse classification: %w", err))
 	}
+	var candidates []*brainv1.ClassificationCandidate
+	if req.Msg.ReturnCandidates {
+		// The primary fields follow the top candidate
+		candidates = rankCandidates(classification.Candidates, classification.Classification, classification.ConfidenceScore)
+		if len(candidates) > 0 {
+			classification.Classification, classification.ConfidenceScore = candidates[0].Classification, float64(candidates[0].ConfidenceScore)
+		}
+	}
+	classification.Classification = biasAmbiguousSearch(classification.Classification, requestData)
+	classification.Tags = biasFeedTags(classification.Tags, requestData)
+	classification.Classification, classification.Tags = biasMediaFocus(classification.Classification, classification.Tags, requestData)
+	variant.logResult(ctx, "website", classification.Classification, float32(classification.ConfidenceScore))
+	if req.Msg.TagsOnly {
+		classification.Reasoning = ""
+	}
 
 	return connect.NewResponse(&brainv1.ClassifyWebsiteResponse{
-		Classification: &brainv1.ClassificationResult{
+		Classification: calibrate(&brainv1.ClassificationResult{
 			Classification:               classification.Classification,
 			Reasoning:                    classification.Reasoning,
-			Tags:                         classification.Tags,
+			Tags:                         normalizeTags(classification.Tags),
 			ConfidenceScore:              float32(classification.ConfidenceScore),
 			DetectedProject:              classification.DetectedProject,
 			DetectedCommunicationChannel: classification.DetectedCommunicationChannel,
-		},
+			Signals:                      classificationSignals(requestData),
+			Model:                        cmp.Or(classification.Model, variant.Model),
+			PromptVersion:                variant.PromptVersion,
+			Approximate:                  classification.Approximate,
+			Policy:                       policy,
+			Candidates:                   candidates,
+		}),
 	}), nil
 }
 
 // classifyWithCache performs classification with caching
 func (cs *ClassificationService) classifyWithCache(ctx context.Context, prompt string, contextData map[string]string) (string, error) {
-	// Generate cache key
-	cacheKey := generateCacheKey(prompt, contextData)
+	return cs.classifyWithCacheKey(ctx, prompt, contextData, contextData)
+}
 
-	// Check cache
-	cached, err := cs.getFromCache(cacheKey)
-	if err == nil && cached != "" {
-		slog.Debug("cache hit", "key", cacheKey[:16])
-		return cached, nil
+// fullPrompt extends a base prompt with the variant's instructions, the
+// sections the input asks for and the caller's addenda
+func (cs *ClassificationService) fullPrompt(ctx context.Context, prompt string, contextData map[string]string) string {
+	prompt = cs.variant.prompt(prompt)
+	if contextData["tags_only"] != "" {
+		prompt += tagsOnlyInstructions
 	}
+	if contextData["return_candidates"] != "" {
+		prompt += candidatesInstructions
+	}
+	if contextData["locale"] != "" {
+		prompt += localeInstructions
+	}
+	// Addenda change the prompt, so they get cache entries of their own
+	return prompt + cs.promptAddendum(ctx)
+}
 
-	slog.Debug("cache miss", "key", cacheKey[:16])
+// classifyWithCacheKey classifies contextData but caches under keyData, for
+// inputs where only some fields identify the result
+func (cs *ClassificationService) classifyWithCacheKey(ctx context.Context, prompt string, keyData, contextData map[string]string) (string, error) {
+	similarity := similarityKey(prompt, keyData)
+	kind := promptKind(prompt)
+	prompt = cs.fullPrompt(ctx, prompt, contextData)
 
-	// Call Gemini
-	result, err := cs.callGemini(ctx, prompt, contextData)
+	// Generate cache key, scoped to the variant and model so results are attributed correctly
+	cacheKey := generateCacheKey(cs.variant.cacheScope()+cs.model+":"+prompt, keyData)
+
+	cacheable := isCacheable(contextData)
+	if cacheable {
+		if cached, ok := cs.lookupCache(ctx, cacheKey); ok {
+			return cached, nil
+		}
+	}
+
+	// Call Gemini, falling back through the configured models, unless the
+	// breaker is open after sustained failures
+	var (
+		result, model string
+		err           error
+	)
+	if cs.breaker.allow() {
+		result, model, err = cs.callModels(ctx, prompt, contextData)
+		cs.breaker.record(err)
+	} else {
+		err = errCircuitOpen
+	}
 	if err != nil {
+		if approximate, ok := cs.approximateFromCache(ctx, similarity, err); ok {
+			slog.Warn("model unavailable, serving approximate classification", "similarity_key", similarity, "error", err)
+			return approximate, nil
+		}
 		return "", err
 	}
+	first, firstModel := result, model
+	result, model = cs.reaskIfUncertain(ctx, prompt, contextData, result, model)
+	if model != cs.model {
+		result = markModel(result, model)
+	}
+
+	// A re-ask rolled out to some users must not reach the others through the
+	// cache, they get the first answer until the flag is on for everyone
+	stored := result
+	if (result != first || model != firstModel) && !cs.flags.enabledFor(flagReask, 0) {
+		stored = first
+		if firstModel != cs.model {
+			stored = markModel(first, firstModel)
+		}
+	}
+
+	if !cacheable {
+		return result, nil
+	}
+
+	// Responses the schema rejects fail this request, they must not fail every repeat
+	if err := parseClassification(stored, &ClassificationResult{}); err != nil {
+		slog.Warn("response does not match the schema, not caching", "key", cacheKey[:16], "error", err)
+		return result, nil
+	}
+
+	// Oversized responses are still served but never persisted
+	if maxBytes := envInt("CLASSIFICATION_CACHE_MAX_RESPONSE_BYTES", defaultMaxCachedResponseBytes); maxBytes > 0 && len(stored) > maxBytes {
+		slog.Warn("response too large to cache", "key", cacheKey[:16], "bytes", len(stored), "max_bytes", maxBytes)
+		return result, nil
+	}
+
+	// Store in cache (non-blocking), batched when a cache writer is configured
+	entry := newCacheEntry(cacheKey, stored, cacheTTL(ctx))
+	entry.SimilarityKey = similarity
+	entry.Kind = kind
+	entry.Input = cacheInput(keyData)
+	recordCacheURLs(ctx, &entry)
+	if cs.writer != nil {
+		cs.writer.add(entry)
+		return result, nil
+	}
+	cs.storeAsync(entry)
+
+	return result, nil
+}
+
+// uncachedInputs are model inputs about one user's moment, like what they
+// were just doing or what is on their screen. They change the answer but
+// rarely repeat, so results classified with them are neither served from nor
+// stored to the shared cache.
+var uncachedInputs = []string{"recent_activity", "screen_text"}
+
+// isCacheable reports whether the result for contextData may be cached
+func isCacheable(contextData map[string]string) bool {
+	for _, name := range uncachedInputs {
+		if contextData[name] != "" {
+			return false
+		}
+	}
+	return true
+}
 
-	// Store in cache (non-blocking)
+// lookupCache returns the cached response under cacheKey, if any
+func (cs *ClassificationService) lookupCache(ctx context.Context, cacheKey string) (string, bool) {
+	_, span := startSpan(ctx, "cache.lookup")
+	cached, err := cs.getFromCache(cacheKey)
+	span.SetAttributes(attribute.Bool("cache.hit", err == nil && cached != ""))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		endSpan(span, nil)
+	} else {
+		endSpan(span, err)
+	}
+	if err == nil && cached != "" {
+		slog.Debug("cache hit", "key", cacheKey[:16], "model", cs.model)
+		return cached, true
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Warn("cache lookup failed, falling back to model", "error", err)
+	}
+
+	slog.Debug("cache miss", "key", cacheKey[:16], "model", cs.model, "variant", cs.variant.Name)
+	return "", false
+}
+
+// storeAsync stores entry in a detached goroutine, tracked in cs.pending so
+// shutdown can wait for it instead of dropping a result we paid for
+func (cs *ClassificationService) storeAsync(entry commonv1.PromptHistoryORM) {
+	if cs.pending != nil {
+		cs.pending.Add(1)
+	}
 	go func() {
-		if storeErr := cs.storeInCache(cacheKey, result); storeErr != nil {
+		if cs.pending != nil {
+			defer cs.pending.Done()
+		}
+		if storeErr := cs.storeEntry(entry); storeErr != nil {
 			slog.Error("failed to store in cache", "error", storeErr)
 		}
 	}()
-
-	return result, nil
 }
 
-// callGemini calls the Gemini API for classification
-func (cs *ClassificationService) callGemini(ctx context.Context, prompt string, contextData map[string]string) (string, error) {
+// callGemini calls the Gemini API for classification with the given model
+func (cs *ClassificationService) callGemini(ctx context.Context, model, prompt string, contextData map[string]string) (text string, err error) {
+	ctx, span := startSpan(ctx, "gemini.generate_content", attribute.String("gemini.model", model))
+	defer func() { endSpan(span, err) }()
+
 	contextJSON, err := json.Marshal(contextData)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal context data: %w", err)
 	}
 
-	resp, err := cs.client.Models.GenerateContent(ctx, "gemini-2.5-flash", []*genai.Content{
+	if err := cs.limiter.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer cs.limiter.release()
+
+	resp, err := cs.models.GenerateContent(ctx, model, []*genai.Content{
 		{
 			Role: "user",
 			Parts: []*genai.Part{
@@ -895,22 +1749,108 @@ func (cs *ClassificationService) callGemini(ctx context.Context, prompt string,
 		ResponseMIMEType: "application/json",
 	})
 	if err != nil {
+		cs.debugGemini(ctx, model, prompt, contextJSON, "", err)
 		return "", fmt.Errorf("gemini API error: %w", err)
 	}
 
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("empty response from Gemini")
+	text, err = responseText(resp)
+	cs.debugGemini(ctx, model, prompt, contextJSON, text, err)
+	if err != nil {
+		return "", err
 	}
 
-	text := resp.Candidates[0].Content.Parts[0].Text
-
 	// Clean up response (remove markdown fences if present)
 	text = strings.TrimPrefix(text, "```json")
 	text = strings.TrimPrefix(text, "```")
 	text = strings.TrimSuffix(text, "```")
 	text = strings.TrimSpace(text)
 
-	return text, nil
+	return unwrapJSONArray(text)
+}
+
+// errEmptyResponse is returned when Gemini answers without any text
+var errEmptyResponse = errors.New("empty response from Gemini")
+
+// responseText returns the text of the first candidate, checking every level
+// of the response so an unusual shape fails with a specific error instead of
+// a panic. Text split across several parts is joined; thoughts are skipped.
+func responseText(resp *genai.GenerateContentResponse) (string, error) {
+	if resp == nil {
+		return "", fmt.Errorf("%w: no response", errEmptyResponse)
+	}
+	if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+		return "", fmt.Errorf("%w: prompt blocked (%s)", errSafetyBlocked, resp.PromptFeedback.BlockReason)
+	}
+	if len(resp.Candidates) == 0 {
+		return "", fmt.Errorf("%w: no candidates", errEmptyResponse)
+	}
+
+	candidate := resp.Candidates[0]
+	if candidate == nil {
+		return "", fmt.Errorf("%w: nil candidate", errEmptyResponse)
+	}
+	switch reason := candidate.FinishReason; reason {
+	case genai.FinishReasonSafety, genai.FinishReasonBlocklist, genai.FinishReasonProhibitedContent, genai.FinishReasonSPII:
+		return "", fmt.Errorf("%w: response blocked (%s)", errSafetyBlocked, reason)
+	}
+	if candidate.Content == nil {
+		return "", fmt.Errorf("%w: candidate has no content (finish reason %q)", errEmptyResponse, candidate.FinishReason)
+	}
+	if len(candidate.Content.Parts) == 0 {
+		return "", fmt.Errorf("%w: content has no parts (finish reason %q)", errEmptyResponse, candidate.FinishReason)
+	}
+
+	var text strings.Builder
+	for _, part := range candidate.Content.Parts {
+		if part == nil || part.Thought {
+			continue
+		}
+		text.WriteString(part.Text)
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return "", fmt.Errorf("%w: no text in %d parts (finish reason %q)", errEmptyResponse, len(candidate.Content.Parts), candidate.FinishReason)
+	}
+	return text.String(), nil
+}
+
+// normalizeTags lowercases, trims and de-duplicates the model's tags, then
+// keeps the first CLASSIFICATION_MAX_TAGS in the order the model ranked them.
+func normalizeTags(tags []string) []string {
+	maxTags := envInt("CLASSIFICATION_MAX_TAGS", defaultMaxTags)
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if maxTags > 0 && len(normalized) > maxTags {
+		normalized = normalized[:maxTags]
+	}
+	return normalized
+}
+
+// unwrapJSONArray unwraps the single object the model sometimes returns
+// inside an array. Multi-element arrays are ambiguous and rejected.
+func unwrapJSONArray(text string) (string, error) {
+	if !strings.HasPrefix(text, "[") {
+		return text, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(text), &items); err != nil {
+		return "", fmt.Errorf("invalid JSON array from model: %w", err)
+	}
+	if len(items) != 1 {
+		return "", fmt.Errorf("expected a single classification object, model returned an array of %d", len(items))
+	}
+
+	return strings.TrimSpace(string(items[0])), nil
 }
 
 // generateCacheKey creates a SHA-256 hash of prompt + context
@@ -923,28 +1863,177 @@ func generateCacheKey(prompt string, contextData map[string]string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// getFromCache retrieves a cached response
+// getFromCache retrieves a cached response and marks it as recently used.
+// The lookup goes to a read replica when configured, the access time update
+// always goes to the primary.
 func (cs *ClassificationService) getFromCache(hash string) (string, error) {
 	var cache commonv1.PromptHistoryORM
-	err := cs.db.Where("prompt_hash = ? AND expires_at > ?", hash, time.Now().Unix()).First(&cache).Error
+	now := time.Now().Unix()
+	if cs.writer != nil {
+		if response, ok := cs.writer.get(hash, now); ok {
+			return response, nil
+		}
+	}
+	lookup := func(db *gorm.DB) error {
+		return db.Where("prompt_hash = ? AND expires_at > ?", hash, now).First(&cache).Error
+	}
+
+	var err error
+	if cs.reads != nil {
+		err = cs.reads.read(lookup)
+	} else {
+		err = lookup(cs.db)
+	}
 	if err != nil {
 		return "", err
 	}
+
+	cs.evictor.touch(hash, now)
 	return cache.ResponseJson, nil
 }
 
-// storeInCache stores a response in the cache
-func (cs *ClassificationService) storeInCache(hash, response string) error {
+// storeInCache stores a response in the cache, evicting the least recently
+// used entries when CLASSIFICATION_CACHE_MAX_ROWS is exceeded
+func (cs *ClassificationService) storeInCache(hash, response string, ttl time.Duration) error {
+	return cs.storeEntry(newCacheEntry(hash, response, ttl))
+}
+
+// storeEntry upserts a prepared cache row and applies the row cap
+func (cs *ClassificationService) storeEntry(cache commonv1.PromptHistoryORM) error {
+	// Use upsert to handle race conditions
+	if err := cs.db.Save(&cache).Error; err != nil {
+		return err
+	}
+
+	return cs.evictor.maybeEvict(cs.db)
+}
+
+// newCacheEntry builds a cache row expiring after ttl
+func newCacheEntry(hash, response string, ttl time.Duration) commonv1.PromptHistoryORM {
 	now := time.Now().Unix()
-	cache := commonv1.PromptHistoryORM{
+	return commonv1.PromptHistoryORM{
 		PromptHash:   hash,
 		ResponseJson: response,
 		CreatedAt:    now,
-		ExpiresAt:    now + cacheTTLSeconds,
+		ExpiresAt:    now + int64(ttl.Seconds()),
+		LastAccessed: now,
 	}
+}
 
-	// Use upsert to handle race conditions
-	return cs.db.Save(&cache).Error
+// defaultCacheEvictInterval spaces out row cap checks, overridable via
+// CLASSIFICATION_CACHE_EVICT_INTERVAL
+const defaultCacheEvictInterval = time.Minute
+
+// cacheEvictor applies the CLASSIFICATION_CACHE_MAX_ROWS cap at most once
+// per interval, since counting the rows scans the whole table. The cache may
+// run past the cap in between. Cache hits are only recorded in memory and
+// written in batches right before each run, the only reader of last_accessed.
+// A nil evictor never evicts and records nothing.
+type cacheEvictor struct {
+	maxRows  int
+	interval time.Duration
+	lastRun  atomic.Int64 // unix nanoseconds
+
+	mu       sync.Mutex
+	accessed map[string]int64 // last hit per prompt hash since the previous run
+}
+
+// newCacheEvictor returns nil when maxRows disables the cap
+func newCacheEvictor(maxRows int, interval time.Duration) *cacheEvictor {
+	if maxRows <= 0 {
+		return nil
+	}
+	return &cacheEvictor{maxRows: maxRows, interval: interval}
+}
+
+// maybeEvict trims the cache unless it was trimmed within the interval
+func (e *cacheEvictor) maybeEvict(db *gorm.DB) error {
+	if e == nil {
+		return nil
+	}
+	now := time.Now().UnixNano()
+	last := e.lastRun.Load()
+	if now-last < int64(e.interval) || !e.lastRun.CompareAndSwap(last, now) {
+		return nil
+	}
+	if err := e.writeAccesses(db); err != nil {
+		return err
+	}
+	return evictCache(db, e.maxRows)
+}
+
+// touch records a cache hit for the next eviction run
+func (e *cacheEvictor) touch(hash string, at int64) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.accessed == nil {
+		e.accessed = make(map[string]int64)
+	}
+	e.accessed[hash] = at
+}
+
+// writeAccesses stores the recorded hits as last_accessed, one update per
+// second and batch of hashes
+func (e *cacheEvictor) writeAccesses(db *gorm.DB) error {
+	e.mu.Lock()
+	accessed := e.accessed
+	e.accessed = nil
+	e.mu.Unlock()
+
+	byTime := make(map[int64][]string)
+	for hash, at := range accessed {
+		byTime[at] = append(byTime[at], hash)
+	}
+	for at, hashes := range byTime {
+		for batch := range slices.Chunk(hashes, maintenanceBatchSize) {
+			err := db.Model(&commonv1.PromptHistoryORM{}).
+				Where("prompt_hash IN ?", batch).
+				UpdateColumn("last_accessed", at).Error
+			if err != nil {
+				return fmt.Errorf("failed to record cache access times: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// evictCache trims the cache to maxRows entries, dropping the least recently
+// used (then oldest) first, in batches so traffic is never blocked on one long
+// delete. A non-positive maxRows disables the cap.
+func evictCache(db *gorm.DB, maxRows int) error {
+	if maxRows <= 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.Model(&commonv1.PromptHistoryORM{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count cache entries: %w", err)
+	}
+
+	var evicted int64
+	for excess := count - int64(maxRows); excess > 0; {
+		stale := db.Model(&commonv1.PromptHistoryORM{}).
+			Select("prompt_hash").
+			Order("last_accessed ASC, created_at ASC").
+			Limit(int(min(excess, maintenanceBatchSize)))
+		result := db.Where("prompt_hash IN (?)", stale).Delete(&commonv1.PromptHistoryORM{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to evict cache entries: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			break
+		}
+		excess -= result.RowsAffected
+		evicted += result.RowsAffected
+	}
+
+	if evicted > 0 {
+		slog.Debug("evicted cache entries", "count", evicted, "max_rows", maxRows)
+	}
+	return nil
 }
 
 // WebsiteMetadata holds fetched metadata from a URL
@@ -952,39 +2041,118 @@ type WebsiteMetadata struct {
 	Title       string
 	Description string
 	Keywords    string
+	Feed        bool // served as RSS, Atom or JSON Feed
 }
 
-// fetchWebsiteMetadata fetches metadata from a URL with a 200ms timeout
+// fetchWebsiteMetadata fetches metadata from a URL within the configured
+// budget (WEBSITE_METADATA_TIMEOUT, 200ms by default). A connection or timeout
+// error is retried once if the budget allows; non-200 responses are not.
 func fetchWebsiteMetadata(url string) WebsiteMetadata {
-	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	return fetchWebsiteMetadataContext(context.Background(), url)
+}
+
+// fetchWebsiteMetadataContext is fetchWebsiteMetadata, also bounded by parent
+func fetchWebsiteMetadataContext(parent context.Context, url string) WebsiteMetadata {
+	ctx, cancel := context.WithTimeout(parent, envDuration("WEBSITE_METADATA_TIMEOUT", defaultMetadataTimeout))
 	defer cancel()
 
+	for attempt := 1; attempt <= 2; attempt++ {
+		metadata, err := fetchMetadataOnce(ctx, metadataClient, url)
+		if err == nil {
+			return metadata
+		}
+		if ctx.Err() != nil || errors.Is(err, errPrivateAddress) {
+			break
+		}
+		slog.Debug("website metadata fetch failed", "url", url, "attempt", attempt, "error", err)
+	}
+
+	return WebsiteMetadata{}
+}
+
+// fetchMetadataOnce performs a single metadata request. Only transport errors
+// are returned; unusable responses yield empty metadata.
+func fetchMetadataOnce(ctx context.Context, client *http.Client, url string) (WebsiteMetadata, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return WebsiteMetadata{}
+		return WebsiteMetadata{}, nil
 	}
 
 	req.Header.Set("User-Agent", "FocusdBot/1.0")
 
-	client := &http.Client{}
+	// Revalidate previously fetched pages instead of downloading them again
+	cached, hasCached := websiteMetadataCache.get(url)
+	if hasCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
-		return WebsiteMetadata{}
+		return WebsiteMetadata{}, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		return cached.metadata, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return WebsiteMetadata{}
+		return WebsiteMetadata{}, nil
 	}
 
-	// Read limited body to avoid memory issues
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)) // 64KB limit
+	// Read only as far as the head, the rest of the page is closed unread
+	body, err := readHead(resp.Body, maxMetadataBodyBytes)
 	if err != nil {
-		return WebsiteMetadata{}
+		return WebsiteMetadata{}, err
 	}
 
 	html := string(body)
-	return extractMetadata(html)
+	metadata := extractMetadata(html)
+	metadata.Feed = isFeedContentType(resp.Header.Get("Content-Type"))
+	websiteMetadataCache.put(url, metadataEntry{
+		metadata:     metadata,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	})
+	return metadata, nil
+}
+
+const (
+	// maxMetadataBodyBytes bounds the page read for metadata to avoid memory issues
+	maxMetadataBodyBytes = 64 * 1024
+	metadataChunkSize    = 4 * 1024
+)
+
+// headEnd marks where the title and meta tags end
+var headEnd = regexp.MustCompile(`(?i)</head>|<body[\s>]`)
+
+// readHead reads r up to the end of the document head, at most limit bytes,
+// so slow or large pages don't delay classification once their tags arrived
+func readHead(r io.Reader, limit int) ([]byte, error) {
+	r = io.LimitReader(r, int64(limit))
+	body := make([]byte, 0, metadataChunkSize)
+	chunk := make([]byte, metadataChunkSize)
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			// Overlap the previous chunk so a marker split across reads is found
+			from := max(len(body)-len("</head>"), 0)
+			body = append(body, chunk[:n]...)
+			if headEnd.Match(body[from:]) {
+				return body, nil
+			}
+		}
+		if err == io.EOF {
+			return body, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 }
 
 // extractMetadata extracts title, description, and keywords from HTML
@@ -1017,5 +2185,35 @@ func extractMetadata(html string) WebsiteMetadata {
 		metadata.Keywords = matches[1]
 	}
 
+	// Decode entities like &amp; and &#39; so the model sees plain text
+	metadata.Title = decodeEntities(metadata.Title)
+	metadata.Description = decodeEntities(metadata.Description)
+	metadata.Keywords = decodeEntities(metadata.Keywords)
+
 	return metadata
 }
+
+// decodeEntities unescapes HTML entities and trims surrounding whitespace
+func decodeEntities(s string) string {
+	return strings.TrimSpace(html.UnescapeString(s))
+}
+
+// capMetadata truncates description and keywords to their configured maximum lengths
+func capMetadata(metadata WebsiteMetadata) WebsiteMetadata {
+	metadata.Description = truncateRunes(metadata.Description, envInt("WEBSITE_DESCRIPTION_MAX_LENGTH", defaultDescriptionMaxLength))
+	metadata.Keywords = truncateRunes(metadata.Keywords, envInt("WEBSITE_KEYWORDS_MAX_LENGTH", defaultKeywordsMaxLength))
+	return metadata
+}
+
+// truncateRunes cuts s to at most max runes without splitting multi-byte characters.
+// A non-positive max disables truncation.
+func truncateRunes(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
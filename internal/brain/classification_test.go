@@ -0,0 +1,962 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"connectrpc.com/connect"
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+	"google.golang.org/genai"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCapMetadata_TruncatesLongDescription(t *testing.T) {
+	t.Setenv("WEBSITE_DESCRIPTION_MAX_LENGTH", "50")
+	t.Setenv("WEBSITE_KEYWORDS_MAX_LENGTH", "10")
+
+	metadata := capMetadata(WebsiteMetadata{
+		Title:       "Example",
+		Description: strings.Repeat("a", 5000),
+		Keywords:    "go, rust, zig, python",
+	})
+
+	if got := len(metadata.Description); got != 50 {
+		t.Fatalf("expected description capped to 50 chars, got %d", got)
+	}
+	if metadata.Keywords != "go, rust, " {
+		t.Fatalf("expected keywords capped to 10 chars, got %q", metadata.Keywords)
+	}
+	if metadata.Title != "Example" {
+		t.Fatalf("title should be untouched, got %q", metadata.Title)
+	}
+}
+
+func TestCapMetadata_DoesNotSplitRunes(t *testing.T) {
+	t.Setenv("WEBSITE_DESCRIPTION_MAX_LENGTH", "3")
+
+	metadata := capMetadata(WebsiteMetadata{Description: "héllo"})
+	if metadata.Description != "hél" {
+		t.Fatalf("expected rune-safe truncation, got %q", metadata.Description)
+	}
+}
+
+func TestExtractMetadata_DecodesEntities(t *testing.T) {
+	html := `<html><head>
+<meta property="og:title" content="Tom &amp; Jerry &#8211; Episode 1">
+<meta property="og:description" content="&lt;b&gt;Classic&lt;/b&gt; cartoon &quot;fun&quot;">
+<meta name="keywords" content="cats &amp; mice">
+</head></html>`
+
+	metadata := extractMetadata(html)
+
+	if metadata.Title != "Tom & Jerry – Episode 1" {
+		t.Errorf("unexpected title: %q", metadata.Title)
+	}
+	if metadata.Description != `<b>Classic</b> cartoon "fun"` {
+		t.Errorf("unexpected description: %q", metadata.Description)
+	}
+	if metadata.Keywords != "cats & mice" {
+		t.Errorf("unexpected keywords: %q", metadata.Keywords)
+	}
+}
+
+func TestFetchWebsiteMetadata_RetriesTransientFailure(t *testing.T) {
+	allowPrivateFetches(t)
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Drop the connection without a response to simulate a transient failure
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.Write([]byte(`<html><head><title>Recovered</title></head></html>`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "2s")
+
+	metadata := fetchWebsiteMetadata(srv.URL)
+	if metadata.Title != "Recovered" {
+		t.Fatalf("expected metadata after retry, got %+v", metadata)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestFetchWebsiteMetadata_DoesNotRetryNon200(t *testing.T) {
+	allowPrivateFetches(t)
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	if metadata := fetchWebsiteMetadata(srv.URL); metadata != (WebsiteMetadata{}) {
+		t.Fatalf("expected empty metadata, got %+v", metadata)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestFetchWebsiteMetadata_StopsReadingAfterHead(t *testing.T) {
+	allowPrivateFetches(t)
+
+	disconnected := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(disconnected)
+		w.Write([]byte(`<html><head><title>Slow Page</title><meta name="description" content="Tags up front"></head><body>`))
+		w.(http.Flusher).Flush()
+
+		// A huge body trickling in, far slower than the metadata budget allows
+		filler := []byte(strings.Repeat("<p>filler</p>", 80))
+		for range 1000 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+			}
+			if _, err := w.Write(filler); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "5s")
+
+	start := time.Now()
+	metadata := fetchWebsiteMetadata(srv.URL)
+	if metadata.Title != "Slow Page" || metadata.Description != "Tags up front" {
+		t.Fatalf("expected the head metadata, got %+v", metadata)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected to stop after the head, took %s", elapsed)
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the connection to be closed before the body finished")
+	}
+}
+
+func TestReadHead_FindsMarkerAcrossChunks(t *testing.T) {
+	page := strings.Repeat("x", metadataChunkSize-3) + "</head><body>" + strings.Repeat("y", 10*metadataChunkSize)
+	body, err := readHead(iotest.OneByteReader(strings.NewReader(page)), maxMetadataBodyBytes)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if !strings.Contains(string(body), "</head>") || len(body) > metadataChunkSize+len("</head><body>") {
+		t.Fatalf("expected to stop at the head, read %d bytes", len(body))
+	}
+}
+
+func TestFetchWebsiteMetadata_ReusesCachedMetadataOn304(t *testing.T) {
+	allowPrivateFetches(t)
+
+	var fullResponses, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == "Mon, 06 Oct 2025 10:00:00 GMT" {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 06 Oct 2025 10:00:00 GMT")
+		w.Write([]byte(`<html><head><title>Cached Page</title><meta name="description" content="First fetch"></head></html>`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "2s")
+
+	first := fetchWebsiteMetadata(srv.URL)
+	second := fetchWebsiteMetadata(srv.URL)
+
+	if first.Title != "Cached Page" || first.Description != "First fetch" {
+		t.Fatalf("unexpected metadata on first fetch: %+v", first)
+	}
+	if second != first {
+		t.Fatalf("expected cached metadata after 304, got %+v", second)
+	}
+	if fullResponses.Load() != 1 || notModified.Load() != 1 {
+		t.Fatalf("expected one full response and one 304, got %d and %d", fullResponses.Load(), notModified.Load())
+	}
+}
+
+func TestFetchWebsiteMetadata_SkipsConditionalWithoutValidators(t *testing.T) {
+	allowPrivateFetches(t)
+
+	var conditional atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			conditional.Add(1)
+		}
+		w.Write([]byte(`<html><head><title>No Validators</title></head></html>`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "2s")
+
+	fetchWebsiteMetadata(srv.URL)
+	if metadata := fetchWebsiteMetadata(srv.URL); metadata.Title != "No Validators" {
+		t.Fatalf("unexpected metadata: %+v", metadata)
+	}
+	if got := conditional.Load(); got != 0 {
+		t.Fatalf("expected no conditional requests, got %d", got)
+	}
+}
+
+func TestFetchWebsiteMetadataContext_StopsWithParent(t *testing.T) {
+	allowPrivateFetches(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "5s")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if metadata := fetchWebsiteMetadataContext(ctx, srv.URL); metadata != (WebsiteMetadata{}) {
+		t.Fatalf("expected empty metadata, got %+v", metadata)
+	}
+	// The request's deadline cuts the fetch short of its own budget
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the fetch to end with its parent, took %v", elapsed)
+	}
+}
+
+func TestMetadataCache_EvictsOldest(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_CACHE_SIZE", "2")
+
+	c := newMetadataCache()
+	for _, url := range []string{"https://a.example", "https://b.example", "https://c.example"} {
+		c.put(url, metadataEntry{etag: `"x"`})
+	}
+
+	if _, ok := c.get("https://a.example"); ok {
+		t.Fatal("expected the oldest entry to be evicted")
+	}
+	for _, url := range []string{"https://b.example", "https://c.example"} {
+		if _, ok := c.get(url); !ok {
+			t.Fatalf("expected %s to be cached", url)
+		}
+	}
+}
+
+func TestClassifyApplication_ReportsModelAndPromptVersion(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "test")
+	t.Setenv("CLASSIFICATION_MODEL", "gemini-test-model")
+	t.Setenv("CLASSIFICATION_PROMPT_VERSION", "2025-10-a")
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&commonv1.PromptHistoryORM{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	// Seed the cache so the handler never reaches the model
+	contextData := map[string]string{"name": "Visual Studio Code", "title": "main.go - focusd", "bundle_id": "com.microsoft.VSCode"}
+	if err := db.Create(&commonv1.PromptHistoryORM{
+		PromptHash:   generateCacheKey("gemini-test-model:"+promptDesktop, contextData),
+		ResponseJson: `{"classification":"productive","reasoning":"coding","tags":["work"],"confidence_score":1}`,
+		ExpiresAt:    time.Now().Add(time.Hour).Unix(),
+	}).Error; err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	resp, err := NewServiceImpl(db).ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:     contextData["name"],
+		WindowTitle:         contextData["title"],
+		ApplicationBundleId: contextData["bundle_id"],
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetModel() != "gemini-test-model" {
+		t.Errorf("expected model gemini-test-model, got %q", result.GetModel())
+	}
+	if result.GetPromptVersion() != "2025-10-a" {
+		t.Errorf("expected prompt version 2025-10-a, got %q", result.GetPromptVersion())
+	}
+}
+
+func TestStoreInCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&commonv1.PromptHistoryORM{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	// Pre-existing entries, "old" least recently used and "hot" recently hit
+	expires := time.Now().Add(time.Hour).Unix()
+	for hash, lastAccessed := range map[string]int64{"old": 100, "older-but-hot": 900, "middle": 500} {
+		if err := db.Create(&commonv1.PromptHistoryORM{
+			PromptHash: hash, ResponseJson: "{}", CreatedAt: 1, ExpiresAt: expires, LastAccessed: lastAccessed,
+		}).Error; err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+	}
+
+	cs := &ClassificationService{db: db, evictor: newCacheEvictor(3, time.Hour)}
+	if err := cs.storeInCache("fresh", "{}", time.Hour); err != nil {
+		t.Fatalf("failed to store: %v", err)
+	}
+
+	var hashes []string
+	if err := db.Model(&commonv1.PromptHistoryORM{}).Order("prompt_hash").Pluck("prompt_hash", &hashes).Error; err != nil {
+		t.Fatalf("failed to list cache: %v", err)
+	}
+	if want := []string{"fresh", "middle", "older-but-hot"}; strings.Join(hashes, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v after eviction, got %v", want, hashes)
+	}
+}
+
+func TestCacheEvictor_RunsOncePerInterval(t *testing.T) {
+	db := newTestDB(t)
+	store := func(hash string) {
+		t.Helper()
+		if err := db.Create(&commonv1.PromptHistoryORM{PromptHash: hash, ResponseJson: "{}", ExpiresAt: time.Now().Add(time.Hour).Unix()}).Error; err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+	}
+	count := func() int64 {
+		t.Helper()
+		var n int64
+		if err := db.Model(&commonv1.PromptHistoryORM{}).Count(&n).Error; err != nil {
+			t.Fatalf("failed to count cache: %v", err)
+		}
+		return n
+	}
+
+	evictor := newCacheEvictor(2, time.Hour)
+	for _, hash := range []string{"a", "b", "c"} {
+		store(hash)
+	}
+	if err := evictor.maybeEvict(db); err != nil || count() != 2 {
+		t.Fatalf("expected the first check to trim to 2 rows, got %d: %v", count(), err)
+	}
+
+	// Within the interval the cap is not checked again
+	store("d")
+	if err := evictor.maybeEvict(db); err != nil || count() != 3 {
+		t.Fatalf("expected no eviction within the interval, got %d rows: %v", count(), err)
+	}
+
+	if newCacheEvictor(0, time.Hour) != nil {
+		t.Fatal("expected no evictor without a row cap")
+	}
+}
+
+func TestEvictCache_InBatches(t *testing.T) {
+	db := newTestDB(t)
+	rows := make([]commonv1.PromptHistoryORM, maintenanceBatchSize+20)
+	for i := range rows {
+		rows[i] = commonv1.PromptHistoryORM{PromptHash: fmt.Sprintf("entry-%04d", i), ResponseJson: "{}", LastAccessed: int64(i)}
+	}
+	if err := db.CreateInBatches(rows, 100).Error; err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	if err := evictCache(db, 10); err != nil {
+		t.Fatalf("eviction failed: %v", err)
+	}
+	var hashes []string
+	if err := db.Model(&commonv1.PromptHistoryORM{}).Order("prompt_hash").Pluck("prompt_hash", &hashes).Error; err != nil {
+		t.Fatalf("failed to list cache: %v", err)
+	}
+	if len(hashes) != 10 || hashes[0] != fmt.Sprintf("entry-%04d", len(rows)-10) {
+		t.Fatalf("expected the 10 most recently used entries to remain, got %d starting at %v", len(hashes), hashes[:min(len(hashes), 1)])
+	}
+}
+
+func TestGetFromCache_RecordsAccessForEviction(t *testing.T) {
+	db := newTestDB(t)
+	for _, hash := range []string{"hit", "cold"} {
+		if err := db.Create(&commonv1.PromptHistoryORM{
+			PromptHash: hash, ResponseJson: "{}", ExpiresAt: time.Now().Add(time.Hour).Unix(), LastAccessed: 1,
+		}).Error; err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+	}
+	lastAccessed := func() int64 {
+		t.Helper()
+		var entry commonv1.PromptHistoryORM
+		if err := db.First(&entry, "prompt_hash = ?", "hit").Error; err != nil {
+			t.Fatalf("failed to reload entry: %v", err)
+		}
+		return entry.LastAccessed
+	}
+
+	// Without a row cap nobody reads last_accessed, so hits never write
+	if _, err := (&ClassificationService{db: db}).getFromCache("hit"); err != nil {
+		t.Fatalf("expected cache hit: %v", err)
+	}
+	if got := lastAccessed(); got != 1 {
+		t.Fatalf("expected no write without a row cap, got last_accessed %d", got)
+	}
+
+	// With a cap the hit is written before the next eviction, which keeps it
+	cs := &ClassificationService{db: db, evictor: newCacheEvictor(1, time.Hour)}
+	if _, err := cs.getFromCache("hit"); err != nil {
+		t.Fatalf("expected cache hit: %v", err)
+	}
+	if got := lastAccessed(); got != 1 {
+		t.Fatalf("expected the hit not to be written synchronously, got last_accessed %d", got)
+	}
+	if err := cs.evictor.maybeEvict(db); err != nil {
+		t.Fatalf("eviction failed: %v", err)
+	}
+	if got := lastAccessed(); got <= 1 {
+		t.Fatalf("expected last_accessed to be bumped, got %d", got)
+	}
+	var hashes []string
+	db.Model(&commonv1.PromptHistoryORM{}).Pluck("prompt_hash", &hashes)
+	if len(hashes) != 1 || hashes[0] != "hit" {
+		t.Fatalf("expected the recently hit entry to survive eviction, got %v", hashes)
+	}
+}
+
+func TestCacheTTL_RequestedPerClassification(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CACHE_TTL", "24h")
+	t.Setenv("CLASSIFICATION_MAX_REQUEST_CACHE_TTL", "1h")
+
+	db := newTestDB(t)
+	svc := NewServiceImpl(db)
+	useTestModels(svc, fakeModels{text: `{"classification":"productive","reasoning":"A live dashboard.","tags":["work"],"confidence_score":0.9}`})
+
+	// A short TTL is applied, a long one is clamped to the max
+	for name, ttl := range map[string]int64{"Grafana": 60, "Datadog": 7 * 24 * 3600} {
+		_, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: name,
+			WindowTitle:     "Live dashboard",
+			CacheTtlSeconds: ttl,
+		}))
+		if err != nil {
+			t.Fatalf("%s: classification failed: %v", name, err)
+		}
+	}
+	if err := svc.Close(context.Background()); err != nil {
+		t.Fatalf("failed to flush cache stores: %v", err)
+	}
+
+	var entries []commonv1.PromptHistoryORM
+	if err := db.Order("expires_at").Find(&entries).Error; err != nil || len(entries) != 2 {
+		t.Fatalf("expected two cache entries, got %d: %v", len(entries), err)
+	}
+	if ttl := entries[0].ExpiresAt - entries[0].CreatedAt; ttl != 60 {
+		t.Errorf("expected the requested 60s ttl, got %ds", ttl)
+	}
+	if ttl := entries[1].ExpiresAt - entries[1].CreatedAt; ttl != int64(time.Hour.Seconds()) {
+		t.Errorf("expected the ttl clamped to 1h, got %ds", ttl)
+	}
+
+	if got := cacheTTL(withRequestTTL(context.Background(), 0)); got != 24*time.Hour {
+		t.Errorf("expected no requested ttl to keep the configured one, got %v", got)
+	}
+}
+
+func TestCacheTTL_RequestedNeverOutlivesConfigured(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CACHE_TTL", "30m")
+	t.Setenv("CLASSIFICATION_CACHE_TTL_PRO", "10m")
+	t.Setenv("CLASSIFICATION_MAX_REQUEST_CACHE_TTL", "24h")
+
+	ctx := withRequestTTL(context.Background(), int64((2 * time.Hour).Seconds()))
+	if got := cacheTTL(ctx); got != 30*time.Minute {
+		t.Errorf("expected the requested ttl capped at the configured 30m, got %v", got)
+	}
+
+	pro := auth.WithUser(ctx, &auth.UserClaims{UserID: 1, Role: "pro"})
+	if got := cacheTTL(pro); got != 10*time.Minute {
+		t.Errorf("expected the requested ttl capped at the role's 10m, got %v", got)
+	}
+
+	if got := cacheTTL(withRequestTTL(pro, 60)); got != time.Minute {
+		t.Errorf("expected a shorter requested ttl to apply, got %v", got)
+	}
+}
+
+func TestCacheTTL_VariesByRole(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CACHE_TTL", "24h")
+	t.Setenv("CLASSIFICATION_CACHE_TTL_PRO", "1h")
+	t.Setenv("CLASSIFICATION_CACHE_TTL_ANONYMOUS", "72h")
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&commonv1.PromptHistoryORM{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	cs := &ClassificationService{db: db}
+
+	for role, want := range map[string]time.Duration{"pro": time.Hour, "anonymous": 72 * time.Hour, "unknown": 24 * time.Hour} {
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: role})
+		if got := cacheTTL(ctx); got != want {
+			t.Errorf("expected %s ttl %v, got %v", role, want, got)
+		}
+		if err := cs.storeInCache(role, "{}", cacheTTL(ctx)); err != nil {
+			t.Fatalf("failed to store: %v", err)
+		}
+	}
+
+	if got := cacheTTL(context.Background()); got != 24*time.Hour {
+		t.Errorf("expected default ttl without a user, got %v", got)
+	}
+
+	var pro, anonymous commonv1.PromptHistoryORM
+	db.First(&pro, "prompt_hash = ?", "pro")
+	db.First(&anonymous, "prompt_hash = ?", "anonymous")
+	if anonymous.ExpiresAt-pro.ExpiresAt < int64((71 * time.Hour).Seconds()) {
+		t.Fatalf("expected anonymous entry to outlive pro entry, got pro=%d anonymous=%d", pro.ExpiresAt, anonymous.ExpiresAt)
+	}
+}
+
+func TestUnwrapJSONArray(t *testing.T) {
+	object := `{"classification":"productive"}`
+
+	for name, in := range map[string]string{"plain object": object, "single-element array": "[" + object + "]"} {
+		got, err := unwrapJSONArray(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if got != object {
+			t.Fatalf("%s: expected %q, got %q", name, object, got)
+		}
+	}
+
+	if _, err := unwrapJSONArray("[" + object + "," + object + "]"); err == nil {
+		t.Fatal("expected error for multi-element array")
+	}
+}
+
+func TestCallGemini_UnwrapsFencedArray(t *testing.T) {
+	cs := &ClassificationService{models: fakeModels{text: "```json\n[{\"classification\":\"neutral\"}]\n```"}, model: "gemini-test"}
+
+	got, err := cs.callGemini(context.Background(), cs.model, promptDesktop, map[string]string{"name": "Finder"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `{"classification":"neutral"}` {
+		t.Fatalf("expected unwrapped object, got %q", got)
+	}
+}
+
+func TestNormalizeTags_CapsAndDeduplicates(t *testing.T) {
+	tags := []string{"Work", " code-editor ", "work", "", "research", "learning", "productivity", "other", "news"}
+
+	got := normalizeTags(tags)
+	want := []string{"work", "code-editor", "research", "learning"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	t.Setenv("CLASSIFICATION_MAX_TAGS", "2")
+	if got := normalizeTags(tags); len(got) != 2 || got[0] != "work" || got[1] != "code-editor" {
+		t.Fatalf("expected configured cap of 2, got %v", got)
+	}
+}
+
+// inputRecorder replies with text and remembers the context sent to the model
+type inputRecorder struct {
+	text  string
+	input map[string]string
+}
+
+func (r *inputRecorder) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &r.input); err != nil {
+		return nil, err
+	}
+	return fakeModels{text: r.text}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_ConsidersSecondaryTabs(t *testing.T) {
+	recorder := &inputRecorder{text: `{"classification":"productive","reasoning":"Coding with one social tab open.","tags":["work","code-editor"],"confidence_score":0.75}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	req := &brainv1.ClassifyApplicationRequest{
+		ApplicationName:     "Visual Studio Code",
+		WindowTitle:         "focusd-backend — main.go",
+		ApplicationBundleId: "com.microsoft.VSCode",
+		SecondaryTitles:     []string{"handler.go", " X / Home ", "focusd-backend — main.go", "", "X / Home"},
+	}
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	if recorder.input["title"] != "focusd-backend — main.go" || recorder.input["tabs"] != "handler.go\nX / Home" {
+		t.Fatalf("expected the active title and deduplicated tabs to reach the model, got %v", recorder.input)
+	}
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "productive" || result.GetConfidenceScore() != 0.75 {
+		t.Fatalf("unexpected classification %v", result)
+	}
+
+	// Tabs can change the answer, so another set of tabs gets its own entry
+	keyData, _ := applicationContext(req)
+	otherKey, otherContext := applicationContext(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:     req.ApplicationName,
+		WindowTitle:         req.WindowTitle,
+		ApplicationBundleId: req.ApplicationBundleId,
+		SecondaryTitles:     []string{"Reddit - Dive into anything"},
+	})
+	if generateCacheKey(promptDesktop, keyData) == generateCacheKey(promptDesktop, otherKey) {
+		t.Fatal("expected tabs to be part of the cache key")
+	}
+	if otherContext["tabs"] != "Reddit - Dive into anything" {
+		t.Fatalf("unexpected tabs %q", otherContext["tabs"])
+	}
+	if strings.Contains(otherKey["tabs"], "Reddit") {
+		t.Fatalf("expected the key to hold a hash of the tabs, got %q", otherKey["tabs"])
+	}
+
+	// The same tabs in another order share the entry
+	reordered, _ := applicationContext(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:     req.ApplicationName,
+		WindowTitle:         req.WindowTitle,
+		ApplicationBundleId: req.ApplicationBundleId,
+		SecondaryTitles:     []string{"X / Home", "handler.go"},
+	})
+	if generateCacheKey(promptDesktop, keyData) != generateCacheKey(promptDesktop, reordered) {
+		t.Fatal("expected reordered tabs to share the cache key")
+	}
+}
+
+// meetingAwareModels answers like the prompt's meeting example: a call app is
+// productive during a meeting and neutral otherwise
+type meetingAwareModels struct {
+	inputs []map[string]string
+}
+
+func (m *meetingAwareModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	var input map[string]string
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &input); err != nil {
+		return nil, err
+	}
+	m.inputs = append(m.inputs, input)
+
+	reply := `{"classification":"neutral","reasoning":"A Zoom window with no meeting context.","tags":["communication"],"confidence_score":0.5}`
+	if input["in_meeting"] == "true" {
+		reply = `{"classification":"productive","reasoning":"Attending a scheduled meeting on Zoom.","tags":["work","communication"],"confidence_score":0.9}`
+	}
+	return fakeModels{text: reply}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_InMeeting(t *testing.T) {
+	models := &meetingAwareModels{}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+
+	req := &brainv1.ClassifyApplicationRequest{ApplicationName: "zoom.us", WindowTitle: "Zoom Meeting"}
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := resp.Msg.GetClassification().GetClassification(); got != "neutral" {
+		t.Fatalf("expected neutral outside a meeting, got %q", got)
+	}
+
+	req.InMeeting = true
+	resp, err = svc.ClassifyApplication(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := resp.Msg.GetClassification().GetClassification(); got != "productive" {
+		t.Fatalf("expected productive during a meeting, got %q", got)
+	}
+
+	if len(models.inputs) != 2 {
+		t.Fatalf("expected both requests to reach the model, got %d", len(models.inputs))
+	}
+	if _, ok := models.inputs[0]["in_meeting"]; ok {
+		t.Fatalf("expected no meeting flag outside a meeting, got %v", models.inputs[0])
+	}
+	if models.inputs[1]["in_meeting"] != "true" {
+		t.Fatalf("expected the meeting flag to reach the model, got %v", models.inputs[1])
+	}
+
+	// Requests without the flag keep their existing cache key
+	keyData, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "zoom.us", WindowTitle: "Zoom Meeting"})
+	if _, ok := keyData["in_meeting"]; ok {
+		t.Fatalf("expected the cache key to be unchanged outside meetings, got %v", keyData)
+	}
+}
+
+// modeAwareModels answers like the prompt's declared mode example: a game is
+// distracting unless the user declared a break
+type modeAwareModels struct {
+	inputs []map[string]string
+}
+
+func (m *modeAwareModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	var input map[string]string
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &input); err != nil {
+		return nil, err
+	}
+	m.inputs = append(m.inputs, input)
+
+	reply := `{"classification":"distracting","reasoning":"Gaming.","tags":["entertainment"],"confidence_score":0.9}`
+	if input["user_mode"] == "break" {
+		reply = `{"classification":"neutral","reasoning":"Gaming during a break the user declared.","tags":["entertainment"],"confidence_score":0.8}`
+	}
+	return fakeModels{text: reply}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_UserMode(t *testing.T) {
+	models := &modeAwareModels{}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+
+	classify := func(mode string) string {
+		t.Helper()
+		resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: "Steam",
+			WindowTitle:     "Steam",
+			UserMode:        mode,
+		}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification().GetClassification()
+	}
+
+	if got := classify(""); got != "distracting" {
+		t.Fatalf("expected distracting without a declared mode, got %q", got)
+	}
+	if got := classify("break"); got != "neutral" {
+		t.Fatalf("expected neutral during a declared break, got %q", got)
+	}
+
+	if len(models.inputs) != 2 {
+		t.Fatalf("expected both requests to reach the model, got %d", len(models.inputs))
+	}
+	if _, ok := models.inputs[0]["user_mode"]; ok {
+		t.Fatalf("expected no mode without a declaration, got %v", models.inputs[0])
+	}
+	if models.inputs[1]["user_mode"] != "break" {
+		t.Fatalf("expected the declared mode to reach the model, got %v", models.inputs[1])
+	}
+
+	// Neutral shares the cache key of requests that declared nothing
+	undeclared, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Steam", WindowTitle: "Steam"})
+	neutral, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Steam", WindowTitle: "Steam", UserMode: "neutral"})
+	if !maps.Equal(undeclared, neutral) {
+		t.Fatalf("expected neutral mode to keep the cache key, got %v and %v", undeclared, neutral)
+	}
+}
+
+func TestClassifyWebsite_UserModeReachesModel(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	models := &modeAwareModels{}
+	svc := newPolicyTestService(t, models)
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:      "http://192.0.2.1/games",
+		Title:    "Browser games",
+		UserMode: "break",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := resp.Msg.GetClassification().GetClassification(); got != "neutral" {
+		t.Fatalf("expected neutral during a declared break, got %q", got)
+	}
+	if len(models.inputs) != 1 || models.inputs[0]["user_mode"] != "break" {
+		t.Fatalf("expected the declared mode to reach the model, got %v", models.inputs)
+	}
+}
+
+// tagsOnlyModels skips the reasoning when the system prompt asks for tags only
+type tagsOnlyModels struct {
+	inputs []map[string]string
+}
+
+func (m *tagsOnlyModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	var input map[string]string
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &input); err != nil {
+		return nil, err
+	}
+	m.inputs = append(m.inputs, input)
+
+	reply := `{"classification":"productive","reasoning":"Editing Go code.","tags":["Work","coding"],"confidence_score":0.9}`
+	if strings.Contains(config.SystemInstruction.Parts[0].Text, "# Tags Only") {
+		reply = `{"classification":"productive","reasoning":"","tags":["Work","coding"],"confidence_score":0.9}`
+	}
+	return fakeModels{text: reply}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_TagsOnly(t *testing.T) {
+	models := &tagsOnlyModels{}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+
+	classify := func(tagsOnly bool) *brainv1.ClassificationResult {
+		t.Helper()
+		resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: "Code",
+			WindowTitle:     "main.go - focusd",
+			TagsOnly:        tagsOnly,
+		}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification()
+	}
+
+	if full := classify(false); full.GetReasoning() == "" {
+		t.Fatalf("expected reasoning in a full classification, got %v", full)
+	}
+	light := classify(true)
+	if light.GetReasoning() != "" || light.GetClassification() != "productive" || !slices.Equal(light.GetTags(), []string{"work", "coding"}) {
+		t.Fatalf("expected only the classification and tags, got %v", light)
+	}
+	if len(models.inputs) != 2 || models.inputs[1]["tags_only"] != "true" {
+		t.Fatalf("expected the tags-only request to reach the model, got %v", models.inputs)
+	}
+
+	// Full answers are never served from the tags-only cache, or the reverse
+	full, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go - focusd"})
+	tagsOnly, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go - focusd", TagsOnly: true})
+	if generateCacheKey(promptDesktop, full) == generateCacheKey(promptDesktop, tagsOnly) {
+		t.Fatal("expected tags-only requests to use a distinct cache key")
+	}
+}
+
+func TestClassifyWebsite_TagsOnlyDropsReasoning(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	// A model ignoring the instruction still yields no reasoning
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"Docs.","tags":["work"],"confidence_score":0.9}`})
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:      "http://192.0.2.1/docs",
+		Title:    "Internal docs",
+		TagsOnly: true,
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	result := resp.Msg.GetClassification()
+	if result.GetReasoning() != "" || len(result.GetTags()) == 0 {
+		t.Fatalf("expected tags without reasoning, got %v", result)
+	}
+}
+
+func TestClassifyWithCache_SkipsOversizedResponse(t *testing.T) {
+	t.Setenv("CLASSIFICATION_CACHE_MAX_RESPONSE_BYTES", "200")
+
+	db := newTestDB(t)
+	writer := newCacheWriter(db, time.Hour, 100, nil)
+
+	small := `{"classification":"productive","reasoning":"coding","tags":["work"],"confidence_score":1}`
+	large := `{"classification":"productive","reasoning":"` + strings.Repeat("very long ", 50) + `","tags":["work"],"confidence_score":1}`
+
+	for title, reply := range map[string]string{"small": small, "large": large} {
+		cs := &ClassificationService{db: db, models: fakeModels{text: reply}, model: "gemini-test", writer: writer}
+		result, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"name": "Code", "title": title})
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		if result != reply {
+			t.Fatalf("expected the %s response to be returned, got %q", title, result)
+		}
+	}
+	writer.Close()
+
+	var rows []commonv1.PromptHistoryORM
+	if err := db.Find(&rows).Error; err != nil {
+		t.Fatalf("failed to list cache: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ResponseJson != small {
+		t.Fatalf("expected only the small response to be cached, got %d rows", len(rows))
+	}
+}
+
+// responseModels replies with a fixed response, whatever its shape
+type responseModels struct {
+	resp *genai.GenerateContentResponse
+}
+
+func (m responseModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	return m.resp, nil
+}
+
+func TestCallGemini_JoinsTextParts(t *testing.T) {
+	resp := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: &genai.Content{Parts: []*genai.Part{
+		{Text: "Checking the title first.", Thought: true},
+		{Text: `{"classification":"productive",`},
+		nil,
+		{Text: `"reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`},
+	}}}}}
+	cs := &ClassificationService{db: newTestDB(t), models: responseModels{resp: resp}, model: "gemini-test"}
+
+	result, err := cs.callGemini(context.Background(), "gemini-test", promptDesktop, map[string]string{"name": "Code"})
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	var classification ClassificationResult
+	if err := parseClassification(result, &classification); err != nil || classification.Classification != "productive" {
+		t.Fatalf("expected the parts joined into one answer, got %s (%v)", result, err)
+	}
+}
+
+func TestResponseText_UnusualShapes(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp *genai.GenerateContentResponse
+		want string
+	}{
+		"nil response":   {nil, "no response"},
+		"no candidates":  {&genai.GenerateContentResponse{}, "no candidates"},
+		"nil candidate":  {&genai.GenerateContentResponse{Candidates: []*genai.Candidate{nil}}, "nil candidate"},
+		"no content":     {&genai.GenerateContentResponse{Candidates: []*genai.Candidate{{FinishReason: genai.FinishReasonMaxTokens}}}, "no content"},
+		"no parts":       {&genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: &genai.Content{}}}}, "no parts"},
+		"nil parts only": {&genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: &genai.Content{Parts: []*genai.Part{nil, {Text: " "}}}}}}, "no text in 2 parts"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := responseText(tc.resp)
+			if !errors.Is(err, errEmptyResponse) || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an empty response error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	blocked := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{FinishReason: genai.FinishReasonSafety}}}
+	if _, err := responseText(blocked); !errors.Is(err, errSafetyBlocked) {
+		t.Fatalf("expected a safety block, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package brain
+
+import (
+	"expvar"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultSkewWarnThreshold flags clients whose clock is noticeably off well
+// before they fall outside the replay window, overridable via HANDSHAKE_SKEW_WARN_THRESHOLD
+const defaultSkewWarnThreshold = 5 * time.Second
+
+// skewStats aggregates client-vs-server clock skew seen on handshakes
+type skewStats struct {
+	mu            sync.Mutex
+	count         int64
+	sumAbs        time.Duration
+	maxAbs        time.Duration
+	last          time.Duration
+	overThreshold int64
+}
+
+// handshakeSkew is exported on /debug/vars as handshake_clock_skew
+var handshakeSkew = &skewStats{}
+
+func init() {
+	expvar.Publish("handshake_clock_skew", expvar.Func(func() any { return handshakeSkew.snapshot() }))
+}
+
+// clockSkew returns how far the client's clock is ahead of the server's
+// (negative when it is behind), from Unix second timestamps
+func clockSkew(clientUnix, serverUnix int64) time.Duration {
+	return time.Duration(clientUnix-serverUnix) * time.Second
+}
+
+// record adds one observation and reports whether it crossed the threshold
+func (s *skewStats) record(skew, threshold time.Duration) bool {
+	abs := skew.Abs()
+	over := threshold > 0 && abs > threshold
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	s.sumAbs += abs
+	s.maxAbs = max(s.maxAbs, abs)
+	s.last = skew
+	if over {
+		s.overThreshold++
+	}
+	return over
+}
+
+func (s *skewStats) snapshot() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var mean float64
+	if s.count > 0 {
+		mean = s.sumAbs.Seconds() / float64(s.count)
+	}
+	return map[string]any{
+		"count":            s.count,
+		"mean_abs_seconds": mean,
+		"max_abs_seconds":  s.maxAbs.Seconds(),
+		"last_seconds":     s.last.Seconds(),
+		"over_threshold":   s.overThreshold,
+	}
+}
+
+// recordClockSkew logs and aggregates the skew of a handshake, whether or not
+// it falls within the replay window. It is purely diagnostic.
+func recordClockSkew(fingerprint string, skew time.Duration) {
+	threshold := envDuration("HANDSHAKE_SKEW_WARN_THRESHOLD", defaultSkewWarnThreshold)
+	if handshakeSkew.record(skew, threshold) {
+		slog.Warn("handshake clock skew", "device_fingerprint", fingerprint, "skew_seconds", skew.Seconds(), "threshold", threshold)
+		return
+	}
+	slog.Info("handshake clock skew", "device_fingerprint", fingerprint, "skew_seconds", skew.Seconds())
+}
@@ -0,0 +1,74 @@
+package brain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// coalescer short-circuits identical classification inputs seen within a small
+// window, so focus thrashing (alt-tabbing back and forth) resolves once.
+type coalescer struct {
+	window  time.Duration
+	mu      sync.Mutex
+	entries map[string]coalescedResult
+}
+
+type coalescedResult struct {
+	result string
+	at     time.Time
+}
+
+func newCoalescer(window time.Duration) *coalescer {
+	return &coalescer{
+		window:  window,
+		entries: make(map[string]coalescedResult),
+	}
+}
+
+// do returns the last result stored for key if it is younger than the window,
+// otherwise it resolves via fn and remembers the result. A zero window disables
+// coalescing entirely.
+func (c *coalescer) do(key string, fn func() (string, error)) (string, error) {
+	if c == nil || c.window <= 0 {
+		return fn()
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && time.Since(e.at) < c.window {
+		c.mu.Unlock()
+		return e.result, nil
+	}
+	c.mu.Unlock()
+
+	result, err := fn()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop anything that has aged out so the map stays small
+	for k, e := range c.entries {
+		if now.Sub(e.at) >= c.window {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = coalescedResult{result: result, at: now}
+
+	return result, nil
+}
+
+// coalesceKey scopes a classification input to the calling user.
+func coalesceKey(ctx context.Context, prompt string, input map[string]string) string {
+	var userID int64
+	if user, ok := auth.GetUser(ctx); ok {
+		userID = user.UserID
+	}
+	return fmt.Sprintf("%d:%s", userID, generateCacheKey(prompt, input))
+}
@@ -0,0 +1,58 @@
+package brain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoalescer_IdenticalInputWithinWindow(t *testing.T) {
+	c := newCoalescer(2 * time.Second)
+
+	calls := 0
+	resolve := func() (string, error) {
+		calls++
+		return `{"classification":"productive"}`, nil
+	}
+
+	first, err := c.do("1:abc", resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := c.do("1:abc", resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 underlying resolution, got %d", calls)
+	}
+	if first != second {
+		t.Fatalf("expected coalesced result %q, got %q", first, second)
+	}
+
+	// A different user with the same input resolves independently
+	if _, err := c.do("2:abc", resolve); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 underlying resolutions, got %d", calls)
+	}
+}
+
+func TestCoalescer_Disabled(t *testing.T) {
+	c := newCoalescer(0)
+
+	calls := 0
+	for i := 0; i < 2; i++ {
+		if _, err := c.do("1:abc", func() (string, error) {
+			calls++
+			return "{}", nil
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected every call to resolve when disabled, got %d", calls)
+	}
+}
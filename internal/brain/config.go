@@ -0,0 +1,200 @@
+package brain
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/focusd-so/brain/internal/auth"
+	"github.com/focusd-so/brain/internal/secrets"
+)
+
+// envString reads a string from the environment, falling back to def when unset
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// envDuration reads a duration (e.g. "2s") from the environment, falling back
+// to def when the variable is unset or invalid.
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Warn("invalid duration in environment, using default", "key", key, "value", v, "default", def)
+		return def
+	}
+	return d
+}
+
+// envInt reads an integer from the environment, falling back to def when the
+// variable is unset or invalid.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		slog.Warn("invalid integer in environment, using default", "key", key, "value", v, "default", def)
+		return def
+	}
+	return n
+}
+
+// envBool reads a boolean (e.g. "true", "1") from the environment, falling back
+// to def when the variable is unset or invalid.
+func envBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		slog.Warn("invalid boolean in environment, using default", "key", key, "value", v, "default", def)
+		return def
+	}
+	return b
+}
+
+// envFloat reads a float (e.g. "0.6") from the environment, falling back to
+// def when the variable is unset or invalid.
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		slog.Warn("invalid number in environment, using default", "key", key, "value", v, "default", def)
+		return def
+	}
+	return f
+}
+
+// Setting is a resolved configuration value, reported by the config command
+type Setting struct {
+	Key    string
+	Value  string
+	Secret bool
+}
+
+// Settings returns the effective value of every environment setting the brain
+// service reads, with defaults applied. Secrets are flagged for redaction.
+func Settings() []Setting {
+	return []Setting{
+		{Key: "AGENT_APP_NAME", Value: envString("AGENT_APP_NAME", "focusd")},
+		{Key: "AGENT_MODEL_PROVIDER", Value: envString("AGENT_MODEL_PROVIDER", agentProviderGemini)},
+		{Key: "AGENT_MODEL", Value: os.Getenv("AGENT_MODEL")},
+		{Key: "AGENT_MAX_RESPONSE_BYTES", Value: strconv.Itoa(envInt("AGENT_MAX_RESPONSE_BYTES", defaultAgentMaxResponseBytes))},
+		{Key: "AGENT_MAX_SUBAGENTS", Value: strconv.Itoa(envInt("AGENT_MAX_SUBAGENTS", defaultAgentMaxSubagents))},
+		{Key: "AGENT_MAX_TOOLS_PER_AGENT", Value: strconv.Itoa(envInt("AGENT_MAX_TOOLS_PER_AGENT", defaultAgentMaxToolsPerAgent))},
+		{Key: "AGENT_RESPONSE_CACHE_TTL", Value: agentCacheTTL().String()},
+		{Key: "AGENT_MAX_OUTPUT_TOKENS", Value: strconv.Itoa(envInt("AGENT_MAX_OUTPUT_TOKENS", defaultAgentMaxOutputTokens))},
+		{Key: "AGENT_WS_ORIGINS", Value: os.Getenv("AGENT_WS_ORIGINS")},
+		{Key: "OPENAI_BASE_URL", Value: envString("OPENAI_BASE_URL", defaultOpenAIBaseURL)},
+		{Key: "ANTHROPIC_BASE_URL", Value: envString("ANTHROPIC_BASE_URL", defaultAnthropicBaseURL)},
+		{Key: "CLASSIFICATION_MODEL", Value: strings.Join(classificationModels(), ",")},
+		{Key: "CLASSIFICATION_PROMPT_VERSION", Value: classificationPromptVersion()},
+		{Key: "CLASSIFICATION_AB_PERCENT", Value: strconv.Itoa(envInt("CLASSIFICATION_AB_PERCENT", 0))},
+		{Key: "CLASSIFICATION_AB_EXPERIMENT", Value: envString("CLASSIFICATION_AB_EXPERIMENT", defaultExperimentName)},
+		{Key: "CLASSIFICATION_AB_MODEL", Value: envString("CLASSIFICATION_AB_MODEL", classificationModel())},
+		{Key: "CLASSIFICATION_AB_PROMPT_VERSION", Value: envString("CLASSIFICATION_AB_PROMPT_VERSION", classificationPromptVersion()+"-b")},
+		{Key: "CLASSIFICATION_AB_INSTRUCTIONS", Value: os.Getenv("CLASSIFICATION_AB_INSTRUCTIONS")},
+		{Key: "CLASSIFICATION_APPROXIMATE_FALLBACK", Value: strconv.FormatBool(envBool("CLASSIFICATION_APPROXIMATE_FALLBACK", false))},
+		{Key: "CLASSIFICATION_ALLOWED_SOURCES", Value: os.Getenv("CLASSIFICATION_ALLOWED_SOURCES")},
+		{Key: "GEMINI_DEBUG_LOG", Value: strconv.FormatBool(envBool("GEMINI_DEBUG_LOG", false))},
+		{Key: "GEMINI_DEBUG_SINK", Value: envString("GEMINI_DEBUG_SINK", geminiDebugStdout)},
+		{Key: "GEMINI_MAX_CONCURRENCY", Value: strconv.Itoa(envInt("GEMINI_MAX_CONCURRENCY", defaultGeminiMaxConcurrency))},
+		{Key: "CLASSIFICATION_SHED_THRESHOLD", Value: strconv.FormatFloat(envFloat("CLASSIFICATION_SHED_THRESHOLD", defaultShedThreshold), 'g', -1, 64)},
+		{Key: "CLASSIFICATION_SHED_RETRY_AFTER", Value: envDuration("CLASSIFICATION_SHED_RETRY_AFTER", defaultShedRetryAfter).String()},
+		{Key: "CLASSIFICATION_BREAKER_THRESHOLD", Value: strconv.Itoa(envInt("CLASSIFICATION_BREAKER_THRESHOLD", defaultBreakerThreshold))},
+		{Key: "CLASSIFICATION_BREAKER_COOLDOWN", Value: envDuration("CLASSIFICATION_BREAKER_COOLDOWN", defaultBreakerCooldown).String()},
+		{Key: "CLASSIFICATION_REASK", Value: strconv.FormatBool(envBool("CLASSIFICATION_REASK", false))},
+		{Key: "CLASSIFICATION_REASK_THRESHOLD", Value: strconv.FormatFloat(envFloat("CLASSIFICATION_REASK_THRESHOLD", defaultReaskThreshold), 'g', -1, 64)},
+		{Key: "CLASSIFICATION_DEVELOPER_MODE", Value: strconv.FormatBool(envBool("CLASSIFICATION_DEVELOPER_MODE", false))},
+		{Key: "CLASSIFICATION_DENYLIST_DOMAINS", Value: os.Getenv("CLASSIFICATION_DENYLIST_DOMAINS")},
+		{Key: "CLASSIFICATION_THIN_APP_POLICY", Value: envString("CLASSIFICATION_THIN_APP_POLICY", thinAppClassify)},
+		{Key: "CLASSIFICATION_THIN_APP_MIN_FIELDS", Value: strconv.Itoa(envInt("CLASSIFICATION_THIN_APP_MIN_FIELDS", defaultThinAppMinFields))},
+		{Key: "CLASSIFICATION_SCHEMA_MODE", Value: envString("CLASSIFICATION_SCHEMA_MODE", schemaModeLenient)},
+		{Key: "CLASSIFICATION_MAX_SCREEN_TEXT", Value: strconv.Itoa(envInt("CLASSIFICATION_MAX_SCREEN_TEXT", defaultMaxScreenTextLength))},
+		{Key: "CLASSIFICATION_HTTPS_ONLY", Value: strconv.FormatBool(envBool("CLASSIFICATION_HTTPS_ONLY", false))},
+		{Key: "CLASSIFICATION_MAX_URL_LENGTH", Value: strconv.Itoa(envInt("CLASSIFICATION_MAX_URL_LENGTH", defaultMaxURLLength))},
+		{Key: "CLASSIFICATION_TAG_WEIGHTS", Value: formatTagWeights(tagWeights())},
+		{Key: "PROMPT_ADDENDUM_MAX_LENGTH", Value: strconv.Itoa(envInt("PROMPT_ADDENDUM_MAX_LENGTH", defaultPromptAddendumMaxLength))},
+		{Key: "CLASSIFICATION_CONFIDENCE_CALIBRATION", Value: formatConfidenceCalibration(confidenceCalibration())},
+		{Key: "CLASSIFICATION_URL_STRIP_PARAMS", Value: strings.Join(urlStripParams(), ",")},
+		{Key: "CLASSIFICATION_CACHE_STORE_URLS", Value: strconv.FormatBool(envBool("CLASSIFICATION_CACHE_STORE_URLS", false))},
+		{Key: "CLASSIFICATION_CACHE_STORE_INPUTS", Value: strconv.FormatBool(envBool("CLASSIFICATION_CACHE_STORE_INPUTS", false))},
+		{Key: "CLASSIFICATION_MAX_TAGS", Value: strconv.Itoa(envInt("CLASSIFICATION_MAX_TAGS", defaultMaxTags))},
+		{Key: "CLASSIFICATION_CACHE_TTL", Value: envDuration("CLASSIFICATION_CACHE_TTL", cacheTTLSeconds*time.Second).String()},
+		{Key: "CLASSIFICATION_MAX_REQUEST_CACHE_TTL", Value: envDuration("CLASSIFICATION_MAX_REQUEST_CACHE_TTL", cacheTTLSeconds*time.Second).String()},
+		{Key: "CLASSIFICATION_CACHE_MAX_ROWS", Value: strconv.Itoa(envInt("CLASSIFICATION_CACHE_MAX_ROWS", 0))},
+		{Key: "CLASSIFICATION_CACHE_EVICT_INTERVAL", Value: envDuration("CLASSIFICATION_CACHE_EVICT_INTERVAL", defaultCacheEvictInterval).String()},
+		{Key: "CLASSIFICATION_CACHE_MAX_RESPONSE_BYTES", Value: strconv.Itoa(envInt("CLASSIFICATION_CACHE_MAX_RESPONSE_BYTES", defaultMaxCachedResponseBytes))},
+		{Key: "CLASSIFICATION_CACHE_FLUSH_INTERVAL", Value: envDuration("CLASSIFICATION_CACHE_FLUSH_INTERVAL", 0).String()},
+		{Key: "CLASSIFICATION_CACHE_BATCH_SIZE", Value: strconv.Itoa(envInt("CLASSIFICATION_CACHE_BATCH_SIZE", defaultCacheBatchSize))},
+		{Key: "CLASSIFICATION_COALESCE_WINDOW", Value: envDuration("CLASSIFICATION_COALESCE_WINDOW", 0).String()},
+		{Key: "CLASSIFICATION_VOTE_METRIC_MAX_INPUTS", Value: strconv.Itoa(envInt("CLASSIFICATION_VOTE_METRIC_MAX_INPUTS", defaultVoteMetricInputs))},
+		{Key: "WEBSITE_METADATA_TIMEOUT", Value: envDuration("WEBSITE_METADATA_TIMEOUT", defaultMetadataTimeout).String()},
+		{Key: "WEBSITE_URL_RULES", Value: os.Getenv("WEBSITE_URL_RULES")},
+		{Key: "CLASSIFICATION_TITLE_VOLATILE_PATTERNS", Value: os.Getenv("CLASSIFICATION_TITLE_VOLATILE_PATTERNS")},
+		{Key: "WEBSITE_METADATA_CACHE_SIZE", Value: strconv.Itoa(envInt("WEBSITE_METADATA_CACHE_SIZE", defaultMetadataCacheSize))},
+		{Key: "WEBSITE_DESCRIPTION_MAX_LENGTH", Value: strconv.Itoa(envInt("WEBSITE_DESCRIPTION_MAX_LENGTH", defaultDescriptionMaxLength))},
+		{Key: "WEBSITE_KEYWORDS_MAX_LENGTH", Value: strconv.Itoa(envInt("WEBSITE_KEYWORDS_MAX_LENGTH", defaultKeywordsMaxLength))},
+		{Key: "CLASSIFICATION_WORK_SEARCH_TERMS", Value: os.Getenv("CLASSIFICATION_WORK_SEARCH_TERMS")},
+		{Key: "SUPPORTING_MEDIA", Value: strings.Join(supportingMedia(), ",")},
+		{Key: "CLASSIFICATION_SUPPORTING_MEDIA_SHORTCUT", Value: strconv.FormatBool(envBool("CLASSIFICATION_SUPPORTING_MEDIA_SHORTCUT", true))},
+		{Key: "FEATURE_FLAGS", Value: os.Getenv("FEATURE_FLAGS")},
+		{Key: "SUPPORTING_AUDIO_KEYWORDS", Value: envString("SUPPORTING_AUDIO_KEYWORDS", defaultSpokenAudioKeywords)},
+		{Key: "FEED_TECHNICAL_KEYWORDS", Value: strings.Join(technicalFeedKeywords(), ",")},
+		{Key: "CLASSIFICATION_RECENT_ACTIVITY", Value: strconv.FormatBool(envBool("CLASSIFICATION_RECENT_ACTIVITY", false))},
+		{Key: "CLASSIFICATION_MAX_RECENT_ACTIVITY", Value: strconv.Itoa(envInt("CLASSIFICATION_MAX_RECENT_ACTIVITY", defaultMaxRecentActivityLength))},
+		{Key: "CLASSIFICATION_RECENT_ACTIVITY_WINDOW", Value: envDuration("CLASSIFICATION_RECENT_ACTIVITY_WINDOW", defaultRecentActivityWindow).String()},
+		{Key: "FOCUS_SESSION_MAX_DURATION", Value: envDuration("FOCUS_SESSION_MAX_DURATION", defaultFocusSessionMaxDuration).String()},
+		{Key: "HANDSHAKE_DIAGNOSTICS", Value: strconv.FormatBool(envBool("HANDSHAKE_DIAGNOSTICS", false))},
+		{Key: "HANDSHAKE_RATE_LIMIT", Value: strconv.Itoa(envInt("HANDSHAKE_RATE_LIMIT", defaultHandshakeRateLimit))},
+		{Key: "HANDSHAKE_IP_RATE_LIMIT", Value: strconv.Itoa(envInt("HANDSHAKE_IP_RATE_LIMIT", defaultHandshakeIPRateLimit))},
+		{Key: "HANDSHAKE_TRUSTED_PROXIES", Value: os.Getenv("HANDSHAKE_TRUSTED_PROXIES")},
+		{Key: "HANDSHAKE_RATE_WINDOW", Value: envDuration("HANDSHAKE_RATE_WINDOW", defaultHandshakeRateWindow).String()},
+		{Key: "HANDSHAKE_TIMESTAMP_WINDOW", Value: handshakeTimestampWindow().String()},
+		{Key: "HANDSHAKE_NONCE_RETENTION", Value: nonceRetention().String()},
+		{Key: "HANDSHAKE_SKEW_WARN_THRESHOLD", Value: envDuration("HANDSHAKE_SKEW_WARN_THRESHOLD", defaultSkewWarnThreshold).String()},
+		{Key: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")},
+		{Key: "OTEL_SERVICE_NAME", Value: envString("OTEL_SERVICE_NAME", "focusd-brain")},
+		{Key: "SECRETS_SOURCE", Value: envString("SECRETS_SOURCE", "env")},
+		{Key: "SECRETS_DIR", Value: envString("SECRETS_DIR", "/run/secrets")},
+		{Key: "VAULT_ADDR", Value: os.Getenv("VAULT_ADDR")},
+		{Key: "VAULT_SECRET_PATH", Value: os.Getenv("VAULT_SECRET_PATH")},
+		{Key: "VAULT_TOKEN", Value: os.Getenv("VAULT_TOKEN"), Secret: true},
+		{Key: "OAUTH2_EXPIRY_WARNING", Value: envDuration("OAUTH2_EXPIRY_WARNING", defaultOAuth2ExpiryWarning).String()},
+		{Key: "REDIRECT_URI", Value: os.Getenv("REDIRECT_URI")},
+		{Key: "GITHUB_CLIENT_ID", Value: os.Getenv("GITHUB_CLIENT_ID")},
+		{Key: "GITHUB_CLIENT_SECRET", Value: secrets.Get("GITHUB_CLIENT_SECRET"), Secret: true},
+		{Key: "GITHUB_API_BASE_URL", Value: os.Getenv("GITHUB_API_BASE_URL")},
+		{Key: "GITHUB_OAUTH_BASE_URL", Value: os.Getenv("GITHUB_OAUTH_BASE_URL")},
+		{Key: "TOKEN_EXPIRY_LEEWAY", Value: auth.ExpiryLeeway().String()},
+		{Key: "GITHUB_REVOKE_TIMEOUT", Value: envDuration("GITHUB_REVOKE_TIMEOUT", defaultGitHubRevokeTimeout).String()},
+		{Key: "GITHUB_REVOKE_RETRY_BACKOFF", Value: envDuration("GITHUB_REVOKE_RETRY_BACKOFF", defaultGitHubRevokeRetryBackoff).String()},
+		{Key: "GITHUB_DEFAULT_SCOPES", Value: strings.Join(defaultScopes("github"), ",")},
+		{Key: "GOOGLE_API_KEY", Value: secrets.Get("GOOGLE_API_KEY"), Secret: true},
+		{Key: "GEMINI_API_KEY", Value: secrets.Get("GEMINI_API_KEY"), Secret: true},
+		{Key: "OPENAI_API_KEY", Value: secrets.Get("OPENAI_API_KEY"), Secret: true},
+		{Key: "ANTHROPIC_API_KEY", Value: secrets.Get("ANTHROPIC_API_KEY"), Secret: true},
+		{Key: "HMAC_SECRET_KEY", Value: secrets.Get("HMAC_SECRET_KEY"), Secret: true},
+		{Key: "CLASSIFICATION_WEBHOOK_URL", Value: os.Getenv("CLASSIFICATION_WEBHOOK_URL")},
+		{Key: "CLASSIFICATION_WEBHOOK_SECRET", Value: secrets.Get("CLASSIFICATION_WEBHOOK_SECRET"), Secret: true},
+		{Key: "CLASSIFICATION_WEBHOOK_MAX_ATTEMPTS", Value: strconv.Itoa(envInt("CLASSIFICATION_WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts))},
+		{Key: "CLASSIFICATION_WEBHOOK_BACKOFF", Value: envDuration("CLASSIFICATION_WEBHOOK_BACKOFF", defaultWebhookBackoff).String()},
+		{Key: "CLASSIFICATION_WEBHOOK_TIMEOUT", Value: envDuration("CLASSIFICATION_WEBHOOK_TIMEOUT", defaultWebhookTimeout).String()},
+		{Key: "CLASSIFICATION_WEBHOOK_MAX_IN_FLIGHT", Value: strconv.Itoa(envInt("CLASSIFICATION_WEBHOOK_MAX_IN_FLIGHT", defaultWebhookMaxInFlight))},
+		{Key: "PASETO_KEYS", Value: secrets.Get("PASETO_KEYS"), Secret: true},
+	}
+}
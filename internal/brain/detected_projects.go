@@ -0,0 +1,140 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// defaultDetectedProjectsLimit is how many projects ListDetectedProjects
+// returns when the request sets no limit
+const defaultDetectedProjectsLimit = 20
+
+// maxDetectedProjectNameLength bounds the stored project name
+const maxDetectedProjectNameLength = 200
+
+// canonicalProjectName folds spelling variants of a project name into one,
+// so "Focusd Backend", "focusd_backend" and "focusd-backend" are one project
+func canonicalProjectName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// recordDetectedProject remembers the project a classification detected for
+// the user, if any. Failures are logged, they never fail the classification.
+func (s *ServiceImpl) recordDetectedProject(ctx context.Context, kind string, result *brainv1.ClassificationResult, now time.Time) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return
+	}
+	name := truncateRunes(strings.TrimSpace(result.GetDetectedProject()), maxDetectedProjectNameLength)
+	canonical := canonicalProjectName(name)
+	if canonical == "" {
+		return
+	}
+
+	project := commonv1.DetectedProjectORM{
+		UserId:              user.UserID,
+		CanonicalName:       canonical,
+		Name:                name,
+		FirstSeenAt:         now.Unix(),
+		LastSeenAt:          now.Unix(),
+		ClassificationCount: 1,
+		LastKind:            kind,
+	}
+	err := s.gormDB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "canonical_name"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"name":                 name,
+			"last_seen_at":         now.Unix(),
+			"last_kind":            kind,
+			"classification_count": gorm.Expr("classification_count + 1"),
+		}),
+	}).Create(&project).Error
+	if err != nil {
+		slog.Warn("failed to record detected project", "user_id", user.UserID, "error", err)
+	}
+}
+
+// ListDetectedProjects lists the authenticated user's detected projects,
+// most recently seen first
+func (s *ServiceImpl) ListDetectedProjects(ctx context.Context, req *connect.Request[brainv1.ListDetectedProjectsRequest]) (*connect.Response[brainv1.ListDetectedProjectsResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultDetectedProjectsLimit
+	}
+
+	var projects []commonv1.DetectedProjectORM
+	err := s.gormDB.WithContext(ctx).
+		Where("user_id = ? AND last_seen_at >= ?", user.UserID, req.Msg.SinceUnix).
+		Order("last_seen_at DESC, id DESC").
+		Limit(limit).
+		Find(&projects).Error
+	if err != nil {
+		return nil, dbError("failed to list detected projects", err)
+	}
+
+	resp := &brainv1.ListDetectedProjectsResponse{Projects: make([]*brainv1.DetectedProject, 0, len(projects))}
+	for _, project := range projects {
+		resp.Projects = append(resp.Projects, &brainv1.DetectedProject{
+			Name:                project.Name,
+			FirstSeenAt:         project.FirstSeenAt,
+			LastSeenAt:          project.LastSeenAt,
+			ClassificationCount: project.ClassificationCount,
+			LastKind:            project.LastKind,
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// detectedProjectInterceptor records the projects classifications detect
+type detectedProjectInterceptor struct {
+	svc *ServiceImpl
+}
+
+// NewDetectedProjectInterceptor creates a ConnectRPC interceptor recording
+// detected projects per user. It must run after auth.
+func NewDetectedProjectInterceptor(svc *ServiceImpl) connect.Interceptor {
+	return &detectedProjectInterceptor{svc: svc}
+}
+
+// WrapUnary records the project of successful classifications
+func (i *detectedProjectInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if kind, result, ok := classifiedKind(resp.Any()); ok && result.GetDetectedProject() != "" {
+			i.svc.recordDetectedProject(ctx, kind, result, time.Now())
+		}
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *detectedProjectInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler is a no-op, classifications are unary
+func (i *detectedProjectInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
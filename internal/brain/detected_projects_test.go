@@ -0,0 +1,69 @@
+package brain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestDetectedProjects_RecordedAndListedByRecency(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"editor","tags":["work"],"detected_project":"Focusd Backend","confidence_score":0.9}`})
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	other := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 8})
+
+	// Classifications record their project through the interceptor
+	unary := NewDetectedProjectInterceptor(svc).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return svc.ClassifyApplication(ctx, req.(*connect.Request[brainv1.ClassifyApplicationRequest]))
+	})
+	if _, err := unary(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go - focusd-backend"})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	project := func(name string) *brainv1.ClassificationResult {
+		return &brainv1.ClassificationResult{Classification: "productive", DetectedProject: &name}
+	}
+	svc.recordDetectedProject(ctx, "website", project("brain"), base.Add(10*time.Minute))
+	svc.recordDetectedProject(ctx, "command", project("focusd_backend"), base.Add(20*time.Minute))
+	svc.recordDetectedProject(ctx, "document", project("Q3 report"), base.Add(5*time.Minute))
+	svc.recordDetectedProject(other, "application", project("secret-project"), base)
+
+	resp, err := svc.ListDetectedProjects(ctx, connect.NewRequest(&brainv1.ListDetectedProjectsRequest{}))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	projects := resp.Msg.Projects
+	if len(projects) != 3 {
+		t.Fatalf("expected 3 projects for the user, got %v", projects)
+	}
+
+	// The spelling variants are one project, named as last detected
+	if p := projects[0]; p.Name != "focusd_backend" || p.LastKind != "command" || p.ClassificationCount != 2 {
+		t.Fatalf("expected the variants to merge into one project, got %v", p)
+	}
+	if projects[1].Name != "brain" || projects[2].Name != "Q3 report" {
+		t.Fatalf("expected projects by recency, got %v", projects)
+	}
+
+	since := base.Add(8 * time.Minute).Unix()
+	resp, err = svc.ListDetectedProjects(ctx, connect.NewRequest(&brainv1.ListDetectedProjectsRequest{Limit: 1, SinceUnix: since}))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(resp.Msg.Projects) != 1 || resp.Msg.Projects[0].ClassificationCount != 2 {
+		t.Fatalf("expected the limit to keep only the latest project, got %v", resp.Msg.Projects)
+	}
+}
+
+func TestCanonicalProjectName(t *testing.T) {
+	for _, name := range []string{"Focusd Backend", "focusd_backend", " focusd-backend ", "focusd.backend"} {
+		if got := canonicalProjectName(name); got != "focusd-backend" {
+			t.Errorf("canonicalProjectName(%q) = %q, want focusd-backend", name, got)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package brain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+const promptDocument = `
+You are a Productivity Analyst. Your job is to analyze document file paths and classify them based on their impact on focus and productivity.
+
+You will receive:
+- **path** (string): The document path, with the user's home directory replaced by "~"
+- **extension** (string): The lowercased file extension, e.g. ".xlsx"
+- **name** (string, optional): The application the document is open in
+
+You must immediately reply **only with a single, raw JSON object**.
+Do **not** wrap the JSON in markdown fences, do **not** add explanations, and do **not** output anything except the JSON object.
+
+---
+
+# JSON Schema (strict)
+
+The JSON object you return must contain exactly these keys:
+
+1. **"classification"** — one of:
+   - "productive"
+   - "supporting"
+   - "neutral"
+   - "distracting"
+
+2. **"reasoning"** — a brief explanation for the classification.
+
+3. **"tags"** — an array containing one or more of the following strictly allowed tags:
+
+[
+  "work",
+  "research",
+  "learning",
+  "productivity",
+  "finance",
+  "code-editor",
+  "design-tool",
+  "entertainment",
+  "personal",
+  "other"
+]
+
+4. **"detected_project"** — *(string | null)*
+   The project or client name when a folder in the path clearly names one, otherwise null.
+
+5. **"detected_communication_channel"** — always null.
+
+6. **"confidence_score"** — *(float)*
+   A confidence score between 0.0 and 1.0 indicating the AI's confidence in the classification.
+
+No other keys or tags are permitted.
+
+---
+
+# Classification Rules
+
+Both the **folder structure** and the **file type** matter.
+
+## **productive**
+- Office documents in work-like folders: "work", "projects", "clients", "reports", company or project names
+- Spreadsheets, slides and docs named like deliverables: "Q3-report", "roadmap", "invoice", "spec", "proposal"
+- Source code, design files and data files
+
+## **neutral**
+- Downloads, installers, archives and system files
+- Documents whose purpose cannot be inferred
+
+## **distracting**
+- Personal media: photos, videos, movies, music libraries ("Pictures", "Photos", "Movies", "DCIM")
+- Games, save files, personal hobby folders
+
+Personal documents that are not media (taxes, bills) are **neutral** and tagged **personal**.
+
+---
+
+# Examples
+
+Input: { "path": "~/work/Q3-report.xlsx", "extension": ".xlsx" }
+Output:
+{
+  "classification": "productive",
+  "reasoning": "Quarterly report spreadsheet in a work folder.",
+  "tags": ["work", "productivity"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.9
+}
+
+Input: { "path": "~/Pictures/Holiday 2024/IMG_0042.jpg", "extension": ".jpg" }
+Output:
+{
+  "classification": "distracting",
+  "reasoning": "Browsing personal holiday photos.",
+  "tags": ["personal", "entertainment"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.9
+}
+
+REMINDER: output must be a valid JSON object with no markdown fences, no explanations, and no other text.
+`
+
+// homePrefix matches user home directories on macOS, Linux and Windows
+var homePrefix = regexp.MustCompile(`(?i)^(?:[a-z]:)?/(?:users|home)/[^/]+(?:/|$)`)
+
+// normalizeDocumentPath strips the user's home directory so usernames never
+// reach the model or the cache, and cleans the path so equivalent spellings
+// share a cache entry.
+func normalizeDocumentPath(p string) string {
+	p = strings.TrimSpace(strings.ReplaceAll(p, `\`, "/"))
+
+	switch {
+	case p == "~" || strings.HasPrefix(p, "~/"):
+		p = strings.TrimPrefix(strings.TrimPrefix(p, "~"), "/")
+	case strings.HasPrefix(p, "$HOME"):
+		p = strings.TrimPrefix(strings.TrimPrefix(p, "$HOME"), "/")
+	case homePrefix.MatchString(p):
+		p = homePrefix.ReplaceAllString(p, "")
+	default:
+		return path.Clean(p)
+	}
+
+	return path.Clean("~/" + p)
+}
+
+// documentContext builds the classification input for a document request
+func documentContext(req *brainv1.ClassifyDocumentRequest) map[string]string {
+	normalized := normalizeDocumentPath(req.GetPath())
+
+	contextData := map[string]string{
+		"path":      normalized,
+		"extension": strings.ToLower(path.Ext(normalized)),
+	}
+	if req.GetApplicationName() != "" {
+		contextData["name"] = req.GetApplicationName()
+	}
+	return contextData
+}
+
+// ClassifyDocument classifies a document by its path
+func (s *ServiceImpl) ClassifyDocument(ctx context.Context, req *connect.Request[brainv1.ClassifyDocumentRequest]) (*connect.Response[brainv1.ClassifyDocumentResponse], error) {
+	contextData := documentContext(req.Msg)
+	withLocale(reasoningLocale(req.Msg.Locale, req.Header()), contextData)
+
+	variant := selectVariant(ctx)
+	result, err := s.coalescer.do(coalesceKey(ctx, promptDocument, contextData), func() (string, error) {
+		cs, err := s.classificationService(variant)
+		if err != nil {
+			slog.Error("failed to create classification service", "error", err)
+			return "", classificationError("classification service error", err)
+		}
+
+		result, err := cs.classifyWithCache(ctx, promptDocument, contextData)
+		if err != nil {
+			slog.Error("classification failed", "error", err)
+			return "", classificationError("classification failed", err)
+		}
+		return result, nil
+	})
+	if errors.Is(err, errSafetyBlocked) {
+		return connect.NewResponse(&brainv1.ClassifyDocumentResponse{Classification: safetyBlockedResult(variant)}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var classification ClassificationResult
+	if err := parseClassification(result, &classification); err != nil {
+		slog.Error("failed to parse classification result", "error", err, "result", result)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse classification: %w", err))
+	}
+	variant.logResult(ctx, "document", classification.Classification, classification.ConfidenceScore)
+
+	return connect.NewResponse(&brainv1.ClassifyDocumentResponse{
+		Classification: calibrate(&brainv1.ClassificationResult{
+			Classification:  classification.Classification,
+			Reasoning:       classification.Reasoning,
+			Tags:            normalizeTags(classification.Tags),
+			ConfidenceScore: classification.ConfidenceScore,
+			DetectedProject: classification.DetectedProject,
+			Signals:         classificationSignals(contextData),
+			Model:           cmp.Or(classification.Model, variant.Model),
+			PromptVersion:   variant.PromptVersion,
+		}),
+	}), nil
+}
@@ -0,0 +1,66 @@
+package brain
+
+import (
+	"slices"
+	"testing"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestNormalizeDocumentPath_StripsHome(t *testing.T) {
+	for in, want := range map[string]string{
+		"/Users/jane/work/Q3-report.xlsx":     "~/work/Q3-report.xlsx",
+		"/home/jane/work/../work/notes.md":    "~/work/notes.md",
+		`C:\Users\Jane\Documents\budget.xlsx`: "~/Documents/budget.xlsx",
+		"~/Pictures/IMG_0042.jpg":             "~/Pictures/IMG_0042.jpg",
+		"$HOME/Downloads/setup.dmg":           "~/Downloads/setup.dmg",
+		"/Volumes/Shared/plan.pdf":            "/Volumes/Shared/plan.pdf",
+	} {
+		if got := normalizeDocumentPath(in); got != want {
+			t.Errorf("normalizeDocumentPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDocumentContext_Spreadsheet(t *testing.T) {
+	contextData := documentContext(&brainv1.ClassifyDocumentRequest{
+		Path:            "/Users/jane/work/Q3-report.xlsx",
+		ApplicationName: "Microsoft Excel",
+	})
+
+	if contextData["path"] != "~/work/Q3-report.xlsx" || contextData["extension"] != ".xlsx" {
+		t.Fatalf("unexpected context %v", contextData)
+	}
+
+	signals := classificationSignals(contextData)
+	if !slices.Contains(signals, "document is a spreadsheet") || !slices.Contains(signals, "path indicates work folder") {
+		t.Fatalf("expected spreadsheet work signals, got %v", signals)
+	}
+}
+
+func TestDocumentContext_PersonalPhotos(t *testing.T) {
+	contextData := documentContext(&brainv1.ClassifyDocumentRequest{
+		Path: "/Users/jane/Pictures/Holiday 2024/IMG_0042.JPG",
+	})
+
+	if contextData["path"] != "~/Pictures/Holiday 2024/IMG_0042.JPG" || contextData["extension"] != ".jpg" {
+		t.Fatalf("unexpected context %v", contextData)
+	}
+	if _, ok := contextData["name"]; ok {
+		t.Fatalf("expected no application name, got %v", contextData)
+	}
+
+	signals := classificationSignals(contextData)
+	if !slices.Contains(signals, "document is a photo") || !slices.Contains(signals, "path indicates personal media") {
+		t.Fatalf("expected personal photo signals, got %v", signals)
+	}
+}
+
+func TestDocumentContext_SameFileSharesCacheKey(t *testing.T) {
+	a := documentContext(&brainv1.ClassifyDocumentRequest{Path: "/Users/alice/work/Q3-report.xlsx"})
+	b := documentContext(&brainv1.ClassifyDocumentRequest{Path: "~/work/./Q3-report.xlsx"})
+
+	if generateCacheKey(promptDocument, a) != generateCacheKey(promptDocument, b) {
+		t.Fatal("expected normalized paths to share a cache key")
+	}
+}
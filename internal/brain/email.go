@@ -0,0 +1,212 @@
+package brain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+const promptEmail = `
+You are a Productivity Analyst. Your job is to analyze email entries and classify them based on their impact on focus and productivity.
+
+You will receive:
+- **sender** (string): The sender's email address
+- **sender_domain** (string): The domain of the sender's address
+- **subject** (string): The subject line, with reply/forward prefixes removed
+- **snippet** (string, optional): The first lines of the email body
+
+You must immediately reply **only with a single, raw JSON object**.
+Do **not** wrap the JSON in markdown fences, do **not** add explanations, and do **not** output anything except the JSON object.
+
+---
+
+# JSON Schema (strict)
+
+The JSON object you return must contain exactly these keys:
+
+1. **"classification"** — one of:
+   - "productive"
+   - "supporting"
+   - "neutral"
+   - "distracting"
+
+2. **"reasoning"** — a brief explanation for the classification.
+
+3. **"tags"** — an array containing one or more of the following strictly allowed tags:
+
+[
+  "work",
+  "communication",
+  "productivity",
+  "finance",
+  "newsletter",
+  "promotion",
+  "social-media",
+  "news",
+  "personal",
+  "other"
+]
+
+4. **"detected_project"** — *(string | null)*
+   The project or client name when the subject clearly names one, otherwise null.
+
+5. **"detected_communication_channel"** — always null.
+
+6. **"confidence_score"** — *(float)*
+   A confidence score between 0.0 and 1.0 indicating the AI's confidence in the classification.
+
+No other keys or tags are permitted.
+
+---
+
+# Classification Rules
+
+## **productive**
+Work correspondence from colleagues, clients or work tools:
+- Direct messages about projects, reviews, incidents, meetings, deadlines
+- Notifications from work tools (GitHub, Jira, Linear, PagerDuty) about the user's work
+
+## **neutral**
+- Transactional mail: receipts, invoices, shipping updates, password resets
+- Calendar invitations without clear work context
+
+## **distracting**
+- Newsletters, digests and marketing campaigns ("Weekly digest", "% off", "Don't miss")
+- Social network notifications (likes, follows, "people you may know")
+- Bulk senders such as "noreply@", "newsletter@", "marketing@" unless the content is clearly work
+
+---
+
+# Examples
+
+Input: { "sender": "jane@acme.com", "sender_domain": "acme.com", "subject": "Q3 planning – draft for review" }
+Output:
+{
+  "classification": "productive",
+  "reasoning": "Colleague asking for a review of work planning.",
+  "tags": ["work", "communication"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 0.9
+}
+
+Input: { "sender": "newsletter@shop.example", "sender_domain": "shop.example", "subject": "48h only: 30% off everything" }
+Output:
+{
+  "classification": "distracting",
+  "reasoning": "Marketing newsletter promoting a sale.",
+  "tags": ["newsletter", "promotion"],
+  "detected_project": null,
+  "detected_communication_channel": null,
+  "confidence_score": 1
+}
+
+REMINDER: output must be a valid JSON object with no markdown fences, no explanations, and no other text.
+`
+
+// maxEmailSnippetLength keeps email bodies from bloating the prompt
+const maxEmailSnippetLength = 300
+
+// replyPrefix matches reply/forward markers such as "Re:", "FW:" or "Fwd:"
+var replyPrefix = regexp.MustCompile(`(?i)^\s*(?:re|fw|fwd|aw|sv)\s*:\s*`)
+
+// bulkSenderMarkers hint that an address sends newsletters or promotions
+var bulkSenderMarkers = []string{"noreply", "no-reply", "newsletter", "marketing", "news@", "promo", "digest"}
+
+// normalizeEmailSender reduces "Jane Doe <Jane@Acme.com>" to "jane@acme.com"
+func normalizeEmailSender(sender string) string {
+	if addr, err := mail.ParseAddress(sender); err == nil {
+		sender = addr.Address
+	}
+	return strings.ToLower(strings.TrimSpace(sender))
+}
+
+// normalizeEmailSubject strips reply/forward prefixes and collapses whitespace
+// so a thread shares one cache entry
+func normalizeEmailSubject(subject string) string {
+	for replyPrefix.MatchString(subject) {
+		subject = replyPrefix.ReplaceAllString(subject, "")
+	}
+	return strings.Join(strings.Fields(subject), " ")
+}
+
+// emailContext builds the model input and the cache key input for an email.
+// The snippet is sent to the model but never part of the cache key.
+func emailContext(req *brainv1.ClassifyEmailRequest) (keyData, contextData map[string]string) {
+	sender := normalizeEmailSender(req.GetSender())
+	subject := normalizeEmailSubject(req.GetSubject())
+
+	_, domain, _ := strings.Cut(sender, "@")
+
+	keyData = map[string]string{
+		"sender":  sender,
+		"subject": strings.ToLower(subject),
+	}
+
+	contextData = map[string]string{
+		"sender":        sender,
+		"sender_domain": domain,
+		"subject":       subject,
+	}
+	if snippet := strings.TrimSpace(req.GetSnippet()); snippet != "" {
+		contextData["snippet"] = truncateRunes(snippet, maxEmailSnippetLength)
+	}
+	return keyData, contextData
+}
+
+// ClassifyEmail classifies an email by sender and subject
+func (s *ServiceImpl) ClassifyEmail(ctx context.Context, req *connect.Request[brainv1.ClassifyEmailRequest]) (*connect.Response[brainv1.ClassifyEmailResponse], error) {
+	keyData, contextData := emailContext(req.Msg)
+	withLocale(reasoningLocale(req.Msg.Locale, req.Header()), keyData, contextData)
+
+	variant := selectVariant(ctx)
+	result, err := s.coalescer.do(coalesceKey(ctx, promptEmail, keyData), func() (string, error) {
+		cs, err := s.classificationService(variant)
+		if err != nil {
+			slog.Error("failed to create classification service", "error", err)
+			return "", classificationError("classification service error", err)
+		}
+
+		result, err := cs.classifyWithCacheKey(ctx, promptEmail, keyData, contextData)
+		if err != nil {
+			slog.Error("classification failed", "error", err)
+			return "", classificationError("classification failed", err)
+		}
+		return result, nil
+	})
+	if errors.Is(err, errSafetyBlocked) {
+		return connect.NewResponse(&brainv1.ClassifyEmailResponse{Classification: safetyBlockedResult(variant)}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var classification ClassificationResult
+	if err := parseClassification(result, &classification); err != nil {
+		slog.Error("failed to parse classification result", "error", err, "result", result)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse classification: %w", err))
+	}
+	variant.logResult(ctx, "email", classification.Classification, classification.ConfidenceScore)
+
+	return connect.NewResponse(&brainv1.ClassifyEmailResponse{
+		Classification: calibrate(&brainv1.ClassificationResult{
+			Classification:  classification.Classification,
+			Reasoning:       classification.Reasoning,
+			Tags:            normalizeTags(classification.Tags),
+			ConfidenceScore: classification.ConfidenceScore,
+			DetectedProject: classification.DetectedProject,
+			Signals:         classificationSignals(contextData),
+			Model:           cmp.Or(classification.Model, variant.Model),
+			PromptVersion:   variant.PromptVersion,
+		}),
+	}), nil
+}
@@ -0,0 +1,77 @@
+package brain
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func newTestEmailService(t *testing.T, reply string) *ServiceImpl {
+	t.Helper()
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, fakeModels{text: reply})
+	return svc
+}
+
+func TestClassifyEmail_WorkEmail(t *testing.T) {
+	svc := newTestEmailService(t, `{"classification":"productive","reasoning":"Colleague review request.","tags":["work","communication"],"confidence_score":0.9}`)
+
+	req := &brainv1.ClassifyEmailRequest{
+		Sender:  "Jane Doe <Jane@Acme.com>",
+		Subject: "Re: RE:  Q3 planning draft",
+	}
+	resp, err := svc.ClassifyEmail(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "productive" {
+		t.Fatalf("expected productive, got %q", result.GetClassification())
+	}
+	if slices.Contains(result.GetSignals(), "sender indicates bulk mail") {
+		t.Fatalf("work email should not look like bulk mail, got %v", result.GetSignals())
+	}
+
+	_, contextData := emailContext(req)
+	if contextData["sender"] != "jane@acme.com" || contextData["sender_domain"] != "acme.com" || contextData["subject"] != "Q3 planning draft" {
+		t.Fatalf("unexpected normalized context %v", contextData)
+	}
+}
+
+func TestClassifyEmail_MarketingNewsletter(t *testing.T) {
+	svc := newTestEmailService(t, `{"classification":"distracting","reasoning":"Promotional newsletter.","tags":["newsletter","promotion"],"confidence_score":1}`)
+
+	resp, err := svc.ClassifyEmail(context.Background(), connect.NewRequest(&brainv1.ClassifyEmailRequest{
+		Sender:  "Shop <newsletter@shop.example>",
+		Subject: "48h only: 30% off everything",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "distracting" {
+		t.Fatalf("expected distracting, got %q", result.GetClassification())
+	}
+	if !slices.Contains(result.GetSignals(), "sender indicates bulk mail") {
+		t.Fatalf("expected bulk mail signal, got %v", result.GetSignals())
+	}
+}
+
+func TestEmailContext_CacheKeyIgnoresSnippetAndReplyPrefix(t *testing.T) {
+	snippet := "Hi team, see attached."
+	a, _ := emailContext(&brainv1.ClassifyEmailRequest{Sender: "jane@acme.com", Subject: "Q3 planning"})
+	b, contextData := emailContext(&brainv1.ClassifyEmailRequest{Sender: "Jane <JANE@acme.com>", Subject: "Fwd: q3  planning", Snippet: &snippet})
+
+	if generateCacheKey(promptEmail, a) != generateCacheKey(promptEmail, b) {
+		t.Fatalf("expected shared cache key, got %v and %v", a, b)
+	}
+	if contextData["snippet"] != snippet {
+		t.Fatalf("expected snippet to reach the model, got %v", contextData)
+	}
+}
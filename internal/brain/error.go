@@ -1,10 +1,25 @@
 package brain
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-github/v80/github"
+	"golang.org/x/oauth2"
+	"google.golang.org/genai"
+	"gorm.io/gorm"
 )
 
+// errMissingAPIKey is returned when no Gemini API key is configured
+var errMissingAPIKey = errors.New("GOOGLE_API_KEY or GEMINI_API_KEY environment variable not set")
+
 type ToolError struct {
 	Message string
 }
@@ -31,3 +46,127 @@ func WrapToolError(msg string, err error) ToolError {
 	}
 	return ToolError{Message: fmt.Sprintf("%s: %v", msg, err.Error())}
 }
+
+// dbError maps a GORM error onto the closest Connect code so clients can tell
+// a missing record or a conflicting write apart from a genuine failure.
+func dbError(msg string, err error) *connect.Error {
+	code := connect.CodeInternal
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = connect.CodeNotFound
+	case isUniqueViolation(err):
+		code = connect.CodeAlreadyExists
+	case errors.Is(err, gorm.ErrInvalidData), errors.Is(err, gorm.ErrInvalidField),
+		errors.Is(err, gorm.ErrInvalidValue), errors.Is(err, gorm.ErrPrimaryKeyRequired):
+		code = connect.CodeInvalidArgument
+	case errors.Is(err, context.DeadlineExceeded):
+		code = connect.CodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = connect.CodeCanceled
+	}
+	return connect.NewError(code, fmt.Errorf("%s: %w", msg, err))
+}
+
+// isUniqueViolation reports whether err is a unique constraint failure. Drivers
+// only return gorm.ErrDuplicatedKey with TranslateError enabled, so the sqlite
+// and libsql messages are matched as well.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "duplicate key")
+}
+
+// classificationError maps a classification failure onto a Connect code so
+// clients can tell a transient outage (retry later) from a configuration
+// problem (give up).
+func classificationError(msg string, err error) *connect.Error {
+	return connect.NewError(classificationCode(err), fmt.Errorf("%s: %w", msg, err))
+}
+
+func classificationCode(err error) connect.Code {
+	if errors.Is(err, errMissingAPIKey) {
+		return connect.CodeFailedPrecondition
+	}
+	if errors.Is(err, errCircuitOpen) {
+		return connect.CodeUnavailable
+	}
+
+	// genai returns APIError by value
+	var apiErr genai.APIError
+	errors.As(err, &apiErr)
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests || apiErr.Status == "RESOURCE_EXHAUSTED":
+		return connect.CodeResourceExhausted
+	case apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden || apiErr.Code == http.StatusNotFound:
+		return connect.CodeFailedPrecondition
+	case apiErr.Code >= http.StatusInternalServerError || apiErr.Status == "UNAVAILABLE":
+		return connect.CodeUnavailable
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return connect.CodeUnavailable
+	}
+
+	return connect.CodeInternal
+}
+
+// errOAuth2NotConfigured is returned when a provider's client credentials or
+// the redirect URI are missing
+var errOAuth2NotConfigured = errors.New("oauth2 provider not configured")
+
+// oauthError maps an OAuth provider failure onto a Connect code. The message is
+// kept stable for clients; the provider's own error is only logged.
+func oauthError(msg string, err error) *connect.Error {
+	code := oauthCode(err)
+	slog.Warn(msg, "code", code, "error", err)
+	return connect.NewError(code, errors.New(msg))
+}
+
+func oauthCode(err error) connect.Code {
+	if errors.Is(err, errOAuth2NotConfigured) {
+		return connect.CodeFailedPrecondition
+	}
+
+	// Token endpoint failures, RFC 6749 error codes first
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		switch retrieveErr.ErrorCode {
+		case "bad_verification_code", "invalid_grant", "invalid_request":
+			return connect.CodeInvalidArgument
+		case "expired_token", "token_expired":
+			return connect.CodeUnauthenticated
+		case "incorrect_client_credentials", "invalid_client", "unauthorized_client", "redirect_uri_mismatch":
+			return connect.CodeFailedPrecondition
+		}
+		return connect.CodeUnavailable
+	}
+
+	// GitHub API failures
+	var githubErr *github.ErrorResponse
+	if errors.As(err, &githubErr) && githubErr.Response != nil {
+		switch status := githubErr.Response.StatusCode; {
+		case status == http.StatusUnauthorized:
+			return connect.CodeUnauthenticated
+		case status == http.StatusNotFound || status == http.StatusUnprocessableEntity:
+			return connect.CodeInvalidArgument
+		}
+		return connect.CodeUnavailable
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return connect.CodeCanceled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return connect.CodeDeadlineExceeded
+	}
+	return connect.CodeUnavailable
+}
@@ -0,0 +1,130 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"google.golang.org/genai"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// Every connection to :memory: is a separate database, keep to one
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&commonv1.UserORM{}, &commonv1.NonceORM{}, &commonv1.SessionORM{}, &commonv1.PromptHistoryORM{}, &commonv1.LinkedProviderORM{}, &commonv1.ClassificationVoteORM{}, &commonv1.ClassificationOverrideORM{}, &commonv1.GlobalClassificationOverrideORM{}, &commonv1.FocusSessionORM{}, &commonv1.FocusSessionEventORM{}, &commonv1.DetectedProjectORM{}, &commonv1.PromptAddendumORM{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+// useTestModels makes svc classify with models instead of Gemini
+func useTestModels(svc *ServiceImpl, models contentGenerator) {
+	svc.newClassificationService = func(db *gorm.DB) (*ClassificationService, error) {
+		return &ClassificationService{db: db, models: models, model: "gemini-test"}, nil
+	}
+}
+
+func TestDBError_NotFound(t *testing.T) {
+	db := newTestDB(t)
+
+	err := db.Where("device_fingerprint_hash = ?", "missing").First(&commonv1.UserORM{}).Error
+	if got := connect.CodeOf(dbError("lookup failed", err)); got != connect.CodeNotFound {
+		t.Fatalf("expected NotFound, got %v", got)
+	}
+}
+
+func TestDBError_DuplicateInsert(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.Create(&commonv1.NonceORM{Nonce: "n1", CreatedAt: 1, ExpiresAt: 2}).Error; err != nil {
+		t.Fatalf("first insert failed: %v", err)
+	}
+	err := db.Create(&commonv1.NonceORM{Nonce: "n1", CreatedAt: 1, ExpiresAt: 2}).Error
+	if err == nil {
+		t.Fatal("expected duplicate insert to fail")
+	}
+	if got := connect.CodeOf(dbError("insert failed", err)); got != connect.CodeAlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", got)
+	}
+}
+
+func TestDBError_DefaultsToInternal(t *testing.T) {
+	if got := connect.CodeOf(dbError("boom", errors.New("disk on fire"))); got != connect.CodeInternal {
+		t.Fatalf("expected Internal, got %v", got)
+	}
+}
+
+// fakeModels returns a fixed reply or error from every GenerateContent call
+type fakeModels struct {
+	text string
+	err  error
+}
+
+func (f fakeModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{Content: genai.NewContentFromText(f.text, genai.RoleModel)}},
+	}, nil
+}
+
+func TestClassifyApplication_ErrorCodes(t *testing.T) {
+	for name, tc := range map[string]struct {
+		newService func(db *gorm.DB) (*ClassificationService, error)
+		want       connect.Code
+	}{
+		"missing api key": {
+			newService: func(db *gorm.DB) (*ClassificationService, error) { return nil, errMissingAPIKey },
+			want:       connect.CodeFailedPrecondition,
+		},
+		"rate limited": {
+			newService: fakeClassificationService(genai.APIError{Code: 429, Status: "RESOURCE_EXHAUSTED"}),
+			want:       connect.CodeResourceExhausted,
+		},
+		"gemini unavailable": {
+			newService: fakeClassificationService(genai.APIError{Code: 503, Status: "UNAVAILABLE"}),
+			want:       connect.CodeUnavailable,
+		},
+		"gemini timeout": {
+			newService: fakeClassificationService(context.DeadlineExceeded),
+			want:       connect.CodeUnavailable,
+		},
+		"unexpected": {
+			newService: fakeClassificationService(errors.New("boom")),
+			want:       connect.CodeInternal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewServiceImpl(newTestDB(t))
+			svc.newClassificationService = tc.newService
+
+			_, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+				ApplicationName: "Visual Studio Code",
+			}))
+			if got := connect.CodeOf(err); got != tc.want {
+				t.Fatalf("expected %v, got %v (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func fakeClassificationService(err error) func(db *gorm.DB) (*ClassificationService, error) {
+	return func(db *gorm.DB) (*ClassificationService, error) {
+		return &ClassificationService{db: db, models: fakeModels{err: err}, model: "gemini-test"}, nil
+	}
+}
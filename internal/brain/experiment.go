@@ -0,0 +1,108 @@
+package brain
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// Classification A/B variants
+const (
+	variantControl   = "control"
+	variantTreatment = "treatment"
+)
+
+// defaultExperimentName salts the bucketing hash when no experiment is named
+const defaultExperimentName = "classification-ab"
+
+// classificationVariant is the model and prompt a request is classified with.
+// Users are bucketed into the treatment by CLASSIFICATION_AB_PERCENT; everyone
+// else, and every unauthenticated request, gets the control.
+type classificationVariant struct {
+	Name          string
+	Experiment    string // empty when no experiment is running
+	Model         string
+	PromptVersion string
+	Instructions  string // appended to the prompt for the treatment
+}
+
+// controlVariant returns the deployment's configured model and prompt
+func controlVariant() classificationVariant {
+	return classificationVariant{
+		Name:          variantControl,
+		Model:         classificationModel(),
+		PromptVersion: classificationPromptVersion(),
+	}
+}
+
+// selectVariant deterministically picks the variant for the requesting user
+func selectVariant(ctx context.Context) classificationVariant {
+	v := controlVariant()
+
+	percent := envInt("CLASSIFICATION_AB_PERCENT", 0)
+	if percent <= 0 {
+		return v
+	}
+	v.Experiment = envString("CLASSIFICATION_AB_EXPERIMENT", defaultExperimentName)
+
+	user, ok := auth.GetUser(ctx)
+	if !ok || variantBucket(v.Experiment, user.UserID) >= percent {
+		return v
+	}
+
+	v.Name = variantTreatment
+	v.Model = envString("CLASSIFICATION_AB_MODEL", v.Model)
+	v.PromptVersion = envString("CLASSIFICATION_AB_PROMPT_VERSION", v.PromptVersion+"-b")
+	v.Instructions = os.Getenv("CLASSIFICATION_AB_INSTRUCTIONS")
+	return v
+}
+
+// variantBucket maps a user to a stable bucket in [0, 100). The experiment name
+// salts the hash so a new experiment reshuffles users.
+func variantBucket(experiment string, userID int64) int {
+	h := fnv.New32a()
+	h.Write([]byte(experiment + ":" + strconv.FormatInt(userID, 10)))
+	return int(h.Sum32() % 100)
+}
+
+// prompt returns the base prompt with the variant's extra instructions
+func (v classificationVariant) prompt(base string) string {
+	if v.Instructions == "" {
+		return base
+	}
+	return base + "\n# Additional Instructions\n\n" + v.Instructions + "\n"
+}
+
+// cacheScope prefixes cache keys for the treatment so its results never mix
+// with the control's. Control keys are unchanged so existing entries stay valid.
+func (v classificationVariant) cacheScope() string {
+	if v.Name != variantTreatment {
+		return ""
+	}
+	return v.Experiment + ":" + variantTreatment + ":"
+}
+
+// logResult records the served variant and its result for later analysis
+func (v classificationVariant) logResult(ctx context.Context, kind, classification string, confidence float32) {
+	if v.Experiment == "" {
+		return
+	}
+	var userID int64
+	if user, ok := auth.GetUser(ctx); ok {
+		userID = user.UserID
+	}
+	slog.Info("classification variant served",
+		"experiment", v.Experiment,
+		"variant", v.Name,
+		"user_id", userID,
+		"kind", kind,
+		"model", v.Model,
+		"prompt_version", v.PromptVersion,
+		"classification", classification,
+		"confidence_score", confidence,
+	)
+}
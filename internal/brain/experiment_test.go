@@ -0,0 +1,145 @@
+package brain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// userInBucket finds a user ID whose bucket is below (or, when below is false,
+// at or above) the given percent
+func userInBucket(t *testing.T, experiment string, percent int, below bool) int64 {
+	t.Helper()
+	for id := int64(1); id < 10000; id++ {
+		if (variantBucket(experiment, id) < percent) == below {
+			return id
+		}
+	}
+	t.Fatalf("no user found for bucket condition")
+	return 0
+}
+
+func TestSelectVariant_DeterministicBucketing(t *testing.T) {
+	t.Setenv("CLASSIFICATION_AB_PERCENT", "30")
+	t.Setenv("CLASSIFICATION_AB_MODEL", "gemini-b")
+
+	treated := 0
+	for id := int64(1); id <= 1000; id++ {
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: id})
+		first := selectVariant(ctx)
+		for range 3 {
+			if again := selectVariant(ctx); again != first {
+				t.Fatalf("user %d moved from %q to %q", id, first.Name, again.Name)
+			}
+		}
+		if first.Name == variantTreatment {
+			treated++
+		}
+	}
+	if treated < 200 || treated > 400 {
+		t.Fatalf("expected roughly 30%% of users in treatment, got %d of 1000", treated)
+	}
+
+	if v := selectVariant(context.Background()); v.Name != variantControl {
+		t.Fatalf("unauthenticated requests should get control, got %q", v.Name)
+	}
+}
+
+func TestSelectVariant_Disabled(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: userInBucket(t, defaultExperimentName, 1, true)})
+
+	v := selectVariant(ctx)
+	if v.Name != variantControl || v.Experiment != "" {
+		t.Fatalf("expected control outside an experiment, got %+v", v)
+	}
+}
+
+func TestSelectVariant_ExperimentReshufflesUsers(t *testing.T) {
+	moved := 0
+	for id := int64(1); id <= 100; id++ {
+		if variantBucket("first", id) != variantBucket("second", id) {
+			moved++
+		}
+	}
+	if moved == 0 {
+		t.Fatal("expected a new experiment name to reshuffle buckets")
+	}
+}
+
+// promptRecorder replies with text and remembers the model and prompt it saw
+type promptRecorder struct {
+	text   string
+	model  string
+	prompt string
+}
+
+func (r *promptRecorder) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	r.model = model
+	r.prompt = config.SystemInstruction.Parts[0].Text
+	return fakeModels{text: r.text}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_VariantAwareCache(t *testing.T) {
+	t.Setenv("CLASSIFICATION_AB_PERCENT", "50")
+	t.Setenv("CLASSIFICATION_AB_MODEL", "gemini-b")
+	t.Setenv("CLASSIFICATION_AB_INSTRUCTIONS", "Treat chat apps as distracting.")
+
+	db := newTestDB(t)
+	recorder := &promptRecorder{text: `{"classification":"distracting","reasoning":"treatment","tags":["communication"],"confidence_score":0.8}`}
+	svc := NewServiceImpl(db)
+	useTestModels(svc, recorder)
+
+	// Seed the control cache entry
+	contextData := map[string]string{"name": "Slack", "title": "#general", "bundle_id": "com.tinyspeck.slackmacgap"}
+	if err := db.Create(&commonv1.PromptHistoryORM{
+		PromptHash:   generateCacheKey("gemini-test:"+promptDesktop, contextData),
+		ResponseJson: `{"classification":"neutral","reasoning":"control","tags":["communication"],"confidence_score":0.7}`,
+		ExpiresAt:    time.Now().Add(time.Hour).Unix(),
+	}).Error; err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	classify := func(userID int64) *brainv1.ClassificationResult {
+		t.Helper()
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: userID})
+		resp, err := svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName:     contextData["name"],
+			WindowTitle:         contextData["title"],
+			ApplicationBundleId: contextData["bundle_id"],
+		}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification()
+	}
+
+	control := classify(userInBucket(t, defaultExperimentName, 50, false))
+	if control.GetClassification() != "neutral" {
+		t.Fatalf("control user should hit the control cache, got %q", control.GetClassification())
+	}
+	if recorder.model != "" {
+		t.Fatalf("control user should not reach the model, called %q", recorder.model)
+	}
+
+	treatment := classify(userInBucket(t, defaultExperimentName, 50, true))
+	if treatment.GetClassification() != "distracting" {
+		t.Fatalf("treatment user should not share the control cache, got %q", treatment.GetClassification())
+	}
+	if recorder.model != "gemini-b" || treatment.GetModel() != "gemini-b" {
+		t.Fatalf("expected treatment model gemini-b, called %q and reported %q", recorder.model, treatment.GetModel())
+	}
+	if treatment.GetPromptVersion() != classificationPromptVersion()+"-b" {
+		t.Fatalf("unexpected treatment prompt version %q", treatment.GetPromptVersion())
+	}
+	if !strings.Contains(recorder.prompt, "Treat chat apps as distracting.") {
+		t.Fatal("treatment prompt is missing the variant instructions")
+	}
+}
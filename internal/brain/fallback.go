@@ -0,0 +1,102 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// similarityKey groups cache entries that describe the same app or site, so a
+// result for one window title can stand in for another during an outage.
+// Inputs without a meaningful grouping return "".
+func similarityKey(prompt string, keyData map[string]string) string {
+	switch prompt {
+	case promptDesktop:
+		if bundleID := strings.TrimSpace(keyData["bundle_id"]); bundleID != "" {
+			return "app:" + strings.ToLower(bundleID)
+		}
+		if name := strings.TrimSpace(keyData["name"]); name != "" {
+			return "app:" + strings.ToLower(name)
+		}
+	case promptWebsite:
+		if host := websiteHost(keyData["url"]); host != "" {
+			return "domain:" + host
+		}
+	}
+	return ""
+}
+
+// websiteHost returns the lowercased host of rawURL without a leading "www."
+func websiteHost(rawURL string) string {
+	u, err := parseWebsiteURL(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// parseWebsiteURL parses rawURL, accepting URLs without a scheme
+func parseWebsiteURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		// Bare hosts like "github.com/focusd" parse as a path
+		return url.Parse("https://" + strings.TrimSpace(rawURL))
+	}
+	return u, nil
+}
+
+// approximateFromCache returns the label of the most recent cached result
+// sharing the similarity key, marked as approximate. It is opt-in via the
+// approximate_fallback flag and deliberately ignores expiry: a stale
+// answer for the same app beats a blanket neutral while the model is down.
+func (cs *ClassificationService) approximateFromCache(ctx context.Context, key string, cause error) (string, bool) {
+	if key == "" || !cs.flags.Enabled(ctx, flagApproximateFallback) {
+		return "", false
+	}
+	// The caller gave up, there is nobody to serve; a safety block is an answer, not an outage
+	if errors.Is(cause, context.Canceled) || errors.Is(cause, errSafetyBlocked) || ctx.Err() != nil {
+		return "", false
+	}
+
+	var cache commonv1.PromptHistoryORM
+	err := cs.db.WithContext(ctx).
+		Where("similarity_key = ?", key).
+		Order("created_at DESC").
+		First(&cache).Error
+	if err != nil {
+		return "", false
+	}
+
+	return markApproximate(cache.ResponseJson)
+}
+
+// approximateFields are the parts of a cached result that hold for any input
+// sharing its similarity key. Reasoning, detected projects and channels are
+// derived from another user's titles and URLs and are never passed on.
+var approximateFields = []string{"classification", "tags", "confidence_score"}
+
+// markApproximate keeps the input-independent fields of a cached model
+// response and flags it as approximate
+func markApproximate(response string) (string, bool) {
+	var cached map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(response), &cached); err != nil {
+		return "", false
+	}
+
+	fields := map[string]any{"approximate": true}
+	for _, name := range approximateFields {
+		if value, ok := cached[name]; ok {
+			fields[name] = value
+		}
+	}
+
+	marked, err := json.Marshal(fields)
+	if err != nil {
+		return "", false
+	}
+	return string(marked), true
+}
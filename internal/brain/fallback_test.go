@@ -0,0 +1,116 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+func TestSimilarityKey(t *testing.T) {
+	cases := []struct {
+		prompt string
+		data   map[string]string
+		want   string
+	}{
+		{promptDesktop, map[string]string{"name": "Slack", "bundle_id": "com.tinyspeck.slackmacgap"}, "app:com.tinyspeck.slackmacgap"},
+		{promptDesktop, map[string]string{"name": "Slack"}, "app:slack"},
+		{promptWebsite, map[string]string{"url": "https://www.GitHub.com/focusd-so/brain/pull/1"}, "domain:github.com"},
+		{promptWebsite, map[string]string{"url": "news.ycombinator.com/item?id=1"}, "domain:news.ycombinator.com"},
+		{promptDocument, map[string]string{"path": "~/work/report.xlsx"}, ""},
+	}
+	for _, tc := range cases {
+		if got := similarityKey(tc.prompt, tc.data); got != tc.want {
+			t.Errorf("similarityKey(%v) = %q, want %q", tc.data, got, tc.want)
+		}
+	}
+}
+
+// newOutageService returns a service whose model always fails, with a cached
+// Slack result for a different window title
+func newOutageService(t *testing.T) *ServiceImpl {
+	t.Helper()
+	db := newTestDB(t)
+	if err := db.Create(&commonv1.PromptHistoryORM{
+		PromptHash:    "other-title",
+		ResponseJson:  `{"classification":"supporting","reasoning":"Chatting in #acme-layoffs","tags":["communication"],"detected_project":"acme-layoffs","detected_communication_channel":"#acme-layoffs","confidence_score":0.8}`,
+		CreatedAt:     time.Now().Add(-time.Hour).Unix(),
+		ExpiresAt:     time.Now().Add(time.Hour).Unix(),
+		SimilarityKey: "app:com.tinyspeck.slackmacgap",
+	}).Error; err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	svc := NewServiceImpl(db)
+	svc.newClassificationService = fakeClassificationService(genai.APIError{Code: 503, Status: "UNAVAILABLE"})
+	return svc
+}
+
+var slackRequest = &brainv1.ClassifyApplicationRequest{
+	ApplicationName:     "Slack",
+	WindowTitle:         "#random - Acme",
+	ApplicationBundleId: "com.tinyspeck.slackmacgap",
+}
+
+func TestClassifyApplication_ApproximateFallbackOnOutage(t *testing.T) {
+	t.Setenv("CLASSIFICATION_APPROXIMATE_FALLBACK", "true")
+
+	resp, err := newOutageService(t).ClassifyApplication(context.Background(), connect.NewRequest(slackRequest))
+	if err != nil {
+		t.Fatalf("expected approximate result, got %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "supporting" || result.GetConfidenceScore() != 0.8 || len(result.GetTags()) != 1 {
+		t.Fatalf("expected the cached Slack result, got %v", result)
+	}
+	if !result.GetApproximate() {
+		t.Fatal("expected the result to be flagged approximate")
+	}
+}
+
+func TestClassifyApplication_ApproximateFallbackHidesOtherInputs(t *testing.T) {
+	t.Setenv("CLASSIFICATION_APPROXIMATE_FALLBACK", "true")
+
+	// The cached entry came from another title under the same bundle ID
+	resp, err := newOutageService(t).ClassifyApplication(context.Background(), connect.NewRequest(slackRequest))
+	if err != nil {
+		t.Fatalf("expected approximate result, got %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetReasoning() != "" {
+		t.Errorf("expected no reasoning from another title, got %q", result.GetReasoning())
+	}
+	if result.DetectedProject != nil || result.DetectedCommunicationChannel != nil {
+		t.Errorf("expected no detected project or channel from another title, got %q and %q", result.GetDetectedProject(), result.GetDetectedCommunicationChannel())
+	}
+}
+
+func TestClassifyApplication_ApproximateFallbackIsOptIn(t *testing.T) {
+	_, err := newOutageService(t).ClassifyApplication(context.Background(), connect.NewRequest(slackRequest))
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeUnavailable {
+		t.Fatalf("expected unavailable without the fallback enabled, got %v", err)
+	}
+}
+
+func TestClassifyApplication_ApproximateFallbackNeedsSameApp(t *testing.T) {
+	t.Setenv("CLASSIFICATION_APPROXIMATE_FALLBACK", "true")
+
+	_, err := newOutageService(t).ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:     "Discord",
+		WindowTitle:         "#general",
+		ApplicationBundleId: "com.hnc.Discord",
+	}))
+	if err == nil {
+		t.Fatal("expected an error when no similar entry is cached")
+	}
+}
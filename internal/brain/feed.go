@@ -0,0 +1,96 @@
+package brain
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// feedReaderMarkers are lowercase fragments of the names and bundle ids of
+// RSS/Atom feed readers
+var feedReaderMarkers = []string{"netnewswire", "reeder", "feedly", "inoreader", "newsblur", "feedbin", "readkit", "miniflux", "fluent reader", "newsflash", "liferea", "quiterss"}
+
+// feedReaderHosts are web feed readers, matched with their subdomains
+var feedReaderHosts = []string{"feedly.com", "inoreader.com", "newsblur.com", "feedbin.com"}
+
+// feedPathSuffixes end the path of feed URLs
+var feedPathSuffixes = []string{"/feed", "/rss", "/atom", "/feed.xml", "/rss.xml", "/atom.xml", "/index.xml", ".rss", ".atom"}
+
+// defaultTechnicalFeedKeywords mark a feed as technical when they appear as
+// whole words in its name, title or url
+const defaultTechnicalFeedKeywords = "programming,software,engineering,developer,developers,dev,golang,rust,python,javascript,typescript,kubernetes,devops,linux,database,security,api,changelog,release,releases,github,hacker news,lobsters"
+
+// technicalFeedKeywords returns the configured technical feed markers,
+// overridable via FEED_TECHNICAL_KEYWORDS as a comma separated list
+func technicalFeedKeywords() []string {
+	var keywords []string
+	for _, k := range strings.Split(envString("FEED_TECHNICAL_KEYWORDS", defaultTechnicalFeedKeywords), ",") {
+		if k = normalizeSearch(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
+
+// isFeedReader reports whether an application is a feed reader
+func isFeedReader(name, bundleID string) bool {
+	app := strings.ToLower(name + " " + bundleID)
+	return slices.ContainsFunc(feedReaderMarkers, func(marker string) bool { return strings.Contains(app, marker) })
+}
+
+// isFeedURL reports whether a url is a feed or a web feed reader
+func isFeedURL(rawURL string) bool {
+	u, err := parseWebsiteURL(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, reader := range feedReaderHosts {
+		if host == reader || strings.HasSuffix(host, "."+reader) {
+			return true
+		}
+	}
+	path := strings.TrimSuffix(strings.ToLower(u.Path), "/")
+	return slices.ContainsFunc(feedPathSuffixes, func(suffix string) bool { return strings.HasSuffix(path, suffix) })
+}
+
+// isFeedContentType reports whether a response Content-Type is a feed
+func isFeedContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "rss+xml") || strings.Contains(contentType, "atom+xml") || strings.Contains(contentType, "feed+json")
+}
+
+// feedTopic returns "technical" when the feed's name, title, url or
+// description contains a technical keyword, "general" otherwise
+func feedTopic(contextData map[string]string) string {
+	text := " " + normalizeSearch(strings.Join([]string{contextData["name"], contextData["title"], contextData["url"], contextData["description"]}, " ")) + " "
+	for _, keyword := range technicalFeedKeywords() {
+		if strings.Contains(text, " "+keyword+" ") {
+			return "technical"
+		}
+	}
+	return "general"
+}
+
+// biasFeedTags nudges the tags of feed reading towards the feed's topic:
+// technical feeds are research rather than a time sink, general feeds are
+// news and a time sink. Other results are left alone.
+func biasFeedTags(tags []string, contextData map[string]string) []string {
+	var add []string
+	switch contextData["feed"] {
+	case "technical":
+		tags = slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == "time-sink" })
+		add = []string{"research", "content-consumption"}
+	case "general":
+		add = []string{"news", "time-sink"}
+	default:
+		return tags
+	}
+	for _, tag := range add {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	slog.Debug("feed tags biased towards topic", "topic", contextData["feed"], "tags", tags)
+	return tags
+}
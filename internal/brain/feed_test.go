@@ -0,0 +1,98 @@
+package brain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestClassifyApplication_TechnicalFeed(t *testing.T) {
+	recorder := &inputRecorder{text: `{"classification":"neutral","reasoning":"Reading a feed.","tags":["content-consumption","time-sink"],"confidence_score":0.6}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName:     "NetNewsWire",
+		ApplicationBundleId: "com.ranchero.NetNewsWire-Evergreen",
+		WindowTitle:         "Rust Blog: Announcing Rust 1.80",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if recorder.input["feed"] != "technical" {
+		t.Fatalf("expected the model to be told about a technical feed, got %q", recorder.input["feed"])
+	}
+	result := resp.Msg.Classification
+	if !slices.Equal(result.Tags, []string{"content-consumption", "research"}) {
+		t.Fatalf("expected tags biased towards research, got %v", result.Tags)
+	}
+	if !slices.Contains(result.Signals, "reading a technical feed") {
+		t.Fatalf("expected a feed signal, got %v", result.Signals)
+	}
+}
+
+func TestClassifyWebsite_GeneralNewsFeed(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	recorder := &inputRecorder{text: `{"classification":"distracting","reasoning":"Headlines.","tags":["news"],"confidence_score":0.7}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:   "https://news.example.invalid/world/rss.xml",
+		Title: "World headlines",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if recorder.input["feed"] != "general" {
+		t.Fatalf("expected the model to be told about a general feed, got %q", recorder.input["feed"])
+	}
+	if tags := resp.Msg.Classification.Tags; !slices.Equal(tags, []string{"news", "time-sink"}) {
+		t.Fatalf("expected tags biased towards news, got %v", tags)
+	}
+}
+
+func TestFeedDetection(t *testing.T) {
+	for url, want := range map[string]bool{
+		"https://go.dev/blog/feed.atom":             true,
+		"https://example.com/feed/":                 true,
+		"https://feedly.com/i/latest":               true,
+		"https://www.inoreader.com/all_articles":    true,
+		"https://example.com/blog/feeding-your-cat": false,
+		"https://example.com/sitemap.xml":           false,
+		"https://notfeedly.com/i/latest":            false,
+	} {
+		if got := isFeedURL(url); got != want {
+			t.Errorf("isFeedURL(%q) = %v, want %v", url, got, want)
+		}
+	}
+
+	t.Setenv("FEED_TECHNICAL_KEYWORDS", "kubernetes")
+	if got := feedTopic(map[string]string{"title": "Kubernetes release notes"}); got != "technical" {
+		t.Fatalf("expected a configured keyword to mark the feed technical, got %q", got)
+	}
+	if got := feedTopic(map[string]string{"title": "Python weekly"}); got != "general" {
+		t.Fatalf("expected keywords to be replaced by the configuration, got %q", got)
+	}
+}
+
+func TestFetchWebsiteMetadata_DetectsFeedContentType(t *testing.T) {
+	allowPrivateFetches(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0"?><rss><channel><title>Engineering</title></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	if metadata := fetchWebsiteMetadata(srv.URL + "/latest"); !metadata.Feed {
+		t.Fatalf("expected an RSS response to be detected as a feed, got %+v", metadata)
+	}
+}
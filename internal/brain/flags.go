@@ -0,0 +1,146 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// Feature flags gating behaviors that are rolled out gradually
+const (
+	flagDeveloperMode        = "developer_mode"
+	flagSupportingMedia      = "supporting_media"
+	flagApproximateFallback  = "approximate_fallback"
+	flagReask                = "reask"
+	flagHandshakeDiagnostics = "handshake_diagnostics"
+	flagGeminiDebug          = "gemini_debug"
+	flagRecentActivity       = "recent_activity"
+)
+
+// featureFlag describes a flag and the setting that turns it on for everyone
+type featureFlag struct {
+	name        string
+	setting     string
+	def         bool
+	description string
+}
+
+var featureFlags = []featureFlag{
+	{flagDeveloperMode, "CLASSIFICATION_DEVELOPER_MODE", false, "Classify local development servers as productive without a model call"},
+	{flagSupportingMedia, "CLASSIFICATION_SUPPORTING_MEDIA_SHORTCUT", true, "Classify the SUPPORTING_MEDIA services as supporting without a model call"},
+	{flagApproximateFallback, "CLASSIFICATION_APPROXIMATE_FALLBACK", false, "Serve a similar cached classification while the model is unavailable"},
+	{flagReask, "CLASSIFICATION_REASK", false, "Ask the model again when its confidence is below CLASSIFICATION_REASK_THRESHOLD"},
+	{flagHandshakeDiagnostics, "HANDSHAKE_DIAGNOSTICS", false, "Serve VerifyHandshakeSignature for integrators debugging their signing"},
+	{flagGeminiDebug, "GEMINI_DEBUG_LOG", false, "Log every Gemini prompt, input and raw response to GEMINI_DEBUG_SINK"},
+	{flagRecentActivity, "CLASSIFICATION_RECENT_ACTIVITY", false, "Summarize the user's open focus session for the model when the client sends no recent activity"},
+}
+
+// preAuthFlags are checked before the caller is authenticated, so rollouts
+// can never match and only their setting turns them on
+var preAuthFlags = map[string]bool{flagHandshakeDiagnostics: true}
+
+// flagRollout turns a flag on for some users while it is off for everyone.
+// Users are bucketed by the flag's name, so raising the percentage only adds users.
+type flagRollout struct {
+	Percent int     `json:"percent"`
+	Users   []int64 `json:"users"`
+}
+
+// Flags are the feature flags loaded at startup. Each flag is on for everyone
+// through its setting, or for some users through FEATURE_FLAGS, e.g.
+// {"reask":{"percent":10,"users":[7]}}. A rolled-out re-ask only changes
+// what its users are served, the cache keeps the first answer for everyone
+// else. A nil Flags reads the environment on every check.
+type Flags struct {
+	enabled  map[string]bool
+	rollouts map[string]flagRollout
+}
+
+// loadFlags reads the flags' settings and FEATURE_FLAGS rollouts
+func loadFlags() *Flags {
+	f := &Flags{enabled: make(map[string]bool, len(featureFlags)), rollouts: map[string]flagRollout{}}
+	for _, flag := range featureFlags {
+		f.enabled[flag.name] = envBool(flag.setting, flag.def)
+	}
+
+	raw := os.Getenv("FEATURE_FLAGS")
+	if raw == "" {
+		return f
+	}
+	var rollouts map[string]flagRollout
+	if err := json.Unmarshal([]byte(raw), &rollouts); err != nil {
+		slog.Warn("invalid FEATURE_FLAGS, ignoring rollouts", "error", err)
+		return f
+	}
+	for name, rollout := range rollouts {
+		if _, ok := f.enabled[name]; !ok {
+			slog.Warn("unknown feature flag in FEATURE_FLAGS", "flag", name)
+			continue
+		}
+		if preAuthFlags[name] {
+			slog.Warn("feature flag is checked before auth and can't be rolled out per user, use its setting", "flag", name)
+			continue
+		}
+		rollout.Percent = min(max(rollout.Percent, 0), 100)
+		f.rollouts[name] = rollout
+	}
+	return f
+}
+
+// Enabled reports whether the flag is on for the requesting user
+func (f *Flags) Enabled(ctx context.Context, name string) bool {
+	var userID int64
+	if user, ok := auth.GetUser(ctx); ok {
+		userID = user.UserID
+	}
+	return f.enabledFor(name, userID)
+}
+
+// enabledFor reports whether the flag is on for a user, 0 for none
+func (f *Flags) enabledFor(name string, userID int64) bool {
+	if f == nil {
+		f = loadFlags()
+	}
+	if f.enabled[name] {
+		return true
+	}
+	rollout, ok := f.rollouts[name]
+	if !ok || userID == 0 {
+		return false
+	}
+	return slices.Contains(rollout.Users, userID) || variantBucket(name, userID) < rollout.Percent
+}
+
+// GetFlags lists the feature flags and their rollout
+func (s *ServiceImpl) GetFlags(ctx context.Context, req *connect.Request[brainv1.GetFlagsRequest]) (*connect.Response[brainv1.GetFlagsResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	flags := s.flags
+	if flags == nil {
+		flags = loadFlags()
+	}
+
+	resp := &brainv1.GetFlagsResponse{Flags: make([]*brainv1.FeatureFlag, 0, len(featureFlags))}
+	for _, flag := range featureFlags {
+		rollout := flags.rollouts[flag.name]
+		resp.Flags = append(resp.Flags, &brainv1.FeatureFlag{
+			Name:           flag.name,
+			Description:    flag.description,
+			Setting:        flag.setting,
+			Enabled:        flags.enabled[flag.name],
+			RolloutPercent: int32(rollout.Percent),
+			UserIds:        rollout.Users,
+			EnabledForUser: flags.enabledFor(flag.name, req.Msg.UserId),
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,127 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestFlags_RolloutGatesReaskPerUser(t *testing.T) {
+	t.Setenv("FEATURE_FLAGS", `{"reask":{"users":[7]}}`)
+	unsure := `{"classification":"neutral","reasoning":"Unclear.","tags":["other"],"confidence_score":0.4}`
+	models := &sequenceModels{replies: []string{unsure}}
+	svc := newPolicyTestService(t, models)
+
+	classify := func(userID int64, name string) {
+		t.Helper()
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: userID})
+		if _, err := svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: name})); err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+	}
+
+	classify(8, "Mystery")
+	if models.calls != 1 {
+		t.Fatalf("expected no re-ask outside the rollout, got %d calls", models.calls)
+	}
+	classify(7, "Enigma")
+	if models.calls != 3 {
+		t.Fatalf("expected a re-ask for a user in the rollout, got %d calls in total", models.calls)
+	}
+}
+
+func TestFlags_RolledOutReaskStaysOutOfCache(t *testing.T) {
+	t.Setenv("FEATURE_FLAGS", `{"reask":{"users":[7]}}`)
+	unsure := `{"classification":"neutral","reasoning":"Unclear.","tags":["other"],"confidence_score":0.4}`
+	sure := `{"classification":"productive","reasoning":"A terminal.","tags":["work"],"confidence_score":0.9}`
+	models := &sequenceModels{replies: []string{unsure, sure}}
+	svc := newPolicyTestService(t, models)
+
+	classify := func(userID int64) string {
+		t.Helper()
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: userID})
+		resp, err := svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Mystery"}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		svc.pendingStores.Wait()
+		return resp.Msg.Classification.Classification
+	}
+
+	if got := classify(7); got != "productive" {
+		t.Fatalf("expected the re-asked answer for a user in the rollout, got %s", got)
+	}
+	if got := classify(8); got != "neutral" || models.calls != 2 {
+		t.Fatalf("expected the first answer from the cache outside the rollout, got %s after %d calls", got, models.calls)
+	}
+}
+
+func TestFlags_PreAuthFlagsIgnoreRollouts(t *testing.T) {
+	t.Setenv("FEATURE_FLAGS", `{"handshake_diagnostics":{"percent":100}}`)
+	if _, ok := loadFlags().rollouts[flagHandshakeDiagnostics]; ok {
+		t.Fatal("expected the rollout of a flag checked before auth to be ignored")
+	}
+}
+
+func TestFlags_LoadedAtStartup(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"neutral","reasoning":"A page.","tags":["other"],"confidence_score":0.6}`})
+
+	// Turning developer mode on later does not reach a running service
+	t.Setenv("CLASSIFICATION_DEVELOPER_MODE", "true")
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "http://localhost:3000/"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if resp.Msg.Classification.Model == localDevModel {
+		t.Fatalf("expected flags to be read at startup, got %v", resp.Msg.Classification)
+	}
+}
+
+func TestFlags_SupportingMediaShortcutCanBeTurnedOff(t *testing.T) {
+	t.Setenv("CLASSIFICATION_SUPPORTING_MEDIA_SHORTCUT", "false")
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"distracting","reasoning":"Music videos.","tags":["music"],"confidence_score":0.7}`})
+
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Spotify"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if result := resp.Msg.Classification; result.Model == supportingMediaModel || result.Classification != "distracting" {
+		t.Fatalf("expected the model to classify without the shortcut, got %v", result)
+	}
+}
+
+func TestGetFlags(t *testing.T) {
+	t.Setenv("CLASSIFICATION_APPROXIMATE_FALLBACK", "true")
+	t.Setenv("FEATURE_FLAGS", `{"reask":{"percent":100},"unknown":{"percent":50}}`)
+	svc := NewServiceImpl(newTestDB(t))
+
+	if _, err := svc.GetFlags(auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7}), connect.NewRequest(&brainv1.GetFlagsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission denied without the admin role, got %v", err)
+	}
+
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	resp, err := svc.GetFlags(admin, connect.NewRequest(&brainv1.GetFlagsRequest{UserId: 7}))
+	if err != nil {
+		t.Fatalf("get flags failed: %v", err)
+	}
+	flags := map[string]*brainv1.FeatureFlag{}
+	for _, flag := range resp.Msg.Flags {
+		flags[flag.Name] = flag
+	}
+	if len(flags) != len(featureFlags) {
+		t.Fatalf("expected only the known flags, got %v", resp.Msg.Flags)
+	}
+	if f := flags[flagApproximateFallback]; !f.Enabled || !f.EnabledForUser || f.Setting != "CLASSIFICATION_APPROXIMATE_FALLBACK" {
+		t.Fatalf("expected approximate fallback on for everyone, got %v", f)
+	}
+	if f := flags[flagReask]; f.Enabled || f.RolloutPercent != 100 || !f.EnabledForUser {
+		t.Fatalf("expected re-ask rolled out to the user, got %v", f)
+	}
+	if f := flags[flagDeveloperMode]; f.Enabled || f.EnabledForUser {
+		t.Fatalf("expected developer mode off, got %v", f)
+	}
+}
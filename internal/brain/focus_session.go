@@ -0,0 +1,236 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// defaultFocusSessionMaxDuration caps a focus session, overridable via
+// FOCUS_SESSION_MAX_DURATION. Sessions left open longer are abandoned, e.g.
+// the client quit without stopping them, and are closed at the cap.
+const defaultFocusSessionMaxDuration = 12 * time.Hour
+
+// StartFocusSession opens a focus session for the authenticated user. Open
+// sessions are closed first, so a user has at most one.
+func (s *ServiceImpl) StartFocusSession(ctx context.Context, req *connect.Request[brainv1.StartFocusSessionRequest]) (*connect.Response[brainv1.StartFocusSessionResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	now := time.Now()
+	resp := &brainv1.StartFocusSessionResponse{}
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var open []commonv1.FocusSessionORM
+		if err := tx.Where("user_id = ? AND ended_at = 0", user.UserID).Order("started_at").Find(&open).Error; err != nil {
+			return err
+		}
+		for i := range open {
+			summary, err := closeFocusSession(tx, &open[i], now)
+			if err != nil {
+				return err
+			}
+			resp.Closed = append(resp.Closed, summary)
+		}
+
+		session := commonv1.FocusSessionORM{UserId: user.UserID, StartedAt: now.Unix()}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		resp.SessionId = session.Id
+		resp.StartedAt = session.StartedAt
+		return nil
+	})
+	if err != nil {
+		return nil, dbError("failed to start focus session", err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// StopFocusSession closes the authenticated user's open focus session and
+// summarizes the classifications made during it
+func (s *ServiceImpl) StopFocusSession(ctx context.Context, req *connect.Request[brainv1.StopFocusSessionRequest]) (*connect.Response[brainv1.StopFocusSessionResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	var summary *brainv1.FocusSessionSummary
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var session commonv1.FocusSessionORM
+		if err := tx.Where("user_id = ? AND ended_at = 0", user.UserID).Order("started_at DESC").First(&session).Error; err != nil {
+			return err
+		}
+		var err error
+		summary, err = closeFocusSession(tx, &session, time.Now())
+		return err
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("no open focus session"))
+	}
+	if err != nil {
+		return nil, dbError("failed to stop focus session", err)
+	}
+
+	return connect.NewResponse(&brainv1.StopFocusSessionResponse{Summary: summary}), nil
+}
+
+// closeFocusSession ends session at now, or at the maximum duration when it
+// was abandoned, and summarizes it
+func closeFocusSession(tx *gorm.DB, session *commonv1.FocusSessionORM, now time.Time) (*brainv1.FocusSessionSummary, error) {
+	endedAt := now.Unix()
+	abandoned := false
+	if deadline := session.StartedAt + int64(focusSessionMaxDuration().Seconds()); endedAt > deadline {
+		endedAt = deadline
+		abandoned = true
+	}
+	if err := tx.Model(session).Update("ended_at", endedAt).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		Classification string
+		Count          int32
+	}
+	err := tx.Model(&commonv1.FocusSessionEventORM{}).
+		Select("classification, COUNT(*) AS count").
+		Where("session_id = ? AND created_at <= ?", session.Id, endedAt).
+		Group("classification").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &brainv1.FocusSessionSummary{
+		SessionId:       session.Id,
+		StartedAt:       session.StartedAt,
+		EndedAt:         endedAt,
+		DurationSeconds: endedAt - session.StartedAt,
+		Abandoned:       abandoned,
+	}
+	for _, c := range counts {
+		summary.ClassificationCount += c.Count
+		switch c.Classification {
+		case "productive":
+			summary.ProductiveCount = c.Count
+		case "supporting":
+			summary.SupportingCount = c.Count
+		case "neutral":
+			summary.NeutralCount = c.Count
+		case "distracting":
+			summary.DistractingCount = c.Count
+		}
+	}
+	return summary, nil
+}
+
+func focusSessionMaxDuration() time.Duration {
+	return envDuration("FOCUS_SESSION_MAX_DURATION", defaultFocusSessionMaxDuration)
+}
+
+// openFocusSession returns the user's open focus session, with a zero ID when
+// there is none. Sessions past the maximum duration are abandoned, not open.
+func (s *ServiceImpl) openFocusSession(ctx context.Context, userID int64, now time.Time) (commonv1.FocusSessionORM, error) {
+	var session commonv1.FocusSessionORM
+	err := s.gormDB.WithContext(ctx).
+		Where("user_id = ? AND ended_at = 0 AND started_at > ?", userID, now.Add(-focusSessionMaxDuration()).Unix()).
+		Order("started_at DESC").
+		Limit(1).
+		Find(&session).Error
+	return session, err
+}
+
+// recordFocusEvent adds a classification to the user's open focus session, if
+// any. Failures are logged, they never fail the classification.
+func (s *ServiceImpl) recordFocusEvent(ctx context.Context, kind string, result *brainv1.ClassificationResult) {
+	user, ok := auth.GetUser(ctx)
+	if !ok || result.GetClassification() == "" {
+		return
+	}
+
+	now := time.Now()
+	session, err := s.openFocusSession(ctx, user.UserID, now)
+	if err != nil {
+		slog.Warn("failed to look up focus session", "user_id", user.UserID, "error", err)
+		return
+	}
+	if session.Id == 0 {
+		return
+	}
+
+	event := commonv1.FocusSessionEventORM{
+		SessionId:      session.Id,
+		Kind:           kind,
+		Classification: result.GetClassification(),
+		CreatedAt:      now.Unix(),
+		OutcomeHash:    outcomeHash(result),
+	}
+	if err := s.gormDB.WithContext(ctx).Create(&event).Error; err != nil {
+		slog.Warn("failed to record focus session event", "session_id", session.Id, "error", err)
+	}
+}
+
+// focusSessionInterceptor records classification responses against the
+// caller's open focus session
+type focusSessionInterceptor struct {
+	svc *ServiceImpl
+}
+
+// NewFocusSessionInterceptor creates a ConnectRPC interceptor recording
+// classifications made during focus sessions. It must run after auth.
+func NewFocusSessionInterceptor(svc *ServiceImpl) connect.Interceptor {
+	return &focusSessionInterceptor{svc: svc}
+}
+
+// WrapUnary records successful classifications
+func (i *focusSessionInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if kind, result, ok := classifiedKind(resp.Any()); ok {
+			i.svc.recordFocusEvent(ctx, kind, result)
+		}
+		return resp, nil
+	}
+}
+
+// classifiedKind returns the kind of input and the result of a
+// classification response, false for any other message
+func classifiedKind(msg any) (string, *brainv1.ClassificationResult, bool) {
+	switch msg := msg.(type) {
+	case *brainv1.ClassifyApplicationResponse:
+		return "application", msg.GetClassification(), true
+	case *brainv1.ClassifyWebsiteResponse:
+		return "website", msg.GetClassification(), true
+	case *brainv1.ClassifyDocumentResponse:
+		return "document", msg.GetClassification(), true
+	case *brainv1.ClassifyEmailResponse:
+		return "email", msg.GetClassification(), true
+	case *brainv1.ClassifyCommandResponse:
+		return "command", msg.GetClassification(), true
+	}
+	return "", nil, false
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *focusSessionInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler is a no-op, classifications are unary
+func (i *focusSessionInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,98 @@
+package brain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// classifyInFocus classifies an application through the focus session interceptor
+func classifyInFocus(t *testing.T, svc *ServiceImpl, ctx context.Context, name string) {
+	t.Helper()
+	unary := NewFocusSessionInterceptor(svc).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return svc.ClassifyApplication(ctx, req.(*connect.Request[brainv1.ClassifyApplicationRequest]))
+	})
+	if _, err := unary(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: name, WindowTitle: name})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+}
+
+func TestFocusSession_StartClassifyStop(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"editor","tags":["code-editor"],"confidence_score":0.9}`})
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	// Classifications outside a session are not recorded
+	classifyInFocus(t, svc, ctx, "Code")
+
+	started, err := svc.StartFocusSession(ctx, connect.NewRequest(&brainv1.StartFocusSessionRequest{}))
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if started.Msg.GetSessionId() == 0 || len(started.Msg.GetClosed()) != 0 {
+		t.Fatalf("unexpected start %v", started.Msg)
+	}
+
+	classifyInFocus(t, svc, ctx, "Code")
+	classifyInFocus(t, svc, ctx, "Terminal")
+
+	// Another user's classifications stay out of the session
+	classifyInFocus(t, svc, auth.WithUser(context.Background(), &auth.UserClaims{UserID: 8}), "Code")
+
+	stopped, err := svc.StopFocusSession(ctx, connect.NewRequest(&brainv1.StopFocusSessionRequest{}))
+	if err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	summary := stopped.Msg.GetSummary()
+	if summary.GetSessionId() != started.Msg.GetSessionId() || summary.GetClassificationCount() != 2 || summary.GetProductiveCount() != 2 || summary.GetAbandoned() {
+		t.Fatalf("unexpected summary %v", summary)
+	}
+
+	if _, err := svc.StopFocusSession(ctx, connect.NewRequest(&brainv1.StopFocusSessionRequest{})); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected failed_precondition without an open session, got %v", err)
+	}
+}
+
+func TestFocusSession_StartClosesOverlappingAndAbandoned(t *testing.T) {
+	t.Setenv("FOCUS_SESSION_MAX_DURATION", "1h")
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	// A session left open on another device a day ago
+	abandoned := commonv1.FocusSessionORM{UserId: 7, StartedAt: time.Now().Add(-24 * time.Hour).Unix()}
+	if err := svc.gormDB.Create(&abandoned).Error; err != nil {
+		t.Fatalf("failed to seed session: %v", err)
+	}
+
+	// It no longer collects classifications
+	svc.recordFocusEvent(ctx, "website", &brainv1.ClassificationResult{Classification: "distracting"})
+
+	first, err := svc.StartFocusSession(ctx, connect.NewRequest(&brainv1.StartFocusSessionRequest{}))
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	closed := first.Msg.GetClosed()
+	if len(closed) != 1 || !closed[0].GetAbandoned() || closed[0].GetDurationSeconds() != int64(time.Hour.Seconds()) || closed[0].GetClassificationCount() != 0 {
+		t.Fatalf("expected the abandoned session closed at the cap, got %v", closed)
+	}
+
+	second, err := svc.StartFocusSession(ctx, connect.NewRequest(&brainv1.StartFocusSessionRequest{}))
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if closed := second.Msg.GetClosed(); len(closed) != 1 || closed[0].GetSessionId() != first.Msg.GetSessionId() || closed[0].GetAbandoned() {
+		t.Fatalf("expected the overlapping session to be closed, got %v", closed)
+	}
+}
+
+func TestFocusSession_RequiresSession(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	if _, err := svc.StartFocusSession(context.Background(), connect.NewRequest(&brainv1.StartFocusSessionRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// geminiDebugHeader asks for the Gemini calls of one request to be logged.
+// Only honored for admins.
+const geminiDebugHeader = "X-Debug-Gemini"
+
+// geminiDebugStdout is the GEMINI_DEBUG_SINK writing to standard output
+const geminiDebugStdout = "stdout"
+
+// geminiDebugRecord is one Gemini call, written as a JSON line. Prompt and
+// input are exactly what the model received, so inputs are only as redacted
+// as they are before reaching the model, e.g. commands and screen text.
+type geminiDebugRecord struct {
+	Time     time.Time `json:"time"`
+	Model    string    `json:"model"`
+	Prompt   string    `json:"prompt"`
+	Input    string    `json:"input"`
+	Response string    `json:"response,omitempty"` // the model's text before cleanup
+	Error    string    `json:"error,omitempty"`
+}
+
+// geminiDebugSink writes Gemini calls to GEMINI_DEBUG_SINK, "stdout" or a file
+// path. The file is opened on the first write, so an unused sink creates nothing.
+type geminiDebugSink struct {
+	target string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newGeminiDebugSink() *geminiDebugSink {
+	return &geminiDebugSink{target: envString("GEMINI_DEBUG_SINK", geminiDebugStdout)}
+}
+
+// write appends rec to the sink. Failures are logged and the record dropped;
+// debugging never fails a classification.
+func (d *geminiDebugSink) write(rec geminiDebugRecord) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		slog.Warn("failed to encode gemini debug record", "error", err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.w == nil {
+		if d.target == geminiDebugStdout {
+			d.w = os.Stdout
+		} else {
+			f, err := os.OpenFile(d.target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+			if err != nil {
+				slog.Warn("failed to open gemini debug sink", "path", d.target, "error", err)
+				return
+			}
+			d.w = f
+		}
+	}
+	if _, err := d.w.Write(append(line, '\n')); err != nil {
+		slog.Warn("failed to write gemini debug record", "error", err)
+	}
+}
+
+type geminiDebugKey struct{}
+
+// withGeminiDebug marks ctx so its Gemini calls are logged
+func withGeminiDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, geminiDebugKey{}, true)
+}
+
+// geminiDebugRequested reports whether ctx asked for its Gemini calls to be logged
+func geminiDebugRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(geminiDebugKey{}).(bool)
+	return requested
+}
+
+// debugGemini logs a Gemini call when GEMINI_DEBUG_LOG is on for the user or
+// an admin asked for it with X-Debug-Gemini
+func (cs *ClassificationService) debugGemini(ctx context.Context, model, prompt string, input []byte, response string, err error) {
+	if cs.debug == nil || (!geminiDebugRequested(ctx) && !cs.flags.Enabled(ctx, flagGeminiDebug)) {
+		return
+	}
+	rec := geminiDebugRecord{Time: time.Now().UTC(), Model: model, Prompt: prompt, Input: string(input), Response: response}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	cs.debug.write(rec)
+}
+
+// geminiDebugInterceptor turns on Gemini logging for admin requests sending
+// X-Debug-Gemini. It runs after auth, which puts the user in the context.
+type geminiDebugInterceptor struct{}
+
+// NewGeminiDebugInterceptor creates a ConnectRPC interceptor honoring the
+// X-Debug-Gemini header of admins
+func NewGeminiDebugInterceptor() connect.Interceptor {
+	return &geminiDebugInterceptor{}
+}
+
+// debugContext marks ctx for Gemini logging when an admin asked for it
+func debugContext(ctx context.Context, header string) context.Context {
+	if on, _ := strconv.ParseBool(header); !on {
+		return ctx
+	}
+	if requireAdmin(ctx) != nil {
+		slog.Debug("ignoring " + geminiDebugHeader + " from a non-admin")
+		return ctx
+	}
+	return withGeminiDebug(ctx)
+}
+
+// WrapUnary implements the header check for unary RPCs
+func (i *geminiDebugInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return next(debugContext(ctx, req.Header().Get(geminiDebugHeader)), req)
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *geminiDebugInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements the header check for streaming RPCs
+func (i *geminiDebugInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(debugContext(ctx, conn.RequestHeader().Get(geminiDebugHeader)), conn)
+	}
+}
@@ -0,0 +1,105 @@
+package brain
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// readGeminiDebug returns the records written to the sink at path
+func readGeminiDebug(t *testing.T, path string) []geminiDebugRecord {
+	t.Helper()
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to open the debug sink: %v", err)
+	}
+	defer f.Close()
+
+	var records []geminiDebugRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var rec geminiDebugRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid debug record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestGeminiDebug_LogsRequestAndResponseWhenEnabled(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "gemini.jsonl")
+	t.Setenv("GEMINI_DEBUG_LOG", "true")
+	t.Setenv("GEMINI_DEBUG_SINK", sink)
+	reply := `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`
+	svc := newPolicyTestService(t, fakeModels{text: reply})
+
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Visual Studio Code", WindowTitle: "main.go - focusd"})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	records := readGeminiDebug(t, sink)
+	if len(records) != 1 {
+		t.Fatalf("expected one logged call, got %d", len(records))
+	}
+	rec := records[0]
+	if rec.Model != "gemini-test" || !strings.Contains(rec.Prompt, "Productivity Analyst") || rec.Response != reply || rec.Error != "" {
+		t.Fatalf("expected the model, prompt and raw response, got %+v", rec)
+	}
+	var input map[string]string
+	if err := json.Unmarshal([]byte(rec.Input), &input); err != nil || input["name"] != "Visual Studio Code" || input["title"] != "main.go - focusd" {
+		t.Fatalf("expected the full model input, got %q (%v)", rec.Input, err)
+	}
+}
+
+func TestGeminiDebug_OffByDefault(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "gemini.jsonl")
+	t.Setenv("GEMINI_DEBUG_SINK", sink)
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`})
+
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Visual Studio Code"})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if _, err := os.Stat(sink); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no debug sink to be written, got %v", err)
+	}
+}
+
+func TestGeminiDebug_AdminHeaderLogsOneRequest(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "gemini.jsonl")
+	t.Setenv("GEMINI_DEBUG_SINK", sink)
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model exploded")})
+
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 2})
+	if geminiDebugRequested(debugContext(user, "true")) {
+		t.Fatal("expected the header to be ignored for a non-admin")
+	}
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	if geminiDebugRequested(debugContext(admin, "")) {
+		t.Fatal("expected no logging without the header")
+	}
+	ctx := debugContext(admin, "1")
+	if !geminiDebugRequested(ctx) {
+		t.Fatal("expected the header to turn logging on for an admin")
+	}
+
+	svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Visual Studio Code"}))
+	records := readGeminiDebug(t, sink)
+	if len(records) != 1 || !strings.Contains(records[0].Error, "model exploded") || records[0].Response != "" {
+		t.Fatalf("expected the failed call to be logged with its error, got %+v", records)
+	}
+}
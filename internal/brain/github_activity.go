@@ -0,0 +1,119 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-github/v80/github"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+const (
+	defaultGitHubActivityWindow = 24 * time.Hour
+	// maxGitHubActivityPages bounds the API calls per request at 100 commits a page
+	maxGitHubActivityPages = 5
+)
+
+// GetGitHubActivity counts the token user's commits to a repository within
+// the requested window. The token comes from the client, the server never
+// stores provider tokens.
+func (s *ServiceImpl) GetGitHubActivity(ctx context.Context, req *connect.Request[brainv1.GetGitHubActivityRequest]) (*connect.Response[brainv1.GetGitHubActivityResponse], error) {
+	client, err := newGitHubClient(nil)
+	if err != nil {
+		return nil, oauthError("github is not configured", err)
+	}
+	client = client.WithAuthToken(req.Msg.Token)
+
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, githubActivityError("failed to load github user", err)
+	}
+	login := user.GetLogin()
+
+	// A bare name, e.g. a detected project, is looked up among the user's own repositories
+	owner, repo, ok := strings.Cut(strings.TrimSpace(req.Msg.Repository), "/")
+	if !ok {
+		owner, repo = login, owner
+	}
+	if owner == "" || repo == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(`repository must be "owner/name" or a name`))
+	}
+
+	window := defaultGitHubActivityWindow
+	if hours := req.Msg.WindowHours; hours > 0 {
+		window = time.Duration(hours) * time.Hour
+	}
+	until := time.Now()
+	since := until.Add(-window)
+
+	opts := &github.CommitsListOptions{
+		Author:      login,
+		Since:       since,
+		Until:       until,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var count int
+	truncated := false
+	for page := 0; ; page++ {
+		if page == maxGitHubActivityPages {
+			truncated = true
+			break
+		}
+		commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if isEmptyRepository(err) {
+			break
+		}
+		if err != nil {
+			return nil, githubActivityError("failed to list github commits", err)
+		}
+		count += len(commits)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return connect.NewResponse(&brainv1.GetGitHubActivityResponse{
+		Repository:      owner + "/" + repo,
+		Login:           login,
+		CommitCount:     int32(count),
+		WindowStartUnix: since.Unix(),
+		WindowEndUnix:   until.Unix(),
+		Truncated:       truncated,
+	}), nil
+}
+
+// isEmptyRepository reports GitHub's 409 for a repository without commits,
+// which simply means no activity
+func isEmptyRepository(err error) bool {
+	var githubErr *github.ErrorResponse
+	return errors.As(err, &githubErr) && githubErr.Response != nil && githubErr.Response.StatusCode == http.StatusConflict
+}
+
+// githubActivityError maps a GitHub API failure onto a Connect code with a
+// stable message, rate limits telling the client when to retry
+func githubActivityError(msg string, err error) *connect.Error {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		slog.Warn(msg, "error", err, "reset", rateErr.Rate.Reset.Time)
+		return connect.NewError(connect.CodeResourceExhausted, errors.New("github rate limit exceeded, retry after "+rateErr.Rate.Reset.UTC().Format(time.RFC3339)))
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		slog.Warn(msg, "error", err)
+		return connect.NewError(connect.CodeResourceExhausted, errors.New("github secondary rate limit exceeded, retry later"))
+	}
+
+	var githubErr *github.ErrorResponse
+	if errors.As(err, &githubErr) && githubErr.Response != nil && githubErr.Response.StatusCode == http.StatusNotFound {
+		return connect.NewError(connect.CodeNotFound, errors.New("github repository not found"))
+	}
+	return oauthError(msg, err)
+}
@@ -0,0 +1,115 @@
+package brain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// githubActivityAPI mocks the GitHub endpoints GetGitHubActivity calls
+func githubActivityAPI(t *testing.T, commits func(w http.ResponseWriter, r *http.Request)) {
+	t.Helper()
+	newGitHubStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer gho_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+		if r.URL.Path == "/user" {
+			w.Write([]byte(`{"login":"octo"}`))
+			return
+		}
+		commits(w, r)
+	})
+}
+
+func getGitHubActivity(t *testing.T, repository string) (*brainv1.GetGitHubActivityResponse, error) {
+	t.Helper()
+	resp, err := NewServiceImpl(newTestDB(t)).GetGitHubActivity(context.Background(), connect.NewRequest(&brainv1.GetGitHubActivityRequest{
+		Token:      "gho_test",
+		Repository: repository,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+func TestGetGitHubActivity_CountsCommitsAcrossPages(t *testing.T) {
+	githubActivityAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/focusd-backend/commits" || r.URL.Query().Get("author") != "octo" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		if r.URL.Query().Get("since") == "" {
+			t.Errorf("expected the window to be passed, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+			w.Write([]byte(`[{"sha":"a"},{"sha":"b"}]`))
+			return
+		}
+		w.Write([]byte(`[{"sha":"c"}]`))
+	})
+
+	// A detected project resolves to the user's own repository
+	activity, err := getGitHubActivity(t, "focusd-backend")
+	if err != nil {
+		t.Fatalf("activity failed: %v", err)
+	}
+	if activity.GetCommitCount() != 3 || activity.GetRepository() != "octo/focusd-backend" || activity.GetLogin() != "octo" {
+		t.Fatalf("unexpected activity %v", activity)
+	}
+	if window := activity.GetWindowEndUnix() - activity.GetWindowStartUnix(); window != int64((24 * time.Hour).Seconds()) {
+		t.Errorf("expected a 24h default window, got %ds", window)
+	}
+}
+
+func TestGetGitHubActivity_EmptyRepository(t *testing.T) {
+	githubActivityAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"Git Repository is empty."}`))
+	})
+
+	activity, err := getGitHubActivity(t, "octo/new-repo")
+	if err != nil {
+		t.Fatalf("activity failed: %v", err)
+	}
+	if activity.GetCommitCount() != 0 {
+		t.Fatalf("expected no commits, got %d", activity.GetCommitCount())
+	}
+}
+
+func TestGetGitHubActivity_MissingRepository(t *testing.T) {
+	githubActivityAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	if _, err := getGitHubActivity(t, "octo/missing"); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestGetGitHubActivity_RateLimited(t *testing.T) {
+	githubActivityAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+	})
+
+	if _, err := getGitHubActivity(t, "octo/focusd-backend"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected resource_exhausted, got %v", err)
+	}
+}
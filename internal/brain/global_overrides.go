@@ -0,0 +1,120 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm/clause"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// globalOverride returns the global override for the first of targets that
+// has one. Lookup failures fall through to classification.
+func (s *ServiceImpl) globalOverride(ctx context.Context, kind string, targets ...string) *commonv1.GlobalClassificationOverrideORM {
+	normalized := normalizeOverrideTargets(kind, targets)
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	var overrides []commonv1.GlobalClassificationOverrideORM
+	err := s.gormDB.WithContext(ctx).
+		Where("kind = ? AND target IN ?", kind, normalized).
+		Find(&overrides).Error
+	if err != nil {
+		slog.Warn("failed to look up global classification overrides", "kind", kind, "error", err)
+		return nil
+	}
+
+	for _, target := range normalized {
+		for i := range overrides {
+			if overrides[i].Target == target {
+				return &overrides[i]
+			}
+		}
+	}
+	return nil
+}
+
+// SetGlobalOverride creates or replaces the global override for a target
+func (s *ServiceImpl) SetGlobalOverride(ctx context.Context, req *connect.Request[brainv1.SetGlobalOverrideRequest]) (*connect.Response[brainv1.SetGlobalOverrideResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	target := normalizeOverrideTarget(req.Msg.Kind, req.Msg.Target)
+	if target == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid override target"))
+	}
+
+	now := time.Now().Unix()
+	override := commonv1.GlobalClassificationOverrideORM{
+		Kind:           req.Msg.Kind,
+		Target:         target,
+		Classification: req.Msg.Classification,
+		Tags:           strings.Join(normalizeTags(req.Msg.Tags), ","),
+		Note:           req.Msg.Note,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err := s.gormDB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "target"}},
+		DoUpdates: clause.AssignmentColumns([]string{"classification", "tags", "note", "updated_at"}),
+	}).Create(&override).Error
+	if err != nil {
+		return nil, dbError("failed to store global override", err)
+	}
+
+	slog.Info("global override set", "kind", override.Kind, "target", override.Target, "classification", override.Classification)
+	return connect.NewResponse(&brainv1.SetGlobalOverrideResponse{Override: globalOverrideProto(override)}), nil
+}
+
+// DeleteGlobalOverride removes the global override for a target
+func (s *ServiceImpl) DeleteGlobalOverride(ctx context.Context, req *connect.Request[brainv1.DeleteGlobalOverrideRequest]) (*connect.Response[brainv1.DeleteGlobalOverrideResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	result := s.gormDB.WithContext(ctx).
+		Where("kind = ? AND target = ?", req.Msg.Kind, normalizeOverrideTarget(req.Msg.Kind, req.Msg.Target)).
+		Delete(&commonv1.GlobalClassificationOverrideORM{})
+	if result.Error != nil {
+		return nil, dbError("failed to delete global override", result.Error)
+	}
+
+	return connect.NewResponse(&brainv1.DeleteGlobalOverrideResponse{Deleted: result.RowsAffected > 0}), nil
+}
+
+// ListGlobalOverrides lists every global override
+func (s *ServiceImpl) ListGlobalOverrides(ctx context.Context, req *connect.Request[brainv1.ListGlobalOverridesRequest]) (*connect.Response[brainv1.ListGlobalOverridesResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var overrides []commonv1.GlobalClassificationOverrideORM
+	if err := s.gormDB.WithContext(ctx).Order("kind, target").Find(&overrides).Error; err != nil {
+		return nil, dbError("failed to load global overrides", err)
+	}
+
+	resp := &brainv1.ListGlobalOverridesResponse{Overrides: make([]*brainv1.GlobalOverride, 0, len(overrides))}
+	for _, override := range overrides {
+		resp.Overrides = append(resp.Overrides, globalOverrideProto(override))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func globalOverrideProto(override commonv1.GlobalClassificationOverrideORM) *brainv1.GlobalOverride {
+	return &brainv1.GlobalOverride{
+		Kind:           override.Kind,
+		Target:         override.Target,
+		Classification: override.Classification,
+		Tags:           splitTags(override.Tags),
+		Note:           override.Note,
+		UpdatedAt:      override.UpdatedAt,
+	}
+}
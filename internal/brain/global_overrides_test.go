@@ -0,0 +1,94 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestGlobalOverrides_AdminManaged(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+
+	set, err := svc.SetGlobalOverride(admin, connect.NewRequest(&brainv1.SetGlobalOverrideRequest{
+		Kind: "domain", Target: "https://www.Intranet.example.com/wiki", Classification: "productive", Tags: []string{"Work"}, Note: "company wiki",
+	}))
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := set.Msg.GetOverride(); got.GetTarget() != "intranet.example.com" || got.GetTags()[0] != "work" {
+		t.Fatalf("expected a normalized override, got %v", got)
+	}
+
+	// Setting the same target again replaces it
+	if _, err := svc.SetGlobalOverride(admin, connect.NewRequest(&brainv1.SetGlobalOverrideRequest{
+		Kind: "domain", Target: "intranet.example.com", Classification: "supporting",
+	})); err != nil {
+		t.Fatalf("replace failed: %v", err)
+	}
+	list, err := svc.ListGlobalOverrides(admin, connect.NewRequest(&brainv1.ListGlobalOverridesRequest{}))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if overrides := list.Msg.GetOverrides(); len(overrides) != 1 || overrides[0].GetClassification() != "supporting" {
+		t.Fatalf("expected one replaced override, got %v", overrides)
+	}
+
+	deleted, err := svc.DeleteGlobalOverride(admin, connect.NewRequest(&brainv1.DeleteGlobalOverrideRequest{Kind: "domain", Target: "intranet.example.com"}))
+	if err != nil || !deleted.Msg.GetDeleted() {
+		t.Fatalf("expected the override to be deleted, got %v, %v", deleted, err)
+	}
+}
+
+func TestGlobalOverrides_RequireAdmin(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	if _, err := svc.SetGlobalOverride(user, connect.NewRequest(&brainv1.SetGlobalOverrideRequest{Kind: "domain", Target: "example.com", Classification: "productive"})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+	if _, err := svc.ListGlobalOverrides(context.Background(), connect.NewRequest(&brainv1.ListGlobalOverridesRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission_denied without a session, got %v", err)
+	}
+}
+
+func TestClassifyApplication_OverridePrecedence(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model must not be called")})
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	if _, err := svc.SetGlobalOverride(admin, connect.NewRequest(&brainv1.SetGlobalOverrideRequest{
+		Kind: "application", Target: "Steam", Classification: "distracting",
+	})); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	classify := func(ctx context.Context) *brainv1.ClassificationResult {
+		t.Helper()
+		resp, err := svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Steam", WindowTitle: "Steam"}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification()
+	}
+
+	// The global override applies to everyone without their own
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	if result := classify(user); result.GetClassification() != "distracting" || result.GetModel() != globalOverrideModel {
+		t.Fatalf("expected the global override, got %v", result)
+	}
+
+	// The user's own override wins
+	doc := rulesDocument{Version: rulesDocumentVersion, Overrides: []ruleOverride{
+		{Kind: "application", Target: "Steam", Classification: "supporting"},
+	}}
+	if _, err := importRules(t, svc, user, doc, ""); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if result := classify(user); result.GetClassification() != "supporting" || result.GetModel() != userOverrideModel {
+		t.Fatalf("expected the user's override, got %v", result)
+	}
+}
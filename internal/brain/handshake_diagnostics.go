@@ -0,0 +1,61 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// unattributedClientIP is the rate limit bucket of requests whose client
+// address is unknown
+const unattributedClientIP = "unattributed"
+
+// VerifyHandshakeSignature is a dry run of the handshake's HMAC check for
+// integrators debugging their signing. Nonces and clock skew are not
+// recorded and no session is created. On failure the server's string-to-sign
+// is returned so clients can compare it with theirs; the secret never leaves
+// the server.
+func (s *ServiceImpl) VerifyHandshakeSignature(ctx context.Context, req *connect.Request[brainv1.VerifyHandshakeSignatureRequest]) (*connect.Response[brainv1.VerifyHandshakeSignatureResponse], error) {
+	if !s.flags.Enabled(ctx, flagHandshakeDiagnostics) {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("handshake diagnostics are disabled"))
+	}
+	// Callers without an address share one bucket rather than going unlimited
+	ip := s.clientIP(req)
+	if ip == "" {
+		ip = unattributedClientIP
+	}
+	quota := s.handshakeIPLimiter.take(ip)
+	if !quota.allowed {
+		slog.Warn("handshake diagnostics rate limited", "ip", ip)
+		return nil, rateLimitedError(quota)
+	}
+
+	fingerprint := req.Msg.DeviceFingerprint
+	timestamp := req.Header().Get("X-Timestamp")
+	nonce := req.Header().Get("X-Nonce")
+	signature := req.Header().Get("X-Signature")
+
+	// Clock skew is not recorded, a dry run is not a handshake
+	now := time.Now().Unix()
+	ts, err := parseAttestationTimestamp(timestamp, signature)
+	if err == nil {
+		err = checkAttestationWindow(ts, now)
+	}
+	if err == nil {
+		err = checkSignature(handshakePayload(fingerprint, timestamp, nonce), signature)
+	}
+
+	resp := &brainv1.VerifyHandshakeSignatureResponse{Valid: err == nil, ServerTime: now}
+	if err != nil {
+		resp.Reason = err.Error()
+		resp.ExpectedPayload = handshakePayload(fingerprint, timestamp, nonce)
+	}
+	out := connect.NewResponse(resp)
+	quota.setHeaders(out.Header())
+	return out, nil
+}
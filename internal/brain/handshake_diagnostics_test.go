@@ -0,0 +1,93 @@
+package brain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func verifySignature(t *testing.T, svc *ServiceImpl, fingerprint string, sign func(*connect.Request[brainv1.VerifyHandshakeSignatureRequest])) (*brainv1.VerifyHandshakeSignatureResponse, error) {
+	t.Helper()
+	req := connect.NewRequest(&brainv1.VerifyHandshakeSignatureRequest{DeviceFingerprint: fingerprint})
+	sign(req)
+	resp, err := svc.VerifyHandshakeSignature(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+func TestVerifyHandshakeSignature_Diagnostics(t *testing.T) {
+	t.Setenv("HANDSHAKE_DIAGNOSTICS", "true")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	ok, err := verifySignature(t, svc, "laptop", func(req *connect.Request[brainv1.VerifyHandshakeSignatureRequest]) {
+		signAttestation(t, req.Header(), "laptop", "nonce-1")
+	})
+	if err != nil || !ok.GetValid() || ok.GetExpectedPayload() != "" {
+		t.Fatalf("expected a valid signature, got %v, %v", ok, err)
+	}
+
+	// Signed over another fingerprint than the one sent
+	bad, err := verifySignature(t, svc, "laptop", func(req *connect.Request[brainv1.VerifyHandshakeSignatureRequest]) {
+		signAttestation(t, req.Header(), "laptop-other", "nonce-2")
+	})
+	if err != nil {
+		t.Fatalf("diagnostics failed: %v", err)
+	}
+	if bad.GetValid() || bad.GetReason() != "invalid signature" {
+		t.Fatalf("expected an invalid signature, got %v", bad)
+	}
+	// The expected payload is built from the request's fingerprint and headers
+	if payload := bad.GetExpectedPayload(); !strings.HasPrefix(payload, "laptop") || !strings.HasSuffix(payload, "nonce-2") {
+		t.Fatalf("unexpected expected payload %q", bad.GetExpectedPayload())
+	}
+
+	// The dry run leaves the nonce usable for the real handshake
+	handshakeAs(t, svc, "laptop", "nonce-1")
+}
+
+func TestVerifyHandshakeSignature_DisabledByDefault(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	_, err := verifySignature(t, svc, "laptop", func(*connect.Request[brainv1.VerifyHandshakeSignatureRequest]) {})
+	if connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Fatalf("expected unimplemented, got %v", err)
+	}
+}
+
+func TestVerifyHandshakeSignature_RecordsNoClockSkew(t *testing.T) {
+	t.Setenv("HANDSHAKE_DIAGNOSTICS", "true")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+	before := handshakeSkew.snapshot()["count"].(int64)
+
+	if _, err := verifySignature(t, svc, "laptop", func(req *connect.Request[brainv1.VerifyHandshakeSignatureRequest]) {
+		signAttestation(t, req.Header(), "laptop", "nonce-1")
+	}); err != nil {
+		t.Fatalf("diagnostics failed: %v", err)
+	}
+	if after := handshakeSkew.snapshot()["count"].(int64); after != before {
+		t.Fatalf("expected the dry run to record no clock skew, count went from %d to %d", before, after)
+	}
+}
+
+func TestVerifyHandshakeSignature_LimitsCallersWithoutAddress(t *testing.T) {
+	t.Setenv("HANDSHAKE_DIAGNOSTICS", "true")
+	t.Setenv("HANDSHAKE_IP_RATE_LIMIT", "1")
+	svc := NewServiceImpl(newTestDB(t))
+
+	// Requests built in process carry no peer address
+	unsigned := func(*connect.Request[brainv1.VerifyHandshakeSignatureRequest]) {}
+	if _, err := verifySignature(t, svc, "laptop", unsigned); err != nil {
+		t.Fatalf("expected the first call through, got %v", err)
+	}
+	if _, err := verifySignature(t, svc, "laptop", unsigned); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected resource_exhausted, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// geminiReachable is exported on /debug/vars as 1 when the last probe succeeded, 0 otherwise
+var geminiReachable = expvar.NewInt("gemini_reachable")
+
+// GeminiProbe periodically checks that Gemini is reachable and caches the
+// result, so readiness checks never hit the API directly.
+type GeminiProbe struct {
+	probe    func(ctx context.Context) error
+	interval time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+}
+
+// NewGeminiProbe creates a probe that lists a single model as a cheap reachability check
+func NewGeminiProbe(interval time.Duration) *GeminiProbe {
+	return newGeminiProbe(func(ctx context.Context) error {
+		client, err := newGeminiClient(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = client.Models.List(ctx, &genai.ListModelsConfig{PageSize: 1})
+		return err
+	}, interval)
+}
+
+func newGeminiProbe(probe func(ctx context.Context) error, interval time.Duration) *GeminiProbe {
+	return &GeminiProbe{probe: probe, interval: interval}
+}
+
+// Run probes Gemini every interval until ctx is cancelled
+func (p *GeminiProbe) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.refresh(ctx)
+		}
+	}
+}
+
+// Reachable reports whether the last probe succeeded, probing again only when
+// the cached result is older than the interval.
+func (p *GeminiProbe) Reachable(ctx context.Context) bool {
+	p.mu.Lock()
+	stale := time.Since(p.checkedAt) >= p.interval
+	p.mu.Unlock()
+
+	if stale {
+		p.refresh(ctx)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr == nil
+}
+
+func (p *GeminiProbe) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := p.probe(ctx)
+	if err != nil {
+		slog.Warn("gemini reachability probe failed", "error", err)
+		geminiReachable.Set(0)
+	} else {
+		geminiReachable.Set(1)
+	}
+
+	p.mu.Lock()
+	p.checkedAt = time.Now()
+	p.lastErr = err
+	p.mu.Unlock()
+}
+
+// ReadinessStatus is the JSON body served by the readiness endpoint
+type ReadinessStatus struct {
+	Status  string `json:"status"`            // "ok" or "degraded"
+	Gemini  string `json:"gemini"`            // "up" or "down"
+	Circuit string `json:"circuit,omitempty"` // "closed", "open" or "half-open"
+}
+
+// ReadinessHandler reports the service as ready while marking it degraded when
+// Gemini is unreachable or svc's circuit breaker is not closed, since cached
+// classifications can still be served. svc may be nil.
+func ReadinessHandler(probe *GeminiProbe, svc *ServiceImpl) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := ReadinessStatus{Status: "ok", Gemini: "up"}
+		if !probe.Reachable(r.Context()) {
+			status = ReadinessStatus{Status: "degraded", Gemini: "down"}
+		}
+		if svc != nil {
+			status.Circuit = svc.breaker.State()
+			if status.Circuit != circuitClosed {
+				status.Status = "degraded"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			slog.Error("failed to write readiness status", "error", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadinessHandler_ReflectsGeminiReachability(t *testing.T) {
+	var probeErr error
+	calls := 0
+	probe := newGeminiProbe(func(ctx context.Context) error {
+		calls++
+		return probeErr
+	}, time.Hour)
+
+	check := func() ReadinessStatus {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		ReadinessHandler(probe, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected readiness to stay 200, got %d", rec.Code)
+		}
+		var status ReadinessStatus
+		if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("failed to decode status: %v", err)
+		}
+		return status
+	}
+
+	// Gemini up
+	if status := check(); status.Status != "ok" || status.Gemini != "up" {
+		t.Fatalf("expected ok/up, got %+v", status)
+	}
+	if geminiReachable.Value() != 1 {
+		t.Fatalf("expected gemini_reachable metric 1, got %d", geminiReachable.Value())
+	}
+
+	// Cached result is reused within the interval
+	check()
+	if calls != 1 {
+		t.Fatalf("expected probe result to be cached, got %d probes", calls)
+	}
+
+	// Gemini down
+	probeErr = errors.New("connection refused")
+	probe.refresh(context.Background())
+
+	if status := check(); status.Status != "degraded" || status.Gemini != "down" {
+		t.Fatalf("expected degraded/down, got %+v", status)
+	}
+	if geminiReachable.Value() != 0 {
+		t.Fatalf("expected gemini_reachable metric 0, got %d", geminiReachable.Value())
+	}
+}
@@ -0,0 +1,132 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// Default overload protection, overridable via GEMINI_MAX_CONCURRENCY (0
+// removes the cap), CLASSIFICATION_SHED_THRESHOLD (the share of Gemini slots
+// in use at which new classifications are shed, 0 disables shedding) and
+// CLASSIFICATION_SHED_RETRY_AFTER
+const (
+	defaultGeminiMaxConcurrency = 32
+	defaultShedThreshold        = 0.9
+	defaultShedRetryAfter       = 2 * time.Second
+)
+
+// errOverloaded is returned to classifications shed under load
+var errOverloaded = errors.New("server overloaded, retry later")
+
+var (
+	// geminiInFlight is exported on /debug/vars as the Gemini calls holding a slot
+	geminiInFlight = expvar.NewInt("gemini_in_flight")
+	// classificationsShed counts classifications rejected under load
+	classificationsShed = expvar.NewInt("classifications_shed")
+)
+
+// geminiLimiter caps concurrent Gemini calls. Calls past the cap wait for a
+// slot, so the share of slots in use tells how close the server is to queueing.
+type geminiLimiter struct {
+	slots chan struct{}
+}
+
+// newGeminiLimiter returns a limiter allowing max concurrent calls, or nil,
+// which never limits, for a non-positive max
+func newGeminiLimiter(max int) *geminiLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &geminiLimiter{slots: make(chan struct{}, max)}
+}
+
+// acquire waits for a free slot or for ctx to end
+func (l *geminiLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		geminiInFlight.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire
+func (l *geminiLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+	geminiInFlight.Add(-1)
+}
+
+// saturation is the share of slots in use, from 0 to 1
+func (l *geminiLimiter) saturation() float64 {
+	if l == nil {
+		return 0
+	}
+	return float64(len(l.slots)) / float64(cap(l.slots))
+}
+
+// loadSheddingInterceptor rejects new classifications while Gemini is
+// saturated, so in-flight work keeps its latency instead of queueing more
+type loadSheddingInterceptor struct {
+	svc *ServiceImpl
+}
+
+// NewLoadSheddingInterceptor creates a ConnectRPC interceptor shedding
+// classification requests with resource_exhausted and a Retry-After header
+// once CLASSIFICATION_SHED_THRESHOLD of the Gemini slots are in use
+func NewLoadSheddingInterceptor(svc *ServiceImpl) connect.Interceptor {
+	return &loadSheddingInterceptor{svc: svc}
+}
+
+// shed returns the error for a classification arriving under overload
+func (i *loadSheddingInterceptor) shed(procedure string) error {
+	if !classificationProcedures[procedure] {
+		return nil
+	}
+	threshold := envFloat("CLASSIFICATION_SHED_THRESHOLD", defaultShedThreshold)
+	saturation := i.svc.geminiLimiter.saturation()
+	if threshold <= 0 || saturation < threshold {
+		return nil
+	}
+
+	classificationsShed.Add(1)
+	retryAfter := envDuration("CLASSIFICATION_SHED_RETRY_AFTER", defaultShedRetryAfter)
+	slog.Warn("classification shed under load", "procedure", procedure, "saturation", saturation, "retry_after", retryAfter)
+
+	err := connect.NewError(connect.CodeResourceExhausted, errOverloaded)
+	err.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return err
+}
+
+// WrapUnary sheds classifications before any other work is done for them
+func (i *loadSheddingInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.shed(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *loadSheddingInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler is a no-op, classifications are unary
+func (i *loadSheddingInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
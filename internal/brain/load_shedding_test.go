@@ -0,0 +1,82 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+)
+
+func TestLoadShedding_RejectsClassificationsWhenSaturated(t *testing.T) {
+	t.Setenv("GEMINI_MAX_CONCURRENCY", "4")
+	t.Setenv("CLASSIFICATION_SHED_THRESHOLD", "0.75")
+	t.Setenv("CLASSIFICATION_SHED_RETRY_AFTER", "2500ms")
+
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`})
+	_, handler := brainv1connect.NewBrainServiceHandler(svc, connect.WithInterceptors(NewLoadSheddingInterceptor(svc)))
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+
+	classify := func(name string) error {
+		_, err := client.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: name, WindowTitle: name}))
+		return err
+	}
+
+	// Simulate slow Gemini calls holding slots: half the slots still classify
+	for range 2 {
+		svc.geminiLimiter.slots <- struct{}{}
+	}
+	if err := classify("Code"); err != nil {
+		t.Fatalf("expected a classification below the threshold, got %v", err)
+	}
+
+	// Past the threshold new classifications fail fast with a retry hint
+	svc.geminiLimiter.slots <- struct{}{}
+	err := classify("Xcode")
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("expected resource_exhausted, got %v", err)
+	}
+	if got := connectErr.Meta().Get("Retry-After"); got != "3" {
+		t.Fatalf("expected a Retry-After of 3 seconds, got %q", got)
+	}
+
+	// Other RPCs are never shed
+	if _, err := client.GetTaxonomy(context.Background(), connect.NewRequest(&brainv1.GetTaxonomyRequest{})); err != nil {
+		t.Fatalf("expected non-classification RPCs to pass, got %v", err)
+	}
+
+	// Once in-flight calls finish, classifications are accepted again
+	svc.geminiLimiter.release()
+	if err := classify("Xcode"); err != nil {
+		t.Fatalf("expected classification after load drops, got %v", err)
+	}
+}
+
+func TestGeminiLimiter_WaitsForFreeSlot(t *testing.T) {
+	limiter := newGeminiLimiter(1)
+	if err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("failed to acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected to wait for a slot until the deadline, got %v", err)
+	}
+
+	limiter.release()
+	if err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("expected the released slot to be reusable, got %v", err)
+	}
+	if newGeminiLimiter(0).saturation() != 0 {
+		t.Fatal("expected an unlimited limiter to never saturate")
+	}
+}
@@ -0,0 +1,43 @@
+package brain
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// localDevModel is reported as the model of results for local development servers
+const localDevModel = "developer-mode"
+
+// isLocalDevHost reports whether host is the user's own machine, such as
+// localhost:3000 or 127.0.0.1:8080 while running a dev server
+func isLocalDevHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsUnspecified()
+}
+
+// localDevResult classifies a local development server as work when the
+// developer_mode flag is on. Unlike the private_ip policy it
+// is a confident answer; the page is still never fetched from the server.
+func (s *ServiceImpl) localDevResult(ctx context.Context, host string, signals []string) *brainv1.ClassificationResult {
+	if !s.flags.Enabled(ctx, flagDeveloperMode) || !isLocalDevHost(host) {
+		return nil
+	}
+	return &brainv1.ClassificationResult{
+		Classification:  "productive",
+		Reasoning:       "A local development server, almost always work in progress.",
+		Tags:            []string{"work"},
+		ConfidenceScore: 1,
+		Signals:         append(signals, "url is a local development server"),
+		Model:           localDevModel,
+	}
+}
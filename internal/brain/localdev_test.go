@@ -0,0 +1,61 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestClassifyWebsite_DeveloperModeLocalhost(t *testing.T) {
+	t.Setenv("CLASSIFICATION_DEVELOPER_MODE", "true")
+
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write([]byte(`<html><head><title>My App</title></head></html>`))
+	}))
+	defer srv.Close()
+
+	// Neither the page nor the model is consulted for a dev server
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model called")})
+	for _, url := range []string{srv.URL + "/dashboard", "http://localhost:3000", "http://api.localhost:8080/health", "http://[::1]:5173/"} {
+		resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url}))
+		if err != nil {
+			t.Fatalf("%s: classification failed: %v", url, err)
+		}
+		result := resp.Msg.GetClassification()
+		if result.GetClassification() != "productive" || result.GetModel() != localDevModel || result.GetPolicy() != nil {
+			t.Fatalf("%s: expected a productive dev server result, got %v", url, result)
+		}
+	}
+	if got := fetches.Load(); got != 0 {
+		t.Fatalf("expected localhost never to be fetched, got %d fetches", got)
+	}
+}
+
+func TestClassifyWebsite_DeveloperModeKeepsPrivateNetworkPolicy(t *testing.T) {
+	t.Setenv("CLASSIFICATION_DEVELOPER_MODE", "true")
+
+	// Other private addresses are not the user's machine, the SSRF policy applies
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"neutral","reasoning":"A router page.","tags":["other"],"confidence_score":0.6}`})
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "http://192.168.1.1/"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if result := resp.Msg.GetClassification(); result.GetPolicy().GetReason() != policyPrivateIP || result.GetModel() == localDevModel {
+		t.Fatalf("expected the private_ip policy, got %v", result)
+	}
+}
+
+func TestLocalDevResult_OffByDefault(t *testing.T) {
+	if result := (&ServiceImpl{}).localDevResult(context.Background(), "localhost", nil); result != nil {
+		t.Fatalf("expected no dev server result without developer mode, got %v", result)
+	}
+}
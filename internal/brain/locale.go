@@ -0,0 +1,65 @@
+package brain
+
+import (
+	"log/slog"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// localeInstructions ask for the reasoning in the user's language. The
+// labels stay English since clients match on them.
+const localeInstructions = `
+# Reasoning Language
+
+The input sets **locale**, a BCP 47 language tag. Write "reasoning" in that language. Keep "classification" and "tags" exactly as the English values listed above, and "detected_project" and "detected_communication_channel" as they appear in the input.
+`
+
+// reasoningLocale picks the language the model writes reasoning in: the
+// requested locale, else the client's first Accept-Language preference.
+// English, the prompts' own language, returns "" so it shares cache entries
+// with requests that asked for nothing.
+func reasoningLocale(requested string, header http.Header) string {
+	var tag language.Tag
+	if requested != "" {
+		parsed, err := language.Parse(requested)
+		if err != nil {
+			slog.Debug("ignoring invalid locale", "locale", requested, "error", err)
+			return ""
+		}
+		tag = parsed
+	} else {
+		tags, _, err := language.ParseAcceptLanguage(header.Get("Accept-Language"))
+		if err != nil || len(tags) == 0 {
+			return ""
+		}
+		tag = tags[0]
+	}
+
+	// Regional variants share a cache entry, only a non-default script such
+	// as Traditional Chinese is kept
+	base, confidence := tag.Base()
+	if confidence == language.No {
+		return ""
+	}
+	switch base.String() {
+	case "en", "und", "mul": // "mul" is the Accept-Language wildcard
+		return ""
+	}
+	script, _ := tag.Script()
+	if defaultScript, _ := language.Make(base.String()).Script(); script != defaultScript {
+		return base.String() + "-" + script.String()
+	}
+	return base.String()
+}
+
+// withLocale adds locale to the classification inputs, keeping the cache key
+// separate per language
+func withLocale(locale string, inputs ...map[string]string) {
+	if locale == "" {
+		return
+	}
+	for _, input := range inputs {
+		input["locale"] = locale
+	}
+}
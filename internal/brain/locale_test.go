@@ -0,0 +1,118 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// localizedModels writes reasoning in the input's locale when the prompt asks for it
+type localizedModels struct {
+	inputs []map[string]string
+}
+
+var localizedReasoning = map[string]string{
+	"":   "Editing Go code.",
+	"de": "Bearbeitet Go-Code.",
+	"ja": "Goのコードを編集しています。",
+}
+
+func (m *localizedModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	var input map[string]string
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &input); err != nil {
+		return nil, err
+	}
+	m.inputs = append(m.inputs, input)
+
+	locale := ""
+	if strings.Contains(config.SystemInstruction.Parts[0].Text, "# Reasoning Language") {
+		locale = input["locale"]
+	}
+	reply, err := json.Marshal(map[string]any{
+		"classification":   "productive",
+		"reasoning":        localizedReasoning[locale],
+		"tags":             []string{"work", "code-editor"},
+		"confidence_score": 0.9,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fakeModels{text: string(reply)}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_ReasoningLocale(t *testing.T) {
+	models := &localizedModels{}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+
+	classify := func(locale, acceptLanguage string) *brainv1.ClassificationResult {
+		t.Helper()
+		req := connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: "Code",
+			WindowTitle:     "main.go - focusd",
+			Locale:          locale,
+		})
+		if acceptLanguage != "" {
+			req.Header().Set("Accept-Language", acceptLanguage)
+		}
+		resp, err := svc.ClassifyApplication(context.Background(), req)
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification()
+	}
+
+	if got := classify("de-AT", "").GetReasoning(); got != localizedReasoning["de"] {
+		t.Fatalf("expected german reasoning, got %q", got)
+	}
+
+	// The header applies when the request names no locale
+	result := classify("", "ja-JP,en;q=0.5")
+	if result.GetReasoning() != localizedReasoning["ja"] {
+		t.Fatalf("expected japanese reasoning, got %q", result.GetReasoning())
+	}
+	if result.GetClassification() != "productive" || !strings.Contains(strings.Join(result.GetTags(), ","), "code-editor") {
+		t.Fatalf("expected english labels, got %v", result)
+	}
+
+	if got := classify("", "").GetReasoning(); got != localizedReasoning[""] {
+		t.Fatalf("expected english reasoning by default, got %q", got)
+	}
+	if len(models.inputs) != 3 {
+		t.Fatalf("expected each locale to be classified separately, got %d model calls", len(models.inputs))
+	}
+}
+
+func TestReasoningLocale(t *testing.T) {
+	cases := []struct {
+		requested, acceptLanguage, want string
+	}{
+		{"de", "", "de"},
+		{"pt-BR", "fr", "pt"},
+		{"", "fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"", "en;q=0.2, es;q=0.9", "es"},
+		{"zh-TW", "", "zh-Hant"},
+		{"zh-CN", "", "zh"},
+		// English and unusable values keep the default cache entries
+		{"en-GB", "de", ""},
+		{"", "*", ""},
+		{"not a locale!", "de", ""},
+		{"", "", ""},
+	}
+	for _, tc := range cases {
+		header := http.Header{}
+		if tc.acceptLanguage != "" {
+			header.Set("Accept-Language", tc.acceptLanguage)
+		}
+		if got := reasoningLocale(tc.requested, header); got != tc.want {
+			t.Errorf("reasoningLocale(%q, %q): expected %q, got %q", tc.requested, tc.acceptLanguage, tc.want, got)
+		}
+	}
+}
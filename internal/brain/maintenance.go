@@ -0,0 +1,96 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// adminRole is the session role allowed to call admin RPCs
+const adminRole = "admin"
+
+// requireAdmin rejects callers without an admin session
+func requireAdmin(ctx context.Context) error {
+	if user, ok := auth.GetUser(ctx); !ok || user.Role != adminRole {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("admin role required"))
+	}
+	return nil
+}
+
+// maintenanceBatchSize bounds each purge statement so concurrent traffic is
+// never blocked on one long-running delete
+const maintenanceBatchSize = 500
+
+// RunMaintenance purges expired cache, nonce and session rows on demand
+func (s *ServiceImpl) RunMaintenance(ctx context.Context, req *connect.Request[brainv1.RunMaintenanceRequest]) (*connect.Response[brainv1.RunMaintenanceResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if !s.maintenanceMu.TryLock() {
+		return nil, connect.NewError(connect.CodeAborted, errors.New("maintenance already running"))
+	}
+	defer s.maintenanceMu.Unlock()
+
+	now := time.Now().Unix()
+
+	cacheRows, err := purgeExpired(ctx, s.gormDB, &commonv1.PromptHistoryORM{}, "prompt_hash", now)
+	if err != nil {
+		return nil, dbError("failed to purge cache", err)
+	}
+
+	nonceRows, err := purgeExpired(ctx, s.gormDB, &commonv1.NonceORM{}, "nonce", now)
+	if err != nil {
+		return nil, dbError("failed to purge nonces", err)
+	}
+
+	// Sessions stay until tokens past expiry but within the leeway are rejected too
+	sessionRows, err := purgeExpired(ctx, s.gormDB, &commonv1.SessionORM{}, "jti", now-int64(auth.ExpiryLeeway().Seconds()))
+	if err != nil {
+		return nil, dbError("failed to purge sessions", err)
+	}
+
+	resp := &brainv1.RunMaintenanceResponse{
+		CacheRowsRemoved:   cacheRows,
+		NonceRowsRemoved:   nonceRows,
+		SessionRowsRemoved: sessionRows,
+	}
+
+	if req.Msg.GetVacuum() {
+		if err := s.gormDB.WithContext(ctx).Exec("VACUUM").Error; err != nil {
+			// Remote databases may not support VACUUM, the purge still succeeded
+			slog.Warn("maintenance vacuum failed", "error", err)
+		} else {
+			resp.Vacuumed = true
+		}
+	}
+
+	slog.Info("maintenance completed", "cache_rows_removed", cacheRows, "nonce_rows_removed", nonceRows, "session_rows_removed", sessionRows, "vacuumed", resp.Vacuumed)
+	return connect.NewResponse(resp), nil
+}
+
+// purgeExpired deletes rows of model whose expires_at is at or before now, in
+// batches keyed by the given unique column, and returns how many were removed.
+func purgeExpired(ctx context.Context, db *gorm.DB, model any, key string, now int64) (int64, error) {
+	var total int64
+	for {
+		expired := db.Model(model).Select(key).Where("expires_at <= ?", now).Limit(maintenanceBatchSize)
+		result := db.WithContext(ctx).Where(fmt.Sprintf("%s IN (?)", key), expired).Delete(model)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < maintenanceBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package brain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestRunMaintenance_PurgesExpiredRows(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Now().Unix()
+
+	for i, expires := range []int64{now - 10, now - 5, now + 3600} {
+		db.Create(&commonv1.PromptHistoryORM{PromptHash: string(rune('a' + i)), ResponseJson: "{}", CreatedAt: now, ExpiresAt: expires})
+	}
+	for i, expires := range []int64{now - 30, now + 30} {
+		db.Create(&commonv1.NonceORM{Nonce: string(rune('a' + i)), CreatedAt: now, ExpiresAt: expires})
+	}
+
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	resp, err := NewServiceImpl(db).RunMaintenance(ctx, connect.NewRequest(&brainv1.RunMaintenanceRequest{Vacuum: true}))
+	if err != nil {
+		t.Fatalf("maintenance failed: %v", err)
+	}
+
+	if resp.Msg.GetCacheRowsRemoved() != 2 || resp.Msg.GetNonceRowsRemoved() != 1 {
+		t.Fatalf("unexpected counts: %+v", resp.Msg)
+	}
+	if !resp.Msg.GetVacuumed() {
+		t.Errorf("expected vacuum to run")
+	}
+
+	var cacheLeft, noncesLeft int64
+	db.Model(&commonv1.PromptHistoryORM{}).Count(&cacheLeft)
+	db.Model(&commonv1.NonceORM{}).Count(&noncesLeft)
+	if cacheLeft != 1 || noncesLeft != 1 {
+		t.Fatalf("expected only fresh rows to remain, got cache=%d nonces=%d", cacheLeft, noncesLeft)
+	}
+}
+
+func TestRunMaintenance_RequiresAdmin(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: "pro"})
+	_, err := NewServiceImpl(newTestDB(t)).RunMaintenance(ctx, connect.NewRequest(&brainv1.RunMaintenanceRequest{}))
+	if got := connect.CodeOf(err); got != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", got)
+	}
+}
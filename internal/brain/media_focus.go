@@ -0,0 +1,56 @@
+package brain
+
+import (
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// videoMarkers hint that the app, title or url plays video rather than audio alone
+var videoMarkers = []string{"youtube", "vimeo", "twitch", "netflix", "video"}
+
+// audioTags mark a result as music or spoken audio
+var audioTags = []string{"music", "supporting-audio", "spoken-audio"}
+
+// withForeground records whether the input is in the foreground when the
+// client knows. It changes the answer for media, so it is part of the key.
+func withForeground(foreground *bool, data ...map[string]string) {
+	if foreground == nil {
+		return
+	}
+	for _, d := range data {
+		d["foreground"] = strconv.FormatBool(*foreground)
+	}
+}
+
+// isVideo reports whether the input looks like a video player or page
+func isVideo(contextData map[string]string) bool {
+	text := strings.ToLower(contextData["name"] + " " + contextData["title"] + " " + contextData["url"])
+	return slices.ContainsFunc(videoMarkers, func(marker string) bool { return strings.Contains(text, marker) })
+}
+
+// biasMediaFocus nudges media results by whether the user is looking at them:
+// audio playing in the background supports focus, video watched in the
+// foreground is entertainment. Other results, and requests that did not say,
+// are left alone.
+func biasMediaFocus(classification string, tags []string, contextData map[string]string) (string, []string) {
+	audio := slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(audioTags, tag) })
+	switch {
+	case contextData["foreground"] == "false" && audio && classification == "distracting":
+		classification = "supporting"
+		if !slices.Contains(tags, "supporting-audio") {
+			tags = append(slices.Clone(tags), "supporting-audio")
+		}
+	case contextData["foreground"] == "true" && audio && classification == "supporting" && isVideo(contextData):
+		classification = "distracting"
+		tags = slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == "supporting-audio" })
+		if !slices.Contains(tags, "entertainment") {
+			tags = append(tags, "entertainment")
+		}
+	default:
+		return classification, tags
+	}
+	slog.Debug("media classification biased by focus", "foreground", contextData["foreground"], "classification", classification)
+	return classification, tags
+}
@@ -0,0 +1,86 @@
+package brain
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestClassifyApplication_BackgroundMediaLeansSupporting(t *testing.T) {
+	recorder := &inputRecorder{text: `{"classification":"distracting","reasoning":"A music video.","tags":["music","entertainment"],"confidence_score":0.6}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName: "Google Chrome",
+		WindowTitle:     "Daft Punk - Get Lucky (Official Video) - YouTube",
+		Foreground:      proto.Bool(false),
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if recorder.input["foreground"] != "false" {
+		t.Fatalf("expected the model to see the background state, got %v", recorder.input)
+	}
+	result := resp.Msg.Classification
+	if result.Classification != "supporting" || !slices.Contains(result.Tags, "supporting-audio") {
+		t.Fatalf("expected background music to be supporting, got %v", result)
+	}
+	if !slices.Contains(result.Signals, "playing in the background") {
+		t.Fatalf("expected a background signal, got %v", result.Signals)
+	}
+}
+
+func TestClassifyWebsite_ForegroundVideoLeansDistracting(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"supporting","reasoning":"Music.","tags":["supporting-audio"],"confidence_score":0.6}`})
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:        "https://youtube.example.invalid/watch?v=5NV6Rdv1a3I",
+		Title:      "Daft Punk - Get Lucky (Official Video)",
+		Foreground: proto.Bool(true),
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	result := resp.Msg.Classification
+	if result.Classification != "distracting" || slices.Contains(result.Tags, "supporting-audio") || !slices.Contains(result.Tags, "entertainment") {
+		t.Fatalf("expected a watched video to be distracting, got %v", result)
+	}
+}
+
+func TestBiasMediaFocus_LeavesOtherResultsAlone(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		contextData    map[string]string
+		classification string
+		tags           []string
+	}{
+		{"foreground unknown", map[string]string{"title": "Lofi beats - YouTube"}, "distracting", []string{"music"}},
+		{"not media", map[string]string{"title": "Home / X", "foreground": "false"}, "distracting", []string{"social-media"}},
+		{"watched music player", map[string]string{"name": "Spotify", "foreground": "true"}, "supporting", []string{"supporting-audio"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			classification, tags := biasMediaFocus(tc.classification, tc.tags, tc.contextData)
+			if classification != tc.classification || !slices.Equal(tags, tc.tags) {
+				t.Fatalf("expected %s %v to be kept, got %s %v", tc.classification, tc.tags, classification, tags)
+			}
+		})
+	}
+}
+
+func TestApplicationContext_ForegroundOnlyKeysWhenSet(t *testing.T) {
+	unset, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Spotify"})
+	if _, ok := unset["foreground"]; ok {
+		t.Fatalf("expected no foreground key when the client does not say, got %v", unset)
+	}
+	set, _ := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Spotify", Foreground: proto.Bool(true)})
+	if set["foreground"] != "true" {
+		t.Fatalf("expected the foreground state in the key, got %v", set)
+	}
+}
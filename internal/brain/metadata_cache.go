@@ -0,0 +1,69 @@
+package brain
+
+import "sync"
+
+// defaultMetadataCacheSize caps how many URLs keep validators, overridable via WEBSITE_METADATA_CACHE_SIZE
+const defaultMetadataCacheSize = 1000
+
+// metadataEntry is previously fetched metadata with the validators the origin sent
+type metadataEntry struct {
+	metadata     WebsiteMetadata
+	etag         string
+	lastModified string
+	seq          uint64 // store order, for eviction
+}
+
+// metadataCache remembers website metadata per URL so repeat fetches can be
+// sent as conditional requests and a 304 reuses the stored result
+type metadataCache struct {
+	mu      sync.Mutex
+	seq     uint64
+	entries map[string]metadataEntry
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{
+		entries: make(map[string]metadataEntry),
+	}
+}
+
+// websiteMetadataCache is shared by all metadata fetches
+var websiteMetadataCache = newMetadataCache()
+
+func (c *metadataCache) get(url string) (metadataEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	return entry, ok
+}
+
+// put stores metadata for url. Responses without validators are not stored
+// since they can never be revalidated. A non-positive size disables the cache.
+func (c *metadataCache) put(url string, entry metadataEntry) {
+	size := envInt("WEBSITE_METADATA_CACHE_SIZE", defaultMetadataCacheSize)
+	if size <= 0 || (entry.etag == "" && entry.lastModified == "") {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	entry.seq = c.seq
+
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= size {
+		c.evictOldest()
+	}
+	c.entries[url] = entry
+}
+
+// evictOldest drops the least recently stored entry
+func (c *metadataCache) evictOldest() {
+	var oldestURL string
+	var oldest uint64
+	for url, entry := range c.entries {
+		if oldestURL == "" || entry.seq < oldest {
+			oldestURL, oldest = url, entry.seq
+		}
+	}
+	delete(c.entries, oldestURL)
+}
@@ -0,0 +1,148 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// splitModels parses a prioritized, comma-separated model list such as
+// "gemini-2.0-flash,gemini-1.5-flash"
+func splitModels(value string) []string {
+	var models []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			models = append(models, name)
+		}
+	}
+	return models
+}
+
+// modelAPIError is a non-200 response from an OpenAI-compatible or Anthropic endpoint
+type modelAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *modelAPIError) Error() string {
+	return fmt.Sprintf("model API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// isModelUnavailable reports whether err is specific to the model asked:
+// overloaded, out of quota or unknown. Another model may still answer, unlike
+// a cancelled request, a safety block or a bad API key.
+func isModelUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, errSafetyBlocked) {
+		return false
+	}
+
+	// genai returns APIError by value
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Status {
+		case "UNAVAILABLE", "NOT_FOUND", "RESOURCE_EXHAUSTED":
+			return true
+		}
+		return isUnavailableStatus(apiErr.Code)
+	}
+
+	var modelErr *modelAPIError
+	if errors.As(err, &modelErr) {
+		return isUnavailableStatus(modelErr.StatusCode)
+	}
+	return false
+}
+
+func isUnavailableStatus(code int) bool {
+	switch code {
+	case http.StatusNotFound, http.StatusTooManyRequests, http.StatusServiceUnavailable,
+		529: // Anthropic's overloaded_error
+		return true
+	}
+	return false
+}
+
+// callModels classifies with the primary model, then each fallback in order
+// while the previous one is unavailable. It returns the model that answered.
+func (cs *ClassificationService) callModels(ctx context.Context, prompt string, contextData map[string]string) (string, string, error) {
+	chain := append([]string{cs.model}, cs.fallbackModels...)
+
+	var lastErr error
+	for i, name := range chain {
+		if i > 0 && name == cs.model {
+			continue
+		}
+		text, err := cs.callGemini(ctx, name, prompt, contextData)
+		if err == nil {
+			return text, name, nil
+		}
+		if !isModelUnavailable(err) {
+			return "", "", err
+		}
+		slog.Warn("classification model unavailable", "model", name, "error", err)
+		lastErr = err
+	}
+	return "", "", lastErr
+}
+
+// markModel records the fallback model that produced a response, so cache
+// hits report it too
+func markModel(response, name string) string {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(response), &fields); err != nil {
+		return response
+	}
+	fields["model"] = name
+
+	marked, err := json.Marshal(fields)
+	if err != nil {
+		return response
+	}
+	return string(marked)
+}
+
+// fallbackLLM is an agent model trying each configured model in order. It
+// moves on only when a model fails before producing any output, a partial
+// answer is never replayed by another model.
+type fallbackLLM struct {
+	models []model.LLM
+}
+
+// Name reports the primary model
+func (m *fallbackLLM) Name() string {
+	return m.models[0].Name()
+}
+
+func (m *fallbackLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for i, llm := range m.models {
+			var unavailable error
+			started := false
+			for resp, err := range llm.GenerateContent(ctx, req, stream) {
+				if err != nil && !started && i < len(m.models)-1 && isModelUnavailable(err) {
+					unavailable = err
+					break
+				}
+				started = true
+				if !yield(resp, err) {
+					return
+				}
+			}
+			if unavailable == nil {
+				if i > 0 {
+					slog.Info("agent served by fallback model", "model", llm.Name())
+				}
+				return
+			}
+			slog.Warn("agent model unavailable, trying the next", "model", llm.Name(), "error", unavailable)
+		}
+	}
+}
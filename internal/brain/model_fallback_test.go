@@ -0,0 +1,133 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// modelChain answers per model name, recording the order models were asked
+type modelChain struct {
+	answers map[string]fakeModels
+	asked   []string
+}
+
+func (c *modelChain) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	c.asked = append(c.asked, model)
+	return c.answers[model].GenerateContent(ctx, model, contents, config)
+}
+
+func newChainTestService(t *testing.T, chain *modelChain, primary string, fallbacks ...string) *ServiceImpl {
+	t.Helper()
+	svc := NewServiceImpl(newTestDB(t))
+	svc.newClassificationService = func(db *gorm.DB) (*ClassificationService, error) {
+		return &ClassificationService{db: db, models: chain, model: primary, fallbackModels: fallbacks}, nil
+	}
+	return svc
+}
+
+func TestClassifyApplication_FallsBackToNextModel(t *testing.T) {
+	chain := &modelChain{answers: map[string]fakeModels{
+		"gemini-2.0-flash": {err: genai.APIError{Code: http.StatusServiceUnavailable, Status: "UNAVAILABLE", Message: "The model is overloaded."}},
+		"gemini-1.5-flash": {text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`},
+	}}
+	svc := newChainTestService(t, chain, "gemini-2.0-flash", "gemini-1.5-flash")
+
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "productive" || result.GetModel() != "gemini-1.5-flash" {
+		t.Fatalf("expected the second model's result, got %v", result)
+	}
+	if len(chain.asked) != 2 {
+		t.Fatalf("expected both models to be asked, got %v", chain.asked)
+	}
+}
+
+func TestClassifyApplication_NoFallbackForRequestErrors(t *testing.T) {
+	chain := &modelChain{answers: map[string]fakeModels{
+		"gemini-2.0-flash": {err: genai.APIError{Code: http.StatusBadRequest, Status: "INVALID_ARGUMENT"}},
+		"gemini-1.5-flash": {text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`},
+	}}
+	svc := newChainTestService(t, chain, "gemini-2.0-flash", "gemini-1.5-flash")
+
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code"})); err == nil {
+		t.Fatal("expected a bad request to fail without trying another model")
+	}
+	if len(chain.asked) != 1 {
+		t.Fatalf("expected only the first model to be asked, got %v", chain.asked)
+	}
+}
+
+func TestSelectAgentModel_FallsBackToNextModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Model == "overloaded-model" {
+			http.Error(w, `{"error":"overloaded"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi from ` + req.Model + `"}}]}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("AGENT_MODEL_PROVIDER", "openai")
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("OPENAI_BASE_URL", srv.URL)
+	t.Setenv("AGENT_MODEL", "overloaded-model, backup-model")
+
+	llm, err := selectAgentModel(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if llm.Name() != "overloaded-model" {
+		t.Errorf("expected the primary model's name, got %q", llm.Name())
+	}
+
+	var text string
+	for resp, err := range llm.GenerateContent(context.Background(), &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("hello", genai.RoleUser)}}, false) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text += resp.Content.Parts[0].Text
+	}
+	if text != "hi from backup-model" {
+		t.Fatalf("expected the backup model to answer, got %q", text)
+	}
+}
+
+func TestIsModelUnavailable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{genai.APIError{Code: http.StatusNotFound}, true},
+		{genai.APIError{Code: http.StatusTooManyRequests}, true},
+		{genai.APIError{Code: http.StatusUnauthorized}, false},
+		{&modelAPIError{StatusCode: 529}, true},
+		{&modelAPIError{StatusCode: http.StatusBadRequest}, false},
+		{errors.Join(errSafetyBlocked, genai.APIError{Status: "UNAVAILABLE"}), false},
+		{context.Canceled, false},
+	}
+	for _, tc := range cases {
+		if got := isModelUnavailable(tc.err); got != tc.want {
+			t.Errorf("%v: expected %v, got %v", tc.err, tc.want, got)
+		}
+	}
+}
@@ -3,32 +3,63 @@ package brain
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/endpoints"
+	"gorm.io/gorm/clause"
 
 	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
 	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+	"github.com/focusd-so/brain/internal/secrets"
 	"github.com/google/go-github/v80/github"
 )
 
+// oauth2Providers lists the providers a client may link, matching the request validation
+var oauth2Providers = []string{"github", "slack", "jira", "google", "linear", "notion"}
+
+// defaultOAuth2Scopes are requested when a client asks for none, overridable
+// per provider via e.g. GITHUB_DEFAULT_SCOPES
+var defaultOAuth2Scopes = map[string]string{
+	"github": "read:user,repo",
+}
+
+// defaultOAuth2ExpiryWarning flags tokens close to expiry, overridable via OAUTH2_EXPIRY_WARNING
+const defaultOAuth2ExpiryWarning = 10 * time.Minute
+
+const (
+	// defaultGitHubRevokeTimeout bounds a revoke including its retry,
+	// overridable via GITHUB_REVOKE_TIMEOUT
+	defaultGitHubRevokeTimeout = 10 * time.Second
+	// defaultGitHubRevokeRetryBackoff is the pause before retrying a revoke,
+	// overridable via GITHUB_REVOKE_RETRY_BACKOFF
+	defaultGitHubRevokeRetryBackoff = 250 * time.Millisecond
+)
+
 func (s *ServiceImpl) OAuth2GetAuthorizationURL(ctx context.Context, req *connect.Request[brainv1.OAuth2GetAuthorizationURLRequest]) (*connect.Response[brainv1.OAuth2GetAuthorizationURLResponse], error) {
 	redirectURI := os.Getenv("REDIRECT_URI")
 	if redirectURI == "" {
-		return nil, errors.New("missing redirect URI")
+		return nil, oauthError("missing redirect URI", errOAuth2NotConfigured)
 	}
 
 	switch req.Msg.Provider {
 	case "github":
 		cfg, err := githubConfig()
 		if err != nil {
-			return nil, err
+			return nil, oauthError("github is not configured", err)
 		}
 
 		cfg.Scopes = req.Msg.Scopes
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = defaultScopes(req.Msg.Provider)
+		}
 
 		opts := []oauth2.AuthCodeOption{
 			oauth2.AccessTypeOffline,
@@ -63,7 +94,7 @@ func (s *ServiceImpl) OAuth2ExchangeAuthorizationCode(ctx context.Context, req *
 	case "github":
 		cfg, err := githubConfig()
 		if err != nil {
-			return nil, err
+			return nil, oauthError("github is not configured", err)
 		}
 
 		opts := []oauth2.AuthCodeOption{}
@@ -73,7 +104,11 @@ func (s *ServiceImpl) OAuth2ExchangeAuthorizationCode(ctx context.Context, req *
 
 		token, err := cfg.Exchange(ctx, req.Msg.Code, opts...)
 		if err != nil {
-			return nil, err
+			return nil, oauthError("failed to exchange github authorization code", err)
+		}
+
+		if err := s.recordLinkedProvider(ctx, req.Msg.Provider, token); err != nil {
+			slog.Warn("failed to record linked provider", "provider", req.Msg.Provider, "error", err)
 		}
 
 		return connect.NewResponse(&brainv1.OAuth2ExchangeAuthorizationCodeResponse{
@@ -106,7 +141,7 @@ func (s *ServiceImpl) OAuth2RevokeAccessToken(ctx context.Context, req *connect.
 
 		cfg, err := githubConfig()
 		if err != nil {
-			return nil, err
+			return nil, oauthError("github is not configured", err)
 		}
 
 		t := &BasicAuthTransport{
@@ -114,10 +149,17 @@ func (s *ServiceImpl) OAuth2RevokeAccessToken(ctx context.Context, req *connect.
 			Password: cfg.ClientSecret,
 		}
 
-		githubClient := github.NewClient(t.Client())
+		githubClient, err := newGitHubClient(t.Client())
+		if err != nil {
+			return nil, oauthError("github is not configured", err)
+		}
+
+		if err := revokeGitHubToken(ctx, githubClient, cfg.ClientID, req.Msg.Token); err != nil {
+			return nil, oauthError("failed to revoke github access token", err)
+		}
 
-		if _, err := githubClient.Authorizations.Revoke(ctx, cfg.ClientID, req.Msg.Token); err != nil {
-			return nil, err
+		if err := s.forgetLinkedProvider(ctx, req.Msg.Provider); err != nil {
+			slog.Warn("failed to forget linked provider", "provider", req.Msg.Provider, "error", err)
 		}
 
 		return connect.NewResponse(&brainv1.OAuth2RevokeAccessTokenResponse{
@@ -129,22 +171,203 @@ func (s *ServiceImpl) OAuth2RevokeAccessToken(ctx context.Context, req *connect.
 	}
 }
 
+// revokeGitHubToken revokes token, retrying once on transient failures, all
+// within GITHUB_REVOKE_TIMEOUT. GitHub answers 404 for a token that is already
+// revoked, which counts as revoked so clients can retry safely.
+func revokeGitHubToken(ctx context.Context, client *github.Client, clientID, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, envDuration("GITHUB_REVOKE_TIMEOUT", defaultGitHubRevokeTimeout))
+	defer cancel()
+
+	var err error
+	for attempt := range 2 {
+		if attempt > 0 {
+			slog.Warn("retrying github token revoke", "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(envDuration("GITHUB_REVOKE_RETRY_BACKOFF", defaultGitHubRevokeRetryBackoff)):
+			}
+		}
+
+		_, err = client.Authorizations.Revoke(ctx, clientID, token)
+		var githubErr *github.ErrorResponse
+		if err == nil || (errors.As(err, &githubErr) && githubErr.Response != nil && githubErr.Response.StatusCode == http.StatusNotFound) {
+			return nil
+		}
+		if !transientGitHubError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// transientGitHubError reports whether a failed GitHub call may succeed when
+// repeated: network failures and server errors, but not rate limits, which
+// last longer than a retry waits, nor the caller giving up
+func transientGitHubError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rateErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &rateErr) || errors.As(err, &abuseErr) {
+		return false
+	}
+	var githubErr *github.ErrorResponse
+	if errors.As(err, &githubErr) && githubErr.Response != nil {
+		return githubErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// GetOAuth2Status reports, for every supported provider, whether the
+// authenticated user linked it and how healthy the linked token is
+func (s *ServiceImpl) GetOAuth2Status(ctx context.Context, req *connect.Request[brainv1.GetOAuth2StatusRequest]) (*connect.Response[brainv1.GetOAuth2StatusResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	var links []commonv1.LinkedProviderORM
+	if err := s.gormDB.WithContext(ctx).Where("user_id = ?", user.UserID).Find(&links).Error; err != nil {
+		return nil, dbError("failed to load linked providers", err)
+	}
+
+	linked := make(map[string]commonv1.LinkedProviderORM, len(links))
+	for _, link := range links {
+		linked[link.Provider] = link
+	}
+
+	now := time.Now()
+	warning := envDuration("OAUTH2_EXPIRY_WARNING", defaultOAuth2ExpiryWarning)
+
+	statuses := make([]*brainv1.OAuth2ProviderStatus, 0, len(oauth2Providers))
+	for _, provider := range oauth2Providers {
+		status := &brainv1.OAuth2ProviderStatus{Provider: provider}
+		if link, ok := linked[provider]; ok {
+			status.Linked = true
+			status.Scopes = splitScopes(link.Scopes)
+			status.ExpiryUnix = link.ExpiryUnix
+			status.LinkedAt = link.UpdatedAt
+
+			// Tokens without an expiry (e.g. GitHub) stay valid until revoked
+			if link.ExpiryUnix > 0 {
+				expiry := time.Unix(link.ExpiryUnix, 0)
+				status.Expired = !now.Before(expiry)
+				status.ExpiringSoon = !status.Expired && expiry.Sub(now) < warning
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return connect.NewResponse(&brainv1.GetOAuth2StatusResponse{
+		Providers: statuses,
+	}), nil
+}
+
+// recordLinkedProvider remembers that the authenticated user linked provider,
+// keeping the granted scopes and expiry but not the token itself
+func (s *ServiceImpl) recordLinkedProvider(ctx context.Context, provider string, token *oauth2.Token) error {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil
+	}
+
+	var expiry int64
+	if !token.Expiry.IsZero() {
+		expiry = token.Expiry.Unix()
+	}
+	scope, _ := token.Extra("scope").(string)
+
+	now := time.Now().Unix()
+	link := commonv1.LinkedProviderORM{
+		UserId:     user.UserID,
+		Provider:   provider,
+		Scopes:     strings.Join(splitScopes(scope), ","),
+		ExpiryUnix: expiry,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	return s.gormDB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "provider"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scopes", "expiry_unix", "updated_at"}),
+	}).Create(&link).Error
+}
+
+// forgetLinkedProvider drops the authenticated user's link after a revoke
+func (s *ServiceImpl) forgetLinkedProvider(ctx context.Context, provider string) error {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil
+	}
+	return s.gormDB.WithContext(ctx).
+		Where("user_id = ? AND provider = ?", user.UserID, provider).
+		Delete(&commonv1.LinkedProviderORM{}).Error
+}
+
+// defaultScopes returns the scopes requested for provider when the client
+// sends none, so minimal clients still get a usable token
+func defaultScopes(provider string) []string {
+	return splitScopes(envString(strings.ToUpper(provider)+"_DEFAULT_SCOPES", defaultOAuth2Scopes[provider]))
+}
+
+// splitScopes parses a granted scope string. GitHub separates scopes with
+// commas, the OAuth2 spec with spaces.
+func splitScopes(scope string) []string {
+	return strings.FieldsFunc(scope, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
 func githubConfig() (*oauth2.Config, error) {
 	clientID := os.Getenv("GITHUB_CLIENT_ID")
-	clientSecret := os.Getenv("GITHUB_CLIENT_SECRET")
+	clientSecret := secrets.Get("GITHUB_CLIENT_SECRET")
 
 	if clientID == "" || clientSecret == "" {
-		return nil, errors.New("missing GitHub client ID or client secret")
+		return nil, fmt.Errorf("missing GitHub client ID or client secret: %w", errOAuth2NotConfigured)
 	}
 
 	return &oauth2.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		RedirectURL:  os.Getenv("REDIRECT_URI"),
-		Endpoint:     endpoints.GitHub,
+		Endpoint:     githubOAuthEndpoint(),
 	}, nil
 }
 
+// githubOAuthEndpoint targets a GitHub Enterprise Server instance when
+// GITHUB_OAUTH_BASE_URL is set, e.g. "https://github.example.com", and public
+// GitHub otherwise
+func githubOAuthEndpoint() oauth2.Endpoint {
+	base := strings.TrimSuffix(os.Getenv("GITHUB_OAUTH_BASE_URL"), "/")
+	if base == "" {
+		return endpoints.GitHub
+	}
+	return oauth2.Endpoint{
+		AuthURL:       base + "/login/oauth/authorize",
+		TokenURL:      base + "/login/oauth/access_token",
+		DeviceAuthURL: base + "/login/device/code",
+		AuthStyle:     oauth2.AuthStyleInParams,
+	}
+}
+
+// newGitHubClient builds a REST client for GITHUB_API_BASE_URL, an
+// enterprise instance's root or "/api/v3/" URL, or the public API when unset
+func newGitHubClient(httpClient *http.Client) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+	base := os.Getenv("GITHUB_API_BASE_URL")
+	if base == "" {
+		return client, nil
+	}
+	// Uploads live beside the REST API at "/api/uploads/"
+	uploads := strings.TrimSuffix(strings.TrimSuffix(base, "/"), "/api/v3")
+	client, err := client.WithEnterpriseURLs(base, uploads)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub API base URL %q: %w: %w", base, err, errOAuth2NotConfigured)
+	}
+	return client, nil
+}
+
 type BasicAuthTransport struct {
 	Username string
 	Password string
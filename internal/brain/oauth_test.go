@@ -0,0 +1,337 @@
+package brain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/oauth2"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestGetOAuth2Status_GitHubLinkedOnly(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	// GitHub reports granted scopes comma-separated and its tokens never expire
+	token := (&oauth2.Token{AccessToken: "gho_test", TokenType: "bearer"}).WithExtra(map[string]any{"scope": "repo,read:user"})
+	if err := svc.recordLinkedProvider(ctx, "github", token); err != nil {
+		t.Fatalf("failed to record link: %v", err)
+	}
+
+	// Another user's link must not leak
+	other := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 8})
+	if err := svc.recordLinkedProvider(other, "slack", &oauth2.Token{Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("failed to record link: %v", err)
+	}
+
+	resp, err := svc.GetOAuth2Status(ctx, connect.NewRequest(&brainv1.GetOAuth2StatusRequest{}))
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+
+	providers := resp.Msg.GetProviders()
+	if len(providers) != len(oauth2Providers) {
+		t.Fatalf("expected every supported provider, got %d", len(providers))
+	}
+	for _, status := range providers {
+		if status.GetProvider() != "github" {
+			if status.GetLinked() {
+				t.Errorf("expected %s to be unlinked", status.GetProvider())
+			}
+			continue
+		}
+		if !status.GetLinked() || status.GetExpired() || status.GetExpiringSoon() || status.GetExpiryUnix() != 0 {
+			t.Errorf("unexpected github status %v", status)
+		}
+		if !slices.Equal(status.GetScopes(), []string{"repo", "read:user"}) {
+			t.Errorf("unexpected github scopes %v", status.GetScopes())
+		}
+	}
+}
+
+func TestGetOAuth2Status_TokenHealth(t *testing.T) {
+	t.Setenv("OAUTH2_EXPIRY_WARNING", "1h")
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	for provider, expiry := range map[string]time.Time{
+		"google": time.Now().Add(-time.Minute),
+		"slack":  time.Now().Add(30 * time.Minute),
+		"linear": time.Now().Add(24 * time.Hour),
+	} {
+		if err := svc.recordLinkedProvider(ctx, provider, &oauth2.Token{Expiry: expiry}); err != nil {
+			t.Fatalf("failed to record link: %v", err)
+		}
+	}
+
+	resp, err := svc.GetOAuth2Status(ctx, connect.NewRequest(&brainv1.GetOAuth2StatusRequest{}))
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+
+	want := map[string][2]bool{"google": {true, false}, "slack": {false, true}, "linear": {false, false}}
+	for _, status := range resp.Msg.GetProviders() {
+		health, ok := want[status.GetProvider()]
+		if !ok {
+			continue
+		}
+		if got := [2]bool{status.GetExpired(), status.GetExpiringSoon()}; got != health {
+			t.Errorf("%s: expected expired/expiring %v, got %v", status.GetProvider(), health, got)
+		}
+	}
+}
+
+func TestGetOAuth2Status_RequiresSession(t *testing.T) {
+	_, err := NewServiceImpl(newTestDB(t)).GetOAuth2Status(context.Background(), connect.NewRequest(&brainv1.GetOAuth2StatusRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+// newGitHubStub points the GitHub OAuth and REST clients at handler through
+// the enterprise base URL settings
+func newGitHubStub(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	t.Setenv("GITHUB_CLIENT_ID", "client")
+	t.Setenv("GITHUB_CLIENT_SECRET", "secret")
+
+	// Served like an enterprise instance, the REST API under "/api/v3"
+	mux := http.NewServeMux()
+	mux.Handle("/api/v3/", http.StripPrefix("/api/v3", handler))
+	mux.Handle("/login/", handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	t.Setenv("GITHUB_OAUTH_BASE_URL", srv.URL)
+	t.Setenv("GITHUB_API_BASE_URL", srv.URL)
+}
+
+func TestOAuth2ExchangeAuthorizationCode_ErrorCodes(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		body   string
+		want   connect.Code
+	}{
+		"bad code":       {http.StatusOK, `{"error":"bad_verification_code","error_description":"The code passed is incorrect or expired."}`, connect.CodeInvalidArgument},
+		"expired":        {http.StatusBadRequest, `{"error":"expired_token"}`, connect.CodeUnauthenticated},
+		"provider error": {http.StatusBadGateway, `upstream unavailable`, connect.CodeUnavailable},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			newGitHubStub(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+
+			_, err := NewServiceImpl(newTestDB(t)).OAuth2ExchangeAuthorizationCode(context.Background(), connect.NewRequest(&brainv1.OAuth2ExchangeAuthorizationCodeRequest{
+				Provider: "github",
+				Code:     "code",
+			}))
+			if connect.CodeOf(err) != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			if msg := err.(*connect.Error).Message(); msg != "failed to exchange github authorization code" {
+				t.Errorf("expected a stable message, got %q", msg)
+			}
+		})
+	}
+}
+
+func TestOAuth2RevokeAccessToken_ErrorCodes(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		want   connect.Code
+	}{
+		"unauthorized":   {http.StatusUnauthorized, connect.CodeUnauthenticated},
+		"invalid token":  {http.StatusUnprocessableEntity, connect.CodeInvalidArgument},
+		"provider error": {http.StatusInternalServerError, connect.CodeUnavailable},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GITHUB_REVOKE_RETRY_BACKOFF", "1ms")
+			newGitHubStub(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"message":"nope"}`))
+			})
+
+			_, err := NewServiceImpl(newTestDB(t)).OAuth2RevokeAccessToken(context.Background(), connect.NewRequest(&brainv1.OAuth2RevokeAccessTokenRequest{
+				Provider: "github",
+				Token:    "gho_test",
+			}))
+			if connect.CodeOf(err) != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestOAuth2RevokeAccessToken_Retries(t *testing.T) {
+	t.Setenv("GITHUB_REVOKE_RETRY_BACKOFF", "1ms")
+
+	cases := map[string]struct {
+		statuses []int
+		calls    int32
+		want     connect.Code
+	}{
+		"revoked":         {[]int{http.StatusNoContent}, 1, 0},
+		"already revoked": {[]int{http.StatusNotFound}, 1, 0},
+		"transient":       {[]int{http.StatusBadGateway, http.StatusNoContent}, 2, 0},
+		"still failing":   {[]int{http.StatusBadGateway, http.StatusBadGateway}, 2, connect.CodeUnavailable},
+		"not transient":   {[]int{http.StatusUnauthorized}, 1, connect.CodeUnauthenticated},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			newGitHubStub(t, func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[min(int(calls.Add(1)), len(tc.statuses))-1]
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				if status != http.StatusNoContent {
+					w.Write([]byte(`{"message":"nope"}`))
+				}
+			})
+
+			resp, err := NewServiceImpl(newTestDB(t)).OAuth2RevokeAccessToken(context.Background(), connect.NewRequest(&brainv1.OAuth2RevokeAccessTokenRequest{
+				Provider: "github",
+				Token:    "gho_test",
+			}))
+			if tc.want == 0 && (err != nil || !resp.Msg.Success) {
+				t.Fatalf("expected the revoke to succeed, got %v", err)
+			}
+			if tc.want != 0 && connect.CodeOf(err) != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			if got := calls.Load(); got != tc.calls {
+				t.Errorf("expected %d calls to GitHub, got %d", tc.calls, got)
+			}
+		})
+	}
+}
+
+func TestOAuth2RevokeAccessToken_Timeout(t *testing.T) {
+	t.Setenv("GITHUB_REVOKE_TIMEOUT", "50ms")
+	release := make(chan struct{})
+	newGitHubStub(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	})
+	// Runs before the stub server closes, which waits for the handler
+	t.Cleanup(func() { close(release) })
+
+	start := time.Now()
+	_, err := NewServiceImpl(newTestDB(t)).OAuth2RevokeAccessToken(context.Background(), connect.NewRequest(&brainv1.OAuth2RevokeAccessTokenRequest{
+		Provider: "github",
+		Token:    "gho_test",
+	}))
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Fatalf("expected deadline_exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the revoke to give up after the timeout, took %v", elapsed)
+	}
+}
+
+func TestOAuth2_MissingConfiguration(t *testing.T) {
+	t.Setenv("GITHUB_CLIENT_ID", "")
+	t.Setenv("GITHUB_CLIENT_SECRET", "")
+
+	_, err := NewServiceImpl(newTestDB(t)).OAuth2ExchangeAuthorizationCode(context.Background(), connect.NewRequest(&brainv1.OAuth2ExchangeAuthorizationCodeRequest{
+		Provider: "github",
+		Code:     "code",
+	}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected failed_precondition, got %v", err)
+	}
+}
+
+func TestGitHubEnterpriseEndpoints(t *testing.T) {
+	t.Setenv("GITHUB_OAUTH_BASE_URL", "https://github.example.com/")
+	t.Setenv("GITHUB_API_BASE_URL", "https://github.example.com/api/v3/")
+
+	authURL := authorizationURL(t)
+	if authURL.Host != "github.example.com" || authURL.Path != "/login/oauth/authorize" {
+		t.Fatalf("expected the enterprise authorize endpoint, got %s", authURL)
+	}
+	if endpoint := githubOAuthEndpoint(); endpoint.TokenURL != "https://github.example.com/login/oauth/access_token" {
+		t.Fatalf("expected the enterprise token endpoint, got %s", endpoint.TokenURL)
+	}
+
+	client, err := newGitHubClient(nil)
+	if err != nil {
+		t.Fatalf("client failed: %v", err)
+	}
+	if client.BaseURL.String() != "https://github.example.com/api/v3/" || client.UploadURL.String() != "https://github.example.com/api/uploads/" {
+		t.Fatalf("expected the enterprise API, got %s and %s", client.BaseURL, client.UploadURL)
+	}
+}
+
+func TestGitHubEndpoints_DefaultToPublicGitHub(t *testing.T) {
+	t.Setenv("GITHUB_OAUTH_BASE_URL", "")
+	t.Setenv("GITHUB_API_BASE_URL", "")
+
+	if authURL := authorizationURL(t); authURL.Host != "github.com" {
+		t.Fatalf("expected public github, got %s", authURL)
+	}
+	client, err := newGitHubClient(nil)
+	if err != nil {
+		t.Fatalf("client failed: %v", err)
+	}
+	if client.BaseURL.Host != "api.github.com" {
+		t.Fatalf("expected the public API, got %s", client.BaseURL)
+	}
+}
+
+func authorizationScopes(t *testing.T, scopes ...string) string {
+	t.Helper()
+	return authorizationURL(t, scopes...).Query().Get("scope")
+}
+
+func authorizationURL(t *testing.T, scopes ...string) *url.URL {
+	t.Helper()
+	t.Setenv("REDIRECT_URI", "focusd://oauth")
+	t.Setenv("GITHUB_CLIENT_ID", "client")
+	t.Setenv("GITHUB_CLIENT_SECRET", "secret")
+
+	resp, err := NewServiceImpl(newTestDB(t)).OAuth2GetAuthorizationURL(context.Background(), connect.NewRequest(&brainv1.OAuth2GetAuthorizationURLRequest{
+		Provider: "github",
+		State:    "state",
+		Scopes:   scopes,
+	}))
+	if err != nil {
+		t.Fatalf("authorization url failed: %v", err)
+	}
+	u, err := url.Parse(resp.Msg.GetUrl())
+	if err != nil {
+		t.Fatalf("invalid authorization url: %v", err)
+	}
+	return u
+}
+
+func TestOAuth2GetAuthorizationURL_DefaultScopes(t *testing.T) {
+	if scope := authorizationScopes(t); scope != "read:user repo" {
+		t.Fatalf("expected the built-in default scopes, got %q", scope)
+	}
+
+	t.Setenv("GITHUB_DEFAULT_SCOPES", "read:user, read:org")
+	if scope := authorizationScopes(t); scope != "read:user read:org" {
+		t.Fatalf("expected the configured default scopes, got %q", scope)
+	}
+}
+
+func TestOAuth2GetAuthorizationURL_ClientScopesOverrideDefaults(t *testing.T) {
+	t.Setenv("GITHUB_DEFAULT_SCOPES", "read:user,repo")
+	if scope := authorizationScopes(t, "gist"); scope != "gist" {
+		t.Fatalf("expected only the requested scopes, got %q", scope)
+	}
+}
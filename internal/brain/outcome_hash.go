@@ -0,0 +1,66 @@
+package brain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// outcomeHash fingerprints what a classification decided: the classification
+// and its tags, in any order. Reasoning, confidence and the model are left
+// out, so rewording alone never reads as a change.
+func outcomeHash(result *brainv1.ClassificationResult) string {
+	tags := make([]string, 0, len(result.GetTags()))
+	for _, tag := range result.GetTags() {
+		tags = append(tags, strings.ToLower(strings.TrimSpace(tag)))
+	}
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	hash := sha256.Sum256([]byte(strings.ToLower(result.GetClassification()) + "\n" + strings.Join(tags, ",")))
+	return hex.EncodeToString(hash[:8])
+}
+
+// classificationResponse is implemented by every classification RPC response
+type classificationResponse interface {
+	GetClassification() *brainv1.ClassificationResult
+}
+
+// outcomeHashInterceptor stamps classification responses with their outcome hash
+type outcomeHashInterceptor struct{}
+
+// NewOutcomeHashInterceptor creates a ConnectRPC interceptor setting the
+// outcome hash on every classification result, however it was decided
+func NewOutcomeHashInterceptor() connect.Interceptor {
+	return &outcomeHashInterceptor{}
+}
+
+// WrapUnary sets the hash on successful classifications
+func (i *outcomeHashInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if msg, ok := resp.Any().(classificationResponse); ok && msg.GetClassification() != nil {
+			msg.GetClassification().OutcomeHash = outcomeHash(msg.GetClassification())
+		}
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *outcomeHashInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler is a no-op, classifications are unary
+func (i *outcomeHashInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,67 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestOutcomeHash_ChangesOnlyWithTheOutcome(t *testing.T) {
+	base := &brainv1.ClassificationResult{Classification: "productive", Reasoning: "An editor.", Tags: []string{"work", "code-editor"}, ConfidenceScore: 0.9, Model: "gemini-2.5-flash"}
+
+	same := []*brainv1.ClassificationResult{
+		{Classification: "productive", Reasoning: "Coding in an editor.", Tags: []string{"code-editor", "work"}, ConfidenceScore: 0.6, Model: "gemini-2.5-pro"},
+		{Classification: "productive", Tags: []string{"Work", "code-editor", "work"}},
+	}
+	for _, result := range same {
+		if outcomeHash(result) != outcomeHash(base) {
+			t.Errorf("expected %v to hash like %v", result, base)
+		}
+	}
+
+	different := []*brainv1.ClassificationResult{
+		{Classification: "supporting", Tags: []string{"work", "code-editor"}},
+		{Classification: "productive", Tags: []string{"work"}},
+		{Classification: "productive", Tags: []string{"work", "code-editor", "research"}},
+	}
+	for _, result := range different {
+		if outcomeHash(result) == outcomeHash(base) {
+			t.Errorf("expected %v to hash unlike %v", result, base)
+		}
+	}
+}
+
+func TestOutcomeHash_StampedOnResponsesAndHistory(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"editor","tags":["code-editor"],"confidence_score":0.9}`})
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	if _, err := svc.StartFocusSession(ctx, connect.NewRequest(&brainv1.StartFocusSessionRequest{})); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	unary := NewOutcomeHashInterceptor().WrapUnary(NewFocusSessionInterceptor(svc).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return svc.ClassifyApplication(ctx, req.(*connect.Request[brainv1.ClassifyApplicationRequest]))
+	}))
+	resp, err := unary(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Any().(*brainv1.ClassifyApplicationResponse).GetClassification()
+	want := outcomeHash(&brainv1.ClassificationResult{Classification: "productive", Tags: []string{"code-editor"}})
+	if result.GetOutcomeHash() != want {
+		t.Fatalf("expected outcome hash %q, got %q", want, result.GetOutcomeHash())
+	}
+
+	var event commonv1.FocusSessionEventORM
+	if err := svc.gormDB.First(&event).Error; err != nil {
+		t.Fatalf("expected a recorded event: %v", err)
+	}
+	if event.OutcomeHash != want {
+		t.Fatalf("expected the stored event to keep the hash, got %q", event.OutcomeHash)
+	}
+}
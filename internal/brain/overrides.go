@@ -0,0 +1,283 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// Reported as the model of results pinned by an override
+const (
+	userOverrideModel   = "user-override"
+	globalOverrideModel = "global-override"
+)
+
+const (
+	// rulesDocumentVersion is the format ExportRules writes and ImportRules accepts
+	rulesDocumentVersion = 1
+	// maxImportedOverrides bounds a single import
+	maxImportedOverrides = 1000
+)
+
+// rulesDocument is the JSON form of a user's overrides for backup and restore
+type rulesDocument struct {
+	Version   int            `json:"version"`
+	Overrides []ruleOverride `json:"overrides"`
+}
+
+type ruleOverride struct {
+	Kind           string   `json:"kind"`   // "application" or "domain"
+	Target         string   `json:"target"` // bundle id or app name, or a domain
+	Classification string   `json:"classification"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// normalizeOverrideTarget matches targets the way classification looks them
+// up: applications case-insensitively, domains by host without "www."
+func normalizeOverrideTarget(kind, target string) string {
+	target = strings.TrimSpace(target)
+	switch kind {
+	case "application":
+		return strings.ToLower(target)
+	case "domain":
+		return websiteHost(target)
+	}
+	return ""
+}
+
+// domainTargets lists host and its parent domains, most specific first, so
+// an override for "example.com" also covers "docs.example.com"
+func domainTargets(host string) []string {
+	var targets []string
+	for host != "" && strings.Contains(host, ".") {
+		targets = append(targets, host)
+		_, host, _ = strings.Cut(host, ".")
+	}
+	return targets
+}
+
+// splitTags parses the comma-separated tags of a stored override
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
+// normalizeOverrideTargets normalizes targets in order, dropping empty ones
+func normalizeOverrideTargets(kind string, targets []string) []string {
+	var normalized []string
+	for _, target := range targets {
+		if target = normalizeOverrideTarget(kind, target); target != "" {
+			normalized = append(normalized, target)
+		}
+	}
+	return normalized
+}
+
+// override returns the classification pinned for the first of targets: the
+// user's own override, else a global one. nil means classify as usual.
+func (s *ServiceImpl) override(ctx context.Context, kind string, signals []string, targets ...string) *brainv1.ClassificationResult {
+	if override := s.userOverride(ctx, kind, targets...); override != nil {
+		return pinnedResult(userOverrideModel, "You set this classification.", "user", override.Target, override.Classification, override.Tags, signals)
+	}
+	if override := s.globalOverride(ctx, kind, targets...); override != nil {
+		return pinnedResult(globalOverrideModel, "Your organization set this classification.", "global", override.Target, override.Classification, override.Tags, signals)
+	}
+	return nil
+}
+
+// userOverride returns the authenticated user's override for the first of
+// targets that has one. Lookup failures fall through to classification.
+func (s *ServiceImpl) userOverride(ctx context.Context, kind string, targets ...string) *commonv1.ClassificationOverrideORM {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil
+	}
+
+	normalized := normalizeOverrideTargets(kind, targets)
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	var overrides []commonv1.ClassificationOverrideORM
+	err := s.gormDB.WithContext(ctx).
+		Where("user_id = ? AND kind = ? AND target IN ?", user.UserID, kind, normalized).
+		Find(&overrides).Error
+	if err != nil {
+		slog.Warn("failed to look up classification overrides", "kind", kind, "error", err)
+		return nil
+	}
+
+	for _, target := range normalized {
+		for i := range overrides {
+			if overrides[i].Target == target {
+				return &overrides[i]
+			}
+		}
+	}
+	return nil
+}
+
+// pinnedResult builds the classification served for an override
+func pinnedResult(model, reasoning, scope, target, classification, tags string, signals []string) *brainv1.ClassificationResult {
+	return &brainv1.ClassificationResult{
+		Classification:  classification,
+		Reasoning:       reasoning,
+		Tags:            normalizeTags(splitTags(tags)),
+		ConfidenceScore: 1,
+		Signals:         append(signals, "matched "+scope+" override for "+target),
+		Model:           model,
+	}
+}
+
+// ExportRules serializes the authenticated user's overrides as JSON
+func (s *ServiceImpl) ExportRules(ctx context.Context, req *connect.Request[brainv1.ExportRulesRequest]) (*connect.Response[brainv1.ExportRulesResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	var overrides []commonv1.ClassificationOverrideORM
+	if err := s.gormDB.WithContext(ctx).Where("user_id = ?", user.UserID).Order("kind, target").Find(&overrides).Error; err != nil {
+		return nil, dbError("failed to load classification overrides", err)
+	}
+
+	doc := rulesDocument{Version: rulesDocumentVersion, Overrides: make([]ruleOverride, 0, len(overrides))}
+	for _, override := range overrides {
+		doc.Overrides = append(doc.Overrides, ruleOverride{
+			Kind:           override.Kind,
+			Target:         override.Target,
+			Classification: override.Classification,
+			Tags:           splitTags(override.Tags),
+		})
+	}
+
+	rulesJSON, err := json.Marshal(doc)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode rules: %w", err))
+	}
+
+	return connect.NewResponse(&brainv1.ExportRulesResponse{
+		RulesJson: string(rulesJSON),
+		Count:     int32(len(doc.Overrides)),
+	}), nil
+}
+
+// ImportRules restores overrides from an ExportRules document into the
+// authenticated user's account. The document is validated as a whole before
+// anything is written; existing targets are skipped or overwritten per request.
+func (s *ServiceImpl) ImportRules(ctx context.Context, req *connect.Request[brainv1.ImportRulesRequest]) (*connect.Response[brainv1.ImportRulesResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	overrides, err := parseRulesDocument(req.Msg.RulesJson)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	overwrite := req.Msg.OnConflict == "overwrite"
+
+	resp := &brainv1.ImportRulesResponse{}
+	err = s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []commonv1.ClassificationOverrideORM
+		if err := tx.Where("user_id = ?", user.UserID).Find(&existing).Error; err != nil {
+			return err
+		}
+		byTarget := make(map[[2]string]*commonv1.ClassificationOverrideORM, len(existing))
+		for i := range existing {
+			byTarget[[2]string{existing[i].Kind, existing[i].Target}] = &existing[i]
+		}
+
+		now := time.Now().Unix()
+		for _, override := range overrides {
+			tags := strings.Join(normalizeTags(override.Tags), ",")
+			if current, ok := byTarget[[2]string{override.Kind, override.Target}]; ok {
+				if !overwrite {
+					resp.Skipped++
+					continue
+				}
+				err := tx.Model(current).Updates(map[string]any{
+					"classification": override.Classification,
+					"tags":           tags,
+					"updated_at":     now,
+				}).Error
+				if err != nil {
+					return err
+				}
+				resp.Overwritten++
+				continue
+			}
+
+			err := tx.Create(&commonv1.ClassificationOverrideORM{
+				UserId:         user.UserID,
+				Kind:           override.Kind,
+				Target:         override.Target,
+				Classification: override.Classification,
+				Tags:           tags,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}).Error
+			if err != nil {
+				return err
+			}
+			resp.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, dbError("failed to import classification overrides", err)
+	}
+
+	slog.Info("classification overrides imported", "user_id", user.UserID, "imported", resp.Imported, "overwritten", resp.Overwritten, "skipped", resp.Skipped)
+	return connect.NewResponse(resp), nil
+}
+
+// parseRulesDocument decodes and validates an import, normalizing targets.
+// Errors name the offending override so users can fix their file.
+func parseRulesDocument(rulesJSON string) ([]ruleOverride, error) {
+	var doc rulesDocument
+	if err := json.Unmarshal([]byte(rulesJSON), &doc); err != nil {
+		return nil, fmt.Errorf("invalid rules document: %w", err)
+	}
+	if doc.Version != rulesDocumentVersion {
+		return nil, fmt.Errorf("unsupported rules version %d", doc.Version)
+	}
+	if len(doc.Overrides) > maxImportedOverrides {
+		return nil, fmt.Errorf("too many overrides: %d, at most %d", len(doc.Overrides), maxImportedOverrides)
+	}
+
+	seen := make(map[[2]string]bool, len(doc.Overrides))
+	for i := range doc.Overrides {
+		override := &doc.Overrides[i]
+		if !slices.Contains([]string{"application", "domain"}, override.Kind) {
+			return nil, fmt.Errorf("override %d: unknown kind %q", i, override.Kind)
+		}
+		if override.Target = normalizeOverrideTarget(override.Kind, override.Target); override.Target == "" {
+			return nil, fmt.Errorf("override %d: missing target", i)
+		}
+		if !classificationLabels[override.Classification] {
+			return nil, fmt.Errorf("override %d: unknown classification %q", i, override.Classification)
+		}
+
+		key := [2]string{override.Kind, override.Target}
+		if seen[key] {
+			return nil, fmt.Errorf("override %d: duplicate %s %q", i, override.Kind, override.Target)
+		}
+		seen[key] = true
+	}
+	return doc.Overrides, nil
+}
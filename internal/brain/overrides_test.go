@@ -0,0 +1,186 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func importRules(t *testing.T, svc *ServiceImpl, ctx context.Context, doc rulesDocument, onConflict string) (*brainv1.ImportRulesResponse, error) {
+	t.Helper()
+	rulesJSON, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to encode rules: %v", err)
+	}
+	resp, err := svc.ImportRules(ctx, connect.NewRequest(&brainv1.ImportRulesRequest{RulesJson: string(rulesJSON), OnConflict: onConflict}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+func exportRules(t *testing.T, svc *ServiceImpl, ctx context.Context) rulesDocument {
+	t.Helper()
+	resp, err := svc.ExportRules(ctx, connect.NewRequest(&brainv1.ExportRulesRequest{}))
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	var doc rulesDocument
+	if err := json.Unmarshal([]byte(resp.Msg.GetRulesJson()), &doc); err != nil {
+		t.Fatalf("invalid export: %v", err)
+	}
+	if int(resp.Msg.GetCount()) != len(doc.Overrides) {
+		t.Fatalf("expected the count to match the export, got %d for %d", resp.Msg.GetCount(), len(doc.Overrides))
+	}
+	return doc
+}
+
+func TestRules_RoundTrip(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	doc := rulesDocument{Version: rulesDocumentVersion, Overrides: []ruleOverride{
+		{Kind: "application", Target: "com.valvesoftware.Steam", Classification: "distracting", Tags: []string{"Entertainment"}},
+		{Kind: "domain", Target: "https://www.jira.example.com/browse", Classification: "productive", Tags: []string{"work"}},
+	}}
+	imported, err := importRules(t, svc, ctx, doc, "")
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if imported.GetImported() != 2 {
+		t.Fatalf("expected 2 imported overrides, got %v", imported)
+	}
+
+	// Targets come back normalized, ready to import on another device
+	want := []ruleOverride{
+		{Kind: "application", Target: "com.valvesoftware.steam", Classification: "distracting", Tags: []string{"entertainment"}},
+		{Kind: "domain", Target: "jira.example.com", Classification: "productive", Tags: []string{"work"}},
+	}
+	exported := exportRules(t, svc, ctx)
+	if exported.Version != rulesDocumentVersion || !slices.EqualFunc(exported.Overrides, want, func(a, b ruleOverride) bool {
+		return a.Kind == b.Kind && a.Target == b.Target && a.Classification == b.Classification && slices.Equal(a.Tags, b.Tags)
+	}) {
+		t.Fatalf("unexpected export %+v", exported)
+	}
+
+	other := NewServiceImpl(svc.gormDB)
+	otherCtx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 8})
+	if leaked := exportRules(t, other, otherCtx); len(leaked.Overrides) != 0 {
+		t.Fatalf("expected another user's export to be empty, got %+v", leaked)
+	}
+	if restored, err := importRules(t, other, otherCtx, exported, ""); err != nil || restored.GetImported() != 2 {
+		t.Fatalf("expected the export to import for another user, got %v, %v", restored, err)
+	}
+}
+
+func TestImportRules_ConflictHandling(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	original := rulesDocument{Version: rulesDocumentVersion, Overrides: []ruleOverride{
+		{Kind: "domain", Target: "youtube.com", Classification: "distracting"},
+	}}
+	if _, err := importRules(t, svc, ctx, original, ""); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	update := rulesDocument{Version: rulesDocumentVersion, Overrides: []ruleOverride{
+		{Kind: "domain", Target: "youtube.com", Classification: "supporting", Tags: []string{"learning"}},
+		{Kind: "application", Target: "Figma", Classification: "productive"},
+	}}
+
+	skipped, err := importRules(t, svc, ctx, update, "skip")
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if skipped.GetSkipped() != 1 || skipped.GetImported() != 1 || skipped.GetOverwritten() != 0 {
+		t.Fatalf("unexpected skip result %v", skipped)
+	}
+	if got := svc.userOverride(ctx, "domain", "youtube.com"); got.Classification != "distracting" {
+		t.Fatalf("expected the existing override to be kept, got %q", got.Classification)
+	}
+
+	overwritten, err := importRules(t, svc, ctx, update, "overwrite")
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if overwritten.GetOverwritten() != 2 || overwritten.GetImported() != 0 {
+		t.Fatalf("unexpected overwrite result %v", overwritten)
+	}
+	if got := svc.userOverride(ctx, "domain", "youtube.com"); got.Classification != "supporting" || got.Tags != "learning" {
+		t.Fatalf("expected the override to be replaced, got %+v", got)
+	}
+}
+
+func TestImportRules_RejectsInvalidDocument(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	cases := map[string]rulesDocument{
+		"version":        {Version: 2},
+		"kind":           {Version: 1, Overrides: []ruleOverride{{Kind: "document", Target: "~/notes.md", Classification: "productive"}}},
+		"classification": {Version: 1, Overrides: []ruleOverride{{Kind: "application", Target: "Code", Classification: "very productive"}}},
+		"duplicate": {Version: 1, Overrides: []ruleOverride{
+			{Kind: "domain", Target: "github.com", Classification: "productive"},
+			{Kind: "domain", Target: "www.GitHub.com", Classification: "distracting"},
+		}},
+		// A valid override is not written when another one is invalid
+		"partial": {Version: 1, Overrides: []ruleOverride{
+			{Kind: "application", Target: "Code", Classification: "productive"},
+			{Kind: "application", Target: " ", Classification: "productive"},
+		}},
+	}
+	for name, doc := range cases {
+		if _, err := importRules(t, svc, ctx, doc, ""); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: expected invalid_argument, got %v", name, err)
+		}
+	}
+
+	var count int64
+	svc.gormDB.Model(&commonv1.ClassificationOverrideORM{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected nothing imported, got %d overrides", count)
+	}
+}
+
+func TestRules_RequireSession(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	if _, err := svc.ExportRules(context.Background(), connect.NewRequest(&brainv1.ExportRulesRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestClassifyApplication_UserOverrideSkipsModel(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model must not be called")})
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	doc := rulesDocument{Version: rulesDocumentVersion, Overrides: []ruleOverride{
+		{Kind: "application", Target: "Steam", Classification: "supporting", Tags: []string{"break"}},
+	}}
+	if _, err := importRules(t, svc, ctx, doc, ""); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	resp, err := svc.ClassifyApplication(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Steam", WindowTitle: "Steam"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "supporting" || result.GetModel() != userOverrideModel {
+		t.Fatalf("expected the user's override, got %v", result)
+	}
+}
+
+func TestDomainTargets(t *testing.T) {
+	if got := domainTargets("docs.jira.example.com"); !slices.Equal(got, []string{"docs.jira.example.com", "jira.example.com", "example.com"}) {
+		t.Fatalf("unexpected targets %v", got)
+	}
+}
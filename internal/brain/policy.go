@@ -0,0 +1,169 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// Policy reasons reported on restricted classifications
+const (
+	policyPrivateIP        = "private_ip"
+	policyDenylistedDomain = "denylisted_domain"
+	policySafetyBlocked    = "safety_blocked"
+)
+
+// errSafetyBlocked is returned when the model refuses to answer for safety reasons
+var errSafetyBlocked = errors.New("response blocked by model safety filters")
+
+// isDenylistedDomain reports whether host or one of its parent domains is in
+// CLASSIFICATION_DENYLIST_DOMAINS (comma-separated). Denylisted sites are never
+// fetched or sent to the model.
+func isDenylistedDomain(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, domain := range strings.Split(os.Getenv("CLASSIFICATION_DENYLIST_DOMAINS"), ",") {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHTTPS rejects website URLs not served over HTTPS when
+// CLASSIFICATION_HTTPS_ONLY is on. Bare hosts were normalized to https, and
+// local development servers pass while the developer_mode flag is on.
+func (s *ServiceImpl) checkHTTPS(ctx context.Context, normalized string) error {
+	if !envBool("CLASSIFICATION_HTTPS_ONLY", false) {
+		return nil
+	}
+	u, err := parseWebsiteURL(normalized)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid url: %w", err))
+	}
+	if u.Scheme == "https" {
+		return nil
+	}
+	if isLocalDevHost(strings.ToLower(u.Hostname())) && s.flags.Enabled(ctx, flagDeveloperMode) {
+		return nil
+	}
+	return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only https urls are classified, got %q", u.Scheme))
+}
+
+// isPrivateHost reports whether host is, or resolves to, an address that must
+// not be fetched from the server: loopback, private, link-local or unspecified.
+// Resolution failures are not treated as private; the fetch fails on its own.
+func isPrivateHost(ctx context.Context, host string) bool {
+	if host == "" {
+		return false
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return isPrivateAddr(addr)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, envDuration("WEBSITE_METADATA_TIMEOUT", defaultMetadataTimeout))
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if isPrivateAddr(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// errPrivateAddress is returned when a metadata fetch would reach a private address
+var errPrivateAddress = errors.New("refusing to fetch a private address")
+
+// maxMetadataRedirects bounds the redirects followed for one metadata fetch
+const maxMetadataRedirects = 5
+
+// metadataClient fetches website metadata. isPrivateHost runs before the
+// fetch, but the host may resolve differently at dial time or redirect
+// inward, so every dialed address and redirect hop is checked again.
+var metadataClient = newMetadataClient(isPrivateAddr)
+
+// newMetadataClient returns a client refusing to dial addresses for which
+// private reports true. It never uses a proxy, which would hide the address
+// actually reached.
+func newMetadataClient(private func(netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("unexpected dial address %q: %w", address, err)
+			}
+			if private(addr.Addr()) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxMetadataRedirects {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			if host := strings.ToLower(req.URL.Hostname()); host == "localhost" || strings.HasSuffix(host, ".localhost") {
+				return errPrivateAddress
+			}
+			if addr, err := netip.ParseAddr(req.URL.Hostname()); err == nil && private(addr) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+}
+
+func isPrivateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified()
+}
+
+// restrictedResult is the neutral result served when policy prevented classification
+func restrictedResult(reason, detail string) *brainv1.ClassificationResult {
+	return &brainv1.ClassificationResult{
+		Classification: "neutral",
+		Reasoning:      detail,
+		Policy: &brainv1.ClassificationPolicy{
+			Reason: reason,
+			Detail: detail,
+		},
+	}
+}
+
+// safetyBlockedResult is served when the model refused to classify the input
+func safetyBlockedResult(variant classificationVariant) *brainv1.ClassificationResult {
+	result := restrictedResult(policySafetyBlocked, "The model declined to classify this content.")
+	result.Model = variant.Model
+	result.PromptVersion = variant.PromptVersion
+	return result
+}
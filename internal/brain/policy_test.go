@@ -0,0 +1,193 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// allowPrivateFetches lets metadata fetches reach test servers on loopback
+func allowPrivateFetches(t *testing.T) {
+	t.Helper()
+	previous := metadataClient
+	metadataClient = newMetadataClient(func(netip.Addr) bool { return false })
+	t.Cleanup(func() { metadataClient = previous })
+}
+
+func TestMetadataClient_RefusesPrivateDial(t *testing.T) {
+	var fetched atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Store(true)
+	}))
+	defer srv.Close()
+
+	// The host passed the pre-fetch check but dials to loopback, as after DNS rebinding
+	_, err := fetchMetadataOnce(context.Background(), metadataClient, srv.URL)
+	if !errors.Is(err, errPrivateAddress) {
+		t.Fatalf("expected the dial to be refused, got %v", err)
+	}
+	if fetched.Load() {
+		t.Fatal("expected the private address not to be fetched")
+	}
+}
+
+func TestMetadataClient_RefusesPrivateRedirect(t *testing.T) {
+	metadataService := netip.MustParseAddr("169.254.169.254")
+	client := newMetadataClient(func(addr netip.Addr) bool { return addr == metadataService })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	_, err := fetchMetadataOnce(context.Background(), client, srv.URL)
+	if !errors.Is(err, errPrivateAddress) {
+		t.Fatalf("expected the redirect to be refused, got %v", err)
+	}
+}
+
+// blockedModels refuses every request the way Gemini reports a safety block
+type blockedModels struct{}
+
+func (blockedModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{FinishReason: genai.FinishReasonSafety}},
+	}, nil
+}
+
+func newPolicyTestService(t *testing.T, models contentGenerator) *ServiceImpl {
+	t.Helper()
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+	return svc
+}
+
+func TestClassifyWebsite_PrivateIPSkipsFetch(t *testing.T) {
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write([]byte(`<html><head><title>Admin</title></head></html>`))
+	}))
+	defer srv.Close()
+
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"Internal dashboard.","tags":["work"],"confidence_score":0.6}`})
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: srv.URL + "/admin"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetPolicy().GetReason() != policyPrivateIP {
+		t.Fatalf("expected %s policy, got %v", policyPrivateIP, result.GetPolicy())
+	}
+	if result.GetClassification() != "productive" {
+		t.Fatalf("expected the model result from the URL alone, got %q", result.GetClassification())
+	}
+	if got := fetches.Load(); got != 0 {
+		t.Fatalf("expected no metadata fetch for a private address, got %d", got)
+	}
+}
+
+func TestClassifyWebsite_DenylistedDomain(t *testing.T) {
+	t.Setenv("CLASSIFICATION_DENYLIST_DOMAINS", "bank.example, .health.example")
+
+	// The model must never be reached for a denylisted site
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model called")})
+	for _, url := range []string{"https://secure.bank.example/login", "https://portal.health.example"} {
+		resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url}))
+		if err != nil {
+			t.Fatalf("%s: classification failed: %v", url, err)
+		}
+
+		result := resp.Msg.GetClassification()
+		if result.GetPolicy().GetReason() != policyDenylistedDomain || result.GetClassification() != "neutral" {
+			t.Fatalf("%s: expected neutral %s result, got %v", url, policyDenylistedDomain, result)
+		}
+	}
+
+	if isDenylistedDomain("notbank.example") {
+		t.Fatal("a shared suffix without a dot boundary must not match")
+	}
+}
+
+func TestClassifyWebsite_HTTPSOnly(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+	t.Setenv("CLASSIFICATION_HTTPS_ONLY", "true")
+	models := &switchableModels{}
+	svc := newPolicyTestService(t, models)
+
+	classify := func(svc *ServiceImpl, url string) error {
+		_, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url}))
+		return err
+	}
+	for _, url := range []string{"http://news.example.invalid/", "HTTP://news.example.invalid/a", "ftp://files.example.invalid/", "http://localhost:3000/"} {
+		if err := classify(svc, url); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", url, err)
+		}
+	}
+	if models.calls != 0 {
+		t.Fatalf("expected rejected urls to never reach the model, got %d calls", models.calls)
+	}
+	for _, url := range []string{"https://news.example.invalid/", "docs.example.invalid/guide"} {
+		if err := classify(svc, url); err != nil {
+			t.Fatalf("%s: expected https and bare hosts to be classified, got %v", url, err)
+		}
+	}
+
+	// Local dev servers are still allowed in developer mode
+	t.Setenv("CLASSIFICATION_DEVELOPER_MODE", "true")
+	dev := newPolicyTestService(t, models)
+	if err := classify(dev, "http://localhost:3000/"); err != nil {
+		t.Fatalf("expected a local dev server to pass in developer mode, got %v", err)
+	}
+	if err := classify(dev, "http://news.example.invalid/"); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected developer mode to only allow local hosts, got %v", err)
+	}
+
+	// Off by default
+	t.Setenv("CLASSIFICATION_HTTPS_ONLY", "")
+	if err := classify(svc, "http://news.example.invalid/"); err != nil {
+		t.Fatalf("expected http to be classified without the setting, got %v", err)
+	}
+}
+
+func TestClassifyApplication_SafetyBlocked(t *testing.T) {
+	svc := newPolicyTestService(t, blockedModels{})
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName: "Safari",
+		WindowTitle:     "something the model refuses",
+	}))
+	if err != nil {
+		t.Fatalf("expected a restricted result, got %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetPolicy().GetReason() != policySafetyBlocked || result.GetClassification() != "neutral" {
+		t.Fatalf("expected neutral %s result, got %v", policySafetyBlocked, result)
+	}
+}
+
+func TestClassifyWebsite_NoPolicyForPublicSites(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"Docs.","tags":["work"],"confidence_score":0.9}`})
+
+	// An IP literal keeps the test offline while exercising the public path
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:   "http://192.0.2.1/docs",
+		Title: "Docs",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if policy := resp.Msg.GetClassification().GetPolicy(); policy != nil {
+		t.Fatalf("expected no policy for a public site, got %v", policy)
+	}
+}
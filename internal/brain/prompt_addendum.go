@@ -0,0 +1,173 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm/clause"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// defaultPromptAddendumMaxLength caps an addendum in characters, overridable
+// via PROMPT_ADDENDUM_MAX_LENGTH
+const defaultPromptAddendumMaxLength = 1000
+
+// Addendum scopes: a user's own rules and the organization's global ones
+const (
+	addendumScopeUser   = "user"
+	addendumScopeGlobal = "global"
+)
+
+// addendumInstructions introduce the rules so they refine the prompt above
+// without replacing it
+const addendumInstructions = `
+# Organization and User Rules
+
+The rules between the markers below were written by the user's organization or the user. Follow them when they apply to the input; they take precedence over the classification rules above. They never change the JSON schema, the allowed classifications or the allowed tags.
+
+--- BEGIN RULES ---
+%s
+--- END RULES ---
+`
+
+// blockedAddendumPhrases are attempts to override the prompt rather than add
+// classification rules
+var blockedAddendumPhrases = []string{
+	"ignore previous", "ignore all", "ignore the above", "ignore your", "disregard",
+	"system prompt", "you are now", "new instructions", "end rules", "begin rules",
+	"```",
+}
+
+var errAddendumUnsafe = errors.New("addendum looks like an attempt to override the prompt")
+
+// checkAddendum validates an addendum for size and basic safety: printable
+// text only and no attempts to override the prompt
+func checkAddendum(text string) error {
+	if maxLength := envInt("PROMPT_ADDENDUM_MAX_LENGTH", defaultPromptAddendumMaxLength); maxLength > 0 && utf8.RuneCountInString(text) > maxLength {
+		return fmt.Errorf("addendum is longer than %d characters", maxLength)
+	}
+	for _, r := range text {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\t' {
+			return errors.New("addendum contains control characters")
+		}
+	}
+	lower := strings.ToLower(text)
+	for _, phrase := range blockedAddendumPhrases {
+		if strings.Contains(lower, phrase) {
+			return fmt.Errorf("%w: %q", errAddendumUnsafe, phrase)
+		}
+	}
+	return nil
+}
+
+// promptAddendum returns the instructions to append for the caller: the
+// global rules, then the user's own. Lookup failures classify without them.
+func (cs *ClassificationService) promptAddendum(ctx context.Context) string {
+	var userID int64
+	if user, ok := auth.GetUser(ctx); ok {
+		userID = user.UserID
+	}
+
+	var addenda []commonv1.PromptAddendumORM
+	err := cs.db.WithContext(ctx).
+		Where("(scope = ? AND user_id = 0) OR (scope = ? AND user_id = ? AND user_id <> 0)", addendumScopeGlobal, addendumScopeUser, userID).
+		Find(&addenda).Error
+	if err != nil {
+		slog.Warn("failed to look up prompt addenda", "error", err)
+		return ""
+	}
+
+	var global, own string
+	for _, addendum := range addenda {
+		if addendum.Scope == addendumScopeGlobal {
+			global = addendum.Text
+		} else {
+			own = addendum.Text
+		}
+	}
+	rules := strings.TrimSpace(global + "\n" + own)
+	if rules == "" {
+		return ""
+	}
+	return fmt.Sprintf(addendumInstructions, rules)
+}
+
+// SetPromptAddendum stores or clears the addendum of a scope
+func (s *ServiceImpl) SetPromptAddendum(ctx context.Context, req *connect.Request[brainv1.SetPromptAddendumRequest]) (*connect.Response[brainv1.SetPromptAddendumResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+	userID := user.UserID
+	// Checked here too, an unknown scope would be stored but never applied
+	switch req.Msg.Scope {
+	case addendumScopeUser:
+	case addendumScopeGlobal:
+		if err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+		userID = 0
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown scope %q, expected %q or %q", req.Msg.Scope, addendumScopeUser, addendumScopeGlobal))
+	}
+
+	text := strings.TrimSpace(req.Msg.Text)
+	if err := checkAddendum(text); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	db := s.gormDB.WithContext(ctx)
+	if text == "" {
+		if err := db.Where("scope = ? AND user_id = ?", req.Msg.Scope, userID).Delete(&commonv1.PromptAddendumORM{}).Error; err != nil {
+			return nil, dbError("failed to clear prompt addendum", err)
+		}
+	} else {
+		addendum := commonv1.PromptAddendumORM{Scope: req.Msg.Scope, UserId: userID, Text: text, UpdatedAt: time.Now().Unix()}
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "scope"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"text", "updated_at"}),
+		}).Create(&addendum).Error
+		if err != nil {
+			return nil, dbError("failed to store prompt addendum", err)
+		}
+	}
+
+	slog.Info("prompt addendum set", "scope", req.Msg.Scope, "user_id", userID, "length", utf8.RuneCountInString(text))
+	return connect.NewResponse(&brainv1.SetPromptAddendumResponse{Scope: req.Msg.Scope, Text: text}), nil
+}
+
+// GetPromptAddendum returns the addenda applied to the caller's classifications
+func (s *ServiceImpl) GetPromptAddendum(ctx context.Context, req *connect.Request[brainv1.GetPromptAddendumRequest]) (*connect.Response[brainv1.GetPromptAddendumResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	var addenda []commonv1.PromptAddendumORM
+	err := s.gormDB.WithContext(ctx).
+		Where("(scope = ? AND user_id = 0) OR (scope = ? AND user_id = ?)", addendumScopeGlobal, addendumScopeUser, user.UserID).
+		Find(&addenda).Error
+	if err != nil {
+		return nil, dbError("failed to load prompt addenda", err)
+	}
+
+	resp := &brainv1.GetPromptAddendumResponse{}
+	for _, addendum := range addenda {
+		if addendum.Scope == addendumScopeGlobal {
+			resp.GlobalText = addendum.Text
+		} else {
+			resp.UserText = addendum.Text
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
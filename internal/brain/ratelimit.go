@@ -0,0 +1,127 @@
+package brain
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// rateLimiter allows at most limit events per key within a sliding window
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	hits      map[string][]time.Time
+	lastPrune time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// rateQuota is what is left of a key's quota after an event, sent to clients
+// as X-RateLimit-* headers. The zero value means no limit.
+type rateQuota struct {
+	allowed   bool
+	limit     int
+	remaining int
+	reset     time.Time // when the oldest counted event leaves the window
+}
+
+// setHeaders sets the X-RateLimit-* headers, with the reset as a Unix time
+func (q rateQuota) setHeaders(header http.Header) {
+	if q.limit <= 0 {
+		return
+	}
+	header.Set("X-RateLimit-Limit", strconv.Itoa(q.limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(q.remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(q.reset.Unix(), 10))
+}
+
+// tighter returns whichever of q and other leaves fewer events, so a request
+// checked against several limiters reports the one it will hit first
+func (q rateQuota) tighter(other rateQuota) rateQuota {
+	switch {
+	case q.limit <= 0:
+		return other
+	case other.limit <= 0:
+		return q
+	case other.remaining < q.remaining, other.remaining == q.remaining && other.reset.After(q.reset):
+		return other
+	}
+	return q
+}
+
+// allow records an event for key and reports whether it is within the limit.
+// A non-positive limit disables limiting.
+func (l *rateLimiter) allow(key string) bool {
+	return l.take(key).allowed
+}
+
+// take records an event for key, unless it is over the limit, and returns
+// the quota left. A non-positive limit disables limiting.
+func (l *rateLimiter) take(key string) rateQuota {
+	if l.limit <= 0 {
+		return rateQuota{allowed: true}
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-l.window)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop hits that fell out of the window
+	recent := l.hits[key][:0]
+	for _, t := range l.hits[key] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= l.limit {
+		l.hits[key] = recent
+		return rateQuota{limit: l.limit, reset: recent[0].Add(l.window)}
+	}
+
+	l.hits[key] = append(recent, now)
+	l.prune(now)
+	return rateQuota{
+		allowed:   true,
+		limit:     l.limit,
+		remaining: l.limit - len(recent) - 1,
+		reset:     l.hits[key][0].Add(l.window),
+	}
+}
+
+// rateLimitedError rejects a handshake over its quota, with the quota headers
+func rateLimitedError(quota rateQuota) *connect.Error {
+	err := connect.NewError(connect.CodeResourceExhausted, errors.New("too many handshakes, retry later"))
+	quota.setHeaders(err.Meta())
+	return err
+}
+
+// prune forgets keys with no recent hits so the map doesn't grow unbounded.
+// The scan runs at most once per window, so its cost is spread over all the
+// events in between.
+func (l *rateLimiter) prune(now time.Time) {
+	if len(l.hits) < 1024 || now.Sub(l.lastPrune) < l.window {
+		return
+	}
+	l.lastPrune = now
+	cutoff := now.Add(-l.window)
+	for key, hits := range l.hits {
+		if len(hits) == 0 || !hits[len(hits)-1].After(cutoff) {
+			delete(l.hits, key)
+		}
+	}
+}
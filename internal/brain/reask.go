@@ -0,0 +1,44 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+// defaultReaskThreshold is the confidence below which a result is re-asked,
+// overridable via CLASSIFICATION_REASK_THRESHOLD
+const defaultReaskThreshold = 0.6
+
+// reaskIfUncertain asks the model a second time when the reask flag is on and
+// the first answer's confidence is below the threshold, keeping
+// the more confident of the two. A failed re-ask keeps the first answer.
+func (cs *ClassificationService) reaskIfUncertain(ctx context.Context, prompt string, contextData map[string]string, result, model string) (string, string) {
+	if !cs.flags.Enabled(ctx, flagReask) {
+		return result, model
+	}
+	first, ok := resultConfidence(result)
+	if !ok || first >= envFloat("CLASSIFICATION_REASK_THRESHOLD", defaultReaskThreshold) {
+		return result, model
+	}
+
+	second, secondModel, err := cs.callModels(ctx, prompt, contextData)
+	if err != nil {
+		slog.Warn("classification re-ask failed, keeping the first answer", "error", err)
+		return result, model
+	}
+	if confidence, ok := resultConfidence(second); ok && confidence > first {
+		slog.Debug("classification re-ask was more confident", "first", first, "second", confidence)
+		return second, secondModel
+	}
+	return result, model
+}
+
+// resultConfidence reads the confidence score of a raw model response
+func resultConfidence(result string) (float64, bool) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(result), &fields); err != nil {
+		return 0, false
+	}
+	return coerceScore(fields["confidence_score"])
+}
@@ -0,0 +1,101 @@
+package brain
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/genai"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// sequenceModels replies with the next answer on every call
+type sequenceModels struct {
+	replies []string
+	calls   int
+}
+
+func (m *sequenceModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	reply := m.replies[min(m.calls, len(m.replies)-1)]
+	m.calls++
+	return fakeModels{text: reply}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyWithCache_ReasksBelowThreshold(t *testing.T) {
+	t.Setenv("CLASSIFICATION_REASK", "true")
+	t.Setenv("CLASSIFICATION_REASK_THRESHOLD", "0.6")
+
+	unsure := `{"classification":"neutral","reasoning":"Unclear.","tags":["other"],"confidence_score":0.4}`
+	sure := `{"classification":"productive","reasoning":"A terminal.","tags":["work"],"confidence_score":0.9}`
+
+	db := newTestDB(t)
+	models := &sequenceModels{replies: []string{unsure, sure}}
+	var pending sync.WaitGroup
+	cs := &ClassificationService{db: db, models: models, model: "gemini-test", pending: &pending}
+
+	result, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"name": "Ghostty"})
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.calls != 2 {
+		t.Fatalf("expected a re-ask below the threshold, got %d calls", models.calls)
+	}
+	if result != sure {
+		t.Fatalf("expected the more confident answer, got %s", result)
+	}
+
+	// The chosen answer is what gets cached
+	pending.Wait()
+	var cached commonv1.PromptHistoryORM
+	if err := db.First(&cached).Error; err != nil {
+		t.Fatalf("expected a cached result: %v", err)
+	}
+	if cached.ResponseJson != sure {
+		t.Fatalf("expected the chosen answer to be cached, got %s", cached.ResponseJson)
+	}
+}
+
+func TestClassifyWithCache_KeepsFirstWhenReaskIsLessConfident(t *testing.T) {
+	t.Setenv("CLASSIFICATION_REASK", "true")
+
+	first := `{"classification":"neutral","reasoning":"Unclear.","tags":["other"],"confidence_score":0.5}`
+	second := `{"classification":"distracting","reasoning":"Maybe a game.","tags":["entertainment"],"confidence_score":0.3}`
+
+	models := &sequenceModels{replies: []string{first, second}}
+	cs := &ClassificationService{db: newTestDB(t), models: models, model: "gemini-test"}
+
+	result, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"name": "Mystery"})
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.calls != 2 || result != first {
+		t.Fatalf("expected the first, more confident answer after a re-ask, got %s after %d calls", result, models.calls)
+	}
+}
+
+func TestClassifyWithCache_NoReaskAboveThreshold(t *testing.T) {
+	t.Setenv("CLASSIFICATION_REASK", "true")
+
+	models := &sequenceModels{replies: []string{`{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.8}`}}
+	cs := &ClassificationService{db: newTestDB(t), models: models, model: "gemini-test"}
+
+	if _, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"name": "Code"}); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.calls != 1 {
+		t.Fatalf("expected a single call above the threshold, got %d", models.calls)
+	}
+}
+
+func TestClassifyWithCache_ReaskOffByDefault(t *testing.T) {
+	models := &sequenceModels{replies: []string{`{"classification":"neutral","reasoning":"Unclear.","tags":["other"],"confidence_score":0.1}`}}
+	cs := &ClassificationService{db: newTestDB(t), models: models, model: "gemini-test"}
+
+	if _, err := cs.classifyWithCache(context.Background(), promptDesktop, map[string]string{"name": "Mystery"}); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.calls != 1 {
+		t.Fatalf("expected no re-ask unless enabled, got %d calls", models.calls)
+	}
+}
@@ -0,0 +1,109 @@
+package brain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+const (
+	// defaultMaxRecentActivityLength caps the recent activity sent to the
+	// model, overridable via CLASSIFICATION_MAX_RECENT_ACTIVITY
+	defaultMaxRecentActivityLength = 300
+	// defaultRecentActivityWindow is how far back a summary derived from the
+	// focus session looks, overridable via CLASSIFICATION_RECENT_ACTIVITY_WINDOW
+	defaultRecentActivityWindow = time.Hour
+)
+
+// cleanRecentActivity collapses the whitespace of a client's summary and
+// trims it to CLASSIFICATION_MAX_RECENT_ACTIVITY
+func cleanRecentActivity(activity string) string {
+	activity = strings.Join(strings.Fields(activity), " ")
+	return truncateRunes(activity, envInt("CLASSIFICATION_MAX_RECENT_ACTIVITY", defaultMaxRecentActivityLength))
+}
+
+// recentActivity returns the client's summary of what the user was just
+// doing or, when it sent none and the recent_activity flag is on, one derived
+// from the user's open focus session. Failures are logged and leave it empty.
+func (s *ServiceImpl) recentActivity(ctx context.Context, client string) string {
+	if activity := cleanRecentActivity(client); activity != "" {
+		return activity
+	}
+	if !s.flags.Enabled(ctx, flagRecentActivity) {
+		return ""
+	}
+	activity, err := s.focusActivity(ctx, time.Now())
+	if err != nil {
+		slog.Warn("failed to summarize recent activity", "error", err)
+		return ""
+	}
+	return cleanRecentActivity(activity)
+}
+
+// focusActivity summarizes the classifications of the user's open focus
+// session within CLASSIFICATION_RECENT_ACTIVITY_WINDOW, e.g. "In a focus
+// session for 55 minutes. Last 55 minutes: 40 productive, 2 neutral. Most
+// recently productive, 1 minute ago." It is "" outside a focus session.
+func (s *ServiceImpl) focusActivity(ctx context.Context, now time.Time) (string, error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return "", nil
+	}
+	session, err := s.openFocusSession(ctx, user.UserID, now)
+	if err != nil || session.Id == 0 {
+		return "", err
+	}
+
+	since := max(session.StartedAt, now.Add(-envDuration("CLASSIFICATION_RECENT_ACTIVITY_WINDOW", defaultRecentActivityWindow)).Unix())
+	var counts []struct {
+		Classification string
+		Count          int
+	}
+	err = s.gormDB.WithContext(ctx).Model(&commonv1.FocusSessionEventORM{}).
+		Select("classification, COUNT(*) AS count").
+		Where("session_id = ? AND created_at >= ?", session.Id, since).
+		Group("classification").
+		Scan(&counts).Error
+	if err != nil {
+		return "", err
+	}
+	var latest commonv1.FocusSessionEventORM
+	err = s.gormDB.WithContext(ctx).
+		Where("session_id = ? AND created_at >= ?", session.Id, since).
+		Order("created_at DESC, id DESC").
+		Limit(1).
+		Find(&latest).Error
+	if err != nil {
+		return "", err
+	}
+
+	summary := fmt.Sprintf("In a focus session for %s.", formatMinutes(now.Unix()-session.StartedAt))
+	if latest.Id == 0 {
+		return summary, nil
+	}
+	byClassification := make(map[string]int, len(counts))
+	for _, c := range counts {
+		byClassification[c.Classification] = c.Count
+	}
+	var parts []string
+	for _, classification := range []string{"productive", "supporting", "neutral", "distracting"} {
+		if n := byClassification[classification]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, classification))
+		}
+	}
+	return fmt.Sprintf("%s Last %s: %s. Most recently %s, %s ago.",
+		summary, formatMinutes(now.Unix()-since), strings.Join(parts, ", "), latest.Classification, formatMinutes(now.Unix()-latest.CreatedAt)), nil
+}
+
+// formatMinutes formats a number of seconds as whole minutes, e.g. "1 minute"
+func formatMinutes(seconds int64) string {
+	if minutes := seconds / 60; minutes != 1 {
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+	return "1 minute"
+}
@@ -0,0 +1,150 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genai"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// activityModels reads a quick distraction after productive work as a break,
+// like the prompt's recent activity example
+type activityModels struct {
+	calls int
+	input map[string]string
+}
+
+func (m *activityModels) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	m.calls++
+	m.input = nil
+	if err := json.Unmarshal([]byte(contents[0].Parts[0].Text), &m.input); err != nil {
+		return nil, err
+	}
+	if strings.Contains(m.input["recent_activity"], "productive") {
+		return fakeModels{text: `{"classification":"neutral","reasoning":"A short break after focused work.","tags":["social-media"],"confidence_score":0.7}`}.GenerateContent(ctx, model, contents, config)
+	}
+	return fakeModels{text: `{"classification":"distracting","reasoning":"Social media.","tags":["social-media"],"confidence_score":0.8}`}.GenerateContent(ctx, model, contents, config)
+}
+
+func TestClassifyApplication_RecentActivityInfluencesOutcome(t *testing.T) {
+	req := func(activity string) *connect.Request[brainv1.ClassifyApplicationRequest] {
+		return connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: "Google Chrome",
+			WindowTitle:     "r/golang - Reddit",
+			RecentActivity:  activity,
+		})
+	}
+
+	without := newPolicyTestService(t, &activityModels{})
+	resp, err := without.ClassifyApplication(context.Background(), req(""))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := resp.Msg.Classification.Classification; got != "distracting" {
+		t.Fatalf("expected Reddit alone to be distracting, got %s", got)
+	}
+
+	models := &activityModels{}
+	with := newPolicyTestService(t, models)
+	resp, err = with.ClassifyApplication(context.Background(), req("  coding in VS Code,\n 55 productive minutes  "))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.input["recent_activity"] != "coding in VS Code, 55 productive minutes" {
+		t.Fatalf("expected the cleaned recent activity in the model input, got %v", models.input)
+	}
+	if got := resp.Msg.Classification.Classification; got != "neutral" {
+		t.Fatalf("expected Reddit after focused work to read as a break, got %s", got)
+	}
+
+	// The answer was shaped by one user's activity, so it is never served to others
+	with.pendingStores.Wait()
+	resp, err = with.ClassifyApplication(context.Background(), req(""))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if models.calls != 2 {
+		t.Fatalf("expected the request without activity to reach the model, got %d model calls", models.calls)
+	}
+	if got := resp.Msg.Classification.Classification; got != "distracting" {
+		t.Fatalf("expected Reddit alone to be distracting, got %s", got)
+	}
+}
+
+func TestClassifyWebsite_DerivesRecentActivityFromFocusSession(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+	t.Setenv("CLASSIFICATION_RECENT_ACTIVITY", "true")
+	models := &activityModels{}
+	svc := newPolicyTestService(t, models)
+
+	now := time.Now()
+	session := commonv1.FocusSessionORM{UserId: 7, StartedAt: now.Add(-70 * time.Minute).Unix()}
+	if err := svc.gormDB.Create(&session).Error; err != nil {
+		t.Fatalf("failed to seed focus session: %v", err)
+	}
+	for _, event := range []commonv1.FocusSessionEventORM{
+		{SessionId: session.Id, Kind: "website", Classification: "distracting", CreatedAt: now.Add(-65 * time.Minute).Unix()},
+		{SessionId: session.Id, Kind: "application", Classification: "productive", CreatedAt: now.Add(-40 * time.Minute).Unix()},
+		{SessionId: session.Id, Kind: "application", Classification: "productive", CreatedAt: now.Add(-20 * time.Minute).Unix()},
+		{SessionId: session.Id, Kind: "website", Classification: "supporting", CreatedAt: now.Add(-2 * time.Minute).Unix()},
+	} {
+		if err := svc.gormDB.Create(&event).Error; err != nil {
+			t.Fatalf("failed to seed focus event: %v", err)
+		}
+	}
+
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	resp, err := svc.ClassifyWebsite(ctx, connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "https://reddit.example.invalid/r/golang", Title: "r/golang"}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	want := "In a focus session for 70 minutes. Last 60 minutes: 2 productive, 1 supporting. Most recently supporting, 2 minutes ago."
+	if got := models.input["recent_activity"]; got != want {
+		t.Fatalf("expected the focus session summary\n%q, got\n%q", want, got)
+	}
+	if got := resp.Msg.Classification.Classification; got != "neutral" {
+		t.Fatalf("expected the summary to read the visit as a break, got %s", got)
+	}
+
+	var count int64
+	svc.pendingStores.Wait()
+	svc.gormDB.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected a result shaped by recent activity not to be cached, got %d rows", count)
+	}
+}
+
+func TestRecentActivity_OffWithoutFlagOrSession(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	session := commonv1.FocusSessionORM{UserId: 7, StartedAt: time.Now().Add(-time.Minute).Unix()}
+	if err := svc.gormDB.Create(&session).Error; err != nil {
+		t.Fatalf("failed to seed focus session: %v", err)
+	}
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	if got := svc.recentActivity(user, ""); got != "" {
+		t.Fatalf("expected no derived summary with the flag off, got %q", got)
+	}
+
+	t.Setenv("CLASSIFICATION_RECENT_ACTIVITY", "true")
+	svc = NewServiceImpl(svc.gormDB)
+	if got := svc.recentActivity(user, ""); got != "In a focus session for 1 minute." {
+		t.Fatalf("expected a summary of the empty session, got %q", got)
+	}
+	other := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 8})
+	if got := svc.recentActivity(other, ""); got != "" {
+		t.Fatalf("expected no summary outside a focus session, got %q", got)
+	}
+
+	t.Setenv("CLASSIFICATION_MAX_RECENT_ACTIVITY", "6")
+	if got := svc.recentActivity(other, "coding  for an hour"); got != "coding" {
+		t.Fatalf("expected the client summary trimmed to 6 runes, got %q", got)
+	}
+}
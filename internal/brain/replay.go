@@ -0,0 +1,137 @@
+package brain
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// defaultReplayLimit is how many entries a replay covers when the request sets no limit
+const defaultReplayLimit = 50
+
+// kindPrompt returns the base prompt of a classifier, "" for unknown kinds
+func kindPrompt(kind string) string {
+	for _, src := range taxonomySources {
+		if src.source == kind {
+			return src.prompt
+		}
+	}
+	return ""
+}
+
+// replayEntry classifies a cached input again with the current prompt,
+// bypassing the cache, and caches the new answer when write is set
+func (cs *ClassificationService) replayEntry(ctx context.Context, entry commonv1.PromptHistoryORM, write bool) (string, error) {
+	var keyData map[string]string
+	if err := json.Unmarshal([]byte(entry.Input), &keyData); err != nil {
+		return "", err
+	}
+	base := kindPrompt(entry.Kind)
+	prompt := cs.fullPrompt(ctx, base, keyData)
+
+	result, model, err := cs.callModels(ctx, prompt, keyData)
+	if err != nil {
+		return "", err
+	}
+	if model != cs.model {
+		result = markModel(result, model)
+	}
+	if !write {
+		return result, nil
+	}
+
+	replayed := newCacheEntry(generateCacheKey(cs.variant.cacheScope()+cs.model+":"+prompt, keyData), result, cacheTTL(ctx))
+	replayed.SimilarityKey = similarityKey(base, keyData)
+	replayed.Kind = entry.Kind
+	replayed.Input = entry.Input
+	return result, cs.storeEntry(replayed)
+}
+
+// cachedClassification reads the classification of a cached answer
+func cachedClassification(response string) (string, error) {
+	var result struct {
+		Classification string `json:"classification"`
+	}
+	if err := parseClassification(response, &result); err != nil {
+		return "", err
+	}
+	return result.Classification, nil
+}
+
+// ReplayClassifications classifies recent cache entries again and reports the differences
+func (s *ServiceImpl) ReplayClassifications(ctx context.Context, req *connect.Request[brainv1.ReplayClassificationsRequest]) (*connect.Response[brainv1.ReplayClassificationsResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	query := s.gormDB.WithContext(ctx).Where("kind <> '' AND input <> ''")
+	if req.Msg.Kind != "" {
+		query = query.Where("kind = ?", req.Msg.Kind)
+	}
+	if req.Msg.SinceUnix > 0 {
+		query = query.Where("created_at >= ?", req.Msg.SinceUnix)
+	}
+	var entries []commonv1.PromptHistoryORM
+	limit := cmp.Or(int(req.Msg.Limit), defaultReplayLimit)
+	if err := query.Order("created_at DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, dbError("failed to load cache entries", err)
+	}
+
+	cs, err := s.classificationService(controlVariant())
+	if err != nil {
+		return nil, classificationError("classification service error", err)
+	}
+	// Replays use the global addenda only, never the admin's own
+	replayCtx := auth.WithUser(ctx, &auth.UserClaims{})
+
+	resp := &brainv1.ReplayClassificationsResponse{}
+	transitions := map[[2]string]int64{}
+	for _, entry := range entries {
+		before, err := cachedClassification(entry.ResponseJson)
+		if err != nil {
+			slog.Warn("unreadable cache entry skipped in replay", "kind", entry.Kind, "error", err)
+			resp.Failed++
+			continue
+		}
+
+		result, err := cs.replayEntry(replayCtx, entry, req.Msg.WriteCache)
+		if ctx.Err() != nil {
+			return nil, classificationError("replay interrupted", ctx.Err())
+		}
+		var after string
+		if err == nil {
+			after, err = cachedClassification(result)
+		}
+		if err != nil {
+			slog.Warn("replay failed", "kind", entry.Kind, "error", err)
+			resp.Failed++
+			continue
+		}
+
+		resp.Replayed++
+		if after == before {
+			continue
+		}
+		resp.Changed++
+		transitions[[2]string{before, after}]++
+		resp.Changes = append(resp.Changes, &brainv1.ReplayChange{Kind: entry.Kind, Input: entry.Input, From: before, To: after})
+	}
+
+	for transition, count := range transitions {
+		resp.Transitions = append(resp.Transitions, &brainv1.ReplayTransition{From: transition[0], To: transition[1], Count: count})
+	}
+	slices.SortFunc(resp.Transitions, func(a, b *brainv1.ReplayTransition) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+
+	slog.Info("classifications replayed", "kind", req.Msg.Kind, "replayed", resp.Replayed, "changed", resp.Changed, "failed", resp.Failed, "write_cache", req.Msg.WriteCache)
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,77 @@
+package brain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestReplayClassifications(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"distracting","reasoning":"A feed.","tags":["social-media"],"confidence_score":0.9}`})
+	db := svc.gormDB
+
+	now := time.Now().Unix()
+	productive := `{"classification":"productive","reasoning":"Docs.","tags":["work"],"confidence_score":0.8}`
+	seed := []commonv1.PromptHistoryORM{
+		{PromptHash: "site-1", Kind: "website", Input: `{"domain":"news.example.invalid","url":"https://news.example.invalid/"}`, ResponseJson: productive, CreatedAt: now},
+		{PromptHash: "site-2", Kind: "website", Input: `{"domain":"feed.example.invalid","url":"https://feed.example.invalid/"}`, ResponseJson: productive, CreatedAt: now - 1},
+		{PromptHash: "app-1", Kind: "application", Input: `{"name":"Slack"}`, ResponseJson: `{"classification":"distracting","reasoning":"Chat.","tags":["communication"],"confidence_score":0.7}`, CreatedAt: now - 2},
+		{PromptHash: "broken", Kind: "website", Input: `{"domain":"broken.example.invalid"}`, ResponseJson: "not json", CreatedAt: now - 3},
+		{PromptHash: "no-input", Kind: "website", ResponseJson: productive, CreatedAt: now - 4},
+		{PromptHash: "old", Kind: "website", Input: `{"domain":"old.example.invalid"}`, ResponseJson: productive, CreatedAt: now - 3600},
+	}
+	for _, entry := range seed {
+		entry.ExpiresAt = now + 3600
+		if err := db.Create(&entry).Error; err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+	}
+
+	if _, err := svc.ReplayClassifications(auth.WithUser(context.Background(), &auth.UserClaims{UserID: 2}), connect.NewRequest(&brainv1.ReplayClassificationsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected a non-admin to be denied, got %v", err)
+	}
+
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	resp, err := svc.ReplayClassifications(admin, connect.NewRequest(&brainv1.ReplayClassificationsRequest{SinceUnix: now - 60}))
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if resp.Msg.Replayed != 3 || resp.Msg.Changed != 2 || resp.Msg.Failed != 1 {
+		t.Fatalf("expected 3 replayed, 2 changed and 1 failed, got %+v", resp.Msg)
+	}
+	if len(resp.Msg.Transitions) != 1 || resp.Msg.Transitions[0].From != "productive" || resp.Msg.Transitions[0].To != "distracting" || resp.Msg.Transitions[0].Count != 2 {
+		t.Fatalf("expected one productive to distracting transition, got %v", resp.Msg.Transitions)
+	}
+	if len(resp.Msg.Changes) != 2 || resp.Msg.Changes[0].Input != seed[0].Input {
+		t.Fatalf("expected the changes newest first, got %v", resp.Msg.Changes)
+	}
+
+	var count int64
+	db.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	var stored commonv1.PromptHistoryORM
+	db.First(&stored, "prompt_hash = ?", "site-1")
+	if count != int64(len(seed)) || stored.ResponseJson != productive {
+		t.Fatalf("expected a read-only replay to leave the cache alone, got %d entries and %q", count, stored.ResponseJson)
+	}
+
+	resp, err = svc.ReplayClassifications(admin, connect.NewRequest(&brainv1.ReplayClassificationsRequest{Kind: "application", WriteCache: true}))
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if resp.Msg.Replayed != 1 || resp.Msg.Changed != 0 {
+		t.Fatalf("expected the application entry to replay unchanged, got %+v", resp.Msg)
+	}
+	var written commonv1.PromptHistoryORM
+	if err := db.Where("kind = ? AND prompt_hash <> ?", "application", "app-1").First(&written).Error; err != nil {
+		t.Fatalf("expected write_cache to store the replayed answer: %v", err)
+	}
+	if written.Input != seed[2].Input || written.SimilarityKey == "" {
+		t.Fatalf("expected the replayed entry to keep its input and similarity key, got %+v", written)
+	}
+}
@@ -0,0 +1,54 @@
+package brain
+
+import (
+	"errors"
+	"log/slog"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// readRouter sends cache reads to read replicas in round-robin order while
+// writes stay on the primary. A failing replica falls back to the primary.
+type readRouter struct {
+	primary  *gorm.DB
+	replicas []*gorm.DB
+	next     atomic.Uint64
+}
+
+func newReadRouter(primary *gorm.DB, replicas []*gorm.DB) *readRouter {
+	return &readRouter{primary: primary, replicas: replicas}
+}
+
+// replica picks the next replica, or the primary when none are configured
+func (r *readRouter) replica() *gorm.DB {
+	if len(r.replicas) == 0 {
+		return r.primary
+	}
+	n := r.next.Add(1) - 1
+	return r.replicas[n%uint64(len(r.replicas))]
+}
+
+// read runs fn against a replica and retries on the primary if the replica
+// errors. A missing row is an answer rather than a failure: at worst replica
+// lag turns a cache hit into a miss.
+func (r *readRouter) read(fn func(db *gorm.DB) error) error {
+	db := r.replica()
+	err := fn(db)
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) || db == r.primary {
+		return err
+	}
+
+	slog.Warn("read replica query failed, falling back to primary", "error", err)
+	return fn(r.primary)
+}
+
+// UseReadReplicas routes classification cache lookups to the given replicas,
+// writes keep going to the primary passed to NewServiceImpl
+func (s *ServiceImpl) UseReadReplicas(replicas ...*gorm.DB) {
+	if len(replicas) == 0 {
+		s.reads = nil
+		return
+	}
+	s.reads = newReadRouter(s.gormDB, replicas)
+}
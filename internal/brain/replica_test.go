@@ -0,0 +1,108 @@
+package brain
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+func seedCacheEntry(t *testing.T, db *gorm.DB, hash, response string) {
+	t.Helper()
+	if err := db.Create(&commonv1.PromptHistoryORM{
+		PromptHash: hash, ResponseJson: response, ExpiresAt: time.Now().Add(time.Hour).Unix(), LastAccessed: 1,
+	}).Error; err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+}
+
+func countCacheEntries(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(&commonv1.PromptHistoryORM{}).Count(&n).Error; err != nil {
+		t.Fatalf("failed to count cache: %v", err)
+	}
+	return n
+}
+
+func TestReadRouter_ReadsFromReplicaWritesToPrimary(t *testing.T) {
+	primary, replica := newTestDB(t), newTestDB(t)
+	seedCacheEntry(t, primary, "hit", `"primary"`)
+	seedCacheEntry(t, replica, "hit", `"replica"`)
+
+	cs := &ClassificationService{db: primary, reads: newReadRouter(primary, []*gorm.DB{replica})}
+
+	got, err := cs.getFromCache("hit")
+	if err != nil {
+		t.Fatalf("expected cache hit: %v", err)
+	}
+	if got != `"replica"` {
+		t.Fatalf("expected the read to hit the replica, got %s", got)
+	}
+
+	if err := cs.storeInCache("fresh", `"fresh"`, time.Hour); err != nil {
+		t.Fatalf("failed to store: %v", err)
+	}
+	if n := countCacheEntries(t, primary); n != 2 {
+		t.Fatalf("expected the new entry on the primary, got %d rows", n)
+	}
+	if n := countCacheEntries(t, replica); n != 1 {
+		t.Fatalf("expected no writes to the replica, got %d rows", n)
+	}
+}
+
+func TestReadRouter_RoundRobin(t *testing.T) {
+	primary, first, second := newTestDB(t), newTestDB(t), newTestDB(t)
+	seedCacheEntry(t, first, "hit", `"first"`)
+	seedCacheEntry(t, second, "hit", `"second"`)
+
+	cs := &ClassificationService{db: primary, reads: newReadRouter(primary, []*gorm.DB{first, second})}
+
+	var got []string
+	for range 4 {
+		response, err := cs.getFromCache("hit")
+		if err != nil {
+			t.Fatalf("expected cache hit: %v", err)
+		}
+		got = append(got, response)
+	}
+
+	want := []string{`"first"`, `"second"`, `"first"`, `"second"`}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected reads %v, got %v", want, got)
+		}
+	}
+}
+
+func TestReadRouter_FallsBackToPrimaryOnReplicaError(t *testing.T) {
+	primary, replica := newTestDB(t), newTestDB(t)
+	seedCacheEntry(t, primary, "hit", `"primary"`)
+
+	sqlDB, err := replica.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql db: %v", err)
+	}
+	sqlDB.Close()
+
+	cs := &ClassificationService{db: primary, reads: newReadRouter(primary, []*gorm.DB{replica})}
+	got, err := cs.getFromCache("hit")
+	if err != nil {
+		t.Fatalf("expected fallback to the primary: %v", err)
+	}
+	if got != `"primary"` {
+		t.Fatalf("expected the primary's entry, got %s", got)
+	}
+}
+
+func TestReadRouter_MissOnReplicaIsNotRetried(t *testing.T) {
+	primary, replica := newTestDB(t), newTestDB(t)
+	seedCacheEntry(t, primary, "lagging", `"primary"`)
+
+	cs := &ClassificationService{db: primary, reads: newReadRouter(primary, []*gorm.DB{replica})}
+	if _, err := cs.getFromCache("lagging"); err == nil {
+		t.Fatal("expected a replica miss to be reported as a miss")
+	}
+}
@@ -0,0 +1,143 @@
+package brain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Schema modes for parsing model output, selected via CLASSIFICATION_SCHEMA_MODE
+const (
+	schemaModeStrict  = "strict"
+	schemaModeLenient = "lenient"
+)
+
+// classificationLabels are the only classifications the prompts allow
+var classificationLabels = map[string]bool{
+	"productive":  true,
+	"supporting":  true,
+	"neutral":     true,
+	"distracting": true,
+}
+
+// requiredClassificationFields must be present in every model response in strict mode
+var requiredClassificationFields = []string{"classification", "reasoning", "tags", "confidence_score"}
+
+// parseClassification decodes a model response into out. Lenient mode, the
+// default, coerces near-misses such as a quoted score or a tag string; strict
+// mode rejects any deviation from the schema.
+func parseClassification(result string, out any) error {
+	if envString("CLASSIFICATION_SCHEMA_MODE", schemaModeLenient) == schemaModeStrict {
+		return parseClassificationStrict(result, out)
+	}
+	return parseClassificationLenient(result, out)
+}
+
+func parseClassificationStrict(result string, out any) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result), &fields); err != nil {
+		return err
+	}
+	for _, name := range requiredClassificationFields {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("missing field %q", name)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(result)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return err
+	}
+
+	var values struct {
+		Classification  string  `json:"classification"`
+		ConfidenceScore float64 `json:"confidence_score"`
+	}
+	if err := json.Unmarshal([]byte(result), &values); err != nil {
+		return err
+	}
+	if !classificationLabels[values.Classification] {
+		return fmt.Errorf("unknown classification %q", values.Classification)
+	}
+	if values.ConfidenceScore < 0 || values.ConfidenceScore > 1 {
+		return fmt.Errorf("confidence_score %v out of range", values.ConfidenceScore)
+	}
+	return nil
+}
+
+func parseClassificationLenient(result string, out any) error {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(result), &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return errors.New("classification is not an object")
+	}
+
+	if value, ok := fields["classification"].(string); ok {
+		fields["classification"] = strings.ToLower(strings.TrimSpace(value))
+	}
+	if _, ok := fields["reasoning"].(string); !ok {
+		delete(fields, "reasoning")
+	}
+	fields["tags"] = coerceTags(fields["tags"])
+	if score, ok := coerceScore(fields["confidence_score"]); ok {
+		fields["confidence_score"] = score
+	} else {
+		delete(fields, "confidence_score")
+	}
+	if _, ok := fields["candidates"]; ok {
+		fields["candidates"] = coerceCandidates(fields["candidates"])
+	}
+	for _, name := range []string{"detected_project", "detected_communication_channel"} {
+		if value, ok := fields[name].(string); !ok || strings.TrimSpace(value) == "" {
+			delete(fields, name)
+		}
+	}
+
+	cleaned, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(cleaned, out)
+}
+
+// coerceTags accepts a list of tags or a single comma-separated string,
+// dropping anything that isn't a string
+func coerceTags(value any) []string {
+	switch tags := value.(type) {
+	case string:
+		return strings.Split(tags, ",")
+	case []any:
+		var out []string
+		for _, tag := range tags {
+			if tag, ok := tag.(string); ok {
+				out = append(out, tag)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// coerceScore accepts a number or a numeric string and clamps it to 0..1
+func coerceScore(value any) (float64, bool) {
+	var score float64
+	switch v := value.(type) {
+	case float64:
+		score = v
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		score = parsed
+	default:
+		return 0, false
+	}
+	return min(max(score, 0), 1), true
+}
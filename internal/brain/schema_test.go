@@ -0,0 +1,89 @@
+package brain
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// A response the model plausibly returns: odd casing, a quoted score, tags as
+// one string, an empty optional field and an extra key
+const malformedClassification = `{"classification":"Productive ","reasoning":"Editing Go code.","tags":"work, code-editor","confidence_score":"0.8","detected_project":"","mood":"focused"}`
+
+func TestParseClassification_LenientCoerces(t *testing.T) {
+	var classification ClassificationResult
+	if err := parseClassification(malformedClassification, &classification); err != nil {
+		t.Fatalf("expected lenient parsing to succeed: %v", err)
+	}
+
+	if classification.Classification != "productive" || classification.ConfidenceScore != 0.8 {
+		t.Fatalf("unexpected classification %+v", classification)
+	}
+	if tags := normalizeTags(classification.Tags); !slices.Equal(tags, []string{"work", "code-editor"}) {
+		t.Fatalf("expected the tag string to be split, got %v", tags)
+	}
+	if classification.DetectedProject != nil {
+		t.Fatalf("expected an empty project to be dropped, got %q", *classification.DetectedProject)
+	}
+}
+
+func TestParseClassification_LenientClampsScore(t *testing.T) {
+	var classification WebsiteClassificationResult
+	if err := parseClassification(`{"classification":"neutral","reasoning":"","tags":[],"confidence_score":7}`, &classification); err != nil {
+		t.Fatalf("expected lenient parsing to succeed: %v", err)
+	}
+	if classification.ConfidenceScore != 1 {
+		t.Fatalf("expected the score to be clamped to 1, got %v", classification.ConfidenceScore)
+	}
+}
+
+func TestParseClassification_StrictRejectsDeviations(t *testing.T) {
+	t.Setenv("CLASSIFICATION_SCHEMA_MODE", "strict")
+
+	for name, response := range map[string]string{
+		"malformed":     malformedClassification,
+		"extra key":     `{"classification":"productive","reasoning":"Coding.","tags":["work"],"confidence_score":0.8,"mood":"focused"}`,
+		"missing field": `{"classification":"productive","reasoning":"Coding.","confidence_score":0.8}`,
+		"unknown label": `{"classification":"great","reasoning":"Coding.","tags":["work"],"confidence_score":0.8}`,
+		"score range":   `{"classification":"productive","reasoning":"Coding.","tags":["work"],"confidence_score":1.5}`,
+	} {
+		var classification ClassificationResult
+		if err := parseClassification(response, &classification); err == nil {
+			t.Errorf("%s: expected strict parsing to fail", name)
+		}
+	}
+
+	var classification ClassificationResult
+	valid := `{"classification":"productive","reasoning":"Coding.","tags":["work"],"detected_project":"focusd","detected_communication_channel":null,"confidence_score":0.8}`
+	if err := parseClassification(valid, &classification); err != nil {
+		t.Fatalf("expected a well-formed response to pass strict parsing: %v", err)
+	}
+}
+
+func TestClassifyApplication_StrictSchemaMode(t *testing.T) {
+	t.Setenv("CLASSIFICATION_SCHEMA_MODE", "strict")
+
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, fakeModels{text: malformedClassification})
+
+	_, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName: "Visual Studio Code",
+		WindowTitle:     "main.go",
+	}))
+	if connect.CodeOf(err) != connect.CodeInternal {
+		t.Fatalf("expected strict mode to reject the response, got %v", err)
+	}
+
+	// The rejected response is not cached, a retry reaches the model again
+	var count int64
+	svc.pendingStores.Wait()
+	svc.gormDB.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected the rejected response not to be cached, got %d rows", count)
+	}
+}
@@ -0,0 +1,29 @@
+package brain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// defaultMaxScreenTextLength caps the on-screen text sent to the model, in
+// runes, overridable via CLASSIFICATION_MAX_SCREEN_TEXT. 0 never sends it.
+const defaultMaxScreenTextLength = 1000
+
+var (
+	screenTextEmail  = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	screenTextDigits = regexp.MustCompile(`\d[\d -]{4,}\d`)
+)
+
+// screenText prepares OCR'd on-screen text for the prompt: email addresses and
+// long digit runs such as phone or card numbers are masked, whitespace is
+// collapsed and the result is cut to CLASSIFICATION_MAX_SCREEN_TEXT runes.
+func screenText(text string) string {
+	maxLength := envInt("CLASSIFICATION_MAX_SCREEN_TEXT", defaultMaxScreenTextLength)
+	if maxLength <= 0 {
+		return ""
+	}
+
+	text = screenTextEmail.ReplaceAllString(text, "[email]")
+	text = screenTextDigits.ReplaceAllString(text, "[number]")
+	return truncateRunes(strings.Join(strings.Fields(text), " "), maxLength)
+}
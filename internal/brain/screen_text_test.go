@@ -0,0 +1,68 @@
+package brain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+func TestClassifyApplication_ScreenTextReachesPrompt(t *testing.T) {
+	recorder := &inputRecorder{text: `{"classification":"productive","reasoning":"Reviewing a pull request.","tags":["work"],"confidence_score":0.9}`}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, recorder)
+
+	req := &brainv1.ClassifyApplicationRequest{
+		ApplicationName: "Google Chrome",
+		WindowTitle:     "Pull Request #42",
+		ScreenText:      "Files changed\n\n  Approve   changes  by jane@example.com, call +1 555 123 4567",
+	}
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(req)); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := recorder.input["screen_text"]; got != "Files changed Approve changes by [email], call +[number]" {
+		t.Fatalf("expected cleaned screen text in the prompt, got %q", got)
+	}
+
+	// The verdict depends on the screen, so it is never served for another one
+	svc.pendingStores.Wait()
+	req.ScreenText = "Slack huddle, 3 participants"
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(req)); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if got := recorder.input["screen_text"]; got != "Slack huddle, 3 participants" {
+		t.Fatalf("expected the second screen to reach the model, got %q", got)
+	}
+	var count int64
+	svc.gormDB.Model(&commonv1.PromptHistoryORM{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected results classified with screen text not to be cached, got %d rows", count)
+	}
+
+	// Without it nothing is sent
+	if _, contextData := applicationContext(&brainv1.ClassifyApplicationRequest{ApplicationName: "Google Chrome"}); contextData["screen_text"] != "" {
+		t.Fatalf("expected no screen text, got %q", contextData["screen_text"])
+	}
+}
+
+func TestScreenText_LengthLimited(t *testing.T) {
+	long := strings.Repeat("ä word ", 1000)
+	if got := screenText(long); utf8.RuneCountInString(got) != defaultMaxScreenTextLength {
+		t.Fatalf("expected %d runes, got %d", defaultMaxScreenTextLength, utf8.RuneCountInString(got))
+	}
+
+	t.Setenv("CLASSIFICATION_MAX_SCREEN_TEXT", "10")
+	if got := screenText(long); got != "ä word ä w" {
+		t.Fatalf("unexpected truncation %q", got)
+	}
+
+	t.Setenv("CLASSIFICATION_MAX_SCREEN_TEXT", "0")
+	if got := screenText(long); got != "" {
+		t.Fatalf("expected screen text to be disabled, got %q", got)
+	}
+}
@@ -0,0 +1,96 @@
+package brain
+
+import (
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// searchEngines maps a search engine host to the query parameter holding the terms
+var searchEngines = map[string]string{
+	"google.com":       "q",
+	"bing.com":         "q",
+	"duckduckgo.com":   "q",
+	"search.brave.com": "q",
+	"kagi.com":         "q",
+	"ecosia.org":       "q",
+	"search.yahoo.com": "p",
+}
+
+// searchTitleSuffixes end the window title of a browser showing search results
+var searchTitleSuffixes = []string{" - google search", " - bing", " at duckduckgo", " - brave search", " - kagi search"}
+
+// workSearchTerms returns the terms that make an ambiguous search count as
+// work, configured via CLASSIFICATION_WORK_SEARCH_TERMS as a comma separated
+// list. Unset, searches stay neutral as the prompts say.
+func workSearchTerms() []string {
+	var terms []string
+	for _, term := range strings.Split(envString("CLASSIFICATION_WORK_SEARCH_TERMS", ""), ",") {
+		if term = normalizeSearch(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// searchQuery extracts the search terms from a search engine url or a
+// browser's search results title, "" when the input is not a search
+func searchQuery(contextData map[string]string) string {
+	if rawURL := contextData["url"]; rawURL != "" {
+		if u, err := parseWebsiteURL(rawURL); err == nil {
+			host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+			if param, ok := searchEngines[host]; ok {
+				return u.Query().Get(param)
+			}
+		}
+	}
+
+	title := strings.ToLower(strings.TrimSpace(contextData["title"]))
+	for _, suffix := range searchTitleSuffixes {
+		if query, ok := strings.CutSuffix(title, suffix); ok {
+			return query
+		}
+	}
+	return ""
+}
+
+// workSearchTerm returns the first configured work term found in the search,
+// matched on whole words
+func workSearchTerm(contextData map[string]string) string {
+	terms := workSearchTerms()
+	if len(terms) == 0 {
+		return ""
+	}
+	query := normalizeSearch(searchQuery(contextData))
+	if query == "" {
+		return ""
+	}
+	query = " " + query + " "
+	for _, term := range terms {
+		if strings.Contains(query, " "+term+" ") {
+			return term
+		}
+	}
+	return ""
+}
+
+// biasAmbiguousSearch turns a neutral search into supporting work when it
+// contains a configured work term. Confident labels are left alone.
+func biasAmbiguousSearch(classification string, contextData map[string]string) string {
+	if classification != "neutral" {
+		return classification
+	}
+	term := workSearchTerm(contextData)
+	if term == "" {
+		return classification
+	}
+	slog.Debug("ambiguous search biased towards work", "term", term)
+	return "supporting"
+}
+
+// normalizeSearch lowercases s and collapses punctuation into single spaces
+func normalizeSearch(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}), " ")
+}
@@ -0,0 +1,78 @@
+package brain
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestClassifyApplication_WorkSearchBias(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, fakeModels{text: `{"classification":"neutral","reasoning":"A generic search.","tags":["other"],"confidence_score":0.6}`})
+
+	classify := func(title string) *brainv1.ClassificationResult {
+		t.Helper()
+		resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+			ApplicationName: "Google Chrome",
+			WindowTitle:     title,
+		}))
+		if err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		return resp.Msg.GetClassification()
+	}
+
+	// Searches stay neutral by default
+	if got := classify("kubernetes crashloopbackoff - Google Search").GetClassification(); got != "neutral" {
+		t.Fatalf("expected neutral without work terms, got %q", got)
+	}
+
+	t.Setenv("CLASSIFICATION_WORK_SEARCH_TERMS", "Kubernetes, stack trace")
+
+	result := classify("kubernetes pod crashloopbackoff - Google Search")
+	if result.GetClassification() != "supporting" {
+		t.Fatalf("expected a technical search to be supporting, got %q", result.GetClassification())
+	}
+	if !slices.Contains(result.GetSignals(), "search contains work term kubernetes") {
+		t.Fatalf("expected a work term signal, got %v", result.GetSignals())
+	}
+
+	if got := classify("best pizza near me - Google Search").GetClassification(); got != "neutral" {
+		t.Fatalf("expected other searches to stay neutral, got %q", got)
+	}
+}
+
+func TestWorkSearchTerm(t *testing.T) {
+	t.Setenv("CLASSIFICATION_WORK_SEARCH_TERMS", "golang,stack trace,c++")
+
+	cases := []struct {
+		input map[string]string
+		want  string
+	}{
+		{map[string]string{"url": "https://www.google.com/search?q=golang+generics"}, "golang"},
+		{map[string]string{"url": "https://duckduckgo.com/?q=python+stack+trace+meaning"}, "stack trace"},
+		{map[string]string{"url": "https://search.yahoo.com/search?p=c%2B%2B+templates"}, "c++"},
+		{map[string]string{"title": "Why does my stack trace repeat? at DuckDuckGo"}, "stack trace"},
+		// Whole words only, and only in searches
+		{map[string]string{"url": "https://www.google.com/search?q=golangers+fan+club"}, ""},
+		{map[string]string{"url": "https://go.dev/doc", "title": "golang docs"}, ""},
+	}
+	for _, tc := range cases {
+		if got := workSearchTerm(tc.input); got != tc.want {
+			t.Errorf("%v: expected %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestBiasAmbiguousSearch_KeepsConfidentLabels(t *testing.T) {
+	t.Setenv("CLASSIFICATION_WORK_SEARCH_TERMS", "golang")
+
+	search := map[string]string{"url": "https://www.google.com/search?q=golang+memes"}
+	if got := biasAmbiguousSearch("distracting", search); got != "distracting" {
+		t.Fatalf("expected only neutral results to be biased, got %q", got)
+	}
+}
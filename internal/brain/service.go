@@ -8,38 +8,192 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
 	"gorm.io/gorm"
 
 	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
 	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
 	commonv1 "github.com/focusd-so/brain/gen/common/v1"
 	"github.com/focusd-so/brain/internal/auth"
+	"github.com/focusd-so/brain/internal/secrets"
 )
 
 type ServiceImpl struct {
-	gormDB *gorm.DB
+	gormDB    *gorm.DB
+	coalescer *coalescer
+
+	newClassificationService func(db *gorm.DB) (*ClassificationService, error)
+	cacheWriter              *cacheWriter
+	reads                    *readRouter
+	breaker                  *circuitBreaker
+	geminiLimiter            *geminiLimiter
+	webhook                  *webhookSender
+	flags                    *Flags
+	geminiDebug              *geminiDebugSink
+	cacheEvictor             *cacheEvictor
+
+	// pendingStores tracks detached cache stores so shutdown can wait for them
+	pendingStores sync.WaitGroup
+
+	maintenanceMu sync.Mutex
+
+	// Handshakes are public, so they are throttled per fingerprint and per client IP
+	handshakeFingerprintLimiter *rateLimiter
+	handshakeIPLimiter          *rateLimiter
+	// trustedProxies may set X-Forwarded-For, other callers are keyed by peer
+	trustedProxies []netip.Prefix
+
+	agentAppName     string
+	newAgentModel    func(ctx context.Context) (model.LLM, error)
+	newAgentSessions func() session.Service
 }
 
 func NewServiceImpl(gormDB *gorm.DB) *ServiceImpl {
-	return &ServiceImpl{gormDB: gormDB}
+	s := &ServiceImpl{
+		gormDB:                   gormDB,
+		coalescer:                newCoalescer(envDuration("CLASSIFICATION_COALESCE_WINDOW", 0)),
+		newClassificationService: NewClassificationService,
+		agentAppName:             envString("AGENT_APP_NAME", "focusd"),
+		newAgentModel:            selectAgentModel,
+		newAgentSessions:         session.InMemoryService,
+
+		handshakeFingerprintLimiter: newRateLimiter(
+			envInt("HANDSHAKE_RATE_LIMIT", defaultHandshakeRateLimit),
+			envDuration("HANDSHAKE_RATE_WINDOW", defaultHandshakeRateWindow),
+		),
+		handshakeIPLimiter: newRateLimiter(
+			envInt("HANDSHAKE_IP_RATE_LIMIT", defaultHandshakeIPRateLimit),
+			envDuration("HANDSHAKE_RATE_WINDOW", defaultHandshakeRateWindow),
+		),
+		trustedProxies: parseTrustedProxies(os.Getenv("HANDSHAKE_TRUSTED_PROXIES")),
+
+		breaker: newCircuitBreaker(
+			envInt("CLASSIFICATION_BREAKER_THRESHOLD", defaultBreakerThreshold),
+			envDuration("CLASSIFICATION_BREAKER_COOLDOWN", defaultBreakerCooldown),
+		),
+		geminiLimiter: newGeminiLimiter(envInt("GEMINI_MAX_CONCURRENCY", defaultGeminiMaxConcurrency)),
+		webhook:       newWebhookSender(),
+		flags:         loadFlags(),
+		geminiDebug:   newGeminiDebugSink(),
+		cacheEvictor: newCacheEvictor(
+			envInt("CLASSIFICATION_CACHE_MAX_ROWS", 0),
+			envDuration("CLASSIFICATION_CACHE_EVICT_INTERVAL", defaultCacheEvictInterval),
+		),
+	}
+
+	// Batch cache writes when a flush interval is configured
+	if interval := envDuration("CLASSIFICATION_CACHE_FLUSH_INTERVAL", 0); interval > 0 && gormDB != nil {
+		s.cacheWriter = newCacheWriter(gormDB, interval, envInt("CLASSIFICATION_CACHE_BATCH_SIZE", defaultCacheBatchSize), s.cacheEvictor)
+	}
+
+	return s
+}
+
+// Close waits for in-flight cache stores and webhook deliveries and flushes
+// buffered cache writes, call it on shutdown. Work still running when ctx is
+// done is abandoned.
+func (s *ServiceImpl) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pendingStores.Wait()
+		s.webhook.wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("cache stores or webhooks still running at shutdown: %w", ctx.Err())
+	}
+
+	if s.cacheWriter != nil {
+		s.cacheWriter.Close()
+	}
+	return err
+}
+
+// classificationService creates a classification service for the given variant,
+// wired to the shared cache writer
+func (s *ServiceImpl) classificationService(variant classificationVariant) (*ClassificationService, error) {
+	cs, err := s.newClassificationService(s.gormDB)
+	if err != nil {
+		return nil, err
+	}
+	if variant.Name == variantTreatment {
+		cs.model = variant.Model
+	}
+	cs.variant = variant
+	cs.writer = s.cacheWriter
+	cs.reads = s.reads
+	cs.breaker = s.breaker
+	cs.limiter = s.geminiLimiter
+	cs.pending = &s.pendingStores
+	cs.flags = s.flags
+	cs.debug = s.geminiDebug
+	cs.evictor = s.cacheEvictor
+	return cs, nil
 }
 
 var _ brainv1connect.BrainServiceHandler = (*ServiceImpl)(nil)
 
+// Default handshake throttling: per fingerprint and per client IP within the window
+const (
+	defaultHandshakeRateLimit   = 5
+	defaultHandshakeIPRateLimit = 30
+	defaultHandshakeRateWindow  = time.Minute
+)
+
+// defaultHandshakeTimestampWindow is how far a handshake timestamp may be from
+// server time, overridable via HANDSHAKE_TIMESTAMP_WINDOW
+const defaultHandshakeTimestampWindow = 30 * time.Second
+
+// handshakeTimestampWindow returns the accepted handshake clock difference
+func handshakeTimestampWindow() time.Duration {
+	return envDuration("HANDSHAKE_TIMESTAMP_WINDOW", defaultHandshakeTimestampWindow)
+}
+
+// nonceRetention returns how long a used nonce is kept, and so rejected, after
+// its timestamp. HANDSHAKE_NONCE_RETENTION extends it for stricter deployments
+// at the cost of a larger nonce table; it never drops below the timestamp
+// window, which would let a replay in once the nonce is purged.
+func nonceRetention() time.Duration {
+	window := handshakeTimestampWindow()
+	return max(envDuration("HANDSHAKE_NONCE_RETENTION", window), window)
+}
+
 func (s *ServiceImpl) DeviceHandshake(ctx context.Context, req *connect.Request[brainv1.DeviceHandshakeRequest]) (*connect.Response[brainv1.DeviceHandshakeResponse], error) {
+	// ---------------------------------------------------------
+	// STEP 0: THROTTLE ABUSIVE CLIENTS
+	// ---------------------------------------------------------
+	// Checked before anything touches the database so spam can't create
+	// nonce rows or shadow users.
+	var quota rateQuota
+	if ip := s.clientIP(req); ip != "" {
+		if quota = s.handshakeIPLimiter.take(ip); !quota.allowed {
+			slog.Warn("handshake rate limited", "ip", ip)
+			return nil, rateLimitedError(quota)
+		}
+	}
+
 	// ---------------------------------------------------------
 	// STEP 1: VERIFY HMAC SIGNATURE (App Attestation)
 	// ---------------------------------------------------------
 	// We do this manually here because Handshake is a public endpoint
 	// and doesn't use the standard AuthInterceptor.
-	if err := s.verifyHMAC(req); err != nil {
-		slog.Error("failed to verify hmac", "error", err)
-		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("signature verification failed: %w", err))
+	if err := s.verifyHMAC(req.Header(), req.Msg.DeviceFingerprint); err != nil {
+		return nil, attestationError(err)
 	}
 
 	fingerprint := req.Msg.DeviceFingerprint
@@ -47,79 +201,210 @@ func (s *ServiceImpl) DeviceHandshake(ctx context.Context, req *connect.Request[
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("fingerprint required"))
 	}
 
+	// Only attested handshakes count against the fingerprint, so a caller
+	// who merely knows it can't lock the device out. Both limits are
+	// reported in X-RateLimit-* headers, the tighter one winning.
+	fpQuota := s.handshakeFingerprintLimiter.take(fingerprint)
+	if !fpQuota.allowed {
+		slog.Warn("handshake rate limited", "device_fingerprint", fingerprint)
+		return nil, rateLimitedError(fpQuota)
+	}
+	quota = quota.tighter(fpQuota)
+
 	// ---------------------------------------------------------
 	// STEP 2: FIND OR CREATE SHADOW USER
 	// ---------------------------------------------------------
 
 	user, err := s.upsertShadowUser(ctx, fingerprint)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("db error: %w", err))
+		return nil, dbError("db error", err)
 	}
 
 	// ---------------------------------------------------------
 	// STEP 3: MINT PASETO TOKEN
 	// ---------------------------------------------------------
 
-	sessionToken, err := auth.MintToken(user.Id, user.Role)
+	// Bound to the fingerprint so a stolen token fails from another device
+	sessionToken, claims, err := auth.MintBoundSession(user.Id, user.Role, fingerprint)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to mint session"))
 	}
 
+	// Tracked so the user can list and revoke it, an untracked token is rejected
+	if err := s.recordSession(ctx, claims, req.Msg); err != nil {
+		return nil, dbError("failed to record session", err)
+	}
+
 	// ---------------------------------------------------------
 	// STEP 4: RETURN RESPONSE
 	// ---------------------------------------------------------
 
-	return connect.NewResponse(&brainv1.DeviceHandshakeResponse{
+	resp := connect.NewResponse(&brainv1.DeviceHandshakeResponse{
 		SessionToken: sessionToken,
-	}), nil
+	})
+	quota.setHeaders(resp.Header())
+	return resp, nil
+}
+
+// parseTrustedProxies parses a comma separated list of proxy IPs or CIDRs.
+// Invalid entries are logged and skipped.
+func parseTrustedProxies(list string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			slog.Warn("ignoring invalid trusted proxy", "entry", entry, "error", err)
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
 }
 
-func (s *ServiceImpl) verifyHMAC(req *connect.Request[brainv1.DeviceHandshakeRequest]) error {
-	timestampStr := req.Header().Get("X-Timestamp")
-	nonce := req.Header().Get("X-Nonce")
-	signature := req.Header().Get("X-Signature")
+// clientIP returns the caller's IP for rate limiting
+func (s *ServiceImpl) clientIP(req connect.AnyRequest) string {
+	return forwardedClientIP(req.Peer().Addr, req.Header().Values("X-Forwarded-For"), s.trustedProxies)
+}
 
-	if timestampStr == "" || signature == "" {
-		return errors.New("missing security headers")
+// forwardedClientIP returns the peer's IP unless the peer is a trusted proxy,
+// in which case X-Forwarded-For is walked from the right and the first hop
+// not added by a trusted proxy is returned. Hops further left are set by the
+// client and can't be trusted.
+func forwardedClientIP(peerAddr string, forwarded []string, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(peerAddr)
+	if err != nil {
+		host = peerAddr
+	}
+	if !isTrustedProxy(host, trusted) {
+		return host
 	}
 
-	// Replay Attack Check (Timestamp window: 30 seconds)
-	ts, err := strconv.ParseInt(timestampStr, 10, 64)
+	var hops []string
+	for _, value := range forwarded {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// A malformed hop can't be attributed, so stop at the last good one
+			break
+		}
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+	}
+	return host
+}
+
+// isTrustedProxy reports whether ip is within one of the trusted prefixes
+func isTrustedProxy(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
 	if err != nil {
-		return errors.New("invalid timestamp")
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// attestationError maps a failed HMAC verification onto a Connect error
+func attestationError(err error) *connect.Error {
+	slog.Error("failed to verify hmac", "error", err)
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
 	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("signature verification failed: %w", err))
+}
+
+// verifyHMAC checks the attestation headers signed over fingerprint
+func (s *ServiceImpl) verifyHMAC(header http.Header, fingerprint string) error {
+	timestampStr := header.Get("X-Timestamp")
+	nonce := header.Get("X-Nonce")
+	signature := header.Get("X-Signature")
 
 	now := time.Now().Unix()
-	if now-ts > 30 || ts-now > 30 {
-		return errors.New("request expired")
+	ts, err := parseAttestationTimestamp(timestampStr, signature)
+	if err != nil {
+		return err
+	}
+	recordClockSkew(fingerprint, clockSkew(ts, now))
+	if err := checkAttestationWindow(ts, now); err != nil {
+		return err
 	}
 
 	// Replay Attack Check (Nonce)
 	if err := s.gormDB.Where("nonce = ?", nonce).First(&commonv1.NonceORM{}).Error; err != nil {
-		if err != gorm.ErrRecordNotFound {
-			return fmt.Errorf("db error: %w", err)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return dbError("db error", err)
 		}
 	}
 
+	// Kept until the request could no longer pass the timestamp check, counted
+	// from the later of its timestamp and now since clients may run ahead
 	if err := s.gormDB.Create(&commonv1.NonceORM{
 		Nonce:     nonce,
 		CreatedAt: now,
-		ExpiresAt: now + 30,
+		ExpiresAt: max(ts, now) + int64(nonceRetention().Seconds()),
 	}).Error; err != nil {
-		return fmt.Errorf("db error: %w", err)
+		if isUniqueViolation(err) {
+			return errors.New("nonce already used")
+		}
+		return dbError("db error", err)
+	}
+
+	slog.Info("verifying hmac", "device_fingerprint", fingerprint, "timestamp", timestampStr, "nonce", nonce, "signature", signature)
+	return checkSignature(handshakePayload(fingerprint, timestampStr, nonce), signature)
+}
+
+// parseAttestationTimestamp requires the security headers and returns the
+// parsed timestamp
+func parseAttestationTimestamp(timestampStr, signature string) (int64, error) {
+	if timestampStr == "" || signature == "" {
+		return 0, errors.New("missing security headers")
 	}
+	ts, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid timestamp")
+	}
+	return ts, nil
+}
 
-	slog.Info("verifying hmac", "device_fingerprint", req.Msg.DeviceFingerprint, "timestamp", timestampStr, "nonce", nonce, "signature", signature)
+// checkAttestationWindow rejects replays: timestamps outside the window of
+// now (30 seconds by default)
+func checkAttestationWindow(ts, now int64) error {
+	window := int64(handshakeTimestampWindow().Seconds())
+	if now-ts > window || ts-now > window {
+		return errors.New("request expired")
+	}
+	return nil
+}
 
-	// Reconstruct the String-to-Sign
-	// Must match Client Logic EXACTLY: "BodyJson+Timestamp+Nonce"
-	// Note: In ConnectRPC, we don't always have raw JSON body easily accessbile
-	// in the handler object without middleware.
-	// SIMPLIFICATION: Sign the Fingerprint field specifically, not whole JSON.
-	payload := req.Msg.DeviceFingerprint + timestampStr + nonce
+// handshakePayload reconstructs the String-to-Sign.
+// Must match Client Logic EXACTLY: "BodyJson+Timestamp+Nonce"
+// Note: In ConnectRPC, we don't always have raw JSON body easily accessbile
+// in the handler object without middleware.
+// SIMPLIFICATION: Sign the Fingerprint field specifically, not whole JSON.
+func handshakePayload(fingerprint, timestamp, nonce string) string {
+	return fingerprint + timestamp + nonce
+}
 
+// checkSignature compares signature with the HMAC of payload under HMAC_SECRET_KEY
+func checkSignature(payload, signature string) error {
 	// 5. Calculate Expected Hash
-	secretStr := os.Getenv("HMAC_SECRET_KEY")
+	secretStr := secrets.Get("HMAC_SECRET_KEY")
 	secret, err := hex.DecodeString(secretStr)
 	if err != nil {
 		slog.Error("failed to decode hmac secret", "error", err)
@@ -145,7 +430,7 @@ func (s *ServiceImpl) upsertShadowUser(ctx context.Context, fingerprint string)
 		return user, nil
 	}
 
-	if err != gorm.ErrRecordNotFound {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return commonv1.UserORM{}, err
 	}
 
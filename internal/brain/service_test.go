@@ -1,17 +1,26 @@
 package brain
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
 	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
 	commonv1 "github.com/focusd-so/brain/gen/common/v1"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -94,3 +103,326 @@ func TestDeviceHandshake_HMACVerification(t *testing.T) {
 		// Success
 	}
 }
+
+func TestDeviceHandshake_ThrottlesFingerprint(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HANDSHAKE_RATE_LIMIT", "2")
+	svc := NewServiceImpl(newTestDB(t))
+
+	handshake := func(fingerprint, nonce string) error {
+		req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: fingerprint})
+		if nonce != "" {
+			signAttestation(t, req.Header(), fingerprint, nonce)
+		}
+		_, err := svc.DeviceHandshake(context.Background(), req)
+		return err
+	}
+
+	// Unsigned attempts by someone who knows the fingerprint don't use up its quota
+	for i := 0; i < 5; i++ {
+		if err := handshake("spammy-device", ""); connect.CodeOf(err) != connect.CodePermissionDenied {
+			t.Fatalf("unsigned attempt %d: expected PermissionDenied, got %v", i+1, err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := handshake("spammy-device", "throttle-nonce-"+strconv.Itoa(i)); err != nil {
+			t.Fatalf("attempt %d throttled too early: %v", i+1, err)
+		}
+	}
+	if err := handshake("spammy-device", "throttle-nonce-2"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	// Other devices are unaffected
+	if err := handshake("other-device", "throttle-nonce-3"); err != nil {
+		t.Fatalf("expected other device to pass the limiter, got %v", err)
+	}
+}
+
+func TestDeviceHandshake_ThrottlesIP(t *testing.T) {
+	handshakes := func(t *testing.T, forwarded ...string) []error {
+		t.Setenv("HANDSHAKE_IP_RATE_LIMIT", "1")
+		_, handler := brainv1connect.NewBrainServiceHandler(NewServiceImpl(newTestDB(t)))
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+		client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+
+		var errs []error
+		for i, xff := range forwarded {
+			req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "device-" + strconv.Itoa(i)})
+			req.Header().Set("X-Forwarded-For", xff)
+			_, err := client.DeviceHandshake(context.Background(), req)
+			errs = append(errs, err)
+		}
+		return errs
+	}
+
+	t.Run("untrusted peer", func(t *testing.T) {
+		// Rotating X-Forwarded-For doesn't get a direct caller a fresh quota
+		errs := handshakes(t, "203.0.113.7", "203.0.113.8")
+		if connect.CodeOf(errs[0]) == connect.CodeResourceExhausted || connect.CodeOf(errs[1]) != connect.CodeResourceExhausted {
+			t.Fatalf("expected only the second handshake from the peer to be throttled, got %v", errs)
+		}
+	})
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		t.Setenv("HANDSHAKE_TRUSTED_PROXIES", "127.0.0.0/8, ::1")
+		// The spoofed leftmost hops are ignored, the proxy-added hop is the client
+		errs := handshakes(t, "198.51.100.1, 203.0.113.7", "198.51.100.2, 203.0.113.7", "203.0.113.8")
+		codes := []connect.Code{connect.CodeOf(errs[0]), connect.CodeOf(errs[1]), connect.CodeOf(errs[2])}
+		if codes[0] == connect.CodeResourceExhausted || codes[1] != connect.CodeResourceExhausted || codes[2] == connect.CodeResourceExhausted {
+			t.Fatalf("expected only the second handshake from 203.0.113.7 to be throttled, got %v", errs)
+		}
+	})
+}
+
+func TestForwardedClientIP(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.0/8, 192.0.2.1, not-an-ip")
+	if len(trusted) != 2 {
+		t.Fatalf("expected the invalid entry to be skipped, got %v", trusted)
+	}
+
+	cases := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{"untrusted peer ignores the header", "203.0.113.5:443", []string{"198.51.100.1"}, "203.0.113.5"},
+		{"trusted peer uses the rightmost untrusted hop", "10.1.2.3:443", []string{"198.51.100.1, 203.0.113.7, 10.0.0.2"}, "203.0.113.7"},
+		{"repeated headers are one list", "192.0.2.1:443", []string{"198.51.100.1", "203.0.113.7"}, "203.0.113.7"},
+		{"all hops trusted falls back to the peer", "10.1.2.3:443", []string{"10.0.0.2"}, "10.1.2.3"},
+		{"malformed hop falls back to the peer", "10.1.2.3:443", []string{"203.0.113.7, junk"}, "10.1.2.3"},
+		{"no header", "10.1.2.3:443", nil, "10.1.2.3"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := forwardedClientIP(tc.peer, tc.forwarded, trusted); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDeviceHandshake_RateLimitHeaders(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HANDSHAKE_RATE_LIMIT", "3")
+	t.Setenv("HANDSHAKE_IP_RATE_LIMIT", "10")
+	svc := NewServiceImpl(newTestDB(t))
+
+	start := time.Now().Unix()
+	for i, want := range []string{"2", "1", "0"} {
+		req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "quota-device"})
+		signAttestation(t, req.Header(), "quota-device", "quota-nonce-"+strconv.Itoa(i))
+		resp, err := svc.DeviceHandshake(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handshake %d failed: %v", i+1, err)
+		}
+		// Without a peer address only the fingerprint limit applies
+		if got := resp.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Fatalf("expected a limit of 3, got %q", got)
+		}
+		if got := resp.Header().Get("X-RateLimit-Remaining"); got != want {
+			t.Fatalf("handshake %d: expected %s remaining, got %q", i+1, want, got)
+		}
+		reset, err := strconv.ParseInt(resp.Header().Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil || reset < start+int64(defaultHandshakeRateWindow/time.Second) {
+			t.Fatalf("expected the reset a window after the first handshake, got %q", resp.Header().Get("X-RateLimit-Reset"))
+		}
+	}
+
+	req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "quota-device"})
+	signAttestation(t, req.Header(), "quota-device", "quota-nonce-3")
+	_, err := svc.DeviceHandshake(context.Background(), req)
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if connectErr.Meta().Get("X-RateLimit-Remaining") != "0" || connectErr.Meta().Get("X-RateLimit-Limit") != "3" || connectErr.Meta().Get("X-RateLimit-Reset") == "" {
+		t.Fatalf("expected the rejection to carry the quota headers, got %v", connectErr.Meta())
+	}
+}
+
+func TestRateQuota_TighterAndUnlimited(t *testing.T) {
+	now := time.Now()
+	loose := rateQuota{allowed: true, limit: 30, remaining: 20, reset: now}
+	tight := rateQuota{allowed: true, limit: 5, remaining: 1, reset: now}
+	if got := loose.tighter(tight); got != tight {
+		t.Fatalf("expected the quota with fewer events left, got %+v", got)
+	}
+	if got := (rateQuota{}).tighter(loose); got != loose {
+		t.Fatalf("expected a missing quota to defer to the other, got %+v", got)
+	}
+
+	header := http.Header{}
+	newRateLimiter(0, time.Minute).take("key").setHeaders(header)
+	if len(header) != 0 {
+		t.Fatalf("expected no headers without a limit, got %v", header)
+	}
+}
+
+func TestDeviceHandshake_LogsClockSkew(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	svc := NewServiceImpl(newTestDB(t))
+	before := handshakeSkew.snapshot()["count"].(int64)
+
+	// A client 12 seconds behind is still inside the replay window
+	req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "skewed-device"})
+	req.Header().Set("X-Timestamp", strconv.FormatInt(time.Now().Unix()-12, 10))
+	req.Header().Set("X-Nonce", "skew-nonce")
+	req.Header().Set("X-Signature", "bad")
+	svc.DeviceHandshake(context.Background(), req)
+
+	match := regexp.MustCompile(`level=WARN msg="handshake clock skew" device_fingerprint=skewed-device skew_seconds=(-?\d+)`).FindStringSubmatch(logs.String())
+	if match == nil {
+		t.Fatalf("expected a clock skew warning, got logs:\n%s", logs.String())
+	}
+	// Allow for the second ticking over during the test
+	if match[1] != "-12" && match[1] != "-13" {
+		t.Fatalf("expected skew of about -12s, got %s", match[1])
+	}
+
+	stats := handshakeSkew.snapshot()
+	if stats["count"].(int64) != before+1 {
+		t.Fatalf("expected one more skew observation, got %v", stats)
+	}
+	if stats["last_seconds"].(float64) > -12 {
+		t.Fatalf("expected last skew of about -12s, got %v", stats["last_seconds"])
+	}
+}
+
+func TestSkewStats_Aggregates(t *testing.T) {
+	var stats skewStats
+	for _, skew := range []time.Duration{clockSkew(100, 102), clockSkew(106, 100), 0} {
+		stats.record(skew, 5*time.Second)
+	}
+
+	snapshot := stats.snapshot()
+	if snapshot["count"] != int64(3) || snapshot["over_threshold"] != int64(1) {
+		t.Fatalf("unexpected counts %v", snapshot)
+	}
+	if snapshot["max_abs_seconds"] != 6.0 || snapshot["mean_abs_seconds"] != 8.0/3 || snapshot["last_seconds"] != 0.0 {
+		t.Fatalf("unexpected aggregates %v", snapshot)
+	}
+}
+
+func TestClose_WaitsForPendingCacheStores(t *testing.T) {
+	db := newTestDB(t)
+
+	// Hold every cache write until released
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	block := func(*gorm.DB) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("test:block_create", block); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("test:block_update", block); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	svc := NewServiceImpl(db)
+	useTestModels(svc, fakeModels{text: `{"classification":"productive","reasoning":"Coding.","tags":["work"],"confidence_score":0.9}`})
+
+	if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Xcode"})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	<-started
+
+	// A shutdown deadline that passes first reports the store as lost
+	expired, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := svc.Close(expired); err == nil {
+		t.Fatal("expected an error when the deadline passes before the store finishes")
+	}
+
+	closed := make(chan error, 1)
+	go func() { closed <- svc.Close(context.Background()) }()
+
+	select {
+	case err := <-closed:
+		t.Fatalf("expected Close to wait for the store, returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-closed; err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	var count int64
+	if err := db.Model(&commonv1.PromptHistoryORM{}).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count cache: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected the store to complete before Close returned, got %d rows", count)
+	}
+}
+
+// signedHandshake sends a handshake signed with the test HMAC secret
+func signedHandshake(svc *ServiceImpl, ts int64, nonce string) error {
+	secret, _ := hex.DecodeString("12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	timestamp := strconv.FormatInt(ts, 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("replay-device" + timestamp + nonce))
+
+	req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: "replay-device"})
+	req.Header().Set("X-Timestamp", timestamp)
+	req.Header().Set("X-Nonce", nonce)
+	req.Header().Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+	_, err := svc.DeviceHandshake(context.Background(), req)
+	return err
+}
+
+func TestDeviceHandshake_ExtendedNonceRetentionRejectsLateReplay(t *testing.T) {
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("PASETO_KEYS", "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	t.Setenv("HANDSHAKE_TIMESTAMP_WINDOW", "2m")
+	t.Setenv("HANDSHAKE_NONCE_RETENTION", "10m")
+
+	db := newTestDB(t)
+	svc := NewServiceImpl(db)
+
+	ts := time.Now().Unix() - 40 // outside the old 30s window, inside the configured one
+	if err := signedHandshake(svc, ts, "replayed-nonce"); err != nil {
+		t.Fatalf("first handshake failed: %v", err)
+	}
+
+	// Maintenance just after the old 30s retention must keep the nonce
+	if _, err := purgeExpired(context.Background(), db, &commonv1.NonceORM{}, "nonce", time.Now().Unix()+31); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	var stored commonv1.NonceORM
+	if err := db.Where("nonce = ?", "replayed-nonce").First(&stored).Error; err != nil {
+		t.Fatalf("expected the nonce to be retained: %v", err)
+	}
+	if stored.ExpiresAt < time.Now().Unix()+9*60 {
+		t.Fatalf("expected about 10 minutes of retention, expires at %d", stored.ExpiresAt)
+	}
+
+	err := signedHandshake(svc, ts, "replayed-nonce")
+	if connect.CodeOf(err) != connect.CodePermissionDenied || !strings.Contains(err.Error(), "nonce already used") {
+		t.Fatalf("expected the replay to be rejected, got %v", err)
+	}
+}
+
+func TestNonceRetention_NeverBelowTimestampWindow(t *testing.T) {
+	t.Setenv("HANDSHAKE_TIMESTAMP_WINDOW", "2m")
+	t.Setenv("HANDSHAKE_NONCE_RETENTION", "10s")
+
+	if got := nonceRetention(); got != 2*time.Minute {
+		t.Fatalf("expected retention to cover the timestamp window, got %s", got)
+	}
+}
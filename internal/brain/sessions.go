@@ -0,0 +1,204 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+var (
+	errSessionRevoked   = errors.New("session revoked")
+	errFingerprintInUse = errors.New("device fingerprint belongs to another account")
+)
+
+// recordSession stores the metadata of a freshly minted token
+func (s *ServiceImpl) recordSession(ctx context.Context, claims *auth.UserClaims, req *brainv1.DeviceHandshakeRequest) error {
+	session := commonv1.SessionORM{
+		Jti:               claims.TokenID,
+		UserId:            claims.UserID,
+		DeviceFingerprint: req.GetDeviceFingerprint(),
+		OsPlatform:        req.GetOsPlatform(),
+		AppVersion:        req.GetAppVersion(),
+		CreatedAt:         time.Now().Unix(),
+		ExpiresAt:         claims.ExpiresAt.Unix(),
+	}
+	return s.gormDB.WithContext(ctx).Create(&session).Error
+}
+
+// CheckSession implements auth.SessionChecker. Tokens minted before sessions
+// were tracked carry no ID and stay valid until they expire.
+func (s *ServiceImpl) CheckSession(ctx context.Context, claims *auth.UserClaims) error {
+	if claims.TokenID == "" {
+		return nil
+	}
+
+	var session commonv1.SessionORM
+	err := s.gormDB.WithContext(ctx).Where("jti = ?", claims.TokenID).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && session.RevokedAt > 0) {
+		return connect.NewError(connect.CodeUnauthenticated, errSessionRevoked)
+	}
+	if err != nil {
+		return dbError("failed to load session", err)
+	}
+	return nil
+}
+
+// ListSessions returns the user's sessions that are neither revoked nor expired
+func (s *ServiceImpl) ListSessions(ctx context.Context, req *connect.Request[brainv1.ListSessionsRequest]) (*connect.Response[brainv1.ListSessionsResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	var sessions []commonv1.SessionORM
+	err := s.gormDB.WithContext(ctx).
+		Where("user_id = ? AND revoked_at = 0 AND expires_at > ?", user.UserID, time.Now().Unix()).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, dbError("failed to list sessions", err)
+	}
+
+	infos := make([]*brainv1.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, &brainv1.SessionInfo{
+			SessionId:         session.Jti,
+			DeviceFingerprint: session.DeviceFingerprint,
+			OsPlatform:        session.OsPlatform,
+			AppVersion:        session.AppVersion,
+			CreatedAt:         session.CreatedAt,
+			ExpiresAt:         session.ExpiresAt,
+			Current:           session.Jti == user.TokenID,
+		})
+	}
+
+	return connect.NewResponse(&brainv1.ListSessionsResponse{
+		Sessions: infos,
+	}), nil
+}
+
+// RevokeSession revokes one of the user's sessions, including the current one
+func (s *ServiceImpl) RevokeSession(ctx context.Context, req *connect.Request[brainv1.RevokeSessionRequest]) (*connect.Response[brainv1.RevokeSessionResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	// Scoped to the user so another user's session ID reads as not found
+	result := s.gormDB.WithContext(ctx).Model(&commonv1.SessionORM{}).
+		Where("jti = ? AND user_id = ? AND revoked_at = 0", req.Msg.SessionId, user.UserID).
+		Update("revoked_at", time.Now().Unix())
+	if result.Error != nil {
+		return nil, dbError("failed to revoke session", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("session not found"))
+	}
+
+	return connect.NewResponse(&brainv1.RevokeSessionResponse{
+		Success: true,
+	}), nil
+}
+
+// RevokeAllSessions revokes every active session of the user, optionally
+// keeping the one making the request
+func (s *ServiceImpl) RevokeAllSessions(ctx context.Context, req *connect.Request[brainv1.RevokeAllSessionsRequest]) (*connect.Response[brainv1.RevokeAllSessionsResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	query := s.gormDB.WithContext(ctx).Model(&commonv1.SessionORM{}).
+		Where("user_id = ? AND revoked_at = 0", user.UserID)
+	if req.Msg.KeepCurrent && user.TokenID != "" {
+		query = query.Where("jti <> ?", user.TokenID)
+	}
+
+	result := query.Update("revoked_at", time.Now().Unix())
+	if result.Error != nil {
+		return nil, dbError("failed to revoke sessions", result.Error)
+	}
+
+	return connect.NewResponse(&brainv1.RevokeAllSessionsResponse{
+		Revoked: result.RowsAffected,
+	}), nil
+}
+
+// RebindDevice moves the user to a new device fingerprint after the new device
+// passes attestation. The user keeps their ID and data; the calling session,
+// bound to the old device, is replaced by one for the new device.
+func (s *ServiceImpl) RebindDevice(ctx context.Context, req *connect.Request[brainv1.RebindDeviceRequest]) (*connect.Response[brainv1.RebindDeviceResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	fingerprint := req.Msg.DeviceFingerprint
+	if err := s.verifyHMAC(req.Header(), fingerprint); err != nil {
+		return nil, attestationError(err)
+	}
+	// Counted after attestation, as in DeviceHandshake
+	quota := s.handshakeFingerprintLimiter.take(fingerprint)
+	if !quota.allowed {
+		slog.Warn("rebind rate limited", "device_fingerprint", fingerprint)
+		return nil, rateLimitedError(quota)
+	}
+
+	var account commonv1.UserORM
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&account, user.UserID).Error; err != nil {
+			return err
+		}
+
+		// A fingerprint can only identify one user
+		var owner commonv1.UserORM
+		if err := tx.Where("device_fingerprint_hash = ?", fingerprint).Limit(1).Find(&owner).Error; err != nil {
+			return err
+		}
+		if owner.Id != 0 && owner.Id != account.Id {
+			return errFingerprintInUse
+		}
+		return tx.Model(&account).Update("device_fingerprint_hash", fingerprint).Error
+	})
+	if errors.Is(err, errFingerprintInUse) {
+		return nil, connect.NewError(connect.CodeAlreadyExists, err)
+	}
+	if err != nil {
+		return nil, dbError("failed to rebind device", err)
+	}
+
+	sessionToken, claims, err := auth.MintBoundSession(account.Id, account.Role, fingerprint)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to mint session"))
+	}
+	if err := s.recordSession(ctx, claims, &brainv1.DeviceHandshakeRequest{
+		DeviceFingerprint: fingerprint,
+		OsPlatform:        req.Msg.OsPlatform,
+		OsVersion:         req.Msg.OsVersion,
+		AppVersion:        req.Msg.AppVersion,
+	}); err != nil {
+		return nil, dbError("failed to record session", err)
+	}
+
+	if user.TokenID != "" {
+		err := s.gormDB.WithContext(ctx).Model(&commonv1.SessionORM{}).
+			Where("jti = ? AND revoked_at = 0", user.TokenID).
+			Update("revoked_at", time.Now().Unix()).Error
+		if err != nil {
+			return nil, dbError("failed to revoke session", err)
+		}
+	}
+
+	slog.Info("device rebound", "user_id", account.Id)
+	resp := connect.NewResponse(&brainv1.RebindDeviceResponse{SessionToken: sessionToken})
+	quota.setHeaders(resp.Header())
+	return resp, nil
+}
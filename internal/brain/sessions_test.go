@@ -0,0 +1,213 @@
+package brain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// mintTestSession mints and records a session, returning the token and a
+// context authenticated as it
+func mintTestSession(t *testing.T, svc *ServiceImpl, userID int64, platform string) (string, context.Context) {
+	t.Helper()
+	token, claims, err := auth.MintSession(userID, "anonymous")
+	if err != nil {
+		t.Fatalf("failed to mint session: %v", err)
+	}
+	if err := svc.recordSession(context.Background(), claims, &brainv1.DeviceHandshakeRequest{OsPlatform: platform}); err != nil {
+		t.Fatalf("failed to record session: %v", err)
+	}
+	return token, auth.WithUser(context.Background(), claims)
+}
+
+func listSessions(t *testing.T, svc *ServiceImpl, ctx context.Context) []*brainv1.SessionInfo {
+	t.Helper()
+	resp, err := svc.ListSessions(ctx, connect.NewRequest(&brainv1.ListSessionsRequest{}))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	return resp.Msg.GetSessions()
+}
+
+func TestListSessions_ShowsActiveSessions(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	_, laptop := mintTestSession(t, svc, 7, "darwin")
+	mintTestSession(t, svc, 7, "windows")
+	mintTestSession(t, svc, 8, "linux")
+
+	// An expired session is not listed
+	if err := svc.gormDB.Create(&commonv1.SessionORM{Jti: "expired", UserId: 7, CreatedAt: 1, ExpiresAt: time.Now().Add(-time.Minute).Unix()}).Error; err != nil {
+		t.Fatalf("failed to seed session: %v", err)
+	}
+
+	sessions := listSessions(t, svc, laptop)
+	if len(sessions) != 2 {
+		t.Fatalf("expected the user's two active sessions, got %v", sessions)
+	}
+	for _, session := range sessions {
+		if current := session.GetOsPlatform() == "darwin"; session.GetCurrent() != current {
+			t.Errorf("expected only the darwin session to be current, got %v", session)
+		}
+	}
+}
+
+func TestRevokeSession_RemovesSessionAndRejectsToken(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	_, laptop := mintTestSession(t, svc, 7, "darwin")
+	desktopToken, _ := mintTestSession(t, svc, 7, "windows")
+
+	var desktopID string
+	for _, session := range listSessions(t, svc, laptop) {
+		if session.GetOsPlatform() == "windows" {
+			desktopID = session.GetSessionId()
+		}
+	}
+
+	// Another user can't revoke it
+	_, other := mintTestSession(t, svc, 8, "linux")
+	_, err := svc.RevokeSession(other, connect.NewRequest(&brainv1.RevokeSessionRequest{SessionId: desktopID}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected not found for another user's session, got %v", err)
+	}
+
+	if _, err := svc.RevokeSession(laptop, connect.NewRequest(&brainv1.RevokeSessionRequest{SessionId: desktopID})); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if sessions := listSessions(t, svc, laptop); len(sessions) != 1 || sessions[0].GetSessionId() == desktopID {
+		t.Fatalf("expected only the laptop session to remain, got %v", sessions)
+	}
+
+	// The revoked token no longer authenticates
+	interceptor := auth.NewAuthInterceptor(auth.WithSessionChecker(svc))
+	call := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&brainv1.ListSessionsResponse{}), nil
+	})
+	req := connect.NewRequest(&brainv1.ListSessionsRequest{})
+	req.Header().Set("Authorization", "Bearer "+desktopToken)
+	if _, err := call(context.Background(), req); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected the revoked token to be rejected, got %v", err)
+	}
+}
+
+func TestRevokeAllSessions_KeepCurrent(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	_, laptop := mintTestSession(t, svc, 7, "darwin")
+	mintTestSession(t, svc, 7, "windows")
+	mintTestSession(t, svc, 7, "linux")
+
+	resp, err := svc.RevokeAllSessions(laptop, connect.NewRequest(&brainv1.RevokeAllSessionsRequest{KeepCurrent: true}))
+	if err != nil {
+		t.Fatalf("revoke all failed: %v", err)
+	}
+	if resp.Msg.GetRevoked() != 2 {
+		t.Fatalf("expected two sessions revoked, got %d", resp.Msg.GetRevoked())
+	}
+	if sessions := listSessions(t, svc, laptop); len(sessions) != 1 || !sessions[0].GetCurrent() {
+		t.Fatalf("expected only the current session to remain, got %v", sessions)
+	}
+}
+
+func TestCheckSession_AllowsUntrackedLegacyTokens(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	if err := svc.CheckSession(context.Background(), &auth.UserClaims{UserID: 7}); err != nil {
+		t.Fatalf("expected a token without an ID to pass, got %v", err)
+	}
+	if err := svc.CheckSession(context.Background(), &auth.UserClaims{UserID: 7, TokenID: "unknown"}); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected an unknown session to be rejected, got %v", err)
+	}
+}
+
+// signAttestation sets the handshake HMAC headers for fingerprint
+func signAttestation(t *testing.T, header http.Header, fingerprint, nonce string) {
+	t.Helper()
+	secret, _ := hex.DecodeString("12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(fingerprint + timestamp + nonce))
+	header.Set("X-Timestamp", timestamp)
+	header.Set("X-Nonce", nonce)
+	header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// handshakeAs runs a signed handshake and returns the claims of its token
+func handshakeAs(t *testing.T, svc *ServiceImpl, fingerprint, nonce string) *auth.UserClaims {
+	t.Helper()
+	req := connect.NewRequest(&brainv1.DeviceHandshakeRequest{DeviceFingerprint: fingerprint})
+	signAttestation(t, req.Header(), fingerprint, nonce)
+	resp, err := svc.DeviceHandshake(context.Background(), req)
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	claims, err := auth.ValidateToken(resp.Msg.GetSessionToken())
+	if err != nil {
+		t.Fatalf("invalid session token: %v", err)
+	}
+	return claims
+}
+
+func TestRebindDevice_PreservesUser(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	old := handshakeAs(t, svc, "old-install", "nonce-1")
+	ctx := auth.WithUser(context.Background(), old)
+
+	req := connect.NewRequest(&brainv1.RebindDeviceRequest{DeviceFingerprint: "new-install", OsPlatform: "darwin"})
+	signAttestation(t, req.Header(), "new-install", "nonce-2")
+	resp, err := svc.RebindDevice(ctx, req)
+	if err != nil {
+		t.Fatalf("rebind failed: %v", err)
+	}
+	rebound, err := auth.ValidateToken(resp.Msg.GetSessionToken())
+	if err != nil || rebound.UserID != old.UserID || rebound.DeviceHash != auth.HashFingerprint("new-install") {
+		t.Fatalf("expected a session for the same user on the new device, got %+v, %v", rebound, err)
+	}
+	if err := svc.CheckSession(context.Background(), old); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected the old device's session to be revoked, got %v", err)
+	}
+
+	// The new fingerprint now handshakes into the existing account
+	if claims := handshakeAs(t, svc, "new-install", "nonce-3"); claims.UserID != old.UserID {
+		t.Fatalf("expected user %d, got %d", old.UserID, claims.UserID)
+	}
+}
+
+func TestRebindDevice_Rejections(t *testing.T) {
+	t.Setenv("PASETO_KEYS", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	t.Setenv("HMAC_SECRET_KEY", "12075610360460580dbafc72acfbdd9a4db7890058f409ccaa6ce481396ab52d")
+	svc := NewServiceImpl(newTestDB(t))
+
+	ctx := auth.WithUser(context.Background(), handshakeAs(t, svc, "laptop", "nonce-1"))
+	handshakeAs(t, svc, "someone-else", "nonce-2")
+
+	// Attestation is required
+	unsigned := connect.NewRequest(&brainv1.RebindDeviceRequest{DeviceFingerprint: "new-install"})
+	if _, err := svc.RebindDevice(ctx, unsigned); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission_denied without attestation, got %v", err)
+	}
+
+	// Another user's fingerprint can't be taken over
+	taken := connect.NewRequest(&brainv1.RebindDeviceRequest{DeviceFingerprint: "someone-else"})
+	signAttestation(t, taken.Header(), "someone-else", "nonce-3")
+	if _, err := svc.RebindDevice(ctx, taken); connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Fatalf("expected already_exists, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package brain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// channelPattern matches chat channel names such as "#incident-1234"
+var channelPattern = regexp.MustCompile(`#[\w-]+`)
+
+// communicationPlatforms maps a lowercase marker found in the app name or url to its display name
+var communicationPlatforms = []struct {
+	marker string
+	name   string
+}{
+	{"slack", "Slack"},
+	{"discord", "Discord"},
+	{"teams", "Teams"},
+}
+
+// musicMarkers hint that the title or url is about music playback
+var musicMarkers = []string{"music", "playlist", "spotify", "soundcloud", "lofi", "lo-fi", "album"}
+
+// documentKinds maps a file extension to the kind of document it holds
+var documentKinds = map[string]string{
+	".xlsx": "spreadsheet", ".xls": "spreadsheet", ".csv": "spreadsheet", ".numbers": "spreadsheet",
+	".docx": "text document", ".doc": "text document", ".pages": "text document", ".pdf": "text document", ".md": "text document",
+	".pptx": "presentation", ".ppt": "presentation", ".key": "presentation",
+	".jpg": "photo", ".jpeg": "photo", ".png": "photo", ".heic": "photo",
+	".mp4": "video", ".mov": "video", ".mkv": "video",
+}
+
+// personalFolders and workFolders are path segments hinting at who a document belongs to
+var (
+	personalFolders = []string{"pictures", "photos", "movies", "music", "dcim", "personal"}
+	workFolders     = []string{"work", "projects", "clients", "reports"}
+)
+
+// defaultSpokenAudioKeywords hint that the title is a podcast or audiobook meant for background listening
+const defaultSpokenAudioKeywords = "podcast,audiobook,episode,chapter"
+
+// spokenAudioKeywords returns the configured spoken-audio markers, overridable
+// via SUPPORTING_AUDIO_KEYWORDS as a comma separated list
+func spokenAudioKeywords() []string {
+	var keywords []string
+	for _, k := range strings.Split(envString("SUPPORTING_AUDIO_KEYWORDS", defaultSpokenAudioKeywords), ",") {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
+
+// classificationSignals runs a small rules pass over the classification input
+// and returns human-readable signals describing what drove the decision.
+func classificationSignals(contextData map[string]string) []string {
+	name := strings.ToLower(contextData["name"])
+	title := strings.ToLower(contextData["title"])
+	url := strings.ToLower(contextData["url"])
+
+	var signals []string
+
+	for _, platform := range communicationPlatforms {
+		if !strings.Contains(name, platform.marker) && !strings.Contains(url, platform.marker) && !strings.Contains(title, platform.marker) {
+			continue
+		}
+		if channel := channelPattern.FindString(title); channel != "" {
+			kind := "channel"
+			if strings.Contains(channel, "incident") {
+				kind = "#incident"
+			}
+			signals = append(signals, "matched "+platform.name+" "+kind+" pattern")
+		} else {
+			signals = append(signals, "communication app "+platform.name)
+		}
+		break
+	}
+
+	for _, marker := range musicMarkers {
+		if strings.Contains(title, marker) {
+			signals = append(signals, "title indicates music")
+			break
+		}
+		if strings.Contains(url, marker) {
+			signals = append(signals, "url indicates music")
+			break
+		}
+	}
+
+	for _, keyword := range spokenAudioKeywords() {
+		if strings.Contains(title, keyword) {
+			signals = append(signals, "title indicates spoken audio")
+			break
+		}
+	}
+
+	if term := workSearchTerm(contextData); term != "" {
+		signals = append(signals, "search contains work term "+term)
+	}
+
+	if feed := contextData["feed"]; feed != "" {
+		signals = append(signals, "reading a "+feed+" feed")
+	}
+
+	switch contextData["foreground"] {
+	case "true":
+		signals = append(signals, "in the foreground")
+	case "false":
+		signals = append(signals, "playing in the background")
+	}
+
+	if category := contextData["app_category"]; category != "" {
+		signals = append(signals, "OS category "+category)
+	}
+
+	if strings.Contains(url, "github.com/") && strings.Contains(url, "/pull/") {
+		signals = append(signals, "url is a GitHub pull request")
+	}
+
+	if kind, ok := documentKinds[strings.ToLower(contextData["extension"])]; ok {
+		signals = append(signals, "document is a "+kind)
+	}
+
+	if sender := strings.ToLower(contextData["sender"]); sender != "" {
+		for _, marker := range bulkSenderMarkers {
+			if strings.Contains(sender, marker) {
+				signals = append(signals, "sender indicates bulk mail")
+				break
+			}
+		}
+	}
+
+	segments := strings.Split(strings.ToLower(contextData["path"]), "/")
+	for _, folder := range workFolders {
+		if slices.Contains(segments, folder) {
+			signals = append(signals, "path indicates work folder")
+			break
+		}
+	}
+	for _, folder := range personalFolders {
+		if slices.Contains(segments, folder) {
+			signals = append(signals, "path indicates personal media")
+			break
+		}
+	}
+
+	return signals
+}
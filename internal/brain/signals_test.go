@@ -0,0 +1,73 @@
+package brain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClassificationSignals_SlackIncident(t *testing.T) {
+	signals := classificationSignals(map[string]string{
+		"name":      "Slack",
+		"title":     "#incident-1234 | Acme Corp",
+		"bundle_id": "com.tinyspeck.slackmacgap",
+	})
+
+	if !slices.Contains(signals, "matched Slack #incident pattern") {
+		t.Fatalf("expected slack incident signal, got %v", signals)
+	}
+}
+
+func TestClassificationSignals_MusicTitle(t *testing.T) {
+	signals := classificationSignals(map[string]string{
+		"url":   "https://www.youtube.com/watch?v=jfKfPfyJRdk",
+		"title": "lofi hip hop radio - beats to relax/study to",
+	})
+
+	if !slices.Contains(signals, "title indicates music") {
+		t.Fatalf("expected music signal, got %v", signals)
+	}
+}
+
+func TestClassificationSignals_NoMatch(t *testing.T) {
+	signals := classificationSignals(map[string]string{
+		"name":  "Visual Studio Code",
+		"title": "main.go - focusd",
+	})
+
+	if len(signals) != 0 {
+		t.Fatalf("expected no signals, got %v", signals)
+	}
+}
+
+func TestClassificationSignals_PodcastTitle(t *testing.T) {
+	signals := classificationSignals(map[string]string{
+		"url":   "https://www.youtube.com/watch?v=abc123",
+		"title": "The Changelog Podcast #560 - Background listening",
+	})
+
+	if !slices.Contains(signals, "title indicates spoken audio") {
+		t.Fatalf("expected spoken audio signal, got %v", signals)
+	}
+}
+
+func TestClassificationSignals_SpokenAudioKeywordsConfigurable(t *testing.T) {
+	t.Setenv("SUPPORTING_AUDIO_KEYWORDS", "hörbuch, sermon")
+
+	signals := classificationSignals(map[string]string{
+		"name":  "Safari",
+		"title": "Sunday Sermon - live",
+	})
+
+	if !slices.Contains(signals, "title indicates spoken audio") {
+		t.Fatalf("expected configured keyword to match, got %v", signals)
+	}
+}
+
+func TestPrompts_ClassifyPodcastsAsSupporting(t *testing.T) {
+	for name, prompt := range map[string]string{"desktop": promptDesktop, "website": promptWebsite} {
+		if !strings.Contains(prompt, "spoken-audio") || !strings.Contains(strings.ToLower(prompt), "podcast") {
+			t.Errorf("%s prompt is missing podcast supporting rules", name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+)
+
+// sourceHeader identifies the client app submitting inputs for classification
+const sourceHeader = "X-Client-Source"
+
+// classificationProcedures are the RPCs subject to the source allowlist
+var classificationProcedures = map[string]bool{
+	brainv1connect.BrainServiceClassifyApplicationProcedure: true,
+	brainv1connect.BrainServiceClassifyWebsiteProcedure:     true,
+	brainv1connect.BrainServiceClassifyDocumentProcedure:    true,
+	brainv1connect.BrainServiceClassifyEmailProcedure:       true,
+	brainv1connect.BrainServiceClassifyCommandProcedure:     true,
+}
+
+// allowedSources returns the sources listed in CLASSIFICATION_ALLOWED_SOURCES
+// (comma-separated, case-insensitive). An empty list disables the check.
+func allowedSources() map[string]bool {
+	allowed := map[string]bool{}
+	for _, source := range strings.Split(os.Getenv("CLASSIFICATION_ALLOWED_SOURCES"), ",") {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			allowed[source] = true
+		}
+	}
+	return allowed
+}
+
+// checkSource rejects classification requests from sources outside the allowlist
+func checkSource(procedure, source string) error {
+	if !classificationProcedures[procedure] {
+		return nil
+	}
+	allowed := allowedSources()
+	if len(allowed) == 0 {
+		return nil
+	}
+	if source = strings.ToLower(strings.TrimSpace(source)); source == "" {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("missing "+sourceHeader+" header"))
+	}
+	if !allowed[source] {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("source not allowed"))
+	}
+	return nil
+}
+
+// sourceInterceptor enforces the classification source allowlist
+type sourceInterceptor struct{}
+
+// NewSourceInterceptor creates a ConnectRPC interceptor rejecting
+// classification requests whose X-Client-Source is not allowlisted
+func NewSourceInterceptor() connect.Interceptor {
+	return &sourceInterceptor{}
+}
+
+// WrapUnary implements the allowlist check for unary RPCs
+func (i *sourceInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := checkSource(req.Spec().Procedure, req.Header().Get(sourceHeader)); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *sourceInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements the allowlist check for streaming RPCs
+func (i *sourceInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := checkSource(conn.Spec().Procedure, conn.RequestHeader().Get(sourceHeader)); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,58 @@
+package brain
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+)
+
+func newSourceTestClient(t *testing.T) brainv1connect.BrainServiceClient {
+	t.Helper()
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"An editor.","tags":["work"],"confidence_score":0.9}`})
+	_, handler := brainv1connect.NewBrainServiceHandler(svc, connect.WithInterceptors(NewSourceInterceptor()))
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+}
+
+func classifyFrom(client brainv1connect.BrainServiceClient, source string) error {
+	req := connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", ApplicationBundleId: "com.microsoft.VSCode"})
+	if source != "" {
+		req.Header().Set(sourceHeader, source)
+	}
+	_, err := client.ClassifyApplication(context.Background(), req)
+	return err
+}
+
+func TestSourceAllowlist_AllowedSource(t *testing.T) {
+	t.Setenv("CLASSIFICATION_ALLOWED_SOURCES", "focusd-macos, focusd-windows")
+	client := newSourceTestClient(t)
+
+	if err := classifyFrom(client, "Focusd-MacOS"); err != nil {
+		t.Fatalf("expected an allowlisted source to classify, got %v", err)
+	}
+}
+
+func TestSourceAllowlist_DisallowedSource(t *testing.T) {
+	t.Setenv("CLASSIFICATION_ALLOWED_SOURCES", "focusd-macos")
+	client := newSourceTestClient(t)
+
+	for _, source := range []string{"scraper", ""} {
+		if err := classifyFrom(client, source); connect.CodeOf(err) != connect.CodePermissionDenied {
+			t.Errorf("source %q: expected permission_denied, got %v", source, err)
+		}
+	}
+}
+
+func TestSourceAllowlist_OffByDefault(t *testing.T) {
+	client := newSourceTestClient(t)
+
+	if err := classifyFrom(client, ""); err != nil {
+		t.Fatalf("expected requests without a source to pass when no allowlist is set, got %v", err)
+	}
+}
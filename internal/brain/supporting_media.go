@@ -0,0 +1,57 @@
+package brain
+
+import (
+	"context"
+	"strings"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// supportingMediaModel is reported as the model of results for configured
+// supporting media
+const supportingMediaModel = "supporting-media"
+
+// defaultSupportingMedia are focus music and ambient sound services,
+// overridable via SUPPORTING_MEDIA. Entries are website domains, application
+// names or bundle IDs.
+const defaultSupportingMedia = "spotify.com,brain.fm,noisli.com,music.apple.com,tidal.com,endel.io,Spotify,Music,Tidal,Noisli,Endel,com.spotify.client,com.apple.Music"
+
+// supportingMedia returns the configured entries, lowercased
+func supportingMedia() []string {
+	var media []string
+	for _, entry := range strings.Split(envString("SUPPORTING_MEDIA", defaultSupportingMedia), ",") {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			media = append(media, entry)
+		}
+	}
+	return media
+}
+
+// supportingMediaResult classifies the configured focus music services as
+// supporting without asking the model, unless the supporting_media flag is
+// off. A website host matches an entry or its subdomains, applications match
+// by bundle ID or name.
+func (s *ServiceImpl) supportingMediaResult(ctx context.Context, signals []string, host string, apps ...string) *brainv1.ClassificationResult {
+	if !s.flags.Enabled(ctx, flagSupportingMedia) {
+		return nil
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	for _, entry := range supportingMedia() {
+		matched := host != "" && (host == entry || strings.HasSuffix(host, "."+entry))
+		for _, app := range apps {
+			matched = matched || strings.ToLower(strings.TrimSpace(app)) == entry
+		}
+		if !matched {
+			continue
+		}
+		return &brainv1.ClassificationResult{
+			Classification:  "supporting",
+			Reasoning:       "A focus music or ambient sound service configured as supporting media.",
+			Tags:            []string{"supporting-audio"},
+			ConfidenceScore: 1,
+			Signals:         append(signals, "configured supporting media "+entry),
+			Model:           supportingMediaModel,
+		}
+	}
+	return nil
+}
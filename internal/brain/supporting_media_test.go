@@ -0,0 +1,56 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestSupportingMedia_ConfiguredServicesSkipTheModel(t *testing.T) {
+	t.Setenv("SUPPORTING_MEDIA", "focusmusic.example, Focus Radio")
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, fakeModels{err: errors.New("model must not be called")})
+
+	site, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:   "https://play.focusmusic.example/station/42",
+		Title: "Deep Work – Focus Music",
+	}))
+	if err != nil {
+		t.Fatalf("website classification failed: %v", err)
+	}
+	app, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{
+		ApplicationName: "Focus Radio",
+		WindowTitle:     "Now playing",
+	}))
+	if err != nil {
+		t.Fatalf("application classification failed: %v", err)
+	}
+
+	for _, result := range []*brainv1.ClassificationResult{site.Msg.GetClassification(), app.Msg.GetClassification()} {
+		if result.GetClassification() != "supporting" || result.GetConfidenceScore() != 1 || result.GetModel() != supportingMediaModel {
+			t.Fatalf("expected a confident supporting result, got %v", result)
+		}
+		if len(result.GetTags()) != 1 || result.GetTags()[0] != "supporting-audio" {
+			t.Fatalf("expected the supporting-audio tag, got %v", result.GetTags())
+		}
+	}
+}
+
+func TestSupportingMedia_OnlyConfiguredEntriesMatch(t *testing.T) {
+	t.Setenv("SUPPORTING_MEDIA", "focusmusic.example")
+
+	svc := &ServiceImpl{}
+	for _, host := range []string{"notfocusmusic.example", "focusmusic.example.evil.com"} {
+		if result := svc.supportingMediaResult(context.Background(), nil, host); result != nil {
+			t.Errorf("expected %s not to match, got %v", host, result)
+		}
+	}
+	// Replacing the list drops the defaults
+	if result := svc.supportingMediaResult(context.Background(), nil, "open.spotify.com", "Spotify"); result != nil {
+		t.Errorf("expected the defaults to be replaced, got %v", result)
+	}
+}
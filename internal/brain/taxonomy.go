@@ -0,0 +1,135 @@
+package brain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// classificationTaxonomy describes classificationLabels in the order clients show them
+var classificationTaxonomy = []*brainv1.TaxonomyEntry{
+	{Name: "productive", Description: "Directly advances the user's work."},
+	{Name: "supporting", Description: "Helps the work without being the work, e.g. research, planning or focus music."},
+	{Name: "neutral", Description: "Neither helps nor hurts focus, or too little is known to tell."},
+	{Name: "distracting", Description: "Pulls attention away from the work."},
+}
+
+// taxonomySources are the prompts whose tags make up the taxonomy, by the
+// classifier that uses them
+var taxonomySources = []struct {
+	source string
+	prompt string
+}{
+	{"application", promptDesktop},
+	{"website", promptWebsite},
+	{"document", promptDocument},
+	{"email", promptEmail},
+	{"command", promptCommand},
+}
+
+var (
+	allowedTagsList = regexp.MustCompile(`(?s)strictly allowed tags:\s*(\[.*?\])`)
+	tagDescription  = regexp.MustCompile(`(?m)^- \*\*([a-z-]+)\*\* — (.+?)\s*$`)
+)
+
+// promptTags returns the allowed tags listed in prompt and the descriptions
+// its tagging rules give them
+func promptTags(prompt string) ([]string, map[string]string, error) {
+	match := allowedTagsList.FindStringSubmatch(prompt)
+	if match == nil {
+		return nil, nil, fmt.Errorf("prompt lists no allowed tags")
+	}
+	var tags []string
+	if err := json.Unmarshal([]byte(match[1]), &tags); err != nil {
+		return nil, nil, fmt.Errorf("invalid allowed tags in prompt: %w", err)
+	}
+
+	descriptions := make(map[string]string)
+	for _, m := range tagDescription.FindAllStringSubmatch(prompt, -1) {
+		if _, ok := descriptions[m[1]]; !ok {
+			descriptions[m[1]] = m[2]
+		}
+	}
+	return tags, descriptions, nil
+}
+
+// tagWeights parses CLASSIFICATION_TAG_WEIGHTS, comma separated tag:weight
+// pairs (e.g. "time-sink:-2,work:2") clients use to score tags alike.
+// Invalid pairs are skipped.
+func tagWeights() map[string]float64 {
+	weights := make(map[string]float64)
+	for _, pair := range strings.Split(envString("CLASSIFICATION_TAG_WEIGHTS", ""), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if name == "" || err != nil {
+			slog.Warn("invalid tag weight, skipping", "key", "CLASSIFICATION_TAG_WEIGHTS", "value", pair)
+			continue
+		}
+		weights[name] = weight
+	}
+	return weights
+}
+
+// formatTagWeights renders weights as CLASSIFICATION_TAG_WEIGHTS, sorted by tag
+func formatTagWeights(weights map[string]float64) string {
+	pairs := make([]string, 0, len(weights))
+	for name, weight := range weights {
+		pairs = append(pairs, name+":"+strconv.FormatFloat(weight, 'g', -1, 64))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// taxonomyTags merges the tags of every prompt, in order of first appearance,
+// with their configured weights
+func taxonomyTags() ([]*brainv1.TaxonomyEntry, error) {
+	weights := tagWeights()
+	var tags []*brainv1.TaxonomyEntry
+	byName := make(map[string]*brainv1.TaxonomyEntry)
+	for _, src := range taxonomySources {
+		names, descriptions, err := promptTags(src.prompt)
+		if err != nil {
+			return nil, fmt.Errorf("%s prompt: %w", src.source, err)
+		}
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			tag, ok := byName[name]
+			if !ok {
+				tag = &brainv1.TaxonomyEntry{Name: name, Weight: weights[name]}
+				byName[name] = tag
+				tags = append(tags, tag)
+			}
+			if tag.Description == "" {
+				tag.Description = descriptions[name]
+			}
+			tag.Sources = append(tag.Sources, src.source)
+		}
+	}
+	return tags, nil
+}
+
+// GetTaxonomy lists the classifications and tags the classifiers may return,
+// and the weight of each tag
+func (s *ServiceImpl) GetTaxonomy(ctx context.Context, req *connect.Request[brainv1.GetTaxonomyRequest]) (*connect.Response[brainv1.GetTaxonomyResponse], error) {
+	tags, err := taxonomyTags()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load taxonomy: %w", err))
+	}
+	return connect.NewResponse(&brainv1.GetTaxonomyResponse{
+		Classifications: classificationTaxonomy,
+		Tags:            tags,
+	}), nil
+}
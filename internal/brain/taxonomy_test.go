@@ -0,0 +1,84 @@
+package brain
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestGetTaxonomy_MatchesConfiguredSet(t *testing.T) {
+	resp, err := NewServiceImpl(newTestDB(t)).GetTaxonomy(context.Background(), connect.NewRequest(&brainv1.GetTaxonomyRequest{}))
+	if err != nil {
+		t.Fatalf("taxonomy failed: %v", err)
+	}
+
+	classifications := resp.Msg.GetClassifications()
+	if len(classifications) != len(classificationLabels) {
+		t.Fatalf("expected %d classifications, got %v", len(classificationLabels), classifications)
+	}
+	for _, c := range classifications {
+		if !classificationLabels[c.GetName()] || c.GetDescription() == "" {
+			t.Errorf("unexpected classification %v", c)
+		}
+	}
+
+	tags := make(map[string]*brainv1.TaxonomyEntry)
+	for _, tag := range resp.Msg.GetTags() {
+		tags[tag.GetName()] = tag
+	}
+	for _, src := range taxonomySources {
+		names, _, err := promptTags(src.prompt)
+		if err != nil {
+			t.Fatalf("%s prompt: %v", src.source, err)
+		}
+		for _, name := range names {
+			if tag, ok := tags[name]; !ok || !slices.Contains(tag.GetSources(), src.source) {
+				t.Errorf("expected tag %q from the %s prompt, got %v", name, src.source, tag)
+			}
+		}
+	}
+
+	if got := tags["code-editor"]; got.GetDescription() == "" || !slices.Equal(got.GetSources(), []string{"application", "website", "document"}) {
+		t.Errorf("unexpected code-editor tag %v", got)
+	}
+	if got := tags["finance"]; !slices.Equal(got.GetSources(), []string{"website", "document", "email"}) {
+		t.Errorf("unexpected finance tag %v", got)
+	}
+}
+
+func TestGetTaxonomy_TagWeightsFollowConfig(t *testing.T) {
+	t.Setenv("CLASSIFICATION_TAG_WEIGHTS", "time-sink:-2, Work:+2,code-editor:1.5,broken,:3")
+
+	resp, err := NewServiceImpl(newTestDB(t)).GetTaxonomy(context.Background(), connect.NewRequest(&brainv1.GetTaxonomyRequest{}))
+	if err != nil {
+		t.Fatalf("taxonomy failed: %v", err)
+	}
+
+	weights := map[string]float64{"time-sink": -2, "work": 2, "code-editor": 1.5}
+	seen := 0
+	for _, tag := range resp.Msg.GetTags() {
+		want, ok := weights[tag.GetName()]
+		if ok {
+			seen++
+		}
+		if tag.GetWeight() != want {
+			t.Errorf("expected %s to weigh %v, got %v", tag.GetName(), want, tag.GetWeight())
+		}
+	}
+	if seen != len(weights) {
+		t.Fatalf("expected every weighted tag in the taxonomy, saw %d", seen)
+	}
+	for _, c := range resp.Msg.GetClassifications() {
+		if c.GetWeight() != 0 {
+			t.Errorf("expected classifications to stay unweighted, got %v", c)
+		}
+	}
+
+	if got := formatTagWeights(tagWeights()); got != "code-editor:1.5,time-sink:-2,work:2" {
+		t.Errorf("unexpected reported setting %q", got)
+	}
+}
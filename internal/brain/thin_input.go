@@ -0,0 +1,54 @@
+package brain
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// Policies for applications sent with too few fields, selected via
+// CLASSIFICATION_THIN_APP_POLICY
+const (
+	thinAppClassify = "classify"
+	thinAppNeutral  = "neutral"
+	thinAppRequire  = "require"
+)
+
+// defaultThinAppMinFields is a name plus a bundle id or window title
+const defaultThinAppMinFields = 2
+
+// policyInsufficientInput is reported when a thin application was not sent to the model
+const policyInsufficientInput = "insufficient_input"
+
+// applicationFields counts the identifying fields an application request carries
+func applicationFields(req *brainv1.ClassifyApplicationRequest) int {
+	var n int
+	for _, field := range []string{req.GetApplicationName(), req.GetApplicationBundleId(), req.GetWindowTitle()} {
+		if strings.TrimSpace(field) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// thinApplication applies CLASSIFICATION_THIN_APP_POLICY to requests with
+// fewer than CLASSIFICATION_THIN_APP_MIN_FIELDS fields. The default classifies
+// them as usual; "neutral" serves neutral without calling the model and
+// "require" rejects them. A nil result and error means classify.
+func thinApplication(req *brainv1.ClassifyApplicationRequest) (*brainv1.ClassificationResult, error) {
+	minFields := envInt("CLASSIFICATION_THIN_APP_MIN_FIELDS", defaultThinAppMinFields)
+	if applicationFields(req) >= minFields {
+		return nil, nil
+	}
+
+	switch envString("CLASSIFICATION_THIN_APP_POLICY", thinAppClassify) {
+	case thinAppNeutral:
+		return restrictedResult(policyInsufficientInput, "Not enough information about this app to classify it."), nil
+	case thinAppRequire:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at least %d of application name, bundle id and window title are required", minFields))
+	}
+	return nil, nil
+}
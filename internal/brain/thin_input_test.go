@@ -0,0 +1,58 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func classifyNameOnly(t *testing.T, models fakeModels) (*brainv1.ClassificationResult, error) {
+	t.Helper()
+	svc := newPolicyTestService(t, models)
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Helper"}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.GetClassification(), nil
+}
+
+func TestClassifyApplication_NameOnlyClassifiedByDefault(t *testing.T) {
+	result, err := classifyNameOnly(t, fakeModels{text: `{"classification":"supporting","reasoning":"utility","tags":["tools"],"confidence_score":0.4}`})
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if result.GetClassification() != "supporting" || result.GetPolicy() != nil {
+		t.Fatalf("expected the model's classification, got %v", result)
+	}
+}
+
+func TestClassifyApplication_NameOnlyForcedNeutral(t *testing.T) {
+	t.Setenv("CLASSIFICATION_THIN_APP_POLICY", thinAppNeutral)
+
+	result, err := classifyNameOnly(t, fakeModels{err: errors.New("model must not be called")})
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	if result.GetClassification() != "neutral" || result.GetPolicy().GetReason() != policyInsufficientInput {
+		t.Fatalf("expected a neutral insufficient_input result, got %v", result)
+	}
+}
+
+func TestClassifyApplication_NameOnlyRejectedWhenFieldsRequired(t *testing.T) {
+	t.Setenv("CLASSIFICATION_THIN_APP_POLICY", thinAppRequire)
+
+	if _, err := classifyNameOnly(t, fakeModels{err: errors.New("model must not be called")}); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+
+	// Requests meeting the minimum are classified as usual
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"editor","tags":["coding"],"confidence_score":0.9}`})
+	resp, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go"}))
+	if err != nil || resp.Msg.GetClassification().GetClassification() != "productive" {
+		t.Fatalf("expected a full request to be classified, got %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,93 @@
+package brain
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// defaultVolatileTitlePatterns match the parts of window titles that change
+// while the user stays on the same document: unsaved markers and cursor
+// positions. A pattern with a group drops only the group's text.
+var defaultVolatileTitlePatterns = []string{
+	`^\s*[●•*]\s*`,                           // "● main.go" in VS Code
+	`\s*[●•*]\s*$`,                           // "main.go •" in Sublime Text
+	`\s*\[\+\]`,                              // "main.go [+]" in Vim
+	`(?i)\s*\((?:modified|edited|unsaved)\)`, // "main.go (modified)"
+	`\.\w+(:\d+(?::\d+)?)\b`,                 // "main.go:42:7"
+	`(?i)\s*\bLn \d+,\s*Col \d+`,             // "Ln 42, Col 7"
+}
+
+// volatileTitlePatterns are compiled once, on first use, from
+// CLASSIFICATION_TITLE_VOLATILE_PATTERNS
+var volatileTitlePatterns = sync.OnceValue(func() []*regexp.Regexp {
+	return compileVolatileTitlePatterns(os.Getenv("CLASSIFICATION_TITLE_VOLATILE_PATTERNS"))
+})
+
+// compileVolatileTitlePatterns compiles raw, a JSON array of regular
+// expressions replacing the defaults; an empty or invalid value keeps the
+// defaults.
+func compileVolatileTitlePatterns(raw string) []*regexp.Regexp {
+	sources := defaultVolatileTitlePatterns
+	if raw = strings.TrimSpace(raw); raw != "" {
+		var configured []string
+		if err := json.Unmarshal([]byte(raw), &configured); err != nil {
+			slog.Warn("invalid CLASSIFICATION_TITLE_VOLATILE_PATTERNS, using defaults", "error", err)
+		} else {
+			sources = configured
+		}
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(sources))
+	for _, source := range sources {
+		pattern, err := regexp.Compile(source)
+		if err != nil {
+			slog.Warn("invalid volatile title pattern, skipping", "key", "CLASSIFICATION_TITLE_VOLATILE_PATTERNS", "pattern", source, "error", err)
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// canonicalTitle strips volatile markers from a window title so variants of
+// the same document share a cache key. Titles without markers are returned
+// unchanged, keeping their existing cache entries.
+func canonicalTitle(title string) string {
+	return stripVolatile(title, volatileTitlePatterns())
+}
+
+// stripVolatile removes every match of patterns from title, see canonicalTitle
+func stripVolatile(title string, patterns []*regexp.Regexp) string {
+	canonical := title
+	for _, pattern := range patterns {
+		canonical = removeMatches(pattern, canonical)
+	}
+	if canonical == title {
+		return title
+	}
+	return strings.Join(strings.Fields(canonical), " ")
+}
+
+// removeMatches drops every match of pattern from s, or only the text of its
+// first group when it has one
+func removeMatches(pattern *regexp.Regexp, s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range pattern.FindAllStringSubmatchIndex(s, -1) {
+		start, end := m[0], m[1]
+		if len(m) > 2 {
+			if m[2] < 0 {
+				continue
+			}
+			start, end = m[2], m[3]
+		}
+		b.WriteString(s[last:start])
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestCanonicalTitle(t *testing.T) {
+	for title, want := range map[string]string{
+		"main.go •": "main.go",
+		"● main.go - focusd - Visual Studio Code": "main.go - focusd - Visual Studio Code",
+		"main.go [+] (~/src/focusd) - VIM":        "main.go (~/src/focusd) - VIM",
+		"main.go:42:7 - focusd":                   "main.go - focusd",
+		"notes.md (modified)":                     "notes.md",
+		"main.go - focusd Ln 42, Col 7":           "main.go - focusd",
+		"Inbox • Gmail":                           "Inbox • Gmail",
+		"localhost:3000 - Chrome":                 "localhost:3000 - Chrome",
+		"main.go  -  focusd":                      "main.go  -  focusd",
+	} {
+		if got := canonicalTitle(title); got != want {
+			t.Errorf("canonicalTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestCanonicalTitle_ConfigurablePatterns(t *testing.T) {
+	configured := compileVolatileTitlePatterns(`["\\s*\\(\\d+ unread\\)", "("]`)
+	if got := stripVolatile("Mail (3 unread)", configured); got != "Mail" {
+		t.Fatalf("expected the configured pattern to apply, got %q", got)
+	}
+	if got := stripVolatile("main.go •", configured); got != "main.go •" {
+		t.Fatalf("expected the configured patterns to replace the defaults, got %q", got)
+	}
+
+	if got := stripVolatile("main.go •", compileVolatileTitlePatterns(`not json`)); got != "main.go" {
+		t.Fatalf("expected an invalid value to keep the defaults, got %q", got)
+	}
+}
+
+func TestClassifyApplication_DirtyTitleVariantsShareCacheEntry(t *testing.T) {
+	recorder := &inputRecorder{text: `{"classification":"productive","reasoning":"Editing code.","tags":["work"],"confidence_score":0.9}`}
+	models := &switchableModels{}
+	var active contentGenerator = recorder
+	svc := NewServiceImpl(newTestDB(t))
+	// Read active at call time so the switch below takes effect
+	svc.newClassificationService = func(db *gorm.DB) (*ClassificationService, error) {
+		return &ClassificationService{db: db, models: active, model: "gemini-test"}, nil
+	}
+
+	// The model sees the raw title
+	classify := func(title string) {
+		t.Helper()
+		if _, err := svc.ClassifyApplication(context.Background(), connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Sublime Text", WindowTitle: title})); err != nil {
+			t.Fatalf("classification of %q failed: %v", title, err)
+		}
+		svc.pendingStores.Wait()
+	}
+	classify("main.go •")
+	if recorder.input["title"] != "main.go •" {
+		t.Fatalf("expected the raw title in the prompt, got %q", recorder.input["title"])
+	}
+
+	// Variants without the marker hit the entry it created
+	active = models
+	classify("main.go")
+	classify("main.go •")
+	if models.calls != 0 {
+		t.Fatalf("expected the variants to share one cache entry, got %d more model calls", models.calls)
+	}
+}
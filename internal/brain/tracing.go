@@ -0,0 +1,115 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans started by the brain service
+const tracerName = "github.com/focusd-so/brain/internal/brain"
+
+// SetupTracing exports spans over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT
+// is set; the exporter reads the other standard OTEL_EXPORTER_OTLP_* variables
+// itself. Without an endpoint tracing stays a no-op. The returned function
+// flushes pending spans, call it on shutdown.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(envString("OTEL_SERVICE_NAME", "focusd-brain")),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// startSpan starts a span on the current global tracer provider
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingInterceptor wraps every RPC in a server span, continuing the trace
+// the client propagated in the request headers
+type tracingInterceptor struct{}
+
+// NewTracingInterceptor creates a ConnectRPC interceptor tracing unary and streaming RPCs
+func NewTracingInterceptor() connect.Interceptor {
+	return &tracingInterceptor{}
+}
+
+func (i *tracingInterceptor) start(ctx context.Context, spec connect.Spec, header propagation.HeaderCarrier) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, header)
+	return otel.Tracer(tracerName).Start(ctx, spec.Procedure,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.system", "connect_rpc")),
+	)
+}
+
+// WrapUnary implements unary RPC tracing
+func (i *tracingInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, span := i.start(ctx, req.Spec(), propagation.HeaderCarrier(req.Header()))
+		resp, err := next(ctx, req)
+		endRPCSpan(span, err)
+		return resp, err
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *tracingInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements streaming RPC tracing
+func (i *tracingInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, span := i.start(ctx, conn.Spec(), propagation.HeaderCarrier(conn.RequestHeader()))
+		err := next(ctx, conn)
+		endRPCSpan(span, err)
+		return err
+	}
+}
+
+// endRPCSpan tags the span with the connect code before ending it
+func endRPCSpan(span trace.Span, err error) {
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			span.SetAttributes(attribute.String("rpc.connect_rpc.error_code", connectErr.Code().String()))
+		}
+	}
+	endSpan(span, err)
+}
@@ -0,0 +1,67 @@
+package brain
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/gen/brain/v1/brainv1connect"
+)
+
+func TestTracing_ClassifyWebsiteSpans(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"Docs.","tags":["work"],"confidence_score":0.9}`})
+	_, handler := brainv1connect.NewBrainServiceHandler(svc, connect.WithInterceptors(NewTracingInterceptor()))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := brainv1connect.NewBrainServiceClient(srv.Client(), srv.URL)
+	req := connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: "http://192.0.2.1/docs", Title: "Docs"})
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req.Header().Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	if _, err := client.ClassifyWebsite(context.Background(), req); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+
+	rpc := brainv1connect.BrainServiceClassifyWebsiteProcedure
+	for _, name := range []string{rpc, "website.fetch_metadata", "cache.lookup", "gemini.generate_content"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("expected a %q span, got %v", name, exporter.GetSpans().Snapshots())
+		}
+		if got := span.SpanContext.TraceID().String(); got != traceID {
+			t.Errorf("%s: expected the client's trace %s, got %s", name, traceID, got)
+		}
+	}
+
+	// Work spans nest under the RPC span
+	root := spans[rpc].SpanContext.SpanID()
+	for _, name := range []string{"website.fetch_metadata", "cache.lookup", "gemini.generate_content"} {
+		if spans[name].Parent.SpanID() != root {
+			t.Errorf("expected %s to be a child of the RPC span", name)
+		}
+	}
+}
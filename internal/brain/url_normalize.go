@@ -0,0 +1,123 @@
+package brain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// defaultMaxURLLength bounds a website URL after normalization, overridable
+// via CLASSIFICATION_MAX_URL_LENGTH
+const defaultMaxURLLength = 2048
+
+// defaultURLStripParams are the tracking parameters dropped from website URLs
+// unless CLASSIFICATION_URL_STRIP_PARAMS replaces them. A trailing "*"
+// matches any parameter with that prefix.
+const defaultURLStripParams = "utm_*,fbclid,gclid,dclid,msclkid,mc_cid,mc_eid,igshid,yclid,_ga,_gl"
+
+// defaultPorts are dropped from URLs of their scheme
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// urlStripParams returns the configured tracking parameters, lowercased
+func urlStripParams() []string {
+	var params []string
+	for _, param := range strings.Split(envString("CLASSIFICATION_URL_STRIP_PARAMS", defaultURLStripParams), ",") {
+		if param = strings.ToLower(strings.TrimSpace(param)); param != "" {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+func isStrippedParam(name string, strip []string) bool {
+	name = strings.ToLower(name)
+	for _, param := range strip {
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == param {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeWebsiteURL reduces variants of the same page to one URL before it
+// is classified and cached: the scheme and host are lowercased, default
+// ports, fragments and tracking parameters are dropped and the remaining
+// parameters are sorted. Unparseable URLs are only trimmed.
+func normalizeWebsiteURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := parseWebsiteURL(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" && port != defaultPorts[u.Scheme] {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment, u.RawFragment = "", ""
+
+	if u.RawQuery != "" {
+		strip := urlStripParams()
+		query := u.Query()
+		for name := range query {
+			if isStrippedParam(name, strip) {
+				query.Del(name)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	u.ForceQuery = false
+	return u.String()
+}
+
+// checkURLLength rejects normalized URLs longer than CLASSIFICATION_MAX_URL_LENGTH
+func checkURLLength(normalized string) error {
+	maxLength := envInt("CLASSIFICATION_MAX_URL_LENGTH", defaultMaxURLLength)
+	if maxLength > 0 && len(normalized) > maxLength {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("url is longer than %d bytes", maxLength))
+	}
+	return nil
+}
+
+// cacheURLsKey carries the website URLs recorded with a new cache entry
+type cacheURLsKey struct{}
+
+// cacheURLs are the URL a client sent and the normalized form the cache key
+// was built from
+type cacheURLs struct {
+	original   string
+	normalized string
+}
+
+// withCacheURLs returns a copy of ctx recording both forms of a website URL
+// on the cache entry its classification creates, so cache hits can be traced
+// back to the URL that produced them. Original URLs may carry session tokens
+// and personal query parameters, so they are only kept with
+// CLASSIFICATION_CACHE_STORE_URLS=true.
+func withCacheURLs(ctx context.Context, original, normalized string) context.Context {
+	if !envBool("CLASSIFICATION_CACHE_STORE_URLS", false) {
+		return ctx
+	}
+	return context.WithValue(ctx, cacheURLsKey{}, cacheURLs{original: strings.TrimSpace(original), normalized: normalized})
+}
+
+// recordCacheURLs copies the URLs recorded in ctx onto a cache entry
+func recordCacheURLs(ctx context.Context, entry *commonv1.PromptHistoryORM) {
+	if urls, ok := ctx.Value(cacheURLsKey{}).(cacheURLs); ok {
+		entry.OriginalUrl = urls.original
+		entry.NormalizedUrl = urls.normalized
+	}
+}
@@ -0,0 +1,102 @@
+package brain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+func TestNormalizeWebsiteURL(t *testing.T) {
+	for raw, want := range map[string]string{
+		"https://Docs.Example.com:443/guide?utm_source=x&utm_medium=y#install": "https://docs.example.com/guide",
+		"http://example.com:80/a?b=2&a=1&fbclid=abc":                           "http://example.com/a?a=1&b=2",
+		"https://example.com:8443/a?gclid=1&q=go":                              "https://example.com:8443/a?q=go",
+		"https://example.com/search?q=utm_source":                              "https://example.com/search?q=utm_source",
+		"github.com/focusd?UTM_Campaign=launch":                                "https://github.com/focusd",
+		"  https://example.com/path  ":                                         "https://example.com/path",
+		"http://[::1]:80/":                                                     "http://[::1]/",
+	} {
+		if got := normalizeWebsiteURL(raw); got != want {
+			t.Errorf("normalizeWebsiteURL(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestNormalizeWebsiteURL_ConfigurableStripList(t *testing.T) {
+	t.Setenv("CLASSIFICATION_URL_STRIP_PARAMS", "ref, session_*")
+
+	if got, want := normalizeWebsiteURL("https://example.com/?ref=hn&session_id=1&utm_source=x"), "https://example.com/?utm_source=x"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestClassifyWebsite_TrackingVariantsShareCacheEntry(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	models := &switchableModels{}
+	svc := NewServiceImpl(newTestDB(t))
+	useTestModels(svc, models)
+
+	for _, url := range []string{
+		"https://Blog.Example.invalid/post?utm_source=newsletter",
+		"https://blog.example.invalid:443/post?fbclid=abc#comments",
+		"https://blog.example.invalid/post",
+	} {
+		if _, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url})); err != nil {
+			t.Fatalf("classification of %s failed: %v", url, err)
+		}
+		svc.pendingStores.Wait()
+	}
+	if models.calls != 1 {
+		t.Fatalf("expected the variants to share one cache entry, got %d model calls", models.calls)
+	}
+}
+
+func TestClassifyWebsite_RejectsOverlongURL(t *testing.T) {
+	t.Setenv("CLASSIFICATION_MAX_URL_LENGTH", "64")
+
+	svc := newPolicyTestService(t, fakeModels{})
+	url := "https://example.com/" + strings.Repeat("a", 64)
+	if _, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+}
+
+func TestClassifyWebsite_StoresOriginalAndNormalizedURL(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	classify := func(t *testing.T) commonv1.PromptHistoryORM {
+		db := newTestDB(t)
+		svc := NewServiceImpl(db)
+		useTestModels(svc, &switchableModels{})
+		url := "https://Blog.Example.invalid/post?utm_source=newsletter#comments"
+		if _, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{Url: url})); err != nil {
+			t.Fatalf("classification failed: %v", err)
+		}
+		svc.pendingStores.Wait()
+
+		var entry commonv1.PromptHistoryORM
+		if err := db.First(&entry).Error; err != nil {
+			t.Fatalf("expected a cache entry: %v", err)
+		}
+		return entry
+	}
+
+	if entry := classify(t); entry.OriginalUrl != "" || entry.NormalizedUrl != "" {
+		t.Fatalf("expected no URLs stored by default, got %q and %q", entry.OriginalUrl, entry.NormalizedUrl)
+	}
+
+	t.Setenv("CLASSIFICATION_CACHE_STORE_URLS", "true")
+	entry := classify(t)
+	if entry.OriginalUrl != "https://Blog.Example.invalid/post?utm_source=newsletter#comments" {
+		t.Fatalf("unexpected original url %q", entry.OriginalUrl)
+	}
+	if entry.NormalizedUrl != "https://blog.example.invalid/post" {
+		t.Fatalf("unexpected normalized url %q", entry.NormalizedUrl)
+	}
+}
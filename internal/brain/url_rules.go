@@ -0,0 +1,118 @@
+package brain
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+// urlRulesModel is reported as the model of results decided by a URL rule
+const urlRulesModel = "url-rules"
+
+// urlRule classifies a website locally from its domain, path and query. A
+// rule without a classification marks the URL as ambiguous: matching stops
+// and the model decides.
+type urlRule struct {
+	Domain         string            `json:"domain"`                // matches the host and its subdomains
+	PathPrefix     string            `json:"path_prefix,omitempty"` // e.g. "/shorts"
+	Query          map[string]string `json:"query,omitempty"`       // required params, an empty value only requires presence
+	Classification string            `json:"classification,omitempty"`
+	Reasoning      string            `json:"reasoning,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+}
+
+// defaultURLRules cover distinctions that need no model. Rules are checked
+// in order, so more specific hosts come first.
+var defaultURLRules = []urlRule{
+	{
+		Domain:         "music.youtube.com",
+		Classification: "supporting",
+		Reasoning:      "YouTube Music plays audio in the background.",
+		Tags:           []string{"supporting-audio", "music"},
+	},
+	{
+		Domain:         "youtube.com",
+		PathPrefix:     "/shorts",
+		Classification: "distracting",
+		Reasoning:      "YouTube Shorts is a short-form video feed.",
+		Tags:           []string{"entertainment"},
+	},
+	// A video may be music, a podcast or entertainment, only the title tells
+	{Domain: "youtube.com", PathPrefix: "/watch"},
+}
+
+// urlRules returns the configured ruleset. WEBSITE_URL_RULES holds a JSON
+// array of rules replacing the defaults; an invalid value keeps the defaults.
+func urlRules() []urlRule {
+	raw := strings.TrimSpace(os.Getenv("WEBSITE_URL_RULES"))
+	if raw == "" {
+		return defaultURLRules
+	}
+
+	var rules []urlRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		slog.Warn("invalid WEBSITE_URL_RULES, using defaults", "error", err)
+		return defaultURLRules
+	}
+	return rules
+}
+
+// matchURLRule returns the first rule matching rawURL. The rule is nil when
+// nothing matched or when the matching rule defers to the model.
+func matchURLRule(rawURL string) *urlRule {
+	u, err := parseWebsiteURL(rawURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host == "" {
+		return nil
+	}
+
+	rules := urlRules()
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.matches(host, u) {
+			continue
+		}
+		if rule.Classification == "" {
+			return nil
+		}
+		return rule
+	}
+	return nil
+}
+
+func (r *urlRule) matches(host string, u *url.URL) bool {
+	domain := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(r.Domain)), "www.")
+	if domain == "" || (host != domain && !strings.HasSuffix(host, "."+domain)) {
+		return false
+	}
+	if r.PathPrefix != "" && !strings.HasPrefix(u.Path, r.PathPrefix) {
+		return false
+	}
+
+	query := u.Query()
+	for key, value := range r.Query {
+		if !query.Has(key) || (value != "" && query.Get(key) != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// result builds the classification served for a rule match
+func (r *urlRule) result(signals []string) *brainv1.ClassificationResult {
+	return &brainv1.ClassificationResult{
+		Classification:  r.Classification,
+		Reasoning:       r.Reasoning,
+		Tags:            normalizeTags(r.Tags),
+		ConfidenceScore: 1,
+		Signals:         append(signals, "matched url rule for "+r.Domain+r.PathPrefix),
+		Model:           urlRulesModel,
+	}
+}
@@ -0,0 +1,94 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+)
+
+func TestMatchURLRule_YouTube(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://music.youtube.com/watch?v=abc&list=RD": "supporting",
+		"https://www.youtube.com/shorts/xyz":            "distracting",
+		"youtube.com/shorts/xyz":                        "distracting",
+		"https://www.youtube.com/watch?v=abc":           "",
+		"https://m.youtube.com/watch?v=abc":             "",
+		"https://www.youtube.com/":                      "",
+		"https://notyoutube.com/shorts/xyz":             "",
+	} {
+		got := ""
+		if rule := matchURLRule(url); rule != nil {
+			got = rule.Classification
+		}
+		if got != want {
+			t.Errorf("%s: expected %q, got %q", url, want, got)
+		}
+	}
+}
+
+func TestMatchURLRule_ConfiguredQueryRules(t *testing.T) {
+	t.Setenv("WEBSITE_URL_RULES", `[
+		{"domain": "google.com", "path_prefix": "/search", "query": {"tbm": "vid"}, "classification": "distracting", "reasoning": "Video search results."},
+		{"domain": "docs.example", "query": {"edit": ""}, "classification": "productive", "reasoning": "Editing a document."}
+	]`)
+
+	cases := map[string]string{
+		"https://www.google.com/search?q=lofi&tbm=vid": "distracting",
+		"https://www.google.com/search?q=golang":       "",
+		"https://docs.example/d/1?edit":                "productive",
+		"https://docs.example/d/1?view=1":              "",
+		"https://music.youtube.com/":                   "", // configured rules replace the defaults
+	}
+	for url, want := range cases {
+		got := ""
+		if rule := matchURLRule(url); rule != nil {
+			got = rule.Classification
+		}
+		if got != want {
+			t.Errorf("%s: expected %q, got %q", url, want, got)
+		}
+	}
+}
+
+func TestClassifyWebsite_URLRuleSkipsModel(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{err: errors.New("model called")})
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:   "https://music.youtube.com/watch?v=abc",
+		Title: "Lofi beats - YouTube Music",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	result := resp.Msg.GetClassification()
+	if result.GetClassification() != "supporting" || result.GetModel() != urlRulesModel {
+		t.Fatalf("expected a local supporting result, got %v", result)
+	}
+}
+
+func TestClassifyWebsite_AmbiguousURLDefersToModel(t *testing.T) {
+	t.Setenv("WEBSITE_METADATA_TIMEOUT", "1ms")
+
+	recorder := &inputRecorder{text: `{"classification":"distracting","reasoning":"A gaming video.","tags":["entertainment"],"confidence_score":0.9}`}
+	svc := newPolicyTestService(t, recorder)
+
+	resp, err := svc.ClassifyWebsite(context.Background(), connect.NewRequest(&brainv1.ClassifyWebsiteRequest{
+		Url:   "https://www.youtube.com/watch?v=abc",
+		Title: "Speedrun highlights - YouTube",
+	}))
+	if err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+
+	if recorder.input["url"] != "https://www.youtube.com/watch?v=abc" {
+		t.Fatalf("expected the watch page to reach the model, got %v", recorder.input)
+	}
+	if result := resp.Msg.GetClassification(); result.GetClassification() != "distracting" || result.GetModel() == urlRulesModel {
+		t.Fatalf("expected the model's result, got %v", result)
+	}
+}
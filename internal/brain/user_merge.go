@@ -0,0 +1,121 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+)
+
+// userOwnedTables are the tables MergeUsers reassigns, with the columns that
+// together with user_id identify a row. A source row whose keys the target
+// already has is dropped, so the target's own choice wins.
+var userOwnedTables = []struct {
+	table string
+	keys  []string
+}{
+	{"classification_overrides", []string{"kind", "target"}},
+	{"classification_votes", []string{"input_key"}},
+	{"linked_providers", []string{"provider"}},
+	{"prompt_addendums", []string{"scope"}},
+	{"detected_projects", []string{"canonical_name"}},
+	{"focus_sessions", nil},
+}
+
+// mergeTable moves the source's rows of one table to the target and drops the
+// ones that collide with the target's
+func mergeTable(tx *gorm.DB, table string, keys []string, sourceID, targetID int64) (moved, dropped int64, err error) {
+	query := fmt.Sprintf("UPDATE %s SET user_id = ? WHERE user_id = ?", table)
+	args := []any{targetID, sourceID}
+	if len(keys) > 0 {
+		conditions := make([]string, len(keys))
+		for i, key := range keys {
+			conditions[i] = fmt.Sprintf("kept.%[1]s = %[2]s.%[1]s", key, table)
+		}
+		query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM %s AS kept WHERE kept.user_id = ? AND %s)", table, strings.Join(conditions, " AND "))
+		args = append(args, targetID)
+	}
+
+	result := tx.Exec(query, args...)
+	if result.Error != nil {
+		return 0, 0, fmt.Errorf("failed to move %s: %w", table, result.Error)
+	}
+	moved = result.RowsAffected
+
+	result = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE user_id = ?", table), sourceID)
+	if result.Error != nil {
+		return 0, 0, fmt.Errorf("failed to drop %s: %w", table, result.Error)
+	}
+	return moved, result.RowsAffected, nil
+}
+
+// MergeUsers moves a duplicate user's data to another user and deletes it
+func (s *ServiceImpl) MergeUsers(ctx context.Context, req *connect.Request[brainv1.MergeUsersRequest]) (*connect.Response[brainv1.MergeUsersResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sourceID, targetID := req.Msg.SourceUserId, req.Msg.TargetUserId
+	if sourceID == targetID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("cannot merge a user into itself"))
+	}
+
+	resp := &brainv1.MergeUsersResponse{}
+	errTargetNotFound := errors.New("target user not found")
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var users []commonv1.UserORM
+		if err := tx.Where("id IN ?", []int64{sourceID, targetID}).Find(&users).Error; err != nil {
+			return err
+		}
+		var hasSource, hasTarget bool
+		for _, user := range users {
+			hasSource = hasSource || user.Id == sourceID
+			hasTarget = hasTarget || user.Id == targetID
+		}
+		if !hasTarget {
+			return errTargetNotFound
+		}
+		// A retried merge finds the source already gone
+		if !hasSource {
+			resp.AlreadyMerged = true
+			return nil
+		}
+
+		for _, owned := range userOwnedTables {
+			moved, dropped, err := mergeTable(tx, owned.table, owned.keys, sourceID, targetID)
+			if err != nil {
+				return err
+			}
+			resp.RowsMoved += moved
+			resp.RowsDropped += dropped
+		}
+
+		// Tokens name the source, so its sessions end with it
+		err := tx.Model(&commonv1.SessionORM{}).
+			Where("user_id = ? AND revoked_at = 0", sourceID).
+			Update("revoked_at", time.Now().Unix()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&commonv1.UserORM{}, sourceID).Error
+	})
+	if errors.Is(err, errTargetNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, dbError("failed to merge users", err)
+	}
+
+	slog.Info("users merged", "source_user_id", sourceID, "target_user_id", targetID,
+		"rows_moved", resp.RowsMoved, "rows_dropped", resp.RowsDropped, "already_merged", resp.AlreadyMerged)
+	return connect.NewResponse(resp), nil
+}
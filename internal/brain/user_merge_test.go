@@ -0,0 +1,108 @@
+package brain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func TestMergeUsers_MovesDataAndRemovesSource(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	db := svc.gormDB
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	now := time.Now().Unix()
+
+	for _, user := range []commonv1.UserORM{
+		{Id: 7, DeviceFingerprintHash: "laptop", Role: "anonymous", CreatedAt: now},
+		{Id: 8, DeviceFingerprintHash: "desktop", Role: "anonymous", CreatedAt: now},
+	} {
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	override := func(userID int64, target, classification string) *commonv1.ClassificationOverrideORM {
+		return &commonv1.ClassificationOverrideORM{UserId: userID, Kind: "application", Target: target, Classification: classification, CreatedAt: now, UpdatedAt: now}
+	}
+	for _, row := range []any{
+		override(8, "com.valvesoftware.steam", "distracting"),
+		override(8, "com.tinyspeck.slackmacgap", "distracting"),
+		override(7, "com.tinyspeck.slackmacgap", "productive"),
+		&commonv1.LinkedProviderORM{UserId: 8, Provider: "github", CreatedAt: now, UpdatedAt: now},
+		&commonv1.FocusSessionORM{UserId: 8, StartedAt: now},
+		&commonv1.SessionORM{Jti: "desktop-session", UserId: 8, CreatedAt: now, ExpiresAt: now + 3600},
+	} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("failed to seed %T: %v", row, err)
+		}
+	}
+
+	merge := func() *brainv1.MergeUsersResponse {
+		t.Helper()
+		resp, err := svc.MergeUsers(admin, connect.NewRequest(&brainv1.MergeUsersRequest{SourceUserId: 8, TargetUserId: 7}))
+		if err != nil {
+			t.Fatalf("merge failed: %v", err)
+		}
+		return resp.Msg
+	}
+
+	if resp := merge(); resp.RowsMoved != 3 || resp.RowsDropped != 1 || resp.AlreadyMerged {
+		t.Fatalf("expected 3 rows moved and the clashing override dropped, got %v", resp)
+	}
+
+	var overrides []commonv1.ClassificationOverrideORM
+	db.Where("user_id = ?", 7).Order("target").Find(&overrides)
+	if len(overrides) != 2 || overrides[0].Target != "com.tinyspeck.slackmacgap" || overrides[0].Classification != "productive" {
+		t.Fatalf("expected the target's own override to win, got %v", overrides)
+	}
+	var count int64
+	db.Model(&commonv1.LinkedProviderORM{}).Where("user_id = ?", 7).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected the linked provider to move, got %d", count)
+	}
+	db.Model(&commonv1.FocusSessionORM{}).Where("user_id = ?", 7).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected the focus session to move, got %d", count)
+	}
+	db.Model(&commonv1.UserORM{}).Where("id = ?", 8).Count(&count)
+	if count != 0 {
+		t.Fatal("expected the source user to be deleted")
+	}
+	if err := svc.CheckSession(context.Background(), &auth.UserClaims{UserID: 8, TokenID: "desktop-session"}); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected the source's session to be revoked, got %v", err)
+	}
+
+	// Retrying the merge changes nothing
+	if resp := merge(); !resp.AlreadyMerged || resp.RowsMoved != 0 {
+		t.Fatalf("expected a repeated merge to be a no-op, got %v", resp)
+	}
+}
+
+func TestMergeUsers_Rejections(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	admin := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 1, Role: adminRole})
+	user := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+
+	for _, tc := range []struct {
+		name           string
+		ctx            context.Context
+		source, target int64
+		code           connect.Code
+	}{
+		{"not admin", user, 8, 7, connect.CodePermissionDenied},
+		{"same user", admin, 7, 7, connect.CodeInvalidArgument},
+		{"missing target", admin, 8, 9, connect.CodeNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.MergeUsers(tc.ctx, connect.NewRequest(&brainv1.MergeUsersRequest{SourceUserId: tc.source, TargetUserId: tc.target}))
+			if connect.CodeOf(err) != tc.code {
+				t.Fatalf("expected %v, got %v", tc.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// defaultVoteMetricInputs bounds how many inputs the agreement metric tracks,
+// overridable via CLASSIFICATION_VOTE_METRIC_MAX_INPUTS
+const defaultVoteMetricInputs = 1000
+
+// voteTally is the current up/down count for one input
+type voteTally struct {
+	up   int64
+	down int64
+}
+
+// voteStats keeps the latest tally per input for the agreement metric. Votes
+// themselves live in the database, this is only a view for monitoring.
+type voteStats struct {
+	mu     sync.Mutex
+	inputs map[string]voteTally
+}
+
+// classificationVotes is exported on /debug/vars as classification_votes
+var classificationVotes = &voteStats{inputs: map[string]voteTally{}}
+
+func init() {
+	expvar.Publish("classification_votes", expvar.Func(func() any { return classificationVotes.snapshot() }))
+}
+
+// set replaces the tally of an input. New inputs are dropped once the metric
+// tracks maxInputs, existing ones keep updating.
+func (s *voteStats) set(inputKey string, tally voteTally, maxInputs int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.inputs[inputKey]; !ok && maxInputs > 0 && len(s.inputs) >= maxInputs {
+		return
+	}
+	s.inputs[inputKey] = tally
+}
+
+func (s *voteStats) snapshot() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total voteTally
+	inputs := make(map[string]any, len(s.inputs))
+	for key, tally := range s.inputs {
+		total.up += tally.up
+		total.down += tally.down
+		inputs[key] = map[string]any{
+			"up":             tally.up,
+			"down":           tally.down,
+			"agreement_rate": tally.agreementRate(),
+		}
+	}
+	return map[string]any{
+		"up":             total.up,
+		"down":           total.down,
+		"agreement_rate": total.agreementRate(),
+		"inputs":         inputs,
+	}
+}
+
+func (t voteTally) agreementRate() float64 {
+	if t.up+t.down == 0 {
+		return 0
+	}
+	return float64(t.up) / float64(t.up+t.down)
+}
+
+// voteInputKey normalizes the rated input so votes on the same app, site,
+// document, sender or command aggregate together. Websites are grouped by domain.
+func voteInputKey(kind, input string) string {
+	input = strings.TrimSpace(input)
+	switch kind {
+	case "application":
+		input = strings.ToLower(input)
+	case "website":
+		if host := websiteHost(input); host != "" {
+			input = host
+		}
+	case "document":
+		input = normalizeDocumentPath(input)
+	case "email":
+		input = normalizeEmailSender(input)
+	case "command":
+		input = normalizeCommand(input)
+	}
+	return kind + ":" + input
+}
+
+// RateClassification records the user's vote on a classification. A repeat
+// vote on the same input replaces the previous one. Votes feed the agreement
+// metric only, they never change what the classifiers return.
+func (s *ServiceImpl) RateClassification(ctx context.Context, req *connect.Request[brainv1.RateClassificationRequest]) (*connect.Response[brainv1.RateClassificationResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	inputKey := voteInputKey(req.Msg.Kind, req.Msg.Input)
+
+	now := time.Now().Unix()
+	vote := commonv1.ClassificationVoteORM{
+		UserId:         user.UserID,
+		InputKey:       inputKey,
+		Classification: req.Msg.Classification,
+		Agree:          req.Msg.Agree,
+		PromptVersion:  req.Msg.PromptVersion,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err := s.gormDB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "input_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"classification", "agree", "prompt_version", "updated_at"}),
+	}).Create(&vote).Error
+	if err != nil {
+		return nil, dbError("failed to record vote", err)
+	}
+
+	tally, err := tallyVotes(ctx, s.gormDB, inputKey)
+	if err != nil {
+		// The vote is stored, only the metric lags until the next one
+		slog.Warn("failed to tally votes", "input", inputKey, "error", err)
+	} else {
+		classificationVotes.set(inputKey, tally, envInt("CLASSIFICATION_VOTE_METRIC_MAX_INPUTS", defaultVoteMetricInputs))
+	}
+
+	slog.Info("classification rated", "input", inputKey, "agree", req.Msg.Agree, "prompt_version", req.Msg.PromptVersion)
+	return connect.NewResponse(&brainv1.RateClassificationResponse{}), nil
+}
+
+// tallyVotes counts the current up and down votes on an input
+func tallyVotes(ctx context.Context, db *gorm.DB, inputKey string) (voteTally, error) {
+	var rows []struct {
+		Agree bool
+		Count int64
+	}
+	err := db.WithContext(ctx).Model(&commonv1.ClassificationVoteORM{}).
+		Select("agree, COUNT(*) AS count").
+		Where("input_key = ?", inputKey).
+		Group("agree").
+		Scan(&rows).Error
+	if err != nil {
+		return voteTally{}, err
+	}
+
+	var tally voteTally
+	for _, row := range rows {
+		if row.Agree {
+			tally.up = row.Count
+		} else {
+			tally.down = row.Count
+		}
+	}
+	return tally, nil
+}
@@ -0,0 +1,94 @@
+package brain
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+func rate(t *testing.T, svc *ServiceImpl, userID int64, kind, input string, agree bool) {
+	t.Helper()
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: userID})
+	_, err := svc.RateClassification(ctx, connect.NewRequest(&brainv1.RateClassificationRequest{
+		Kind:           kind,
+		Input:          input,
+		Classification: "distracting",
+		Agree:          agree,
+		PromptVersion:  "v2",
+	}))
+	if err != nil {
+		t.Fatalf("rating failed: %v", err)
+	}
+}
+
+func TestRateClassification_AggregatesPerInput(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+
+	// Votes on different pages of one site aggregate by domain
+	rate(t, svc, 1, "website", "https://www.votes-test.example/feed", true)
+	rate(t, svc, 2, "website", "https://votes-test.example/watch?v=1", true)
+	rate(t, svc, 3, "website", "votes-test.example", false)
+
+	tally, err := tallyVotes(context.Background(), svc.gormDB, "website:votes-test.example")
+	if err != nil {
+		t.Fatalf("tally failed: %v", err)
+	}
+	if tally.up != 2 || tally.down != 1 {
+		t.Fatalf("expected 2 up and 1 down, got %+v", tally)
+	}
+
+	inputs := classificationVotes.snapshot()["inputs"].(map[string]any)
+	metric, ok := inputs["website:votes-test.example"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected the input in the agreement metric, got %v", inputs)
+	}
+	if rate := metric["agreement_rate"].(float64); rate < 0.66 || rate > 0.67 {
+		t.Fatalf("expected an agreement rate of 2/3, got %v", rate)
+	}
+}
+
+func TestRateClassification_RevoteReplacesPrevious(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+
+	rate(t, svc, 1, "application", "com.example.Revote", false)
+	rate(t, svc, 1, "application", "COM.EXAMPLE.REVOTE", true)
+
+	tally, err := tallyVotes(context.Background(), svc.gormDB, "application:com.example.revote")
+	if err != nil {
+		t.Fatalf("tally failed: %v", err)
+	}
+	if tally.up != 1 || tally.down != 0 {
+		t.Fatalf("expected the second vote to replace the first, got %+v", tally)
+	}
+}
+
+func TestRateClassification_RequiresSession(t *testing.T) {
+	svc := NewServiceImpl(newTestDB(t))
+	_, err := svc.RateClassification(context.Background(), connect.NewRequest(&brainv1.RateClassificationRequest{
+		Kind:  "application",
+		Input: "Slack",
+	}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestVoteStats_CapsTrackedInputs(t *testing.T) {
+	stats := &voteStats{inputs: map[string]voteTally{}}
+	stats.set("a", voteTally{up: 1}, 2)
+	stats.set("b", voteTally{down: 1}, 2)
+	stats.set("c", voteTally{up: 1}, 2)
+	stats.set("a", voteTally{up: 2}, 2)
+
+	snapshot := stats.snapshot()
+	if inputs := snapshot["inputs"].(map[string]any); len(inputs) != 2 || inputs["c"] != nil {
+		t.Fatalf("expected new inputs to be dropped at the cap, got %v", inputs)
+	}
+	if snapshot["up"].(int64) != 2 || snapshot["down"].(int64) != 1 {
+		t.Fatalf("expected existing inputs to keep updating, got %v", snapshot)
+	}
+}
@@ -0,0 +1,288 @@
+package brain
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+	"github.com/focusd-so/brain/internal/secrets"
+)
+
+// Default webhook delivery: attempts per event, the first retry delay
+// (doubling per attempt), the timeout of each attempt and how many events may
+// be in delivery at once before new ones are dropped
+const (
+	defaultWebhookMaxAttempts = 4
+	defaultWebhookBackoff     = 500 * time.Millisecond
+	defaultWebhookTimeout     = 5 * time.Second
+	defaultWebhookMaxInFlight = 64
+)
+
+var (
+	// webhooksDropped counts events dropped because too many were in delivery
+	webhooksDropped = expvar.NewInt("classification_webhooks_dropped")
+	// webhooksFailed counts events given up on after their last attempt
+	webhooksFailed = expvar.NewInt("classification_webhooks_failed")
+)
+
+// webhookEvent is the JSON body posted for each classification
+type webhookEvent struct {
+	ID             string          `json:"id"` // stable across retries, for deduplication
+	Kind           string          `json:"kind"`
+	UserID         int64           `json:"user_id"`
+	CreatedAt      int64           `json:"created_at"`
+	Classification json.RawMessage `json:"classification"`
+}
+
+// webhookSender posts classification events to CLASSIFICATION_WEBHOOK_URL,
+// signed with CLASSIFICATION_WEBHOOK_SECRET. Deliveries run detached and are
+// retried with exponential backoff on network errors, 429s and 5xx responses.
+type webhookSender struct {
+	url         string
+	secret      []byte
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+
+	slots   chan struct{}
+	pending sync.WaitGroup
+}
+
+// newWebhookSender returns nil when no webhook URL is configured. A URL
+// without a secret is refused, receivers could not verify the events.
+func newWebhookSender() *webhookSender {
+	url := envString("CLASSIFICATION_WEBHOOK_URL", "")
+	if url == "" {
+		return nil
+	}
+	secret := secrets.Get("CLASSIFICATION_WEBHOOK_SECRET")
+	if secret == "" {
+		slog.Error("classification webhook disabled, CLASSIFICATION_WEBHOOK_SECRET is not set")
+		return nil
+	}
+	return &webhookSender{
+		url:         url,
+		secret:      []byte(secret),
+		client:      &http.Client{},
+		maxAttempts: max(envInt("CLASSIFICATION_WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts), 1),
+		backoff:     envDuration("CLASSIFICATION_WEBHOOK_BACKOFF", defaultWebhookBackoff),
+		timeout:     envDuration("CLASSIFICATION_WEBHOOK_TIMEOUT", defaultWebhookTimeout),
+		slots:       make(chan struct{}, max(envInt("CLASSIFICATION_WEBHOOK_MAX_IN_FLIGHT", defaultWebhookMaxInFlight), 1)),
+	}
+}
+
+// signWebhook returns the X-Focusd-Signature of body: "sha256=" followed by
+// the hex HMAC-SHA256 of the raw body under the shared secret
+func signWebhook(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send delivers event in the background, dropping it when too many
+// deliveries are already in flight so a slow receiver can't pile them up
+func (w *webhookSender) send(event webhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("failed to encode webhook event", "error", err)
+		return
+	}
+
+	select {
+	case w.slots <- struct{}{}:
+	default:
+		webhooksDropped.Add(1)
+		slog.Warn("classification webhook busy, dropping event", "event_id", event.ID)
+		return
+	}
+	w.pending.Add(1)
+	go func() {
+		defer func() {
+			<-w.slots
+			w.pending.Done()
+		}()
+		w.deliver(event.ID, body)
+	}()
+}
+
+// deliver posts body until it is accepted, refused or out of attempts
+func (w *webhookSender) deliver(id string, body []byte) {
+	signature := signWebhook(w.secret, body)
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(id, signature, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= w.maxAttempts {
+			webhooksFailed.Add(1)
+			slog.Warn("classification webhook failed", "event_id", id, "attempts", attempt, "error", err)
+			return
+		}
+		slog.Debug("classification webhook attempt failed, retrying", "event_id", id, "attempt", attempt, "error", err)
+		time.Sleep(w.backoff << (attempt - 1))
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (w *webhookSender) post(id, signature string, body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FocusdBot/1.0")
+	req.Header.Set("X-Focusd-Event-Id", id)
+	req.Header.Set("X-Focusd-Signature", signature)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return false, fmt.Errorf("webhook refused the event with %d", resp.StatusCode)
+}
+
+// wait blocks until in-flight opt-in lookups and deliveries finish
+func (w *webhookSender) wait() {
+	if w != nil {
+		w.pending.Wait()
+	}
+}
+
+// notifyWebhook posts a classification to the webhook when the caller opted
+// in. The opt-in is looked up in the background so the response never waits
+// on it. Failures are logged, they never fail the classification.
+func (s *ServiceImpl) notifyWebhook(ctx context.Context, kind string, result *brainv1.ClassificationResult) {
+	user, ok := auth.GetUser(ctx)
+	if s.webhook == nil || !ok || result.GetClassification() == "" {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	s.webhook.pending.Add(1)
+	go func() {
+		defer s.webhook.pending.Done()
+
+		lookupCtx, cancel := context.WithTimeout(ctx, s.webhook.timeout)
+		defer cancel()
+		var optedIn []bool
+		err := s.gormDB.WithContext(lookupCtx).Model(&commonv1.UserORM{}).
+			Where("id = ?", user.UserID).
+			Pluck("classification_webhook", &optedIn).Error
+		if err != nil {
+			slog.Warn("failed to look up webhook opt-in", "user_id", user.UserID, "error", err)
+			return
+		}
+		if len(optedIn) == 0 || !optedIn[0] {
+			return
+		}
+
+		classification, err := protojson.Marshal(result)
+		if err != nil {
+			slog.Error("failed to encode classification for webhook", "error", err)
+			return
+		}
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			slog.Error("failed to generate webhook event id", "error", err)
+			return
+		}
+
+		s.webhook.send(webhookEvent{
+			ID:             hex.EncodeToString(id),
+			Kind:           kind,
+			UserID:         user.UserID,
+			CreatedAt:      time.Now().Unix(),
+			Classification: classification,
+		})
+	}()
+}
+
+// SetClassificationWebhook opts the authenticated user in or out of the
+// classification webhook
+func (s *ServiceImpl) SetClassificationWebhook(ctx context.Context, req *connect.Request[brainv1.SetClassificationWebhookRequest]) (*connect.Response[brainv1.SetClassificationWebhookResponse], error) {
+	user, ok := auth.GetUser(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session"))
+	}
+
+	result := s.gormDB.WithContext(ctx).Model(&commonv1.UserORM{}).
+		Where("id = ?", user.UserID).
+		Update("classification_webhook", req.Msg.Enabled)
+	if result.Error != nil {
+		return nil, dbError("failed to update webhook opt-in", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+
+	return connect.NewResponse(&brainv1.SetClassificationWebhookResponse{
+		Enabled:    req.Msg.Enabled,
+		Configured: s.webhook != nil,
+	}), nil
+}
+
+// webhookInterceptor posts classification responses to the webhook
+type webhookInterceptor struct {
+	svc *ServiceImpl
+}
+
+// NewWebhookInterceptor creates a ConnectRPC interceptor posting
+// classifications of opted-in users to the webhook. It must run after auth.
+func NewWebhookInterceptor(svc *ServiceImpl) connect.Interceptor {
+	return &webhookInterceptor{svc: svc}
+}
+
+// WrapUnary notifies the webhook of successful classifications
+func (i *webhookInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if kind, result, ok := classifiedKind(resp.Any()); ok {
+			i.svc.notifyWebhook(ctx, kind, result)
+		}
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient is a no-op for server-side interceptors
+func (i *webhookInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler is a no-op, classifications are unary
+func (i *webhookInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
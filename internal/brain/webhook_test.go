@@ -0,0 +1,198 @@
+package brain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"gorm.io/gorm"
+
+	brainv1 "github.com/focusd-so/brain/gen/brain/v1"
+	commonv1 "github.com/focusd-so/brain/gen/common/v1"
+	"github.com/focusd-so/brain/internal/auth"
+)
+
+// webhookDelivery is one request received by a test webhook
+type webhookDelivery struct {
+	body      []byte
+	eventID   string
+	signature string
+}
+
+// newWebhookReceiver starts a webhook answering with the given statuses in
+// turn, then 200, and configures the service to post to it
+func newWebhookReceiver(t *testing.T, statuses ...int) (deliveries func() []webhookDelivery) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		received []webhookDelivery
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, webhookDelivery{body: body, eventID: r.Header.Get("X-Focusd-Event-Id"), signature: r.Header.Get("X-Focusd-Signature")})
+		attempt := len(received)
+		mu.Unlock()
+		if attempt <= len(statuses) {
+			w.WriteHeader(statuses[attempt-1])
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("CLASSIFICATION_WEBHOOK_URL", srv.URL)
+	t.Setenv("CLASSIFICATION_WEBHOOK_SECRET", "webhook-secret")
+	t.Setenv("CLASSIFICATION_WEBHOOK_BACKOFF", "1ms")
+	return func() []webhookDelivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookDelivery(nil), received...)
+	}
+}
+
+// classifyWithWebhook classifies an application as user 7, opted in or not
+func classifyWithWebhook(t *testing.T, optIn bool) *ServiceImpl {
+	t.Helper()
+	svc := newPolicyTestService(t, fakeModels{text: `{"classification":"productive","reasoning":"editor","tags":["code-editor"],"confidence_score":0.9}`})
+	if err := svc.gormDB.Create(&commonv1.UserORM{Id: 7, DeviceFingerprintHash: "fp", Role: "anonymous", CreatedAt: time.Now().Unix()}).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+	if optIn {
+		resp, err := svc.SetClassificationWebhook(ctx, connect.NewRequest(&brainv1.SetClassificationWebhookRequest{Enabled: true}))
+		if err != nil {
+			t.Fatalf("opt-in failed: %v", err)
+		}
+		if !resp.Msg.Enabled || !resp.Msg.Configured {
+			t.Fatalf("unexpected opt-in response %v", resp.Msg)
+		}
+	}
+
+	unary := NewWebhookInterceptor(svc).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return svc.ClassifyApplication(ctx, req.(*connect.Request[brainv1.ClassifyApplicationRequest]))
+	})
+	if _, err := unary(ctx, connect.NewRequest(&brainv1.ClassifyApplicationRequest{ApplicationName: "Code", WindowTitle: "main.go"})); err != nil {
+		t.Fatalf("classification failed: %v", err)
+	}
+	svc.webhook.wait()
+	return svc
+}
+
+func TestWebhook_PostsSignedClassification(t *testing.T) {
+	deliveries := newWebhookReceiver(t)
+	classifyWithWebhook(t, true)
+
+	got := deliveries()
+	if len(got) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(got))
+	}
+	mac := hmac.New(sha256.New, []byte("webhook-secret"))
+	mac.Write(got[0].body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); got[0].signature != want {
+		t.Fatalf("expected signature %q, got %q", want, got[0].signature)
+	}
+
+	var event struct {
+		ID             string `json:"id"`
+		Kind           string `json:"kind"`
+		UserID         int64  `json:"user_id"`
+		Classification struct {
+			Classification string `json:"classification"`
+		} `json:"classification"`
+	}
+	if err := json.Unmarshal(got[0].body, &event); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if event.ID == "" || event.ID != got[0].eventID || event.Kind != "application" || event.UserID != 7 || event.Classification.Classification != "productive" {
+		t.Fatalf("unexpected event %s", got[0].body)
+	}
+}
+
+func TestWebhook_OnlyForOptedInUsers(t *testing.T) {
+	deliveries := newWebhookReceiver(t)
+	classifyWithWebhook(t, false)
+
+	if got := deliveries(); len(got) != 0 {
+		t.Fatalf("expected no delivery without opt-in, got %d", len(got))
+	}
+}
+
+func TestWebhook_OptInLookupDoesNotBlockResponse(t *testing.T) {
+	deliveries := newWebhookReceiver(t)
+	svc := classifyWithWebhook(t, true)
+
+	// Stall every query until released, as a slow database would
+	release := make(chan struct{})
+	if err := svc.gormDB.Callback().Query().Before("gorm:query").Register("test:stall", func(*gorm.DB) { <-release }); err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ctx := auth.WithUser(context.Background(), &auth.UserClaims{UserID: 7})
+		svc.notifyWebhook(ctx, "application", &brainv1.ClassificationResult{Classification: "productive"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("expected notifyWebhook to return before the opt-in lookup")
+	}
+
+	close(release)
+	svc.webhook.wait()
+	if got := deliveries(); len(got) != 2 {
+		t.Fatalf("expected the second event delivered after the lookup, got %d deliveries", len(got))
+	}
+}
+
+func TestWebhook_RetriesWithBackoff(t *testing.T) {
+	deliveries := newWebhookReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	classifyWithWebhook(t, true)
+
+	got := deliveries()
+	if len(got) != 3 {
+		t.Fatalf("expected two retries before success, got %d attempts", len(got))
+	}
+	for _, d := range got[1:] {
+		if d.eventID != got[0].eventID || string(d.body) != string(got[0].body) || d.signature != got[0].signature {
+			t.Fatal("expected retries to resend the same signed event")
+		}
+	}
+}
+
+func TestWebhook_GivesUp(t *testing.T) {
+	t.Run("refused", func(t *testing.T) {
+		deliveries := newWebhookReceiver(t, http.StatusBadRequest)
+		classifyWithWebhook(t, true)
+		if got := deliveries(); len(got) != 1 {
+			t.Fatalf("expected a refused event not to be retried, got %d attempts", len(got))
+		}
+	})
+
+	t.Run("out of attempts", func(t *testing.T) {
+		deliveries := newWebhookReceiver(t, 500, 500, 500, 500, 500)
+		t.Setenv("CLASSIFICATION_WEBHOOK_MAX_ATTEMPTS", "2")
+		classifyWithWebhook(t, true)
+		if got := deliveries(); len(got) != 2 {
+			t.Fatalf("expected delivery to stop after 2 attempts, got %d", len(got))
+		}
+	})
+}
+
+func TestSetClassificationWebhook_RequiresSession(t *testing.T) {
+	svc := newPolicyTestService(t, fakeModels{})
+	_, err := svc.SetClassificationWebhook(context.Background(), connect.NewRequest(&brainv1.SetClassificationWebhookRequest{Enabled: true}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package secrets resolves secret values such as PASETO_KEYS, HMAC_SECRET_KEY
+// and model API keys from a configurable source.
+//
+// The source is selected with SECRETS_SOURCE:
+//
+//   - "env" (default): read from the process environment
+//   - "file": read from one file per secret in SECRETS_DIR (default /run/secrets)
+//   - "vault": read from a Vault KV v2 secret at VAULT_SECRET_PATH on VAULT_ADDR,
+//     authenticated with VAULT_TOKEN
+//
+// Secrets missing from a file or Vault source fall back to the environment so
+// deployments can migrate one secret at a time.
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Source looks up a secret by name, returning "" when it is not set
+type Source interface {
+	Get(key string) (string, error)
+}
+
+// Get resolves key from the configured source, logging and returning "" on failure
+func Get(key string) string {
+	v, err := current().Get(key)
+	if err != nil {
+		slog.Error("failed to read secret", "key", key, "error", err)
+		return ""
+	}
+	if v == "" {
+		return os.Getenv(key)
+	}
+	return v
+}
+
+// EnvSource reads secrets from the process environment
+type EnvSource struct{}
+
+func (EnvSource) Get(key string) (string, error) {
+	return os.Getenv(key), nil
+}
+
+// FileSource reads each secret from a file named after it, e.g. Docker or
+// Kubernetes mounted secrets
+type FileSource struct {
+	Dir string
+}
+
+func (f FileSource) Get(key string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// VaultSource reads secrets from the fields of a single Vault KV v2 secret,
+// caching the secret for a minute to avoid a round trip per lookup. Once
+// cached, lookups never wait on Vault: an expired copy is served while one
+// refresh runs in the background, and kept when that refresh fails.
+type VaultSource struct {
+	Addr   string
+	Token  string
+	Path   string // e.g. "secret/data/focusd"
+	Client *http.Client
+
+	refreshes singleflight.Group
+	mu        sync.Mutex
+	data      map[string]string
+	fetchedAt time.Time
+}
+
+const vaultCacheTTL = time.Minute
+
+func (v *VaultSource) Get(key string) (string, error) {
+	v.mu.Lock()
+	data, expired := v.data, time.Since(v.fetchedAt) > vaultCacheTTL
+	v.mu.Unlock()
+
+	if data == nil {
+		// Nothing to serve yet, wait for the first fetch
+		fetched, err, _ := v.refreshes.Do("", v.refresh)
+		if err != nil {
+			return "", err
+		}
+		return fetched.(map[string]string)[key], nil
+	}
+	if expired {
+		v.refreshes.DoChan("", v.refresh)
+	}
+	return data[key], nil
+}
+
+// refresh fetches the secret and replaces the cached copy on success
+func (v *VaultSource) refresh() (any, error) {
+	data, err := v.fetch()
+	if err != nil {
+		v.mu.Lock()
+		stale := v.data != nil
+		v.mu.Unlock()
+		if stale {
+			slog.Warn("failed to refresh vault secrets, serving the last good copy", "error", err)
+		}
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.data = data
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+	return data, nil
+}
+
+func (v *VaultSource) fetch() (map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(v.Addr, "/")+"/v1/"+strings.TrimPrefix(v.Path, "/"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return body.Data.Data, nil
+}
+
+var (
+	vaultMu     sync.Mutex
+	vaultSource *VaultSource
+)
+
+// current returns the source selected by SECRETS_SOURCE
+func current() Source {
+	switch strings.ToLower(os.Getenv("SECRETS_SOURCE")) {
+	case "file":
+		dir := os.Getenv("SECRETS_DIR")
+		if dir == "" {
+			dir = "/run/secrets"
+		}
+		return FileSource{Dir: dir}
+
+	case "vault":
+		addr, token, path := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"), os.Getenv("VAULT_SECRET_PATH")
+
+		// Reuse the cached source while the configuration is unchanged
+		vaultMu.Lock()
+		defer vaultMu.Unlock()
+		if vaultSource == nil || vaultSource.Addr != addr || vaultSource.Token != token || vaultSource.Path != path {
+			vaultSource = &VaultSource{Addr: addr, Token: token, Path: path}
+		}
+		return vaultSource
+
+	default:
+		return EnvSource{}
+	}
+}
@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileSource_LoadsKeys(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "PASETO_KEYS"), []byte("aa11,bb22\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+
+	t.Setenv("SECRETS_SOURCE", "file")
+	t.Setenv("SECRETS_DIR", dir)
+	t.Setenv("PASETO_KEYS", "from-env")
+	t.Setenv("HMAC_SECRET_KEY", "env-only")
+
+	if got := Get("PASETO_KEYS"); got != "aa11,bb22" {
+		t.Fatalf("expected keys from file, got %q", got)
+	}
+	// Secrets without a file fall back to the environment
+	if got := Get("HMAC_SECRET_KEY"); got != "env-only" {
+		t.Fatalf("expected env fallback, got %q", got)
+	}
+}
+
+func TestEnvSource_IsDefault(t *testing.T) {
+	t.Setenv("SECRETS_SOURCE", "")
+	t.Setenv("GEMINI_API_KEY", "env-key")
+
+	if got := Get("GEMINI_API_KEY"); got != "env-key" {
+		t.Fatalf("expected env value, got %q", got)
+	}
+}
+
+func TestVaultSource_ReadsKV(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/focusd" || r.Header.Get("X-Vault-Token") != "root" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"HMAC_SECRET_KEY":"from-vault"}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("SECRETS_SOURCE", "vault")
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "root")
+	t.Setenv("VAULT_SECRET_PATH", "secret/data/focusd")
+
+	if got := Get("HMAC_SECRET_KEY"); got != "from-vault" {
+		t.Fatalf("expected vault value, got %q", got)
+	}
+}
+
+func TestVaultSource_ServesLastGoodCopyWhileRefreshing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Write([]byte(`{"data":{"data":{"HMAC_SECRET_KEY":"from-vault"}}}`))
+			return
+		}
+		// Vault is down: refreshes hang, then fail
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	v := &VaultSource{Addr: srv.URL, Token: "root", Path: "secret/data/focusd"}
+	if got, err := v.Get("HMAC_SECRET_KEY"); err != nil || got != "from-vault" {
+		t.Fatalf("expected vault value, got %q (%v)", got, err)
+	}
+
+	v.mu.Lock()
+	v.fetchedAt = time.Now().Add(-2 * vaultCacheTTL)
+	v.mu.Unlock()
+
+	// Expired lookups don't wait on the hanging refresh, and share it
+	for range 10 {
+		if got, err := v.Get("HMAC_SECRET_KEY"); err != nil || got != "from-vault" {
+			t.Fatalf("expected the last good copy, got %q (%v)", got, err)
+		}
+	}
+	close(release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for requests.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected a single refresh, got %d requests", got)
+	}
+
+	// The failed refresh keeps the last good copy
+	if got, err := v.Get("HMAC_SECRET_KEY"); err != nil || got != "from-vault" {
+		t.Fatalf("expected the last good copy after a failed refresh, got %q (%v)", got, err)
+	}
+}